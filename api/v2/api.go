@@ -19,6 +19,13 @@ import (
 	"github.com/pingcap/ticdc/pkg/node"
 )
 
+const (
+	// apiOpVarTableID is the key of table ID in HTTP API.
+	apiOpVarTableID = "table_id"
+	// apiOpVarCaptureID is the key of capture ID in HTTP API.
+	apiOpVarCaptureID = "capture_id"
+)
+
 // OpenAPIV2 provides CDC v2 APIs
 type OpenAPIV2 struct {
 	server node.Server
@@ -52,11 +59,15 @@ func RegisterOpenAPIV2Routes(router *gin.Engine, api OpenAPIV2) {
 	changefeedGroup.POST("/:changefeed_id/resume", coordinatorMiddleware, api.resumeChangefeed)
 	changefeedGroup.POST("/:changefeed_id/pause", coordinatorMiddleware, api.pauseChangefeed)
 	changefeedGroup.DELETE("/:changefeed_id", coordinatorMiddleware, api.deleteChangefeed)
+	changefeedGroup.POST("/:changefeed_id/tables/:table_id/move", coordinatorMiddleware, api.moveTable)
+	changefeedGroup.GET("/:changefeed_id/tables/:table_id/move", coordinatorMiddleware, api.getTableMoveStatus)
 
 	// capture apis
 	captureGroup := v2.Group("/captures")
 	captureGroup.Use(coordinatorMiddleware)
 	captureGroup.GET("", api.listCaptures)
+	captureGroup.PUT("/:capture_id/drain", api.drainCapture)
+	captureGroup.DELETE("/:capture_id/drain", api.undrainCapture)
 
 	verifyTableGroup := v2.Group("/verify_table")
 	verifyTableGroup.POST("", api.verifyTable)
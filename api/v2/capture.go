@@ -18,6 +18,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
+	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/server/watcher"
 )
 
@@ -53,3 +54,53 @@ func (h *OpenAPIV2) listCaptures(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, resp)
 }
+
+// drainCapture drains all tasks of a capture
+// @Summary Drain a capture
+// @Description Mark the capture unschedulable and move all maintainers and dispatchers on it to other captures,
+// @Description the request should be sent repeatedly until the current task count is 0
+// @Tags capture,v2
+// @Produce json
+// @Param capture_id path string true "capture_id"
+// @Success 200 {object} DrainCaptureResp
+// @Failure 500,400 {object} model.HTTPError
+// @Router	/api/v2/captures/{capture_id}/drain [put]
+func (h *OpenAPIV2) drainCapture(c *gin.Context) {
+	ctx := c.Request.Context()
+	captureID := node.ID(c.Param(apiOpVarCaptureID))
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	count, err := coordinator.DrainNode(ctx, captureID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, &DrainCaptureResp{CurrentTaskCount: count})
+}
+
+// undrainCapture marks a drained capture schedulable again
+// @Summary Undrain a capture
+// @Description Mark the drained capture schedulable again, the tasks are moved back to it by balancing
+// @Tags capture,v2
+// @Produce json
+// @Param capture_id path string true "capture_id"
+// @Success 200 {object} EmptyResponse
+// @Failure 500,400 {object} model.HTTPError
+// @Router	/api/v2/captures/{capture_id}/drain [delete]
+func (h *OpenAPIV2) undrainCapture(c *gin.Context) {
+	ctx := c.Request.Context()
+	captureID := node.ID(c.Param(apiOpVarCaptureID))
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := coordinator.UndrainNode(ctx, captureID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
@@ -15,20 +15,27 @@ package v2
 
 import (
 	"context"
+	"encoding/hex"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/sink/mysql"
 	"github.com/pingcap/ticdc/version"
 	"github.com/pingcap/tiflow/cdc/api"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/owner"
 	"github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
 	"github.com/pingcap/tiflow/pkg/txnutil/gc"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/tikv/client-go/v2/oracle"
@@ -127,6 +134,12 @@ func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
 		_ = c.Error(err)
 		return
 	}
+	// verify sink options
+	err = verifySinkConfig(model.ChangeFeedID{Namespace: cfg.Namespace, ID: cfg.ID}, sinkURIParsed, replicaCfg)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
 
 	pdClient := h.server.GetPdClient()
 	info := &config.ChangeFeedInfo{
@@ -358,6 +371,112 @@ func (h *OpenAPIV2) deleteChangefeed(c *gin.Context) {
 	})
 }
 
+// moveTable handles move table request,
+// it moves all spans of the table to the target node.
+// @Summary Move a table to a node
+// @Description Move all spans of a table of the changefeed to the target node
+// @Tags changefeed,v2
+// @Accept json
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Param table_id path integer true "table_id"
+// @Param moveTableConfig body MoveTableConfig true "move table config"
+// @Success 200 {object} TableMoveStatus
+// @Failure 500,400 {object} model.HTTPError
+// @Router	/api/v2/changefeeds/{changefeed_id}/tables/{table_id}/move [post]
+func (h *OpenAPIV2) moveTable(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedID, tableID, err := getChangefeedIDAndTableID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	cfg := new(MoveTableConfig)
+	if err := c.BindJSON(cfg); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+	if cfg.TargetNodeID == "" {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("target_node_id is required"))
+		return
+	}
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	resp, err := coordinator.MoveTable(ctx, changefeedID, tableID, node.ID(cfg.TargetNodeID))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, toTableMoveStatus(resp))
+}
+
+// getTableMoveStatus handles get table move status request
+// @Summary Get the move status of a table
+// @Description Get the nodes and operators of all spans of a table of the changefeed
+// @Tags changefeed,v2
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Param table_id path integer true "table_id"
+// @Success 200 {object} TableMoveStatus
+// @Failure 500,400 {object} model.HTTPError
+// @Router	/api/v2/changefeeds/{changefeed_id}/tables/{table_id}/move [get]
+func (h *OpenAPIV2) getTableMoveStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedID, tableID, err := getChangefeedIDAndTableID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	resp, err := coordinator.GetTableMoveStatus(ctx, changefeedID, tableID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, toTableMoveStatus(resp))
+}
+
+// getChangefeedIDAndTableID gets the changefeed id and the table id from the path,
+// only the changefeeds in the default namespace are supported by the move table api.
+func getChangefeedIDAndTableID(c *gin.Context) (model.ChangeFeedID, int64, error) {
+	changefeedID := model.ChangeFeedID{Namespace: model.DefaultNamespace, ID: c.Param(api.APIOpVarChangefeedID)}
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		return changefeedID, 0, errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID)
+	}
+	tableID, err := strconv.ParseInt(c.Param(apiOpVarTableID), 10, 64)
+	if err != nil || tableID <= 0 {
+		return changefeedID, 0, errors.ErrAPIInvalidParam.GenWithStack("invalid table_id: %s",
+			c.Param(apiOpVarTableID))
+	}
+	return changefeedID, tableID, nil
+}
+
+func toTableMoveStatus(resp *heartbeatpb.MoveTableResponse) *TableMoveStatus {
+	status := &TableMoveStatus{
+		ChangefeedID: resp.ChangefeedID,
+		TableID:      resp.TableId,
+		Spans:        make([]SpanMoveStatus, 0, len(resp.Spans)),
+	}
+	for _, span := range resp.Spans {
+		status.Spans = append(status.Spans, SpanMoveStatus{
+			DispatcherID: common.NewDispatcherIDFromPB(span.Id).String(),
+			StartKey:     hex.EncodeToString(span.Span.StartKey),
+			EndKey:       hex.EncodeToString(span.Span.EndKey),
+			NodeID:       span.Node,
+			Operator:     span.Operator,
+		})
+	}
+	return status
+}
+
 // pauseChangefeed handles pause changefeed request
 // PauseChangefeed pauses a changefeed
 // @Summary Pause a changefeed
@@ -555,6 +674,17 @@ func (h *OpenAPIV2) updateChangefeed(c *gin.Context) {
 		oldCfInfo.SinkURI = updateCfConfig.SinkURI
 	}
 
+	// verify sink options
+	sinkURIParsed, err := url.Parse(oldCfInfo.SinkURI)
+	if err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrSinkURIInvalid, err))
+		return
+	}
+	if err = verifySinkConfig(changefeedID, sinkURIParsed, oldCfInfo.Config); err != nil {
+		_ = c.Error(errors.ErrChangefeedUpdateRefused.GenWithStackByArgs(errors.Cause(err).Error()))
+		return
+	}
+
 	// verify changefeed filter
 	_, err = filter.NewFilter(oldCfInfo.Config.Filter, "", oldCfInfo.Config.CaseSensitive)
 	if err != nil {
@@ -569,6 +699,32 @@ func (h *OpenAPIV2) updateChangefeed(c *gin.Context) {
 	c.JSON(http.StatusOK, toAPIModel(oldCfInfo, status.CheckpointTs, status.CheckpointTs, nil))
 }
 
+// verifySinkConfig verifies the options in the sink URI and the sink config,
+// so a changefeed with invalid sink options is rejected before it is created.
+func verifySinkConfig(
+	changefeedID model.ChangeFeedID,
+	sinkURI *url.URL,
+	replicaCfg *config.ReplicaConfig,
+) error {
+	// The timezone is the same as the one the maintainer sends to the dispatchers,
+	// which decode the row values with it no matter what the sink is.
+	info := &config.ChangeFeedInfo{SinkURI: sinkURI.String()}
+	if _, err := util.GetTimezone(info.GetTimezone()); err != nil {
+		return err
+	}
+	if !sink.IsMySQLCompatibleScheme(sink.GetScheme(sinkURI)) {
+		return nil
+	}
+	return mysql.VerifySinkConfig(changefeedID, sinkURI, &config.ChangefeedConfig{
+		Namespace:      changefeedID.Namespace,
+		ID:             changefeedID.ID,
+		SinkURI:        sinkURI.String(),
+		ForceReplicate: replicaCfg.ForceReplicate,
+		SinkConfig:     replicaCfg.Sink,
+		TimeZone:       info.GetTimezone(),
+	})
+}
+
 // verifyResumeChangefeedConfig verifies the changefeed config before resuming a changefeed
 // overrideCheckpointTs is the checkpointTs of the changefeed that specified by the user.
 // or it is the checkpointTs of the changefeed before it is paused.
@@ -82,6 +82,30 @@ type ResumeChangefeedConfig struct {
 	OverwriteCheckpointTs uint64 `json:"overwrite_checkpoint_ts"`
 }
 
+// MoveTableConfig is used by move table api
+type MoveTableConfig struct {
+	// TargetNodeID is the id of the node to move the table to
+	TargetNodeID string `json:"target_node_id"`
+}
+
+// TableMoveStatus is the move status of a table in a changefeed
+type TableMoveStatus struct {
+	ChangefeedID string           `json:"changefeed_id"`
+	TableID      int64            `json:"table_id"`
+	Spans        []SpanMoveStatus `json:"spans"`
+}
+
+// SpanMoveStatus is the move status of a span of a table
+type SpanMoveStatus struct {
+	DispatcherID string `json:"dispatcher_id"`
+	StartKey     string `json:"start_key"`
+	EndKey       string `json:"end_key"`
+	// NodeID is the id of the node the span is scheduled to
+	NodeID string `json:"node_id"`
+	// Operator is the operator scheduling the span, empty if the span is not being scheduled
+	Operator string `json:"operator,omitempty"`
+}
+
 // PDConfig is a configuration used to connect to pd
 type PDConfig struct {
 	PDAddrs       []string `json:"pd_addrs,omitempty"`
@@ -204,6 +228,7 @@ type ReplicaConfig struct {
 	Integrity                    *IntegrityConfig           `json:"integrity"`
 	ChangefeedErrorStuckDuration *JSONDuration              `json:"changefeed_error_stuck_duration,omitempty"`
 	SyncedStatus                 *SyncedStatusConfig        `json:"synced_status,omitempty"`
+	ScanLimit                    *ScanLimitConfig           `json:"scan_limit,omitempty"`
 
 	// Deprecated: we don't use this field since v8.0.0.
 	SQLMode string `json:"sql_mode,omitempty"`
@@ -509,10 +534,23 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 		}
 	}
 	if c.Scheduler != nil {
-		res.Scheduler = &config.ChangefeedSchedulerConfig{
-			EnableTableAcrossNodes: c.Scheduler.EnableTableAcrossNodes,
-			RegionThreshold:        c.Scheduler.RegionThreshold,
-			WriteKeyThreshold:      c.Scheduler.WriteKeyThreshold,
+		// the durations are optional, keep the origin or default values if they are not set
+		scheduler := config.GetDefaultReplicaConfig().Scheduler
+		if res.Scheduler != nil {
+			origin := *res.Scheduler
+			scheduler = &origin
+		}
+		scheduler.EnableTableAcrossNodes = c.Scheduler.EnableTableAcrossNodes
+		scheduler.RegionThreshold = c.Scheduler.RegionThreshold
+		scheduler.WriteKeyThreshold = c.Scheduler.WriteKeyThreshold
+		scheduler.EventRowsThreshold = c.Scheduler.EventRowsThreshold
+		scheduler.EventBytesThreshold = c.Scheduler.EventBytesThreshold
+		res.Scheduler = scheduler
+		if c.Scheduler.SinkLagThreshold != nil {
+			res.Scheduler.SinkLagThreshold = c.Scheduler.SinkLagThreshold.duration
+		}
+		if c.Scheduler.SplitCooldown != nil {
+			res.Scheduler.SplitCooldown = c.Scheduler.SplitCooldown.duration
 		}
 	}
 	if c.Integrity != nil {
@@ -530,6 +568,13 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			CheckpointInterval:  c.SyncedStatus.CheckpointInterval,
 		}
 	}
+	if c.ScanLimit != nil {
+		res.ScanLimit = &config.ScanLimitConfig{
+			MaxBytes:        c.ScanLimit.MaxBytes,
+			MaxRows:         c.ScanLimit.MaxRows,
+			MaxDurationInMs: c.ScanLimit.MaxDurationInMs,
+		}
+	}
 	return res
 }
 
@@ -836,6 +881,10 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			EnableTableAcrossNodes: cloned.Scheduler.EnableTableAcrossNodes,
 			RegionThreshold:        cloned.Scheduler.RegionThreshold,
 			WriteKeyThreshold:      cloned.Scheduler.WriteKeyThreshold,
+			EventRowsThreshold:     cloned.Scheduler.EventRowsThreshold,
+			EventBytesThreshold:    cloned.Scheduler.EventBytesThreshold,
+			SinkLagThreshold:       &JSONDuration{cloned.Scheduler.SinkLagThreshold},
+			SplitCooldown:          &JSONDuration{cloned.Scheduler.SplitCooldown},
 		}
 	}
 
@@ -854,6 +903,13 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			CheckpointInterval:  cloned.SyncedStatus.CheckpointInterval,
 		}
 	}
+	if cloned.ScanLimit != nil {
+		res.ScanLimit = &ScanLimitConfig{
+			MaxBytes:        cloned.ScanLimit.MaxBytes,
+			MaxRows:         cloned.ScanLimit.MaxRows,
+			MaxDurationInMs: cloned.ScanLimit.MaxDurationInMs,
+		}
+	}
 	return res
 }
 
@@ -875,6 +931,14 @@ type MounterConfig struct {
 	WorkerNum int `json:"worker_num"`
 }
 
+// ScanLimitConfig represents the limits of scanning events for a dispatcher
+// This is a duplicate of config.ScanLimitConfig
+type ScanLimitConfig struct {
+	MaxBytes        uint64 `json:"max_bytes"`
+	MaxRows         uint64 `json:"max_rows"`
+	MaxDurationInMs uint64 `json:"max_duration_in_ms"`
+}
+
 // EventFilterRule is used by sql event filter and expression filter
 type EventFilterRule struct {
 	Matcher     []string `json:"matcher"`
@@ -1043,6 +1107,14 @@ type ChangefeedSchedulerConfig struct {
 	RegionThreshold int `toml:"region_threshold" json:"region_threshold"`
 	// WriteKeyThreshold is the written keys threshold of splitting a table.
 	WriteKeyThreshold int `toml:"write_key_threshold" json:"write_key_threshold"`
+	// EventRowsThreshold is the threshold of the rows written to the sink per second by a span.
+	EventRowsThreshold int `toml:"event_rows_threshold" json:"event_rows_threshold"`
+	// EventBytesThreshold is the threshold of the size of the events written to the sink per second by a span.
+	EventBytesThreshold int `toml:"event_bytes_threshold" json:"event_bytes_threshold"`
+	// SinkLagThreshold is the threshold of the sink lag of a span.
+	SinkLagThreshold *JSONDuration `toml:"sink_lag_threshold" json:"sink_lag_threshold,omitempty" swaggertype:"string"`
+	// SplitCooldown is the min interval between two splits or merges of the spans of a table.
+	SplitCooldown *JSONDuration `toml:"split_cooldown" json:"split_cooldown,omitempty" swaggertype:"string"`
 }
 
 // IntegrityConfig is the config for integrity check
@@ -1182,6 +1254,13 @@ type Capture struct {
 	ClusterID     string `json:"cluster_id"`
 }
 
+// DrainCaptureResp is the response of draining a capture
+type DrainCaptureResp struct {
+	// the number of maintainers and dispatchers remaining on the capture,
+	// the capture can be shut down safely when it's 0
+	CurrentTaskCount int `json:"current_task_count"`
+}
+
 // CodecConfig represents a MQ codec configuration
 type CodecConfig struct {
 	EnableTiDBExtension            *bool   `json:"enable_tidb_extension,omitempty"`
@@ -0,0 +1,52 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToInternalSchedulerConfig(t *testing.T) {
+	// the unset durations are the default values
+	c := &ReplicaConfig{Scheduler: &ChangefeedSchedulerConfig{
+		EnableTableAcrossNodes: true,
+		RegionThreshold:        10,
+	}}
+	res := c.ToInternalReplicaConfig()
+	require.True(t, res.Scheduler.EnableTableAcrossNodes)
+	require.Equal(t, 10, res.Scheduler.RegionThreshold)
+	require.Equal(t, config.GetDefaultReplicaConfig().Scheduler.SplitCooldown, res.Scheduler.SplitCooldown)
+	require.Zero(t, res.Scheduler.SinkLagThreshold)
+
+	// the unset durations keep the origin values
+	origin := config.GetDefaultReplicaConfig()
+	origin.Scheduler.SplitCooldown = time.Minute
+	origin.Scheduler.SinkLagThreshold = time.Second
+	res = c.toInternalReplicaConfigWithOriginConfig(origin)
+	require.Equal(t, time.Minute, res.Scheduler.SplitCooldown)
+	require.Equal(t, time.Second, res.Scheduler.SinkLagThreshold)
+
+	// the set durations override the origin values
+	c.Scheduler.SplitCooldown = &JSONDuration{duration: 2 * time.Minute}
+	c.Scheduler.SinkLagThreshold = &JSONDuration{duration: 0}
+	origin = config.GetDefaultReplicaConfig()
+	origin.Scheduler.SinkLagThreshold = time.Second
+	res = c.toInternalReplicaConfigWithOriginConfig(origin)
+	require.Equal(t, 2*time.Minute, res.Scheduler.SplitCooldown)
+	require.Zero(t, res.Scheduler.SinkLagThreshold)
+}
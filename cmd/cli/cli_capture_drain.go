@@ -0,0 +1,98 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"time"
+
+	"github.com/pingcap/ticdc/cmd/factory"
+	apiv2client "github.com/pingcap/ticdc/pkg/api/v2"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// drainCheckInterval is the interval to check the remaining tasks of the draining capture.
+const drainCheckInterval = time.Second
+
+// drainCaptureOptions defines flags for the `cli capture drain` command.
+type drainCaptureOptions struct {
+	apiv2Client apiv2client.APIV2Interface
+
+	captureID string
+	wait      bool
+}
+
+// newDrainCaptureOptions creates new drainCaptureOptions for the `cli capture drain` command.
+func newDrainCaptureOptions() *drainCaptureOptions {
+	return &drainCaptureOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *drainCaptureOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&o.captureID, "capture-id", "", "the id of the capture to drain")
+	cmd.PersistentFlags().BoolVar(&o.wait, "wait", true, "wait until all tasks are moved away from the capture")
+	_ = cmd.MarkPersistentFlagRequired("capture-id")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *drainCaptureOptions) complete(f factory.Factory) error {
+	apiv2Client, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiv2Client = apiv2Client
+	return nil
+}
+
+// run runs the `cli capture drain` command.
+func (o *drainCaptureOptions) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	for {
+		resp, err := o.apiv2Client.Captures().Drain(ctx, o.captureID)
+		if err != nil {
+			return err
+		}
+		if !o.wait || resp.CurrentTaskCount == 0 {
+			return util.JSONPrint(cmd, resp)
+		}
+		cmd.Printf("capture %s has %d tasks remaining\n", o.captureID, resp.CurrentTaskCount)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(drainCheckInterval):
+		}
+	}
+}
+
+// newCmdDrainCapture creates the `cli capture drain` command.
+func newCmdDrainCapture(f factory.Factory) *cobra.Command {
+	o := newDrainCaptureOptions()
+
+	command := &cobra.Command{
+		Use:   "drain",
+		Short: "Move all tasks away from a capture, so it can be shut down without replication lag",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -30,6 +30,7 @@ func newCmdChangefeed(f factory.Factory) *cobra.Command {
 	cmds.AddCommand(newCmdUpdateChangefeed(f))
 	cmds.AddCommand(newCmdStatisticsChangefeed(f))
 	cmds.AddCommand(newCmdListChangefeed(f))
+	cmds.AddCommand(newCmdMoveTableChangefeed(f))
 	cmds.AddCommand(newCmdPauseChangefeed(f))
 	cmds.AddCommand(newCmdQueryChangefeed(f))
 	cmds.AddCommand(newCmdRemoveChangefeed(f))
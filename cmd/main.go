@@ -19,6 +19,7 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/cmd/cli"
+	"github.com/pingcap/ticdc/cmd/redo"
 	"github.com/pingcap/ticdc/cmd/server"
 	"github.com/pingcap/ticdc/cmd/version"
 	"github.com/pingcap/ticdc/pkg/config"
@@ -43,6 +44,7 @@ func NewCmd() *cobra.Command {
 func addNewArchCommandTo(cmd *cobra.Command) {
 	cmd.AddCommand(server.NewCmdServer())
 	cmd.AddCommand(cli.NewCmdCli())
+	cmd.AddCommand(redo.NewCmdRedo())
 	cmd.AddCommand(version.NewCmdVersion())
 }
 
@@ -16,6 +16,7 @@ package changefeed
 import (
 	"encoding/json"
 	"net/url"
+	"time"
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
@@ -28,6 +29,19 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	// tablesPerLoadUnit and spansPerLoadUnit are the number of tables and spans
+	// that cost about as much as a maintainer without any table
+	tablesPerLoadUnit = 1000
+	spansPerLoadUnit  = 1000
+	// the load of a maintainer is doubled if its checkpoint lags behind more than laggingThreshold,
+	// a lagging changefeed is catching up, it costs more resources
+	laggingThreshold = 10 * time.Minute
+	// the maintainer is not lagging any more only if its checkpoint lag drops below recoveredThreshold,
+	// so the load doesn't change back and forth when the lag fluctuates around laggingThreshold
+	recoveredThreshold = 5 * time.Minute
+)
+
 // Changefeed is a memory present for changefeed info and status
 type Changefeed struct {
 	ID       model.ChangeFeedID
@@ -41,6 +55,8 @@ type Changefeed struct {
 	lastSavedCheckpointTs *atomic.Uint64
 	// the heartbeatpb.MaintainerStatus is read only
 	status *atomic.Pointer[heartbeatpb.MaintainerStatus]
+	// lagging is true if the checkpoint lag exceeded laggingThreshold and hasn't recovered yet
+	lagging atomic.Bool
 }
 
 // NewChangefeed creates a new changefeed instance
@@ -89,6 +105,12 @@ func (c *Changefeed) UpdateStatus(newStatus *heartbeatpb.MaintainerStatus) {
 	old := c.status.Load()
 	if newStatus != nil && newStatus.CheckpointTs >= old.CheckpointTs {
 		c.status.Store(newStatus)
+		lag := time.Duration(newStatus.CheckpointLagMs) * time.Millisecond
+		if lag > laggingThreshold {
+			c.lagging.Store(true)
+		} else if lag < recoveredThreshold {
+			c.lagging.Store(false)
+		}
 	}
 }
 
@@ -96,6 +118,17 @@ func (c *Changefeed) GetStatus() *heartbeatpb.MaintainerStatus {
 	return c.status.Load()
 }
 
+// GetLoad returns the estimated load of the maintainer,
+// it's weighed by the table count, span count and checkpoint lag reported by the maintainer
+func (c *Changefeed) GetLoad() int {
+	status := c.GetStatus()
+	load := 1 + int(status.TableCount)/tablesPerLoadUnit + int(status.SpanCount)/spansPerLoadUnit
+	if c.lagging.Load() {
+		load *= 2
+	}
+	return load
+}
+
 func (c *Changefeed) SetLastSavedCheckPointTs(ts uint64) {
 	c.lastSavedCheckpointTs.Store(ts)
 }
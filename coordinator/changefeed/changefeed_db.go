@@ -217,7 +217,7 @@ func (db *ChangefeedDB) MarkMaintainerReplicating(task *Changefeed) {
 	db.replicating[task.ID] = task
 }
 
-// GetScheduleSate returns the absent maintainers and the working state of each node
+// GetScheduleSate returns the absent maintainers and the load of each node
 func (db *ChangefeedDB) GetScheduleSate(absent []*Changefeed, maxSize int) ([]*Changefeed, map[node.ID]int) {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
@@ -230,11 +230,15 @@ func (db *ChangefeedDB) GetScheduleSate(absent []*Changefeed, maxSize int) ([]*C
 			break
 		}
 	}
-	var workingState = make(map[node.ID]int, len(db.nodeTasks))
-	for nodeID, stmMap := range db.nodeTasks {
-		workingState[nodeID] = len(stmMap)
-	}
-	return absent, workingState
+	return absent, db.getLoadPerNodeUnLock()
+}
+
+// GetLoadPerNode returns the sum of the maintainer load per node
+func (db *ChangefeedDB) GetLoadPerNode() map[node.ID]int {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	return db.getLoadPerNodeUnLock()
 }
 
 func (db *ChangefeedDB) GetByID(id model.ChangeFeedID) *Changefeed {
@@ -339,6 +343,17 @@ func (db *ChangefeedDB) ReplaceStoppedChangefeed(cf *config.ChangeFeedInfo) {
 	db.stopped[id] = newCf
 }
 
+// getLoadPerNodeUnLock returns the sum of the maintainer load per node without lock
+func (db *ChangefeedDB) getLoadPerNodeUnLock() map[node.ID]int {
+	loadMap := make(map[node.ID]int, len(db.nodeTasks))
+	for nodeID, stmMap := range db.nodeTasks {
+		for _, cf := range stmMap {
+			loadMap[nodeID] += cf.GetLoad()
+		}
+	}
+	return loadMap
+}
+
 // updateNodeMap updates the node map, it will remove the task from the old node and add it to the new node
 func (db *ChangefeedDB) updateNodeMap(old, new node.ID, task *Changefeed) {
 	//clear from the old node
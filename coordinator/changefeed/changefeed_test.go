@@ -0,0 +1,86 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/scheduler"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestChangefeed(id string, nodeID node.ID) *Changefeed {
+	cfID := model.DefaultChangeFeedID(id)
+	cf := NewChangefeed(cfID, &config.ChangeFeedInfo{
+		ID:        cfID.ID,
+		Namespace: cfID.Namespace,
+		SinkURI:   "mysql://127.0.0.1:3306",
+		Config:    config.GetDefaultReplicaConfig(),
+		State:     model.StateNormal,
+	}, 10)
+	cf.setNodeID(nodeID)
+	return cf
+}
+
+func TestLoadBalanceWithCheckpointLag(t *testing.T) {
+	nodes := map[node.ID]*node.Info{
+		"node1": {ID: "node1"},
+		"node2": {ID: "node2"},
+	}
+	cf1 := newTestChangefeed("cf1", "node1")
+	cf2 := newTestChangefeed("cf2", "node1")
+	cf3 := newTestChangefeed("cf3", "node2")
+	cfs := []*Changefeed{cf1, cf2, cf3}
+	move := func(cf *Changefeed, id node.ID) bool {
+		cf.setNodeID(id)
+		return true
+	}
+	checkpointTs := uint64(10)
+	updateLag := func(lag time.Duration) {
+		checkpointTs++
+		cf1.UpdateStatus(&heartbeatpb.MaintainerStatus{
+			CheckpointTs:    checkpointTs,
+			CheckpointLagMs: uint64(lag.Milliseconds()),
+		})
+	}
+	require.Equal(t, 1, cf1.GetLoad())
+	require.Equal(t, 0, scheduler.LoadBalance(10, nodes, cfs, move))
+
+	// the load of the lagging changefeed is doubled, and the other changefeed is moved away from it
+	updateLag(laggingThreshold - time.Minute)
+	require.Equal(t, 1, cf1.GetLoad())
+	updateLag(laggingThreshold + time.Minute)
+	require.Equal(t, 2, cf1.GetLoad())
+	require.Equal(t, 1, scheduler.LoadBalance(10, nodes, cfs, move))
+	require.Equal(t, node.ID("node1"), cf1.GetNodeID())
+	require.Equal(t, node.ID("node2"), cf2.GetNodeID())
+
+	// the load doesn't change when the lag fluctuates around the threshold,
+	// so the changefeeds are not moved back and forth
+	updateLag(laggingThreshold - time.Minute)
+	require.Equal(t, 2, cf1.GetLoad())
+	require.Equal(t, 0, scheduler.LoadBalance(10, nodes, cfs, move))
+	updateLag(laggingThreshold + time.Minute)
+	require.Equal(t, 0, scheduler.LoadBalance(10, nodes, cfs, move))
+
+	// the changefeed is not lagging after the lag drops below the recovered threshold
+	updateLag(recoveredThreshold - time.Minute)
+	require.Equal(t, 1, cf1.GetLoad())
+	require.Equal(t, 0, scheduler.LoadBalance(10, nodes, cfs, move))
+}
@@ -15,6 +15,7 @@ package coordinator
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -47,6 +48,9 @@ type Controller struct {
 	version            int64
 
 	nodeChanged *atomic.Bool
+	// drainLock makes the drain node messages sent in order, so a stale message
+	// can't overwrite the unschedulable nodes of a newer one.
+	drainLock sync.Mutex
 
 	cfScheduller       *scheduler.Scheduler
 	operatorController *operator.Controller
@@ -215,6 +219,14 @@ func (c *Controller) onNodeChanged() {
 		zap.Int("new", len(newNodes)),
 		zap.Int("removed", len(removedNodes)))
 	c.sendMessages(c.bootstrapper.HandleNewNodes(newNodes))
+	if len(newNodes) > 0 && len(c.nodeManager.GetUnschedulableNodes()) > 0 {
+		// the drain state is only kept in memory, notify the new nodes of the unschedulable nodes,
+		// so the maintainers on them don't schedule tasks to the unschedulable nodes,
+		// and the state is not lost if the coordinator is moved to them.
+		c.drainLock.Lock()
+		c.sendMessages(c.newDrainNodeMessages())
+		c.drainLock.Unlock()
+	}
 	cachedResponse := c.bootstrapper.HandleRemoveNodes(removedNodes)
 	if cachedResponse != nil {
 		log.Info("bootstrap done after removed some nodes")
@@ -428,6 +440,71 @@ func (c *Controller) GetTask(id model.ChangeFeedID) *changefeed.Changefeed {
 	return c.changefeedDB.GetByID(id)
 }
 
+// DrainNode marks the node unschedulable and notifies all nodes, the coordinator scheduler
+// moves the maintainers away from the node, and the maintainers move the dispatchers away from it.
+// It returns the number of maintainers and dispatchers remaining on the node.
+func (c *Controller) DrainNode(target node.ID) (int, error) {
+	if _, ok := c.nodeManager.GetAliveNodes()[target]; !ok {
+		return 0, cerror.ErrCaptureNotExist.GenWithStackByArgs(target)
+	}
+	if !c.nodeManager.IsNodeUnschedulable(target) && len(c.nodeManager.GetSchedulableNodes()) <= 1 {
+		return 0, errors.New("no other schedulable node to move the tasks to")
+	}
+	c.drainLock.Lock()
+	c.nodeManager.SetNodeUnschedulable(target)
+	// the request is sent every time, so the nodes that missed it will be notified again
+	c.sendMessages(c.newDrainNodeMessages())
+	c.drainLock.Unlock()
+
+	maintainers := c.changefeedDB.GetTaskSizePerNode()[target]
+	dispatchers := 0
+	for _, cf := range c.changefeedDB.GetAllChangefeeds() {
+		if cf.GetNodeID() == "" {
+			continue
+		}
+		for _, count := range cf.GetStatus().NodeSpanCounts {
+			if count.NodeId == target.String() {
+				dispatchers += int(count.SpanCount)
+			}
+		}
+	}
+	log.Info("drain node",
+		zap.Stringer("node", target),
+		zap.Int("maintainers", maintainers),
+		zap.Int("dispatchers", dispatchers))
+	return maintainers + dispatchers, nil
+}
+
+// UndrainNode marks the drained node schedulable again and notifies all nodes,
+// the tasks are scheduled to the node again by balancing.
+func (c *Controller) UndrainNode(target node.ID) error {
+	c.drainLock.Lock()
+	defer c.drainLock.Unlock()
+	if !c.nodeManager.SetNodeSchedulable(target) {
+		return errors.Errorf("capture %s is not being drained", target)
+	}
+	c.sendMessages(c.newDrainNodeMessages())
+	log.Info("undrain node", zap.Stringer("node", target))
+	return nil
+}
+
+// newDrainNodeMessages returns the messages to notify all alive nodes of the unschedulable nodes.
+func (c *Controller) newDrainNodeMessages() []*messaging.TargetMessage {
+	unschedulable := c.nodeManager.GetUnschedulableNodes()
+	ids := make([]string, 0, len(unschedulable))
+	for _, id := range unschedulable {
+		ids = append(ids, id.String())
+	}
+	aliveNodes := c.nodeManager.GetAliveNodes()
+	msgs := make([]*messaging.TargetMessage, 0, len(aliveNodes))
+	for id := range aliveNodes {
+		msgs = append(msgs, messaging.NewSingleTargetMessage(id,
+			messaging.MaintainerManagerTopic,
+			&heartbeatpb.DrainNodeRequest{NodeIds: ids}))
+	}
+	return msgs
+}
+
 // RemoveNode is called when a node is removed
 func (c *Controller) RemoveNode(id node.ID) {
 	c.operatorController.OnNodeRemoved(id)
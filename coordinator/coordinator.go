@@ -16,6 +16,7 @@ package coordinator
 import (
 	"context"
 	"math"
+	"sync"
 	"time"
 
 	"github.com/pingcap/log"
@@ -34,9 +35,13 @@ import (
 	"github.com/pingcap/tiflow/pkg/txnutil/gc"
 	"github.com/tikv/client-go/v2/oracle"
 	pd "github.com/tikv/pd/client"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
+// maintainerRequestTimeout is the max time to wait for the response of a request sent to a maintainer
+var maintainerRequestTimeout = 10 * time.Second
+
 // coordinator implements the Coordinator interface
 type coordinator struct {
 	nodeInfo     *node.Info
@@ -54,6 +59,11 @@ type coordinator struct {
 	pdClock   pdutil.Clock
 
 	updatedChangefeedCh chan map[model.ChangeFeedID]*changefeed.Changefeed
+
+	// moveTableRequests holds the move table requests waiting for the responses from maintainers
+	requestID         atomic.Uint64
+	requestLock       sync.Mutex
+	moveTableRequests map[uint64]chan *heartbeatpb.MoveTableResponse
 }
 
 func New(node *node.Info,
@@ -74,6 +84,7 @@ func New(node *node.Info,
 		pdClock:             pdClock,
 		mc:                  mc,
 		updatedChangefeedCh: make(chan map[model.ChangeFeedID]*changefeed.Changefeed, 1024),
+		moveTableRequests:   make(map[uint64]chan *heartbeatpb.MoveTableResponse),
 	}
 	c.stream = dynstream.NewDynamicStream[int, string, *Event, *Controller, *StreamHandler](NewStreamHandler())
 	c.stream.Start()
@@ -91,6 +102,10 @@ func New(node *node.Info,
 }
 
 func (c *coordinator) recvMessages(_ context.Context, msg *messaging.TargetMessage) error {
+	if msg.Type == messaging.TypeMoveTableResponse {
+		c.onMoveTableResponse(msg.Message[0].(*heartbeatpb.MoveTableResponse))
+		return nil
+	}
 	c.stream.In() <- &Event{message: msg}
 	return nil
 }
@@ -171,6 +186,103 @@ func (c *coordinator) GetChangefeed(ctx context.Context, id model.ChangeFeedID)
 	return c.controller.GetChangefeed(ctx, id)
 }
 
+func (c *coordinator) MoveTable(
+	ctx context.Context, id model.ChangeFeedID, tableID int64, target node.ID,
+) (*heartbeatpb.MoveTableResponse, error) {
+	return c.sendMoveTableRequest(ctx, id, tableID, target)
+}
+
+func (c *coordinator) GetTableMoveStatus(
+	ctx context.Context, id model.ChangeFeedID, tableID int64,
+) (*heartbeatpb.MoveTableResponse, error) {
+	return c.sendMoveTableRequest(ctx, id, tableID, "")
+}
+
+func (c *coordinator) DrainNode(_ context.Context, target node.ID) (int, error) {
+	return c.controller.DrainNode(target)
+}
+
+func (c *coordinator) UndrainNode(_ context.Context, target node.ID) error {
+	return c.controller.UndrainNode(target)
+}
+
+// sendMoveTableRequest sends a move table request to the maintainer of the changefeed and waits for the response,
+// the request only queries the move status of the table if the target node is empty.
+func (c *coordinator) sendMoveTableRequest(
+	ctx context.Context, id model.ChangeFeedID, tableID int64, target node.ID,
+) (*heartbeatpb.MoveTableResponse, error) {
+	cf := c.controller.GetTask(id)
+	if cf == nil {
+		return nil, errors.ErrChangeFeedNotExists.GenWithStackByArgs(id.ID)
+	}
+	if target != "" {
+		nodeManager := c.controller.nodeManager
+		if _, ok := nodeManager.GetAliveNodes()[target]; !ok {
+			return nil, errors.ErrCaptureNotExist.GenWithStackByArgs(target)
+		}
+		if nodeManager.IsNodeUnschedulable(target) {
+			return nil, errors.ErrAPIInvalidParam.GenWithStack("node %s is being drained", target)
+		}
+	}
+	maintainerID := cf.GetNodeID()
+	if maintainerID == "" {
+		return nil, errors.New("the maintainer of the changefeed is not running")
+	}
+
+	requestID := c.requestID.Inc()
+	respCh := make(chan *heartbeatpb.MoveTableResponse, 1)
+	c.requestLock.Lock()
+	c.moveTableRequests[requestID] = respCh
+	c.requestLock.Unlock()
+	defer func() {
+		c.requestLock.Lock()
+		delete(c.moveTableRequests, requestID)
+		c.requestLock.Unlock()
+	}()
+
+	err := c.mc.SendCommand(messaging.NewSingleTargetMessage(maintainerID, messaging.MaintainerManagerTopic,
+		&heartbeatpb.MoveTableRequest{
+			ChangefeedID: id.ID,
+			RequestId:    requestID,
+			TableId:      tableID,
+			TargetNode:   target.String(),
+		}))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	timer := time.NewTimer(maintainerRequestTimeout)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return nil, errors.Trace(ctx.Err())
+	case <-timer.C:
+		return nil, errors.New("wait for the response of the maintainer timeout")
+	case resp := <-respCh:
+		if resp.Error != "" {
+			// the maintainer refuses the request, e.g. the table is not found
+			return nil, errors.ErrSchedulerRequestFailed.GenWithStackByArgs(resp.Error)
+		}
+		return resp, nil
+	}
+}
+
+func (c *coordinator) onMoveTableResponse(resp *heartbeatpb.MoveTableResponse) {
+	c.requestLock.Lock()
+	defer c.requestLock.Unlock()
+	respCh, ok := c.moveTableRequests[resp.RequestId]
+	if !ok {
+		log.Warn("move table request not found, ignore the response",
+			zap.String("changefeed", resp.ChangefeedID),
+			zap.Uint64("requestID", resp.RequestId))
+		return
+	}
+	select {
+	case respCh <- resp:
+	default:
+	}
+}
+
 func shouldRunChangefeed(state model.FeedState) bool {
 	switch state {
 	case model.StateStopped, model.StateFailed, model.StateFinished:
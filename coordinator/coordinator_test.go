@@ -28,6 +28,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/coordinator/changefeed"
 	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/bootstrap"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/messaging"
@@ -35,7 +36,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/tiflow/cdc/model"
-	config2 "github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/orchestrator"
 	"github.com/pingcap/tiflow/pkg/pdutil"
 	"github.com/stretchr/testify/require"
@@ -248,10 +249,10 @@ func TestCoordinatorScheduling(t *testing.T) {
 	for i := 0; i < cfSize; i++ {
 		cfID := model.DefaultChangeFeedID(fmt.Sprintf("%d", i))
 		cfs[cfID] = &changefeed.ChangefeedMetaWrapper{
-			Info: &model.ChangeFeedInfo{
+			Info: &config.ChangeFeedInfo{
 				ID:        cfID.ID,
 				Namespace: cfID.Namespace,
-				Config:    config2.GetDefaultReplicaConfig(),
+				Config:    config.GetDefaultReplicaConfig(),
 				State:     model.StateNormal,
 			},
 			Status: &model.ChangeFeedStatus{CheckpointTs: 10, MinTableBarrierTs: 10},
@@ -292,10 +293,10 @@ func TestScaleNode(t *testing.T) {
 	for i := 0; i < cfSize; i++ {
 		cfID := model.DefaultChangeFeedID(fmt.Sprintf("%d", i))
 		cfs[cfID] = &changefeed.ChangefeedMetaWrapper{
-			Info: &model.ChangeFeedInfo{
+			Info: &config.ChangeFeedInfo{
 				ID:        cfID.ID,
 				Namespace: cfID.Namespace,
-				Config:    config2.GetDefaultReplicaConfig(),
+				Config:    config.GetDefaultReplicaConfig(),
 				State:     model.StateNormal,
 			},
 			Status: &model.ChangeFeedStatus{CheckpointTs: 10, MinTableBarrierTs: 10},
@@ -392,3 +393,165 @@ func (m *mockBackend) GetAllChangefeeds(ctx context.Context) (map[model.ChangeFe
 func (m *mockBackend) UpdateChangefeedCheckpointTs(ctx context.Context, cps map[model.ChangeFeedID]uint64) error {
 	return nil
 }
+
+type recordMessageCenter struct {
+	messaging.MessageCenter
+	msgs []*messaging.TargetMessage
+}
+
+func (m *recordMessageCenter) SendCommand(msg *messaging.TargetMessage) error {
+	m.msgs = append(m.msgs, msg)
+	return nil
+}
+
+func TestDrainAndUndrainNode(t *testing.T) {
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	for _, id := range []node.ID{"node1", "node2"} {
+		nodeManager.GetAliveNodes()[id] = &node.Info{ID: id}
+	}
+	mc := &recordMessageCenter{}
+	c := &Controller{
+		nodeManager:   nodeManager,
+		messageCenter: mc,
+		changefeedDB:  changefeed.NewChangefeedDB(),
+	}
+	c.bootstrapper = bootstrap.NewBootstrapper[heartbeatpb.CoordinatorBootstrapResponse]("coordinator", c.newBootstrapMessage)
+	c.bootstrapper.HandleNewNodes([]*node.Info{{ID: "node1"}, {ID: "node2"}})
+	requireDrainNodeRequests := func(targets []node.ID, nodeIDs []string) {
+		var got []node.ID
+		for _, msg := range mc.msgs {
+			if msg.Type != messaging.TypeDrainNodeRequest {
+				continue
+			}
+			got = append(got, msg.To)
+			require.ElementsMatch(t, nodeIDs, msg.Message[0].(*heartbeatpb.DrainNodeRequest).NodeIds)
+		}
+		require.ElementsMatch(t, targets, got)
+		mc.msgs = nil
+	}
+
+	_, err := c.DrainNode("node3")
+	require.Error(t, err)
+	count, err := c.DrainNode("node1")
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+	require.True(t, nodeManager.IsNodeUnschedulable("node1"))
+	requireDrainNodeRequests([]node.ID{"node1", "node2"}, []string{"node1"})
+	// the last schedulable node can't be drained
+	_, err = c.DrainNode("node2")
+	require.Error(t, err)
+
+	// the new node is notified of the drained node
+	nodeManager.GetAliveNodes()["node3"] = &node.Info{ID: "node3"}
+	c.onNodeChanged()
+	requireDrainNodeRequests([]node.ID{"node1", "node2", "node3"}, []string{"node1"})
+
+	require.NoError(t, c.UndrainNode("node1"))
+	require.False(t, nodeManager.IsNodeUnschedulable("node1"))
+	requireDrainNodeRequests([]node.ID{"node1", "node2", "node3"}, []string{})
+	require.Error(t, c.UndrainNode("node1"))
+
+	// no drain node request is sent if no node is drained
+	nodeManager.GetAliveNodes()["node4"] = &node.Info{ID: "node4"}
+	c.onNodeChanged()
+	requireDrainNodeRequests(nil, nil)
+}
+
+// chanMessageCenter sends the messages to a channel, it's used by the tests sending messages concurrently
+type chanMessageCenter struct {
+	messaging.MessageCenter
+	msgCh chan *messaging.TargetMessage
+}
+
+func (m *chanMessageCenter) SendCommand(msg *messaging.TargetMessage) error {
+	m.msgCh <- msg
+	return nil
+}
+
+func TestSendMoveTableRequest(t *testing.T) {
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
+	nodeManager.GetAliveNodes()["node2"] = &node.Info{ID: "node2"}
+	nodeManager.GetAliveNodes()["node3"] = &node.Info{ID: "node3"}
+	require.True(t, nodeManager.SetNodeUnschedulable("node3"))
+
+	cfID := model.DefaultChangeFeedID("test")
+	changefeedDB := changefeed.NewChangefeedDB()
+	changefeedDB.AddReplicatingMaintainer(changefeed.NewChangefeed(cfID, &config.ChangeFeedInfo{
+		ID:        cfID.ID,
+		Namespace: cfID.Namespace,
+		SinkURI:   "mysql://127.0.0.1:3306",
+		Config:    config.GetDefaultReplicaConfig(),
+		State:     model.StateNormal,
+	}, 10), "node1")
+	mc := &chanMessageCenter{msgCh: make(chan *messaging.TargetMessage, 10)}
+	c := &coordinator{
+		mc:                mc,
+		controller:        &Controller{changefeedDB: changefeedDB, nodeManager: nodeManager},
+		moveTableRequests: make(map[uint64]chan *heartbeatpb.MoveTableResponse),
+	}
+	ctx := context.Background()
+
+	// the request is refused without sending to the maintainer
+	_, err := c.MoveTable(ctx, model.DefaultChangeFeedID("unknown"), 1, "node2")
+	require.True(t, cerror.ErrChangeFeedNotExists.Equal(err))
+	_, err = c.MoveTable(ctx, cfID, 1, "node4")
+	require.True(t, cerror.ErrCaptureNotExist.Equal(err))
+	_, err = c.MoveTable(ctx, cfID, 1, "node3")
+	require.True(t, cerror.ErrAPIInvalidParam.Equal(err))
+	require.Empty(t, mc.msgCh)
+
+	type result struct {
+		resp *heartbeatpb.MoveTableResponse
+		err  error
+	}
+	moveTable := func(tableID int64) (*heartbeatpb.MoveTableRequest, chan result) {
+		resultCh := make(chan result, 1)
+		go func() {
+			resp, err := c.MoveTable(ctx, cfID, tableID, "node2")
+			resultCh <- result{resp: resp, err: err}
+		}()
+		msg := <-mc.msgCh
+		require.Equal(t, node.ID("node1"), msg.To)
+		require.Equal(t, messaging.MaintainerManagerTopic, msg.Topic)
+		return msg.Message[0].(*heartbeatpb.MoveTableRequest), resultCh
+	}
+	response := func(req *heartbeatpb.MoveTableRequest, requestID uint64, errMsg string) {
+		c.onMoveTableResponse(&heartbeatpb.MoveTableResponse{
+			ChangefeedID: req.ChangefeedID,
+			RequestId:    requestID,
+			TableId:      req.TableId,
+			Error:        errMsg,
+		})
+	}
+
+	// the response is matched by the request id
+	req1, resultCh1 := moveTable(1)
+	req2, resultCh2 := moveTable(2)
+	require.NotEqual(t, req1.RequestId, req2.RequestId)
+	require.Equal(t, "node2", req1.TargetNode)
+	response(req2, req2.RequestId, "")
+	res := <-resultCh2
+	require.NoError(t, res.err)
+	require.Equal(t, int64(2), res.resp.TableId)
+	require.Empty(t, resultCh1)
+	response(req1, req1.RequestId, "table 1 is not found")
+	res = <-resultCh1
+	require.True(t, cerror.ErrSchedulerRequestFailed.Equal(res.err))
+
+	// the late response of a timeout request is ignored
+	defer func(timeout time.Duration) { maintainerRequestTimeout = timeout }(maintainerRequestTimeout)
+	maintainerRequestTimeout = 100 * time.Millisecond
+	req3, resultCh3 := moveTable(3)
+	res = <-resultCh3
+	require.Error(t, res.err)
+	require.Nil(t, res.resp)
+	req4, resultCh4 := moveTable(4)
+	response(req3, req3.RequestId, "")
+	require.Never(t, func() bool { return len(resultCh4) > 0 }, 50*time.Millisecond, 10*time.Millisecond)
+	response(req4, req4.RequestId, "")
+	res = <-resultCh4
+	require.NoError(t, res.err)
+	require.Equal(t, int64(4), res.resp.TableId)
+	require.Empty(t, c.moveTableRequests)
+}
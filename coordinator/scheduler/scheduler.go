@@ -14,7 +14,6 @@
 package scheduler
 
 import (
-	"math/rand"
 	"time"
 
 	"github.com/pingcap/ticdc/coordinator/changefeed"
@@ -26,10 +25,10 @@ import (
 
 // Scheduler generates operators for the maintainers, and push them to the operator controller
 // it generates add operator for the absent maintainers, and move operator for the unbalanced replicating maintainer
-// currently, it only supports balance the maintainers by size
+// the maintainers are weighed by the table count, span count and checkpoint lag reported by them,
+// so the heavy maintainers are spread across the nodes
 type Scheduler struct {
 	batchSize            int
-	random               *rand.Rand
 	lastRebalanceTime    time.Time
 	checkBalanceInterval time.Duration
 	operatorController   *operator.Controller
@@ -48,7 +47,6 @@ func NewScheduler(
 	balanceInterval time.Duration) *Scheduler {
 	return &Scheduler{
 		batchSize:            batchSize,
-		random:               rand.New(rand.NewSource(time.Now().UnixNano())),
 		checkBalanceInterval: balanceInterval,
 		operatorController:   oc,
 		changefeedDB:         db,
@@ -69,26 +67,62 @@ func (s *Scheduler) Execute() time.Time {
 		if availableSize < s.batchSize/2 {
 			return time.Now().Add(time.Millisecond * 100)
 		}
-		absent, nodeSize := s.changefeedDB.GetScheduleSate(s.absent, availableSize)
-		// add the absent node to the node size map
-		// todo: use the bootstrap nodes
-		for id, _ := range s.nodeManager.GetAliveNodes() {
-			if _, ok := nodeSize[id]; !ok {
-				nodeSize[id] = 0
-			}
-		}
-		scheduler.BasicSchedule(availableSize, absent, nodeSize, func(cf *changefeed.Changefeed, nodeID node.ID) bool {
+		absent, nodeLoad := s.changefeedDB.GetScheduleSate(s.absent, availableSize)
+		s.fillSchedulableNodes(nodeLoad)
+		scheduler.LoadSchedule(availableSize, absent, nodeLoad, func(cf *changefeed.Changefeed, nodeID node.ID) bool {
 			return s.operatorController.AddOperator(operator.NewAddMaintainerOperator(s.changefeedDB, cf, nodeID))
 		})
 
 		s.absent = absent[:0]
-	} else {
+	} else if !s.drain() {
 		s.balance()
 	}
 	return time.Now().Add(time.Millisecond * 500)
 }
 
-// balance balances the maintainers by size
+// drain moves the replicating maintainers away from the unschedulable nodes,
+// returns true if there are maintainers on the unschedulable nodes
+func (s *Scheduler) drain() bool {
+	if len(s.nodeManager.GetUnschedulableNodes()) == 0 {
+		return false
+	}
+	var victims []*changefeed.Changefeed
+	for _, cf := range s.changefeedDB.GetReplicating() {
+		if s.nodeManager.IsNodeUnschedulable(cf.GetNodeID()) {
+			victims = append(victims, cf)
+		}
+	}
+	if len(victims) == 0 {
+		return false
+	}
+	availableSize := s.batchSize - s.operatorController.OperatorSize()
+	if availableSize <= 0 {
+		return true
+	}
+	nodeLoad := s.changefeedDB.GetLoadPerNode()
+	s.fillSchedulableNodes(nodeLoad)
+	scheduler.LoadSchedule(availableSize, victims, nodeLoad, func(cf *changefeed.Changefeed, nodeID node.ID) bool {
+		return s.operatorController.AddOperator(operator.NewMoveMaintainerOperator(s.changefeedDB, cf, cf.GetNodeID(), nodeID))
+	})
+	return true
+}
+
+// fillSchedulableNodes removes the unschedulable nodes from the node load map,
+// and adds the schedulable nodes that have no maintainers to it
+func (s *Scheduler) fillSchedulableNodes(nodeLoad map[node.ID]int) {
+	for _, id := range s.nodeManager.GetUnschedulableNodes() {
+		delete(nodeLoad, id)
+	}
+	// add the absent node to the node load map
+	// todo: use the bootstrap nodes
+	for id, _ := range s.nodeManager.GetSchedulableNodes() {
+		if _, ok := nodeLoad[id]; !ok {
+			nodeLoad[id] = 0
+		}
+	}
+}
+
+// balance balances the maintainers by load
 func (s *Scheduler) balance() {
 	if time.Since(s.lastRebalanceTime) < s.checkBalanceInterval {
 		return
@@ -103,14 +137,8 @@ func (s *Scheduler) balance() {
 		return
 	}
 
-	// check the balance status
-	moveSize := scheduler.CheckBalanceStatus(s.changefeedDB.GetTaskSizePerNode(), s.nodeManager.GetAliveNodes())
-	if moveSize <= 0 {
-		// fast check the balance status, no need to do the balance,skip
-		return
-	}
-	// balance changefeeds among the active nodes
-	scheduler.Balance(s.batchSize, s.random, s.nodeManager.GetAliveNodes(), s.changefeedDB.GetReplicating(),
+	// balance changefeeds among the schedulable nodes by the load of the maintainers
+	scheduler.LoadBalance(s.batchSize, s.nodeManager.GetSchedulableNodes(), s.changefeedDB.GetReplicating(),
 		func(cf *changefeed.Changefeed, nodeID node.ID) bool {
 			return s.operatorController.AddOperator(operator.NewMoveMaintainerOperator(s.changefeedDB, cf, cf.GetNodeID(), nodeID))
 		})
@@ -68,6 +68,9 @@ type Dispatcher struct {
 	tableInfo atomic.Pointer[common.TableInfo]
 	tableSpan *heartbeatpb.TableSpan
 	sink      tisink.Sink
+	// redoSink is not nil only when the redo log is enabled for the changefeed.
+	// The events are written to the redo log before they are sent to the sink.
+	redoSink *tisink.RedoSink
 	// startTs is the start timestamp of the dispatcher
 	startTs atomic.Uint64
 	// lastEventSeq is the sequence number of the last received DML/DDL event.
@@ -93,6 +96,8 @@ type Dispatcher struct {
 	isRemoving atomic.Bool
 
 	tableProgress *types.TableProgress
+	// redoProgress tracks the dml events which are not flushed to the redo log yet.
+	redoProgress *types.TableProgress
 
 	resendTask *ResendTask
 
@@ -105,12 +110,20 @@ type Dispatcher struct {
 	// isReady is used to indicate whether the dispatcher is ready.
 	// If false, the dispatcher will drop the event it received.
 	isReady atomic.Bool
+
+	// throughput is the throughput of the dml events flushed to the sink,
+	// it's reported to the maintainer to balance the load among nodes.
+	throughput *throughput
+
+	// hold is used to hold the dispatcher at a ts before merging its span with others.
+	hold holdState
 }
 
 func NewDispatcher(
 	id common.DispatcherID,
 	tableSpan *heartbeatpb.TableSpan,
 	sink tisink.Sink,
+	redoSink *tisink.RedoSink,
 	startTs uint64,
 	dispatcherActionChan chan common.DispatcherAction,
 	blockStatusesChan chan *heartbeatpb.TableSpanBlockStatus,
@@ -122,6 +135,7 @@ func NewDispatcher(
 		id:                    id,
 		tableSpan:             tableSpan,
 		sink:                  sink,
+		redoSink:              redoSink,
 		blockStatusesChan:     blockStatusesChan,
 		dispatcherActionChan:  dispatcherActionChan,
 		SyncPointInfo:         syncPointInfo,
@@ -131,8 +145,10 @@ func NewDispatcher(
 		isRemoving:            atomic.Bool{},
 		blockStatus:           BlockStauts{blockPendingEvent: nil},
 		tableProgress:         types.NewTableProgress(),
+		redoProgress:          types.NewTableProgress(),
 		schemaID:              schemaID,
 		schemaIDToDispatchers: schemaIDToDispatchers,
+		throughput:            newThroughput(),
 	}
 	dispatcher.startTs.Store(startTs)
 
@@ -177,7 +193,7 @@ func (d *Dispatcher) HandleDispatcherStatus(dispatcherStatus *heartbeatpb.Dispat
 		if action.CommitTs == pendingEvent.GetCommitTs() {
 			d.blockStatus.updateBlockStage(heartbeatpb.BlockStage_WRITING)
 			if action.Action == heartbeatpb.Action_Write {
-				d.sink.AddBlockEvent(pendingEvent, d.tableProgress)
+				d.addBlockEventToSink(pendingEvent)
 			} else {
 				d.sink.PassBlockEvent(pendingEvent, d.tableProgress)
 				dispatcherEventDynamicStream := GetDispatcherEventsDynamicStream()
@@ -211,6 +227,10 @@ func (d *Dispatcher) HandleDispatcherStatus(dispatcherStatus *heartbeatpb.Dispat
 // by setting them with different event types in DispatcherEventsHandler.GetType
 // When we handle events, we don't have any previous events still in sink.
 func (d *Dispatcher) HandleEvents(dispatcherEvents []DispatcherEvent) (block bool) {
+	// If the dispatcher is released after dropping some events, reset it to receive them again.
+	if d.resetIfReleased() {
+		return false
+	}
 	// If the dispatcher is not ready, try to find handshake event to make the dispatcher ready.
 	if !d.isReady.Load() {
 		ready, restEvents := d.checkHandshakeEvents(dispatcherEvents)
@@ -237,24 +257,38 @@ func (d *Dispatcher) HandleEvents(dispatcherEvents []DispatcherEvent) (block boo
 				return false
 			}
 		}
+		if d.dropHeldEvent(event) {
+			continue
+		}
 		switch event.GetType() {
 		case commonEvent.TypeResolvedEvent:
-			d.resolvedTs.Set(event.(commonEvent.ResolvedEvent).ResolvedTs)
+			d.updateResolvedTs(event.(commonEvent.ResolvedEvent).ResolvedTs)
 		case commonEvent.TypeDMLEvent:
 			block = true
 			dml := event.(*commonEvent.DMLEvent)
 			dml.AssembleRows(d.tableInfo.Load())
+			dml.AddPostFlushFunc(func() {
+				d.throughput.add(uint64(dml.Len()), uint64(dml.GetSize()))
+			})
 			// Update the last event sequence number.
 			dml.AddPostFlushFunc(func() {
 				// Considering dml event in sink may be write to downstream not in order,
 				// thus, we use tableProgress.Empty() to ensure these events are flushed to downstream completely
 				// and wake dynamic stream to handle the next events.
-				if d.tableProgress.Empty() {
+				// When redo log is enabled, the events may still be waiting for writing to the redo log,
+				// so we also need to check redoProgress.
+				if d.tableProgress.Empty() && d.redoProgress.Empty() {
 					dispatcherEventDynamicStream := GetDispatcherEventsDynamicStream()
 					dispatcherEventDynamicStream.Wake() <- event.GetDispatcherID()
 				}
 			})
-			d.sink.AddDMLEvent(dml, d.tableProgress)
+			if d.redoSink != nil {
+				d.redoSink.AddDMLEvent(dml, d.redoProgress, func() {
+					d.sink.AddDMLEvent(dml, d.tableProgress)
+				})
+			} else {
+				d.sink.AddDMLEvent(dml, d.tableProgress)
+			}
 		case commonEvent.TypeDDLEvent:
 			if len(dispatcherEvents) != 1 {
 				log.Panic("ddl event should only be singly handled", zap.Any("dispatcherID", d.id))
@@ -387,7 +421,7 @@ func (d *Dispatcher) reset() {
 // 2. If the event is a multi-table DDL / sync point Event, it will generate a TableSpanBlockStatus message with ddl info to send to maintainer.
 func (d *Dispatcher) dealWithBlockEvent(event commonEvent.BlockEvent) {
 	if !shouldBlock(event) {
-		d.sink.AddBlockEvent(event, d.tableProgress)
+		d.addBlockEventToSink(event)
 		if event.GetNeedAddedTables() != nil || event.GetNeedDroppedTables() != nil {
 			d.blockStatus.setBlockEvent(event, heartbeatpb.BlockStage_NONE)
 			message := &heartbeatpb.TableSpanBlockStatus{
@@ -448,6 +482,24 @@ func (d *Dispatcher) dealWithBlockEvent(event commonEvent.BlockEvent) {
 	}
 }
 
+// addBlockEventToSink writes the block event to the redo log first if redo log is enabled,
+// and then adds it to the sink for writing to downstream.
+// If the redo log fails to be written, the event is not written to downstream,
+// the error is reported by the redo sink and the changefeed will be restarted.
+func (d *Dispatcher) addBlockEventToSink(event commonEvent.BlockEvent) {
+	if d.redoSink != nil {
+		err := d.redoSink.WriteBlockEvent(event)
+		if err != nil {
+			log.Error("write block event to redo log failed",
+				zap.Stringer("dispatcher", d.id),
+				zap.Uint64("commitTs", event.GetCommitTs()),
+				zap.Error(err))
+			return
+		}
+	}
+	d.sink.AddBlockEvent(event, d.tableProgress)
+}
+
 func (d *Dispatcher) GetTableSpan() *heartbeatpb.TableSpan {
 	return d.tableSpan
 }
@@ -460,7 +512,27 @@ func (d *Dispatcher) GetResolvedTs() uint64 {
 	return d.resolvedTs.Get()
 }
 
+// GetRedoResolvedTs returns the ts that all the events before it have been flushed to the redo log.
+// It equals to the resolvedTs if the redo log is disabled.
+func (d *Dispatcher) GetRedoResolvedTs() uint64 {
+	resolvedTs := d.GetResolvedTs()
+	if redoTs, isEmpty := d.redoProgress.GetCheckpointTs(); !isEmpty {
+		return min(redoTs, resolvedTs)
+	}
+	return resolvedTs
+}
+
 func (d *Dispatcher) GetCheckpointTs() uint64 {
+	checkpointTs := d.getSinkCheckpointTs()
+	// the events still in redo pipeline are not added to the sink yet,
+	// so the checkpointTs can't exceed them.
+	if redoTs, isEmpty := d.redoProgress.GetCheckpointTs(); !isEmpty {
+		return min(checkpointTs, redoTs)
+	}
+	return checkpointTs
+}
+
+func (d *Dispatcher) getSinkCheckpointTs() uint64 {
 	checkpointTs, isEmpty := d.tableProgress.GetCheckpointTs()
 	if checkpointTs == 0 {
 		// This means the dispatcher has never send events to the sink,
@@ -548,9 +620,9 @@ func (d *Dispatcher) AddToDynamicStream() {
 func (d *Dispatcher) TryClose() (w heartbeatpb.Watermark, ok bool) {
 	// removing 后每次收集心跳的时候，call TryClose, 来判断是否能关掉 dispatcher 了（sink.isEmpty)
 	// 如果不能关掉，返回 0， false; 可以关掉的话，就返回 checkpointTs, true -- 这个要对齐过（startTs 和 checkpointTs 的关系）
-	if d.tableProgress.Empty() {
+	if d.tableProgress.Empty() && d.redoProgress.Empty() {
 		w.CheckpointTs = d.GetCheckpointTs()
-		w.ResolvedTs = d.GetResolvedTs()
+		w.ResolvedTs = d.GetRedoResolvedTs()
 
 		d.componentStatus.Set(heartbeatpb.ComponentState_Stopped)
 		return w, true
@@ -594,13 +666,132 @@ func (d *Dispatcher) GetBlockStatus() *heartbeatpb.State {
 
 func (d *Dispatcher) CollectDispatcherHeartBeatInfo(h *HeartBeatInfo) {
 	h.Watermark.CheckpointTs = d.GetCheckpointTs()
-	h.Watermark.ResolvedTs = d.GetResolvedTs()
+	// When redo log is enabled, the resolvedTs reported to the maintainer
+	// is used as the resolvedTs of the redo meta.
+	h.Watermark.ResolvedTs = d.GetRedoResolvedTs()
 	h.Id = d.GetId()
 	h.ComponentStatus = d.GetComponentStatus()
 	h.TableSpan = d.GetTableSpan()
 	h.IsRemoving = d.GetRemovingStatus()
+	h.HoldTs = d.GetHoldTs()
+	h.BlockTs = 0
+	if pendingEvent, _ := d.blockStatus.getEventAndStage(); pendingEvent != nil {
+		h.BlockTs = pendingEvent.GetCommitTs()
+	}
+}
+
+// GetThroughput returns the rows and the size of the dml events flushed to the sink
+// per second since the last call.
+func (d *Dispatcher) GetThroughput() (rowsPerSecond, bytesPerSecond uint64) {
+	return d.throughput.calculate()
 }
 
 func (d *Dispatcher) HandleCheckpointTs(checkpointTs uint64) {
 	d.sink.AddCheckpointTs(checkpointTs)
 }
+
+// Hold holds the dispatcher at holdTs. The events whose commit ts is larger than holdTs are dropped,
+// and the resolved ts doesn't exceed holdTs, so the checkpoint ts of the dispatcher stops at holdTs.
+// The hold is refused if the dispatcher has received the events after holdTs.
+// holdTs 0 releases the dispatcher.
+func (d *Dispatcher) Hold(holdTs uint64) {
+	d.hold.mutex.Lock()
+	defer d.hold.mutex.Unlock()
+
+	if holdTs == 0 {
+		if d.hold.holdTs != 0 && !d.hold.needReset {
+			log.Info("dispatcher is released",
+				zap.Stringer("dispatcher", d.id),
+				zap.Uint64("holdTs", d.hold.holdTs),
+				zap.Bool("dropped", d.hold.dropped))
+			// If some events are dropped, the events after holdTs are still dropped
+			// until the dispatcher is reset, otherwise they are written without the dropped ones.
+			d.hold.needReset = d.hold.dropped
+			if !d.hold.needReset {
+				d.hold.holdTs = 0
+			}
+			d.hold.dropped = false
+		}
+		return
+	}
+	if d.hold.holdTs != 0 || d.hold.needReset {
+		if d.hold.holdTs != holdTs {
+			log.Warn("dispatcher is held at another ts or not reset yet, ignore the hold",
+				zap.Stringer("dispatcher", d.id),
+				zap.Uint64("holdTs", d.hold.holdTs),
+				zap.Uint64("newHoldTs", holdTs))
+		}
+		return
+	}
+	resolvedTs := d.resolvedTs.Get()
+	if d.hold.lastCommitTs > holdTs || resolvedTs > holdTs {
+		log.Info("dispatcher has received the events after the hold ts, ignore the hold",
+			zap.Stringer("dispatcher", d.id),
+			zap.Uint64("holdTs", holdTs),
+			zap.Uint64("lastCommitTs", d.hold.lastCommitTs),
+			zap.Uint64("resolvedTs", resolvedTs))
+		return
+	}
+	d.hold.holdTs = holdTs
+	log.Info("dispatcher is held", zap.Stringer("dispatcher", d.id), zap.Uint64("holdTs", holdTs))
+}
+
+// GetHoldTs returns the ts the dispatcher is held at, 0 means it's not held.
+func (d *Dispatcher) GetHoldTs() uint64 {
+	d.hold.mutex.Lock()
+	defer d.hold.mutex.Unlock()
+	if d.hold.needReset {
+		return 0
+	}
+	return d.hold.holdTs
+}
+
+// dropHeldEvent returns true if the event is after the hold ts and must be dropped.
+func (d *Dispatcher) dropHeldEvent(event commonEvent.Event) bool {
+	switch event.GetType() {
+	case commonEvent.TypeDMLEvent, commonEvent.TypeDDLEvent, commonEvent.TypeSyncPointEvent:
+	default:
+		return false
+	}
+	d.hold.mutex.Lock()
+	defer d.hold.mutex.Unlock()
+	if d.hold.holdTs != 0 && event.GetCommitTs() > d.hold.holdTs {
+		d.hold.dropped = true
+		return true
+	}
+	d.hold.lastCommitTs = event.GetCommitTs()
+	return false
+}
+
+// updateResolvedTs updates the resolved ts, it doesn't exceed the hold ts if the dispatcher is held.
+func (d *Dispatcher) updateResolvedTs(resolvedTs uint64) {
+	d.hold.mutex.Lock()
+	defer d.hold.mutex.Unlock()
+	if d.hold.holdTs != 0 && resolvedTs > d.hold.holdTs {
+		resolvedTs = d.hold.holdTs
+	}
+	d.resolvedTs.Set(resolvedTs)
+}
+
+// resetIfReleased resets the dispatcher if it's released after dropping some events.
+// It waits until all events in the sink are flushed, so the dispatcher restarts
+// from the checkpoint ts without writing any event twice.
+func (d *Dispatcher) resetIfReleased() bool {
+	d.hold.mutex.Lock()
+	if !d.hold.needReset || !d.tableProgress.Empty() || !d.redoProgress.Empty() {
+		d.hold.mutex.Unlock()
+		return false
+	}
+	d.hold.needReset = false
+	d.hold.holdTs = 0
+	d.hold.lastCommitTs = 0
+	// reset may block on the action channel, don't hold the lock while resetting,
+	// otherwise GetHoldTs and Hold called by others are blocked too.
+	d.hold.mutex.Unlock()
+
+	log.Info("reset the released dispatcher to receive the dropped events",
+		zap.Stringer("dispatcher", d.id),
+		zap.Uint64("checkpointTs", d.GetCheckpointTs()))
+	d.reset()
+	return true
+}
@@ -9,7 +9,7 @@ import (
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	sinkutil "github.com/pingcap/ticdc/pkg/sink/util"
-	timodel "github.com/pingcap/tidb/pkg/parser/model"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
 
 	pevent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/stretchr/testify/require"
@@ -42,7 +42,12 @@ func (s *mockSink) AddCheckpointTs(ts uint64) {
 func (s *mockSink) SetTableSchemaStore(tableSchemaStore *sinkutil.TableSchemaStore) {
 }
 
-func (s *mockSink) Close() {
+func (s *mockSink) CheckStartTs(tableId int64, startTs uint64) (int64, error) {
+	return int64(startTs), nil
+}
+
+func (s *mockSink) Close(removeDDLTsItem bool) error {
+	return nil
 }
 
 func (s *mockSink) SinkType() psink.SinkType {
@@ -78,6 +83,14 @@ func newMockSink() *mockSink {
 	}
 }
 
+// removeFromDynamicStream removes the paths of the dispatcher from the dynamic streams,
+// the events are handled by calling HandleEvents directly in the tests, and the wake
+// signals sent after flushing are dropped.
+func removeFromDynamicStream(t *testing.T, d *Dispatcher) {
+	require.NoError(t, GetDispatcherEventsDynamicStream().RemovePath(d.id))
+	require.NoError(t, GetDispatcherStatusDynamicStream().RemovePath(d.id))
+}
+
 func TestDispatcherHandleEvents(t *testing.T) {
 	helper := pevent.NewEventTestHelper(t)
 	defer helper.Close()
@@ -108,6 +121,7 @@ func TestDispatcherHandleEvents(t *testing.T) {
 		dispatcherID,
 		tableSpan,
 		sink,
+		nil,     // redoSink
 		startTs, // startTs
 		dispatcherActionChan,
 		blockStatusesChan,
@@ -116,6 +130,7 @@ func TestDispatcherHandleEvents(t *testing.T) {
 		schemaIDToDispatchers,
 		nil,
 	)
+	removeFromDynamicStream(t, dispatcher)
 	// 1. Dispatcher is not ready, handle dml event, it will return immediately
 	dispatcherEvent := NewDispatcherEvent(dmlEvent)
 	dispatcher.HandleEvents([]DispatcherEvent{dispatcherEvent})
@@ -172,12 +187,14 @@ func TestDispatcherHandleEvents(t *testing.T) {
 		FinishedTs:   resolvedEvent.ResolvedTs + 2,
 		BlockedTables: &pevent.InfluencedTables{
 			InfluenceType: pevent.InfluenceTypeNormal,
-			TableIDs:      []int64{tableInfo.ID},
+			// the ddl also blocks the table trigger event dispatcher, so it waits for the maintainer.
+			TableIDs: []int64{0, tableInfo.ID},
 		},
 	}
 	dispatcherEvent = NewDispatcherEvent(ddlEvent)
 	dispatcher.HandleEvents([]DispatcherEvent{dispatcherEvent})
-	require.Equal(t, dispatcher.blockPendingEvent, ddlEvent)
+	pendingEvent, _ := dispatcher.blockStatus.getEventAndStage()
+	require.Equal(t, pendingEvent, ddlEvent)
 	require.Equal(t, 0, len(sink.blockEvents))
 
 	// 6. Dispatcher is ready, handle action event, ddl event will be sent to sink
@@ -194,3 +211,102 @@ func TestDispatcherHandleEvents(t *testing.T) {
 	sink.flushBlockEvents()
 	require.Equal(t, dispatcher.GetCheckpointTs(), ddlEvent.FinishedTs-1)
 }
+
+func TestDispatcherHold(t *testing.T) {
+	helper := pevent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	ddlJob := helper.DDL2Job("create table t(id int primary key, v int)")
+	require.NotNil(t, ddlJob)
+	dml1 := helper.DML2Event("test", "t", "insert into t values(1, 1)")
+	dml2 := helper.DML2Event("test", "t", "insert into t values(2, 2)")
+	tableInfo := dml1.TableInfo
+
+	dispatcherID := common.NewDispatcherID()
+	tableSpan := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("z")}
+	sink := newMockSink()
+	dispatcherActionChan := make(chan common.DispatcherAction, 16)
+	dispatcher := NewDispatcher(dispatcherID, tableSpan, sink, nil, 100,
+		dispatcherActionChan, make(chan *heartbeatpb.TableSpanBlockStatus, 16),
+		nil, 1, NewSchemaIDToDispatchers(), nil)
+	removeFromDynamicStream(t, dispatcher)
+
+	handle := func(event pevent.Event) {
+		dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(event)})
+	}
+	resolved := func(ts uint64) pevent.ResolvedEvent {
+		return pevent.ResolvedEvent{Version: pevent.ResolvedEventVersion, ResolvedTs: ts, DispatcherID: dispatcherID}
+	}
+	handle(pevent.NewHandshakeEvent(dispatcherID, 100, 1, tableInfo))
+	require.True(t, dispatcher.isReady.Load())
+
+	// 1. the events after the hold ts are dropped and the resolved ts stops at the hold ts
+	dispatcher.Hold(200)
+	require.Equal(t, uint64(200), dispatcher.GetHoldTs())
+	dml1.Seq, dml1.CommitTs = 2, 150
+	handle(dml1)
+	require.Len(t, sink.dmls, 1)
+	dml2.Seq, dml2.CommitTs = 3, 250
+	handle(dml2)
+	require.Len(t, sink.dmls, 1)
+	handle(&pevent.DDLEvent{
+		Version:      pevent.DDLEventVersion,
+		DispatcherID: dispatcherID,
+		Seq:          4,
+		Type:         byte(timodel.ActionAddColumn),
+		SchemaID:     tableInfo.SchemaID,
+		TableID:      tableInfo.ID,
+		Query:        "alter table t add column c int",
+		TableInfo:    tableInfo,
+		FinishedTs:   260,
+		BlockedTables: &pevent.InfluencedTables{
+			InfluenceType: pevent.InfluenceTypeNormal,
+			TableIDs:      []int64{tableInfo.ID},
+		},
+	})
+	handle(&pevent.SyncPointEvent{DispatcherID: dispatcherID, CommitTs: 270})
+	require.Empty(t, sink.blockEvents)
+	pendingEvent, _ := dispatcher.blockStatus.getEventAndStage()
+	require.Nil(t, pendingEvent)
+	handle(resolved(300))
+	require.Equal(t, uint64(200), dispatcher.GetResolvedTs())
+	// the hold at another ts is ignored
+	dispatcher.Hold(180)
+	require.Equal(t, uint64(200), dispatcher.GetHoldTs())
+
+	// 2. after the release, the events are still dropped until all events in the sink are flushed,
+	// then the dispatcher is reset to receive the dropped events again
+	dispatcher.Hold(0)
+	require.Equal(t, uint64(0), dispatcher.GetHoldTs())
+	handle(resolved(310))
+	require.Equal(t, uint64(200), dispatcher.GetResolvedTs())
+	require.Empty(t, dispatcherActionChan)
+	// the dispatcher can't be held before the reset
+	dispatcher.Hold(400)
+	require.Equal(t, uint64(0), dispatcher.GetHoldTs())
+	sink.flushDMLs()
+	handle(resolved(320))
+	require.Equal(t, uint64(200), dispatcher.GetResolvedTs())
+	require.False(t, dispatcher.isReady.Load())
+	require.Equal(t, common.DispatcherAction{DispatcherID: dispatcherID, Action: common.ActionReset}, <-dispatcherActionChan)
+	require.Equal(t, uint64(200), dispatcher.startTs.Load())
+
+	// 3. the hold is refused if the events after the hold ts are received
+	handle(pevent.NewHandshakeEvent(dispatcherID, 200, 1, tableInfo))
+	require.True(t, dispatcher.isReady.Load())
+	dml2.Seq = 2
+	handle(dml2)
+	require.Len(t, sink.dmls, 1)
+	dispatcher.Hold(220)
+	require.Equal(t, uint64(0), dispatcher.GetHoldTs())
+	dispatcher.Hold(250)
+	require.Equal(t, uint64(250), dispatcher.GetHoldTs())
+	// the release without dropping any event doesn't reset the dispatcher
+	dispatcher.Hold(0)
+	require.Equal(t, uint64(0), dispatcher.GetHoldTs())
+	handle(resolved(330))
+	require.Equal(t, uint64(330), dispatcher.GetResolvedTs())
+	require.True(t, dispatcher.isReady.Load())
+	require.Empty(t, dispatcherActionChan)
+}
@@ -15,6 +15,7 @@ package dispatcher
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pingcap/log"
@@ -26,6 +27,59 @@ import (
 	"go.uber.org/zap"
 )
 
+// throughput counts the rows and the size of the events flushed to the sink by the dispatcher.
+type throughput struct {
+	rows  atomic.Uint64
+	bytes atomic.Uint64
+
+	mutex     sync.Mutex
+	lastRows  uint64
+	lastBytes uint64
+	lastTime  time.Time
+}
+
+func newThroughput() *throughput {
+	return &throughput{lastTime: time.Now()}
+}
+
+func (t *throughput) add(rows, bytes uint64) {
+	t.rows.Add(rows)
+	t.bytes.Add(bytes)
+}
+
+// calculate returns the rows and the size of the events flushed per second since the last calculation.
+func (t *throughput) calculate() (rowsPerSecond, bytesPerSecond uint64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(t.lastTime).Seconds()
+	rows, bytes := t.rows.Load(), t.bytes.Load()
+	if elapsed > 0 {
+		rowsPerSecond = uint64(float64(rows-t.lastRows) / elapsed)
+		bytesPerSecond = uint64(float64(bytes-t.lastBytes) / elapsed)
+	}
+	t.lastRows, t.lastBytes, t.lastTime = rows, bytes, now
+	return
+}
+
+// holdState is the state of holding the dispatcher at a ts. The maintainer holds the dispatchers of
+// some adjacent spans at a common ts before merging them, so the merged span can start from the ts
+// without replicating any event twice.
+type holdState struct {
+	mutex sync.Mutex
+	// holdTs is the ts the dispatcher is held at, 0 means the dispatcher is not held.
+	holdTs uint64
+	// lastCommitTs is the commit ts of the last event added to the sink.
+	lastCommitTs uint64
+	// dropped is true if some events after holdTs are dropped.
+	dropped bool
+	// needReset is true if the dispatcher is released after dropping some events,
+	// it must be reset to receive the dropped events again. holdTs is kept until
+	// the reset to drop the events after it.
+	needReset bool
+}
+
 type BlockStauts struct {
 	mutex             sync.Mutex
 	blockPendingEvent commonEvent.BlockEvent
@@ -170,6 +224,8 @@ type HeartBeatInfo struct {
 	TableSpan       *heartbeatpb.TableSpan
 	ComponentStatus heartbeatpb.ComponentState
 	IsRemoving      bool
+	HoldTs          uint64
+	BlockTs         uint64
 }
 
 // Resend Task is reponsible for resending the TableSpanBlockStatus message with ddl info to maintainer each 50ms.
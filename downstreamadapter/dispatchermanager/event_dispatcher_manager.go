@@ -38,6 +38,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/zap"
 )
@@ -65,6 +66,8 @@ type EventDispatcherManager struct {
 
 	sink         sink.Sink
 	maintainerID node.ID
+	// redoSink is only not nil when the redo log is enabled.
+	redoSink *sink.RedoSink
 
 	// statusesChan will fetch the tableSpan status that need to contains in the heartbeat info.
 	statusesChan chan *heartbeatpb.TableSpanStatus
@@ -73,6 +76,9 @@ type EventDispatcherManager struct {
 	blockStatusesChan chan *heartbeatpb.TableSpanBlockStatus
 	// dispatcherActionChan
 	dispatcherActionChan chan common.DispatcherAction
+	// errCh collects the errors of the sink, the redo sink and the event service, which will be reported to maintainer
+	// to fail the changefeed.
+	errCh chan error
 
 	filter filter.Filter
 
@@ -111,6 +117,7 @@ func NewEventDispatcherManager(changefeedID model.ChangeFeedID,
 		maintainerID:                   maintainerID,
 		statusesChan:                   make(chan *heartbeatpb.TableSpanStatus, 10000),
 		blockStatusesChan:              make(chan *heartbeatpb.TableSpanBlockStatus, 1000),
+		errCh:                          make(chan error, 16),
 		cancel:                         cancel,
 		config:                         cfConfig,
 		schemaIDToDispatchers:          dispatcher.NewSchemaIDToDispatchers(),
@@ -165,6 +172,13 @@ func NewEventDispatcherManager(changefeedID model.ChangeFeedID,
 		manager.CollectBlockStatusRequest(ctx)
 	}()
 
+	// collector errors from the sink
+	manager.wg.Add(1)
+	go func() {
+		defer manager.wg.Done()
+		manager.CollectErrors(ctx)
+	}()
+
 	// create tableTriggerEventDispatcher if it is not nil
 	if tableTriggerEventDispatcherID != nil {
 		manager.NewDispatcher(common.NewDispatcherIDFromPB(tableTriggerEventDispatcherID), heartbeatpb.DDLSpan, startTs, 0)
@@ -175,11 +189,19 @@ func NewEventDispatcherManager(changefeedID model.ChangeFeedID,
 }
 
 func (e *EventDispatcherManager) InitSink(ctx context.Context) error {
-	sink, err := sink.NewSink(ctx, e.config, e.changefeedID)
+	s, err := sink.NewSink(ctx, e.config, e.changefeedID, e.errCh)
 	if err != nil {
 		return err
 	}
-	e.sink = sink
+	e.sink = s
+
+	if sink.IsRedoEnabled(e.config.Consistent) {
+		redoSink, err := sink.NewRedoSink(e.changefeedID, e.config.Consistent, e.errCh)
+		if err != nil {
+			return err
+		}
+		e.redoSink = redoSink
+	}
 	return nil
 }
 
@@ -227,6 +249,10 @@ func (e *EventDispatcherManager) close(remove bool) {
 		return
 	}
 
+	if e.redoSink != nil {
+		e.redoSink.Close()
+	}
+
 	metrics.CreateDispatcherDuration.DeleteLabelValues(e.changefeedID.Namespace, e.changefeedID.ID)
 	metrics.EventDispatcherManagerCheckpointTsGauge.DeleteLabelValues(e.changefeedID.Namespace, e.changefeedID.ID)
 	metrics.EventDispatcherManagerResolvedTsGauge.DeleteLabelValues(e.changefeedID.Namespace, e.changefeedID.ID)
@@ -272,7 +298,7 @@ func (e *EventDispatcherManager) NewDispatcher(id common.DispatcherID, tableSpan
 	}
 
 	d := dispatcher.NewDispatcher(
-		id, tableSpan, e.sink,
+		id, tableSpan, e.sink, e.redoSink,
 		startTs, e.dispatcherActionChan, e.blockStatusesChan,
 		e.filter, schemaID, e.schemaIDToDispatchers, &syncPointInfo)
 
@@ -293,6 +319,9 @@ func (e *EventDispatcherManager) NewDispatcher(id common.DispatcherID, tableSpan
 			StartTs:      d.GetStartTs(),
 			ActionType:   eventpb.ActionType_ACTION_TYPE_REGISTER,
 			FilterConfig: toFilterConfigPB(e.config.Filter),
+			ScanLimit:    e.config.ScanLimit,
+			Timezone:     e.config.TimeZone,
+			ErrCh:        e.errCh,
 		},
 	)
 
@@ -391,6 +420,36 @@ func (e *EventDispatcherManager) CollectHeartbeatInfoWhenStatesChanged(ctx conte
 	}
 }
 
+// CollectErrors reports the errors of the sink to the maintainer by the heartbeat request,
+// the maintainer reports them to the coordinator to fail the changefeed.
+func (e *EventDispatcherManager) CollectErrors(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err := <-e.errCh:
+			if errors.Cause(err) == context.Canceled {
+				continue
+			}
+			log.Error("event dispatcher manager meets error",
+				zap.Stringer("changefeedID", e.changefeedID),
+				zap.Error(err))
+			code, ok := errors.RFCCode(err)
+			if !ok {
+				code = errors.ErrProcessorUnknown.RFCCode()
+			}
+			var message heartbeatpb.HeartBeatRequest
+			message.ChangefeedID = e.changefeedID.ID
+			message.Err = &heartbeatpb.RunningError{
+				Time:    time.Now().String(),
+				Code:    string(code),
+				Message: err.Error(),
+			}
+			e.heartbeatRequestQueue.Enqueue(&HeartBeatRequestWithTargetID{TargetID: e.GetMaintainerID(), Request: &message})
+		}
+	}
+}
+
 // CollectDispatcherAction is used to collect the dispatcher action from the dispatcher action channel.
 // The action could be pause, resume, reset.
 func (e *EventDispatcherManager) CollectDispatcherAction(ctx context.Context) {
@@ -414,14 +473,14 @@ func (e *EventDispatcherManager) CollectDispatcherAction(ctx context.Context) {
 			case common.ActionResume:
 				req = eventcollector.DispatcherRequest{
 					Dispatcher: d,
-					ActionType: eventpb.ActionType_ACTION_TYPE_PAUSE,
+					ActionType: eventpb.ActionType_ACTION_TYPE_RESUME,
 				}
 				// Get eventCollector
 			case common.ActionReset:
 				req = eventcollector.DispatcherRequest{
 					Dispatcher: d,
 					StartTs:    d.GetStartTs(),
-					ActionType: eventpb.ActionType_ACTION_TYPE_PAUSE,
+					ActionType: eventpb.ActionType_ACTION_TYPE_RESET,
 				}
 			default:
 				log.Panic("unknown action type", zap.Any("action", a))
@@ -451,6 +510,19 @@ func (e *EventDispatcherManager) RemoveDispatcher(id common.DispatcherID) {
 	}
 }
 
+// HoldDispatcher holds the dispatcher at holdTs before its span is merged with others,
+// holdTs 0 releases the dispatcher.
+func (e *EventDispatcherManager) HoldDispatcher(id common.DispatcherID, holdTs uint64) {
+	dispatcher, ok := e.dispatcherMap.Get(id)
+	if !ok || dispatcher.GetRemovingStatus() {
+		log.Warn("dispatcher not found or removing, ignore the hold request",
+			zap.Stringer("dispatcher", id),
+			zap.Uint64("holdTs", holdTs))
+		return
+	}
+	dispatcher.Hold(holdTs)
+}
+
 // Only called when the dispatcher is removed successfully.
 func (e *EventDispatcherManager) cleanTableEventDispatcher(id common.DispatcherID, schemaID int64) {
 	e.dispatcherMap.Delete(id)
@@ -501,6 +573,7 @@ func (e *EventDispatcherManager) CollectHeartbeatInfo(needCompleteStatus bool) *
 	toReomveDispatcherIDs := make([]common.DispatcherID, 0)
 	removeDispatcherSchemaIDs := make([]int64, 0)
 	heartBeatInfo := &dispatcher.HeartBeatInfo{}
+	phyNow := oracle.GetPhysical(time.Now())
 
 	e.dispatcherMap.ForEach(func(id common.DispatcherID, dispatcherItem *dispatcher.Dispatcher) {
 		// If the dispatcher is in removing state, we need to check if it's closed successfully.
@@ -525,10 +598,20 @@ func (e *EventDispatcherManager) CollectHeartbeatInfo(needCompleteStatus bool) *
 		message.Watermark.UpdateMin(heartBeatInfo.Watermark)
 
 		if needCompleteStatus {
+			rowsPerSecond, bytesPerSecond := dispatcherItem.GetThroughput()
+			sinkLag := phyNow - oracle.ExtractPhysical(heartBeatInfo.Watermark.CheckpointTs)
+			if sinkLag < 0 {
+				sinkLag = 0
+			}
 			message.Statuses = append(message.Statuses, &heartbeatpb.TableSpanStatus{
-				ID:              id.ToPB(),
-				ComponentStatus: heartBeatInfo.ComponentStatus,
-				CheckpointTs:    heartBeatInfo.Watermark.CheckpointTs,
+				ID:                  id.ToPB(),
+				ComponentStatus:     heartBeatInfo.ComponentStatus,
+				CheckpointTs:        heartBeatInfo.Watermark.CheckpointTs,
+				EventRowsPerSecond:  rowsPerSecond,
+				EventBytesPerSecond: bytesPerSecond,
+				SinkLagMs:           uint64(sinkLag),
+				HoldTs:              heartBeatInfo.HoldTs,
+				BlockTs:             heartBeatInfo.BlockTs,
 			})
 		}
 	})
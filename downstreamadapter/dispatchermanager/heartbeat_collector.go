@@ -186,6 +186,8 @@ func (h *SchedulerDispatcherRequestHandler) Handle(eventDispatcherManager *Event
 		eventDispatcherManager.NewDispatcher(dispatcherID, config.Span, config.StartTs, config.SchemaID)
 	case heartbeatpb.ScheduleAction_Remove:
 		eventDispatcherManager.RemoveDispatcher(dispatcherID)
+	case heartbeatpb.ScheduleAction_Hold:
+		eventDispatcherManager.HoldDispatcher(dispatcherID, scheduleDispatcherRequest.HoldTs)
 	}
 	return false
 }
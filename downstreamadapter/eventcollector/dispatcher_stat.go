@@ -0,0 +1,202 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventcollector
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pingcap/ticdc/downstreamadapter/dispatcher"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/node"
+)
+
+// dispatcherStat is the state of a dispatcher in the event collector.
+//
+// A dispatcher is always registered to the event service of the local node.
+// While the local event store is catching up, the dispatcher may read events from
+// the event service of a remote node which already has the data of its table span.
+// Events are only accepted from the event service the dispatcher currently reads from.
+type dispatcherStat struct {
+	target *dispatcher.Dispatcher
+	// registerReq is the request which registers the dispatcher,
+	// it's used to register the dispatcher to another event service.
+	registerReq DispatcherRequest
+
+	mu sync.Mutex
+	// eventServiceID is the node of the event service the dispatcher reads events from.
+	eventServiceID node.ID
+	// startTs is the start ts of the dispatcher in the current event service.
+	startTs uint64
+	// waitHandshake is true until the handshake event of the current event service is received,
+	// the other events from the event service are dropped before it.
+	waitHandshake bool
+	// lastEventSeq is the seq of the last DML/DDL event sent to the dispatcher.
+	lastEventSeq uint64
+	// The seq of an event from the current event service is sent to the dispatcher as
+	// seq - handshakeSeq + seqBase, so the dispatcher always sees continuous seqs
+	// no matter how many times it switches the event service.
+	handshakeSeq uint64
+	seqBase      uint64
+	// sentResolvedTs is the max resolved ts sent to the dispatcher, all events before it are sent.
+	sentResolvedTs uint64
+	// sentCommitTs is the max commit ts of the DML/DDL events sent to the dispatcher.
+	// Several transactions may share a commit ts, so only the events before it are surely all sent.
+	sentCommitTs uint64
+	// sentEvents are the DML/DDL events sent to the dispatcher at sentCommitTs. The dispatcher restarts
+	// from sentCommitTs-1 after switching to another event service if sentCommitTs is larger than
+	// sentResolvedTs, and the events sent again by the new event service are dropped,
+	// so no event is lost or duplicated.
+	sentEvents map[sentEventKey]struct{}
+	// stickToLocal is true after the dispatcher switches back to the local event service,
+	// the dispatcher will not read from a remote event service again.
+	stickToLocal bool
+	// lastCheckTime is the last time to ask the log coordinator about the event services of the dispatcher.
+	lastCheckTime time.Time
+}
+
+// sentEventKey identifies a DML/DDL event among the events with the same commit ts.
+type sentEventKey struct {
+	eventType int
+	startTs   uint64
+}
+
+func newDispatcherStat(req DispatcherRequest, eventServiceID node.ID) *dispatcherStat {
+	return &dispatcherStat{
+		target:         req.Dispatcher,
+		registerReq:    req,
+		eventServiceID: eventServiceID,
+		startTs:        req.StartTs,
+		waitHandshake:  true,
+		sentResolvedTs: req.StartTs,
+		sentCommitTs:   req.StartTs,
+		sentEvents:     make(map[sentEventKey]struct{}),
+	}
+}
+
+func (d *dispatcherStat) getEventServiceID() node.ID {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.eventServiceID
+}
+
+// switchTo makes the dispatcher read events from the event service of the node,
+// and returns the old event service and the start ts in the new event service.
+func (d *dispatcherStat) switchTo(eventServiceID node.ID) (node.ID, uint64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	old := d.eventServiceID
+	d.eventServiceID = eventServiceID
+	d.startTs = d.resumeTs()
+	d.waitHandshake = true
+	return old, d.startTs
+}
+
+// resumeTs returns the ts all events before which are sent to the dispatcher.
+// It must be called with the lock held.
+func (d *dispatcherStat) resumeTs() uint64 {
+	if d.sentCommitTs > d.sentResolvedTs {
+		// Some transactions at sentCommitTs may not be received yet,
+		// restart from the ts before it and drop the ones already sent.
+		return d.sentCommitTs - 1
+	}
+	return d.sentResolvedTs
+}
+
+// reset is called when the dispatcher resets itself to the start ts,
+// it waits a new handshake event and restarts the seq from the beginning.
+func (d *dispatcherStat) reset(startTs uint64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.startTs = startTs
+	d.sentResolvedTs = startTs
+	d.sentCommitTs = startTs
+	clear(d.sentEvents)
+	d.waitHandshake = true
+	d.lastEventSeq = 0
+}
+
+// filterEvent returns whether the event from the node should be sent to the dispatcher.
+// The seq of DML and DDL events is rewritten if the dispatcher has switched the event service.
+func (d *dispatcherStat) filterEvent(from node.ID, event commonEvent.Event) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if from != d.eventServiceID {
+		return false
+	}
+
+	if event.GetType() == commonEvent.TypeHandshakeEvent {
+		if !d.waitHandshake || event.GetCommitTs() != d.startTs {
+			return false
+		}
+		d.waitHandshake = false
+		d.handshakeSeq = event.GetSeq()
+		if d.lastEventSeq == 0 {
+			// The dispatcher is not ready yet, the handshake event makes it ready.
+			d.seqBase = d.handshakeSeq
+			d.lastEventSeq = d.handshakeSeq
+			return true
+		}
+		// The dispatcher is ready, it continues from the last event.
+		d.seqBase = d.lastEventSeq
+		return false
+	}
+	if d.waitHandshake {
+		return false
+	}
+
+	switch event.GetType() {
+	case commonEvent.TypeDMLEvent, commonEvent.TypeDDLEvent:
+		if d.isSent(event) {
+			// The event is sent by the old event service, it doesn't take a seq.
+			d.handshakeSeq++
+			return false
+		}
+		if e, ok := event.(*commonEvent.DMLEvent); ok {
+			e.Seq = e.Seq - d.handshakeSeq + d.seqBase
+			d.lastEventSeq = e.Seq
+		} else {
+			e := event.(*commonEvent.DDLEvent)
+			e.Seq = e.Seq - d.handshakeSeq + d.seqBase
+			d.lastEventSeq = e.Seq
+		}
+	case commonEvent.TypeResolvedEvent:
+		if event.GetCommitTs() > d.sentResolvedTs {
+			d.sentResolvedTs = event.GetCommitTs()
+		}
+	}
+	// The sync point event shares the commit ts with the ddl event after it,
+	// so it's always sent to the dispatcher.
+	return true
+}
+
+// isSent returns true if the DML/DDL event is already sent to the dispatcher, otherwise it's recorded as sent.
+func (d *dispatcherStat) isSent(event commonEvent.Event) bool {
+	key := sentEventKey{eventType: event.GetType(), startTs: event.GetStartTs()}
+	commitTs := event.GetCommitTs()
+	switch {
+	case commitTs < d.sentCommitTs:
+		// the events are sent in the order of commit ts, it can't happen
+		return false
+	case commitTs == d.sentCommitTs:
+		if _, ok := d.sentEvents[key]; ok {
+			return true
+		}
+	default:
+		d.sentCommitTs = commitTs
+		clear(d.sentEvents)
+	}
+	d.sentEvents[key] = struct{}{}
+	return false
+}
@@ -0,0 +1,99 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventcollector
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatcherStatSwitchEventService(t *testing.T) {
+	local, remote := node.ID("local"), node.ID("remote")
+	id := common.NewDispatcherID()
+	stat := newDispatcherStat(DispatcherRequest{StartTs: 100}, local)
+
+	// events are dropped before the handshake event.
+	require.False(t, stat.filterEvent(local, &commonEvent.DMLEvent{DispatcherID: id, CommitTs: 101, Seq: 2}))
+	require.True(t, stat.filterEvent(local, commonEvent.NewHandshakeEvent(id, 100, 1, nil)))
+	dml := &commonEvent.DMLEvent{DispatcherID: id, CommitTs: 110, Seq: 2}
+	require.True(t, stat.filterEvent(local, dml))
+	require.Equal(t, uint64(2), dml.Seq)
+	require.True(t, stat.filterEvent(local, commonEvent.ResolvedEvent{DispatcherID: id, ResolvedTs: 120}))
+
+	// switch to the remote event service, which starts from the last resolved ts.
+	from, startTs := stat.switchTo(remote)
+	require.Equal(t, local, from)
+	require.Equal(t, uint64(120), startTs)
+	// the events from the old event service are dropped.
+	require.False(t, stat.filterEvent(local, &commonEvent.DMLEvent{DispatcherID: id, CommitTs: 130, Seq: 3}))
+	// the handshake is not sent to the dispatcher which is already ready.
+	require.False(t, stat.filterEvent(remote, commonEvent.NewHandshakeEvent(id, 120, 1, nil)))
+	dml = &commonEvent.DMLEvent{DispatcherID: id, CommitTs: 130, Seq: 2}
+	require.True(t, stat.filterEvent(remote, dml))
+	require.Equal(t, uint64(3), dml.Seq)
+	ddl := &commonEvent.DDLEvent{DispatcherID: id, FinishedTs: 140, Seq: 3}
+	require.True(t, stat.filterEvent(remote, ddl))
+	require.Equal(t, uint64(4), ddl.Seq)
+
+	// switch back to the local event service, which starts from the ts before the last ddl,
+	// because the events with the same commit ts as the ddl may not be received yet.
+	_, startTs = stat.switchTo(local)
+	require.Equal(t, uint64(139), startTs)
+	require.False(t, stat.filterEvent(remote, commonEvent.ResolvedEvent{DispatcherID: id, ResolvedTs: 150}))
+	// the handshake of a stale registration is ignored.
+	require.False(t, stat.filterEvent(local, commonEvent.NewHandshakeEvent(id, 100, 1, nil)))
+	require.False(t, stat.filterEvent(local, commonEvent.NewHandshakeEvent(id, 139, 1, nil)))
+	// the ddl sent by the remote event service is dropped.
+	require.False(t, stat.filterEvent(local, &commonEvent.DDLEvent{DispatcherID: id, FinishedTs: 140, Seq: 2}))
+	dml = &commonEvent.DMLEvent{DispatcherID: id, CommitTs: 150, Seq: 3}
+	require.True(t, stat.filterEvent(local, dml))
+	require.Equal(t, uint64(5), dml.Seq)
+
+	// the dispatcher resets itself, the handshake event is sent to it again.
+	stat.reset(150)
+	require.True(t, stat.filterEvent(local, commonEvent.NewHandshakeEvent(id, 150, 1, nil)))
+	dml = &commonEvent.DMLEvent{DispatcherID: id, CommitTs: 160, Seq: 2}
+	require.True(t, stat.filterEvent(local, dml))
+	require.Equal(t, uint64(2), dml.Seq)
+}
+
+func TestDispatcherStatSwitchInTheMiddleOfCommitTs(t *testing.T) {
+	local, remote := node.ID("local"), node.ID("remote")
+	id := common.NewDispatcherID()
+	stat := newDispatcherStat(DispatcherRequest{StartTs: 100}, local)
+	require.True(t, stat.filterEvent(local, commonEvent.NewHandshakeEvent(id, 100, 1, nil)))
+	require.True(t, stat.filterEvent(local, commonEvent.ResolvedEvent{DispatcherID: id, ResolvedTs: 110}))
+	// only one of the two transactions committed at 120 is received.
+	dml := &commonEvent.DMLEvent{DispatcherID: id, StartTs: 115, CommitTs: 120, Seq: 2}
+	require.True(t, stat.filterEvent(local, dml))
+	require.Equal(t, uint64(2), dml.Seq)
+
+	_, startTs := stat.switchTo(remote)
+	require.Equal(t, uint64(119), startTs)
+	require.False(t, stat.filterEvent(remote, commonEvent.NewHandshakeEvent(id, 119, 1, nil)))
+	// the received transaction is dropped, and the other one is sent with the next seq.
+	require.False(t, stat.filterEvent(remote, &commonEvent.DMLEvent{DispatcherID: id, StartTs: 115, CommitTs: 120, Seq: 2}))
+	dml = &commonEvent.DMLEvent{DispatcherID: id, StartTs: 116, CommitTs: 120, Seq: 3}
+	require.True(t, stat.filterEvent(remote, dml))
+	require.Equal(t, uint64(3), dml.Seq)
+	require.True(t, stat.filterEvent(remote, commonEvent.ResolvedEvent{DispatcherID: id, ResolvedTs: 130}))
+
+	// all events before the resolved ts are received, the dispatcher restarts from it.
+	_, startTs = stat.switchTo(local)
+	require.Equal(t, uint64(130), startTs)
+}
@@ -21,11 +21,15 @@ import (
 	"github.com/pingcap/ticdc/downstreamadapter/dispatcher"
 	"github.com/pingcap/ticdc/pkg/node"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/eventpb"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/logservicepb"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/utils/dynstream"
@@ -40,6 +44,17 @@ type DispatcherRequest struct {
 	ActionType   eventpb.ActionType
 	StartTs      uint64
 	FilterConfig *eventpb.FilterConfig
+	// ScanLimit is the limits of a single scan of the dispatcher in the event service.
+	ScanLimit *config.ScanLimitConfig
+	// Timezone is the timezone used to decode the row values of the dispatcher in the event service.
+	Timezone string
+	// ErrCh receives the errors met by the event service when sending the events of the dispatcher,
+	// they are reported to the maintainer to fail the changefeed.
+	ErrCh chan<- error
+
+	// eventServiceID is the node to send the request to, it's only set for the requests
+	// generated by the event collector when the dispatcher switches the event service.
+	eventServiceID node.ID
 }
 
 const (
@@ -56,11 +71,26 @@ EventCollector is the relay between EventService and DispatcherManager, responsi
 EventCollector is an instance-level component.
 */
 type EventCollector struct {
-	serverId          node.ID
+	serverId node.ID
+	// dispatcherMap is a map from dispatcherID to *dispatcherStat.
 	dispatcherMap     sync.Map
 	globalMemoryQuota int64
 	mc                messaging.MessageCenter
 	wg                sync.WaitGroup
+	cfg               *config.EventCollectorConfig
+
+	// coordinatorInfo is the node of the log coordinator,
+	// it's learned from the broadcast request sent by the log coordinator.
+	coordinatorInfo struct {
+		sync.RWMutex
+		id node.ID
+	}
+
+	// aliveNodes is nil before the first node change is received.
+	aliveNodes struct {
+		sync.RWMutex
+		m map[node.ID]*node.Info
+	}
 
 	// dispatcherRequestChan is used cached dispatcher request when some error occurs.
 	dispatcherRequestChan *chann.DrainableChann[DispatcherRequest]
@@ -75,10 +105,11 @@ type EventCollector struct {
 	metricReceiveEventLagDuration                prometheus.Observer
 }
 
-func New(ctx context.Context, globalMemoryQuota int64, serverId node.ID) *EventCollector {
+func New(ctx context.Context, globalMemoryQuota int64, serverId node.ID, cfg *config.EventCollectorConfig) *EventCollector {
 	eventCollector := EventCollector{
 		serverId:                             serverId,
 		globalMemoryQuota:                    globalMemoryQuota,
+		cfg:                                  cfg,
 		dispatcherMap:                        sync.Map{},
 		ds:                                   dispatcher.GetDispatcherEventsDynamicStream(),
 		dispatcherRequestChan:                chann.NewAutoDrainChann[DispatcherRequest](),
@@ -100,6 +131,13 @@ func New(ctx context.Context, globalMemoryQuota int64, serverId node.ID) *EventC
 		defer eventCollector.wg.Done()
 		eventCollector.updateMetrics(ctx)
 	}()
+	if cfg.EnableRemoteEventService {
+		eventCollector.wg.Add(1)
+		go func() {
+			defer eventCollector.wg.Done()
+			eventCollector.checkRemoteEventServices(ctx)
+		}()
+	}
 	return &eventCollector
 }
 
@@ -114,11 +152,56 @@ func (c *EventCollector) processDispatcherRequests() {
 }
 
 func (c *EventCollector) SendDispatcherRequest(req DispatcherRequest) error {
+	if req.eventServiceID != "" {
+		return c.resendDispatcherRequest(req)
+	}
+
+	switch req.ActionType {
+	case eventpb.ActionType_ACTION_TYPE_REGISTER:
+		// The dispatcher always registers to the local event service first.
+		if err := c.sendDispatcherRequest(c.serverId, req); err != nil {
+			return err
+		}
+		stat := newDispatcherStat(req, c.serverId)
+		c.dispatcherMap.Store(req.Dispatcher.GetId(), stat)
+		metrics.EventCollectorRegisteredDispatcherCount.Inc()
+		if c.cfg.EnableRemoteEventService && !req.Dispatcher.GetTableSpan().Equal(heartbeatpb.DDLSpan) {
+			c.askReusableEventService(stat)
+		}
+		return nil
+	case eventpb.ActionType_ACTION_TYPE_REMOVE:
+		if err := c.sendDispatcherRequest(c.serverId, req); err != nil {
+			return err
+		}
+		value, ok := c.dispatcherMap.LoadAndDelete(req.Dispatcher.GetId())
+		if !ok {
+			return nil
+		}
+		if id := value.(*dispatcherStat).getEventServiceID(); id != c.serverId && c.isNodeAlive(id) {
+			c.sendOrRetryDispatcherRequest(id, req)
+		}
+		return nil
+	default:
+		eventServiceID := c.serverId
+		if value, ok := c.dispatcherMap.Load(req.Dispatcher.GetId()); ok {
+			stat := value.(*dispatcherStat)
+			if req.ActionType == eventpb.ActionType_ACTION_TYPE_RESET {
+				stat.reset(req.StartTs)
+			}
+			eventServiceID = stat.getEventServiceID()
+		}
+		return c.sendDispatcherRequest(eventServiceID, req)
+	}
+}
+
+// sendDispatcherRequest sends the request to the event service of the node.
+// The request is put back to the channel for later retry if it fails to send.
+func (c *EventCollector) sendDispatcherRequest(eventServiceID node.ID, req DispatcherRequest) error {
 	message := &messaging.RegisterDispatcherRequest{
 		RegisterDispatcherRequest: &eventpb.RegisterDispatcherRequest{
 			DispatcherId: req.Dispatcher.GetId().ToPB(),
 			ActionType:   req.ActionType,
-			// FIXME: It can be another server id in the future.
+			// The events are always sent to the event collector of the local node.
 			ServerId:  c.serverId.String(),
 			TableSpan: req.Dispatcher.GetTableSpan(),
 			StartTs:   req.StartTs,
@@ -128,52 +211,253 @@ func (c *EventCollector) SendDispatcherRequest(req DispatcherRequest) error {
 	// If the action type is register, we need fill all config related fields.
 	if req.ActionType == eventpb.ActionType_ACTION_TYPE_REGISTER {
 		message.RegisterDispatcherRequest.FilterConfig = req.FilterConfig
+		message.RegisterDispatcherRequest.Timezone = req.Timezone
 		message.RegisterDispatcherRequest.EnableSyncPoint = req.Dispatcher.EnableSyncPoint()
 		message.RegisterDispatcherRequest.SyncPointTs = req.Dispatcher.GetSyncPointTs()
 		message.RegisterDispatcherRequest.SyncPointInterval = uint64(req.Dispatcher.GetSyncPointInterval().Seconds())
+		if req.ScanLimit != nil {
+			message.RegisterDispatcherRequest.ScanMaxBytes = req.ScanLimit.MaxBytes
+			message.RegisterDispatcherRequest.ScanMaxRows = req.ScanLimit.MaxRows
+			message.RegisterDispatcherRequest.ScanMaxDurationMs = req.ScanLimit.MaxDurationInMs
+		}
 	}
 
 	err := c.mc.SendCommand(&messaging.TargetMessage{
-		To:      c.serverId,
+		To:      eventServiceID,
 		Topic:   eventServiceTopic,
 		Type:    typeRegisterDispatcherReq,
 		Message: []messaging.IOTypeT{message},
 	})
 
 	if err != nil {
-		log.Info("failed to send dispatcher request message to event service, try again later", zap.Error(err))
+		log.Info("failed to send dispatcher request message to event service, try again later",
+			zap.Stringer("eventServiceID", eventServiceID), zap.Error(err))
 		// Put the request back to the channel for later retry.
 		c.dispatcherRequestChan.In() <- req
 		return err
 	}
+	return nil
+}
 
-	// If the action type is register or remove, we need to update the dispatcher map.
-	if req.ActionType == eventpb.ActionType_ACTION_TYPE_REGISTER {
-		c.dispatcherMap.Store(req.Dispatcher.GetId(), req.Dispatcher)
-		metrics.EventCollectorRegisteredDispatcherCount.Inc()
-	} else if req.ActionType == eventpb.ActionType_ACTION_TYPE_REMOVE {
-		c.dispatcherMap.Delete(req.Dispatcher.GetId())
+// sendOrRetryDispatcherRequest sends the request generated by the event collector to the node.
+func (c *EventCollector) sendOrRetryDispatcherRequest(eventServiceID node.ID, req DispatcherRequest) {
+	req.eventServiceID = eventServiceID
+	_ = c.sendDispatcherRequest(eventServiceID, req)
+}
+
+// resendDispatcherRequest retries the request generated by the event collector.
+// The request is dropped if it is outdated, because the dispatcher has been removed
+// or switched to another event service, or the node is not alive.
+func (c *EventCollector) resendDispatcherRequest(req DispatcherRequest) error {
+	if !c.isNodeAlive(req.eventServiceID) {
+		return nil
 	}
+	if req.ActionType != eventpb.ActionType_ACTION_TYPE_REMOVE {
+		value, ok := c.dispatcherMap.Load(req.Dispatcher.GetId())
+		if !ok {
+			return nil
+		}
+		current := value.(*dispatcherStat).getEventServiceID()
+		// The pause request is sent to the event service the dispatcher switches from,
+		// others are sent to the event service the dispatcher switches to.
+		if (req.ActionType == eventpb.ActionType_ACTION_TYPE_PAUSE) == (current == req.eventServiceID) {
+			return nil
+		}
+	}
+	return c.sendDispatcherRequest(req.eventServiceID, req)
+}
 
-	return nil
+// switchEventService makes the dispatcher read events from the event service of the node.
+// The dispatcher restarts from the ts all events before which are received,
+// and the events received again are dropped, so no event is lost or duplicated.
+func (c *EventCollector) switchEventService(stat *dispatcherStat, to node.ID) {
+	from, startTs := stat.switchTo(to)
+	if from == to {
+		return
+	}
+	log.Info("dispatcher switches event service",
+		zap.Stringer("dispatcher", stat.target.GetId()),
+		zap.Stringer("from", from), zap.Stringer("to", to),
+		zap.Uint64("startTs", startTs))
+
+	req := stat.registerReq
+	req.StartTs = startTs
+	if from == c.serverId {
+		// Keep the dispatcher in the local event service,
+		// so the local event store keeps pulling the data of the table span.
+		req.ActionType = eventpb.ActionType_ACTION_TYPE_PAUSE
+		c.sendOrRetryDispatcherRequest(from, req)
+	} else if c.isNodeAlive(from) {
+		req.ActionType = eventpb.ActionType_ACTION_TYPE_REMOVE
+		c.sendOrRetryDispatcherRequest(from, req)
+	}
+
+	if to == c.serverId {
+		req.ActionType = eventpb.ActionType_ACTION_TYPE_RESET
+	} else {
+		req.ActionType = eventpb.ActionType_ACTION_TYPE_REGISTER
+	}
+	c.sendOrRetryDispatcherRequest(to, req)
+}
+
+// askReusableEventService asks the log coordinator which nodes have the data of the dispatcher.
+func (c *EventCollector) askReusableEventService(stat *dispatcherStat) {
+	c.coordinatorInfo.RLock()
+	coordinatorID := c.coordinatorInfo.id
+	c.coordinatorInfo.RUnlock()
+	if coordinatorID == "" {
+		return
+	}
+
+	stat.mu.Lock()
+	startTs := stat.resumeTs()
+	stat.lastCheckTime = time.Now()
+	stat.mu.Unlock()
+	req := &logservicepb.ReusableEventServiceRequest{
+		Id:      stat.target.GetId().ToPB(),
+		Span:    stat.target.GetTableSpan(),
+		StartTs: startTs,
+	}
+	err := c.mc.SendCommand(messaging.NewSingleTargetMessage(coordinatorID, messaging.LogCoordinatorTopic, req))
+	if err != nil {
+		log.Warn("failed to send reusable event service request to log coordinator",
+			zap.Stringer("coordinator", coordinatorID), zap.Error(err))
+	}
+}
+
+func (c *EventCollector) handleReusableEventServiceResponse(resp *logservicepb.ReusableEventServiceResponse) {
+	value, ok := c.dispatcherMap.Load(common.NewDispatcherIDFromPB(resp.Id))
+	if !ok || len(resp.Nodes) == 0 {
+		return
+	}
+	stat := value.(*dispatcherStat)
+	// The first node has the most data of the table span.
+	best := node.ID(resp.Nodes[0])
+
+	stat.mu.Lock()
+	current := stat.eventServiceID
+	stickToLocal := stat.stickToLocal
+	if current != c.serverId && best == c.serverId {
+		stat.stickToLocal = true
+	}
+	stat.mu.Unlock()
+
+	switch {
+	case current == c.serverId:
+		// The local event store lags behind, read from the remote one.
+		if !stickToLocal && best != c.serverId && c.isNodeAlive(best) {
+			c.switchEventService(stat, best)
+		}
+	case best == c.serverId:
+		// The local event store has caught up.
+		c.switchEventService(stat, c.serverId)
+	}
+}
+
+// checkRemoteEventServices periodically asks the log coordinator
+// whether the dispatchers reading from remote event services can switch back.
+func (c *EventCollector) checkRemoteEventServices(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.dispatcherMap.Range(func(_, value interface{}) bool {
+				stat := value.(*dispatcherStat)
+				stat.mu.Lock()
+				needCheck := stat.eventServiceID != c.serverId &&
+					time.Since(stat.lastCheckTime) >= time.Duration(c.cfg.RemoteEventServiceCheckInterval)
+				stat.mu.Unlock()
+				if needCheck {
+					c.askReusableEventService(stat)
+				}
+				return true
+			})
+		}
+	}
+}
+
+// OnNodeChanges is called when the alive nodes change.
+// The dispatchers reading from the event service of a removed node switch back to the local event service.
+func (c *EventCollector) OnNodeChanges(allNodes map[node.ID]*node.Info) {
+	c.aliveNodes.Lock()
+	c.aliveNodes.m = allNodes
+	c.aliveNodes.Unlock()
+
+	c.dispatcherMap.Range(func(_, value interface{}) bool {
+		stat := value.(*dispatcherStat)
+		id := stat.getEventServiceID()
+		if _, ok := allNodes[id]; !ok && id != c.serverId {
+			log.Info("remote event service is removed, switch back to local",
+				zap.Stringer("dispatcher", stat.target.GetId()), zap.Stringer("node", id))
+			c.switchEventService(stat, c.serverId)
+		}
+		return true
+	})
+}
+
+func (c *EventCollector) isNodeAlive(id node.ID) bool {
+	c.aliveNodes.RLock()
+	defer c.aliveNodes.RUnlock()
+	if c.aliveNodes.m == nil || id == c.serverId {
+		return true
+	}
+	_, ok := c.aliveNodes.m[id]
+	return ok
+}
+
+// filterEvent returns whether the event should be sent to the dispatcher.
+func (c *EventCollector) filterEvent(from node.ID, event commonEvent.Event) bool {
+	value, ok := c.dispatcherMap.Load(event.GetDispatcherID())
+	if !ok {
+		return true
+	}
+	return value.(*dispatcherStat).filterEvent(from, event)
 }
 
 // RecvEventsMessage is the handler for the events message from EventService.
+// It also handles the messages from the log coordinator.
 func (c *EventCollector) RecvEventsMessage(_ context.Context, msg *messaging.TargetMessage) error {
+	switch msg.Type {
+	case messaging.TypeLogCoordinatorBroadcastRequest:
+		c.coordinatorInfo.Lock()
+		c.coordinatorInfo.id = msg.From
+		c.coordinatorInfo.Unlock()
+		return nil
+	case messaging.TypeReusableEventServiceResponse:
+		for _, m := range msg.Message {
+			c.handleReusableEventServiceResponse(m.(*logservicepb.ReusableEventServiceResponse))
+		}
+		return nil
+	case messaging.TypeDispatcherError:
+		for _, m := range msg.Message {
+			c.handleDispatcherError(msg.From, m.(*eventpb.DispatcherError))
+		}
+		return nil
+	}
+
 	inflightDuration := time.Since(time.Unix(0, msg.CreateAt)).Milliseconds()
 	c.metricReceiveEventLagDuration.Observe(float64(inflightDuration))
-	for _, msg := range msg.Message {
-		event, ok := msg.(commonEvent.Event)
+	for _, m := range msg.Message {
+		event, ok := m.(commonEvent.Event)
 		if !ok {
-			log.Panic("invalid message type", zap.Any("msg", msg))
+			log.Panic("invalid message type", zap.Any("msg", m))
 		}
 		switch event.GetType() {
 		case commonEvent.TypeBatchResolvedEvent:
 			for _, e := range event.(*commonEvent.BatchResolvedEvent).Events {
+				if !c.filterEvent(msg.From, e) {
+					continue
+				}
 				c.metricDispatcherReceivedResolvedTsEventCount.Inc()
 				c.ds.In() <- dispatcher.NewDispatcherEvent(e)
 			}
 		default:
+			if !c.filterEvent(msg.From, event) {
+				continue
+			}
 			c.metricDispatcherReceivedKVEventCount.Inc()
 			c.ds.In() <- dispatcher.NewDispatcherEvent(event)
 		}
@@ -181,6 +465,34 @@ func (c *EventCollector) RecvEventsMessage(_ context.Context, msg *messaging.Tar
 	return nil
 }
 
+// handleDispatcherError reports the error of the dispatcher sent by the event service
+// to the error channel of the dispatcher.
+func (c *EventCollector) handleDispatcherError(from node.ID, e *eventpb.DispatcherError) {
+	id := common.NewDispatcherIDFromPB(e.DispatcherId)
+	value, ok := c.dispatcherMap.Load(id)
+	if !ok {
+		return
+	}
+	stat := value.(*dispatcherStat)
+	// The errors from the event services the dispatcher doesn't read from are stale.
+	if stat.getEventServiceID() != from {
+		return
+	}
+	// Keep the error code, so the changefeed is failed with the original error.
+	err := errors.Normalize("%s", errors.RFCCodeText(e.Err.GetCode())).GenWithStackByArgs(e.Err.GetMessage())
+	log.Error("event service meets error",
+		zap.Stringer("dispatcher", id),
+		zap.Stringer("eventServiceID", from),
+		zap.Error(err))
+	select {
+	case stat.registerReq.ErrCh <- err:
+	default:
+		log.Warn("dispatcher error channel is full, the error is dropped",
+			zap.Stringer("dispatcher", id),
+			zap.Error(err))
+	}
+}
+
 func (c *EventCollector) updateMetrics(ctx context.Context) {
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
@@ -199,10 +511,9 @@ func (c *EventCollector) updateMetrics(ctx context.Context) {
 func (c *EventCollector) updateResolvedTsMetric() {
 	var minResolvedTs uint64
 	c.dispatcherMap.Range(func(_, value interface{}) bool {
-		if d, ok := value.(*dispatcher.Dispatcher); ok {
-			if minResolvedTs == 0 || d.GetResolvedTs() < minResolvedTs {
-				minResolvedTs = d.GetResolvedTs()
-			}
+		d := value.(*dispatcherStat).target
+		if minResolvedTs == 0 || d.GetResolvedTs() < minResolvedTs {
+			minResolvedTs = d.GetResolvedTs()
 		}
 		return true
 	})
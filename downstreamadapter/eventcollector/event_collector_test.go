@@ -0,0 +1,121 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventcollector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/ticdc/downstreamadapter/dispatcher"
+	"github.com/pingcap/ticdc/downstreamadapter/syncpoint"
+	"github.com/pingcap/ticdc/eventpb"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/messaging"
+	"github.com/pingcap/ticdc/pkg/node"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecvDispatcherError(t *testing.T) {
+	local, remote := node.ID("local"), node.ID("remote")
+	id := common.NewDispatcherID()
+	errCh := make(chan error, 1)
+	c := &EventCollector{}
+	c.dispatcherMap.Store(id, newDispatcherStat(DispatcherRequest{StartTs: 100, ErrCh: errCh}, local))
+
+	newMsg := func(from node.ID, id common.DispatcherID) *messaging.TargetMessage {
+		msg := messaging.NewSingleTargetMessage(local, messaging.EventCollectorTopic, &eventpb.DispatcherError{
+			DispatcherId: id.ToPB(),
+			Err: &heartbeatpb.RunningError{
+				Code:    string(cerror.ErrFailedToFilterDML.RFCCode()),
+				Message: "decode row failed",
+			},
+		})
+		msg.From = from
+		return msg
+	}
+
+	// the error from the event service the dispatcher doesn't read from is dropped.
+	require.NoError(t, c.RecvEventsMessage(context.Background(), newMsg(remote, id)))
+	require.Len(t, errCh, 0)
+	// the error of an unknown dispatcher is dropped.
+	require.NoError(t, c.RecvEventsMessage(context.Background(), newMsg(local, common.NewDispatcherID())))
+	require.Len(t, errCh, 0)
+
+	require.NoError(t, c.RecvEventsMessage(context.Background(), newMsg(local, id)))
+	err := <-errCh
+	require.ErrorContains(t, err, "decode row failed")
+	code, ok := cerror.RFCCode(err)
+	require.True(t, ok)
+	require.Equal(t, cerror.ErrFailedToFilterDML.RFCCode(), code)
+}
+
+type recordMessageCenter struct {
+	messaging.MessageCenter
+	msgs []*messaging.TargetMessage
+}
+
+func (m *recordMessageCenter) SendCommand(msg *messaging.TargetMessage) error {
+	m.msgs = append(m.msgs, msg)
+	return nil
+}
+
+func TestRemoteEventServiceRemoved(t *testing.T) {
+	local, remote := node.ID("local"), node.ID("remote")
+	mc := &recordMessageCenter{}
+	c := &EventCollector{serverId: local, mc: mc}
+	c.OnNodeChanges(map[node.ID]*node.Info{local: {ID: local}, remote: {ID: remote}})
+
+	d := &dispatcher.Dispatcher{SyncPointInfo: &syncpoint.SyncPointInfo{}}
+	id := d.GetId()
+	stat := newDispatcherStat(DispatcherRequest{Dispatcher: d, StartTs: 100, Timezone: "Asia/Shanghai"}, local)
+	c.dispatcherMap.Store(id, stat)
+	require.True(t, c.filterEvent(local, commonEvent.NewHandshakeEvent(id, 100, 1, nil)))
+	c.switchEventService(stat, remote)
+	// the dispatcher is paused in the local event service, and registered to the remote one with its timezone.
+	require.Len(t, mc.msgs, 2)
+	req := mc.msgs[1].Message[0].(*messaging.RegisterDispatcherRequest)
+	require.Equal(t, remote, mc.msgs[1].To)
+	require.Equal(t, eventpb.ActionType_ACTION_TYPE_REGISTER, req.ActionType)
+	require.Equal(t, "Asia/Shanghai", req.GetTimezone())
+	mc.msgs = nil
+
+	require.False(t, c.filterEvent(remote, commonEvent.NewHandshakeEvent(id, 100, 1, nil)))
+	require.True(t, c.filterEvent(remote, commonEvent.ResolvedEvent{DispatcherID: id, ResolvedTs: 110}))
+	// the remote event service is removed when only one of the two transactions committed at 120 is received.
+	dml := &commonEvent.DMLEvent{DispatcherID: id, StartTs: 115, CommitTs: 120, Seq: 2}
+	require.True(t, c.filterEvent(remote, dml))
+	require.Equal(t, uint64(2), dml.Seq)
+	c.OnNodeChanges(map[node.ID]*node.Info{local: {ID: local}})
+
+	// the dispatcher is reset in the local event service from the ts before 120.
+	require.Len(t, mc.msgs, 1)
+	require.Equal(t, local, mc.msgs[0].To)
+	req = mc.msgs[0].Message[0].(*messaging.RegisterDispatcherRequest)
+	require.Equal(t, eventpb.ActionType_ACTION_TYPE_RESET, req.ActionType)
+	require.Equal(t, uint64(119), req.StartTs)
+	require.Equal(t, local, stat.getEventServiceID())
+
+	// the received transaction is dropped, the missing one is sent with the next seq.
+	require.False(t, c.filterEvent(local, commonEvent.NewHandshakeEvent(id, 119, 1, nil)))
+	require.False(t, c.filterEvent(local, &commonEvent.DMLEvent{DispatcherID: id, StartTs: 115, CommitTs: 120, Seq: 2}))
+	dml = &commonEvent.DMLEvent{DispatcherID: id, StartTs: 116, CommitTs: 120, Seq: 3}
+	require.True(t, c.filterEvent(local, dml))
+	require.Equal(t, uint64(3), dml.Seq)
+	dml = &commonEvent.DMLEvent{DispatcherID: id, StartTs: 125, CommitTs: 130, Seq: 4}
+	require.True(t, c.filterEvent(local, dml))
+	require.Equal(t, uint64(4), dml.Seq)
+}
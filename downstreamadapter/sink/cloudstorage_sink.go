@@ -0,0 +1,182 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"math"
+	"net/url"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/types"
+	"github.com/pingcap/ticdc/downstreamadapter/worker"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	ticonfig "github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
+	sinkutil "github.com/pingcap/ticdc/pkg/sink/util"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	putil "github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+// CloudStorageSink writes the events to the cloud storage system, e.g. s3, gcs, azblob or local filesystem.
+// The DML events are encoded as csv or canal-json files, and the DDL events are written as schema files.
+type CloudStorageSink struct {
+	changefeedID model.ChangeFeedID
+	storage      storage.ExternalStorage
+	statistics   *metrics.Statistics
+
+	dmlWorker *worker.CloudStorageDMLWorker
+	ddlWorker *worker.CloudStorageDDLWorker
+
+	// errCh is used to report the errors of writing events to the external storage.
+	errCh chan<- error
+}
+
+func (s *CloudStorageSink) SinkType() SinkType {
+	return CloudStorageSinkType
+}
+
+// NewCloudStorageSink creates a cloud storage sink.
+// The errors of writing events are reported to errCh.
+func NewCloudStorageSink(changefeedID model.ChangeFeedID, sinkURI *url.URL, sinkConfig *ticonfig.SinkConfig, errCh chan<- error) (*CloudStorageSink, error) {
+	ctx := context.Background()
+	// create cloud storage config and then apply the params of sinkURI to it.
+	cfg := cloudstorage.NewConfig()
+	if err := cfg.Apply(sinkURI, sinkConfig); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	protocol, err := helper.GetProtocol(putil.GetOrZero(sinkConfig.Protocol))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if protocol != ticonfig.ProtocolCsv && protocol != ticonfig.ProtocolCanalJSON {
+		return nil, cerror.ErrSinkURIInvalid.GenWithStackByArgs(
+			"unsupported protocol, cloud storage sink currently only support these protocols: [csv, canal-json]")
+	}
+	// the last param maxMessageBytes is mainly to limit the size of a single message for
+	// batch protocols in mq scenario. In cloud storage sink, we just set it to max int.
+	encoderConfig, err := sinkutil.GetEncoderConfig(changefeedID, sinkURI, protocol, sinkConfig, math.MaxInt)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	storage, err := putil.GetExternalStorageFromURI(ctx, sinkURI.String())
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrStorageInitialize, err)
+	}
+
+	statistics := metrics.NewStatistics(changefeedID, "CloudStorageSink")
+	dmlWorker, err := worker.NewCloudStorageDMLWorker(changefeedID, storage, cfg, encoderConfig,
+		helper.GetFileExtension(protocol), statistics, nil, errCh)
+	if err != nil {
+		storage.Close()
+		return nil, errors.Trace(err)
+	}
+	ddlWorker, err := worker.NewCloudStorageDDLWorker(changefeedID, sinkURI, cfg, storage, statistics, errCh)
+	if err != nil {
+		dmlWorker.Close()
+		storage.Close()
+		return nil, errors.Trace(err)
+	}
+
+	log.Info("cloud storage sink created",
+		zap.String("namespace", changefeedID.Namespace),
+		zap.String("changefeed", changefeedID.ID),
+		zap.String("protocol", protocol.String()),
+		zap.Int("workerCount", cfg.WorkerCount),
+		zap.Duration("flushInterval", cfg.FlushInterval))
+	return &CloudStorageSink{
+		changefeedID: changefeedID,
+		storage:      storage,
+		statistics:   statistics,
+		dmlWorker:    dmlWorker,
+		ddlWorker:    ddlWorker,
+		errCh:        errCh,
+	}, nil
+}
+
+func (s *CloudStorageSink) AddDMLEvent(event *commonEvent.DMLEvent, tableProgress *types.TableProgress) {
+	if event.Len() == 0 {
+		return
+	}
+	tableProgress.Add(event)
+	s.dmlWorker.AddDMLEvent(event)
+}
+
+func (s *CloudStorageSink) PassBlockEvent(event commonEvent.BlockEvent, tableProgress *types.TableProgress) {
+	tableProgress.Pass(event)
+}
+
+func (s *CloudStorageSink) AddBlockEvent(event commonEvent.BlockEvent, tableProgress *types.TableProgress) {
+	tableProgress.Add(event)
+	switch e := event.(type) {
+	case *commonEvent.DDLEvent:
+		if e.TiDBOnly {
+			// run callback directly and return
+			e.PostFlush()
+			return
+		}
+		if err := s.ddlWorker.WriteBlockEvent(e); err != nil {
+			log.Error("CloudStorageSink failed to write ddl event",
+				zap.String("namespace", s.changefeedID.Namespace),
+				zap.String("changefeed", s.changefeedID.ID),
+				zap.String("query", e.Query),
+				zap.Error(err))
+			// The DDL is not flushed, so the dispatcher keeps blocking on it
+			// until the changefeed is restarted by the error.
+			select {
+			case s.errCh <- err:
+			default:
+				log.Warn("CloudStorageSink error channel is full, the error is dropped",
+					zap.String("namespace", s.changefeedID.Namespace),
+					zap.String("changefeed", s.changefeedID.ID),
+					zap.Error(err))
+			}
+		}
+	case *commonEvent.SyncPointEvent:
+		log.Error("CloudStorageSink doesn't support Sync Point Event",
+			zap.String("namespace", s.changefeedID.Namespace),
+			zap.String("changefeed", s.changefeedID.ID))
+	}
+}
+
+func (s *CloudStorageSink) AddCheckpointTs(ts uint64) {
+	s.ddlWorker.GetCheckpointTsChan() <- ts
+}
+
+func (s *CloudStorageSink) SetTableSchemaStore(tableSchemaStore *sinkutil.TableSchemaStore) {
+}
+
+func (s *CloudStorageSink) CheckStartTs(tableId int64, startTs uint64) (int64, error) {
+	return int64(startTs), nil
+}
+
+func (s *CloudStorageSink) Close(removeDDLTsItem bool) error {
+	s.dmlWorker.Close()
+	s.ddlWorker.Close()
+	if s.storage != nil {
+		s.storage.Close()
+	}
+	if s.statistics != nil {
+		s.statistics.Close()
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/downstreamadapter/sink/types"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func newCloudStorageSinkForTest(t *testing.T, parentDir string, protocol string) *CloudStorageSink {
+	uri := fmt.Sprintf("file:///%s?protocol=%s&flush-interval=2s", parentDir, protocol)
+	sinkURI, err := url.Parse(uri)
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	s, err := NewCloudStorageSink(model.DefaultChangeFeedID("test"), sinkURI, replicaConfig.Sink, make(chan error, 16))
+	require.NoError(t, err)
+	return s
+}
+
+func TestCloudStorageSinkUnsupportedProtocol(t *testing.T) {
+	sinkURI, err := url.Parse(fmt.Sprintf("file:///%s?protocol=csv", t.TempDir()))
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	protocol := config.ProtocolOpen.String()
+	replicaConfig.Sink.Protocol = &protocol
+
+	_, err = NewCloudStorageSink(model.DefaultChangeFeedID("test"), sinkURI, replicaConfig.Sink, make(chan error, 16))
+	require.Error(t, err)
+}
+
+func TestCloudStorageSinkBasicFunctionality(t *testing.T) {
+	parentDir := t.TempDir()
+	s := newCloudStorageSinkForTest(t, parentDir, "csv")
+	defer s.Close(false)
+	require.Equal(t, CloudStorageSinkType, s.SinkType())
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(a tinyint primary key, b int)`)
+	tableInfo := helper.GetTableInfo(job)
+
+	ddlEvent := &commonEvent.DDLEvent{
+		Query:      job.Query,
+		Type:       byte(job.Type),
+		SchemaName: job.SchemaName,
+		TableName:  job.TableName,
+		FinishedTs: 1,
+		TableInfo:  tableInfo,
+	}
+	ddlFlushed := false
+	ddlEvent.AddPostFlushFunc(func() { ddlFlushed = true })
+
+	tableProgress := types.NewTableProgress()
+	s.AddBlockEvent(ddlEvent, tableProgress)
+	require.True(t, ddlFlushed)
+
+	schemaFiles, err := os.ReadDir(filepath.Join(parentDir, "test/t/meta"))
+	require.NoError(t, err)
+	require.Len(t, schemaFiles, 1)
+
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1, 1)`, `insert into test.t values (2, 2)`)
+	dmlEvent.CommitTs = 2
+	dmlEvent.TableInfoVersion = 1
+	dmlFlushed := make(chan struct{})
+	dmlEvent.AddPostFlushFunc(func() { close(dmlFlushed) })
+	s.AddDMLEvent(dmlEvent, tableProgress)
+	select {
+	case <-dmlFlushed:
+	case <-time.After(20 * time.Second):
+		require.FailNow(t, "dml event is not flushed")
+	}
+
+	var content []byte
+	err = filepath.WalkDir(filepath.Join(parentDir, "test/t/1"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if filepath.Ext(path) == ".csv" {
+			content, err = os.ReadFile(path)
+		}
+		return err
+	})
+	require.NoError(t, err)
+	require.Contains(t, string(content), `"I","t","test",1,1`)
+	require.Contains(t, string(content), `"I","t","test",2,2`)
+
+	checkpointTs, isEmpty := tableProgress.GetCheckpointTs()
+	require.True(t, isEmpty)
+	require.Equal(t, uint64(1), checkpointTs)
+}
+
+func TestCloudStorageSinkReportDDLError(t *testing.T) {
+	parentDir := t.TempDir()
+	// The schema file of test.t can't be written since "test" is a regular file.
+	require.NoError(t, os.WriteFile(filepath.Join(parentDir, "test"), nil, 0o644))
+
+	uri := fmt.Sprintf("file:///%s?protocol=csv", parentDir)
+	sinkURI, err := url.Parse(uri)
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	errCh := make(chan error, 16)
+	s, err := NewCloudStorageSink(model.DefaultChangeFeedID("test"), sinkURI, replicaConfig.Sink, errCh)
+	require.NoError(t, err)
+	defer s.Close(false)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(a tinyint primary key, b int)`)
+	ddlEvent := &commonEvent.DDLEvent{
+		Query:      job.Query,
+		Type:       byte(job.Type),
+		SchemaName: job.SchemaName,
+		TableName:  job.TableName,
+		FinishedTs: 1,
+		TableInfo:  helper.GetTableInfo(job),
+	}
+	ddlFlushed := false
+	ddlEvent.AddPostFlushFunc(func() { ddlFlushed = true })
+
+	s.AddBlockEvent(ddlEvent, types.NewTableProgress())
+	require.False(t, ddlFlushed)
+	select {
+	case err := <-errCh:
+		require.Error(t, err)
+	default:
+		require.FailNow(t, "ddl error is not reported")
+	}
+}
+
+func TestCloudStorageSinkReportCheckpointTsError(t *testing.T) {
+	parentDir := t.TempDir()
+	// The metadata file can't be written since "metadata" is a directory.
+	require.NoError(t, os.Mkdir(filepath.Join(parentDir, "metadata"), 0o755))
+
+	uri := fmt.Sprintf("file:///%s?protocol=csv", parentDir)
+	sinkURI, err := url.Parse(uri)
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	errCh := make(chan error, 16)
+	s, err := NewCloudStorageSink(model.DefaultChangeFeedID("test"), sinkURI, replicaConfig.Sink, errCh)
+	require.NoError(t, err)
+	defer s.Close(false)
+
+	// the checkpoint ts is written at most once every 2 seconds.
+	ts := uint64(1)
+	require.Eventually(t, func() bool {
+		s.AddCheckpointTs(ts)
+		ts++
+		select {
+		case err := <-errCh:
+			return err != nil
+		default:
+			return false
+		}
+	}, 5*time.Second, 100*time.Millisecond)
+}
@@ -58,3 +58,8 @@ func GetFileExtension(protocol config.Protocol) string {
 		return ".unknown"
 	}
 }
+
+// IsPulsarSupportedProtocols returns whether the protocol is supported by pulsar.
+func IsPulsarSupportedProtocols(p config.Protocol) bool {
+	return p == config.ProtocolCanalJSON || p == config.ProtocolOpen
+}
@@ -0,0 +1,42 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topicmanager
+
+import (
+	"context"
+)
+
+// pulsarTopicManager is a manager for pulsar topics.
+// Pulsar creates the topic automatically when the producer is created,
+// so it has nothing to do but meet the requirement of the mq workers.
+type pulsarTopicManager struct{}
+
+// NewPulsarTopicManager creates a new topic manager for pulsar.
+func NewPulsarTopicManager() TopicManager {
+	return &pulsarTopicManager{}
+}
+
+// GetPartitionNum always returns 1 because we pass a message key to pulsar producer,
+// and pulsar producer will hash the key to a partition.
+func (m *pulsarTopicManager) GetPartitionNum(_ context.Context, _ string) (int32, error) {
+	return 1, nil
+}
+
+// CreateTopicAndWaitUntilVisible no need to create first
+func (m *pulsarTopicManager) CreateTopicAndWaitUntilVisible(_ context.Context, _ string) (int32, error) {
+	return 1, nil
+}
+
+// Close closes the topic manager.
+func (m *pulsarTopicManager) Close() {}
@@ -44,21 +44,22 @@ type MysqlSink struct {
 	workerCount int
 }
 
-// func NewMysqlSink(changefeedID model.ChangeFeedID, workerCount int, cfg *mysql.MysqlConfig, db *sql.DB) *MysqlSink {
-func NewMysqlSink(changefeedID model.ChangeFeedID, workerCount int, config *config.ChangefeedConfig, sinkURI *url.URL) (*MysqlSink, error) {
+// NewMysqlSink creates a mysql sink, the worker count and other options are
+// parsed from the sink URI and the sink config of the changefeed.
+func NewMysqlSink(changefeedID model.ChangeFeedID, config *config.ChangefeedConfig, sinkURI *url.URL) (*MysqlSink, error) {
 	ctx := context.Background()
-	mysqlSink := MysqlSink{
-		changefeedID: changefeedID,
-		dmlWorker:    make([]*worker.MysqlWorker, workerCount),
-		workerCount:  workerCount,
-	}
-
-	cfg, db, err := mysql.NewMysqlConfigAndDB(ctx, changefeedID, sinkURI)
+	cfg, db, err := mysql.NewMysqlConfigAndDB(ctx, changefeedID, sinkURI, config)
 	if err != nil {
 		return nil, err
 	}
 	cfg.SyncPointRetention = utils.GetOrZero(config.SyncPointRetention)
 
+	workerCount := cfg.WorkerCount
+	mysqlSink := MysqlSink{
+		changefeedID: changefeedID,
+		dmlWorker:    make([]*worker.MysqlWorker, workerCount),
+		workerCount:  workerCount,
+	}
 	for i := 0; i < workerCount; i++ {
 		mysqlSink.dmlWorker[i] = worker.NewMysqlWorker(db, cfg, i, mysqlSink.changefeedID, ctx)
 	}
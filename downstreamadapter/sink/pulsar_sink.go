@@ -0,0 +1,208 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/eventrouter"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/topicmanager"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/types"
+	"github.com/pingcap/ticdc/downstreamadapter/worker"
+	"github.com/pingcap/ticdc/downstreamadapter/worker/ddlproducer"
+	"github.com/pingcap/ticdc/downstreamadapter/worker/dmlproducer"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	ticonfig "github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/codec"
+	"github.com/pingcap/ticdc/pkg/sink/pulsar"
+	sinkutil "github.com/pingcap/ticdc/pkg/sink/util"
+	"github.com/pingcap/tiflow/cdc/model"
+	tiddlproducer "github.com/pingcap/tiflow/cdc/sink/ddlsink/mq/ddlproducer"
+	tidmlproducer "github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dmlproducer"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
+	utils "github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+type PulsarSink struct {
+	changefeedID model.ChangeFeedID
+
+	dmlWorker *worker.PulsarWorker
+	ddlWorker *worker.PulsarDDLWorker
+}
+
+func (s *PulsarSink) SinkType() SinkType {
+	return PulsarSinkType
+}
+
+// NewPulsarSink creates a pulsar sink, the DML and DDL producers use different
+// pulsar clients, so that they can be closed separately.
+// The errors of sending messages are reported to errCh.
+func NewPulsarSink(changefeedID model.ChangeFeedID, sinkURI *url.URL, sinkConfig *ticonfig.SinkConfig, errCh chan<- error) (*PulsarSink, error) {
+	pConfig, err := pulsar.NewPulsarConfig(sinkURI, sinkConfig.PulsarConfig)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrPulsarInvalidConfig, err)
+	}
+
+	dmlClient, err := pulsar.NewCreatorFactory(pConfig, changefeedID, sinkConfig)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrPulsarNewClient, err)
+	}
+	dmlProducer, err := dmlproducer.NewPulsarDMLProducer(changefeedID, dmlClient, pConfig, errCh)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	ddlClient, err := pulsar.NewCreatorFactory(pConfig, changefeedID, sinkConfig)
+	if err != nil {
+		dmlProducer.Close()
+		return nil, cerror.WrapError(cerror.ErrPulsarNewClient, err)
+	}
+	ddlProducer, err := ddlproducer.NewPulsarDDLProducer(changefeedID, ddlClient, pConfig)
+	if err != nil {
+		dmlProducer.Close()
+		ddlClient.Close()
+		return nil, errors.Trace(err)
+	}
+
+	s, err := newPulsarSinkWithProducer(changefeedID, sinkURI, sinkConfig, dmlProducer, ddlProducer, errCh)
+	if err != nil {
+		dmlProducer.Close()
+		ddlProducer.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// newPulsarSinkWithProducer creates a pulsar sink with the given producers,
+// it's separated from NewPulsarSink to make it testable with mock producers.
+func newPulsarSinkWithProducer(
+	changefeedID model.ChangeFeedID,
+	sinkURI *url.URL,
+	sinkConfig *ticonfig.SinkConfig,
+	dmlProducer tidmlproducer.DMLProducer,
+	ddlProducer tiddlproducer.DDLProducer,
+	errCh chan<- error,
+) (*PulsarSink, error) {
+	ctx := context.Background()
+	topic, err := helper.GetTopic(sinkURI)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	protocol, err := helper.GetProtocol(utils.GetOrZero(sinkConfig.Protocol))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !helper.IsPulsarSupportedProtocols(protocol) {
+		return nil, cerror.ErrSinkURIInvalid.GenWithStackByArgs(
+			"unsupported protocol, pulsar sink currently only support these protocols: [canal-json, open-protocol]")
+	}
+
+	scheme := sink.GetScheme(sinkURI)
+	eventRouter, err := eventrouter.NewEventRouter(sinkConfig, protocol, topic, scheme)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	columnSelector, err := common.NewColumnSelectors(sinkConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	encoderConfig, err := sinkutil.GetEncoderConfig(changefeedID, sinkURI, protocol, sinkConfig, ticonfig.DefaultMaxMessageBytes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	encoderGroup := codec.NewEncoderGroup(ctx, sinkConfig, encoderConfig, changefeedID)
+	if encoderGroup == nil {
+		return nil, cerror.ErrPulsarInvalidConfig.GenWithStackByArgs("failed to create encoder group")
+	}
+	encoder, err := codec.NewEventEncoder(ctx, encoderConfig)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrPulsarInvalidConfig, err)
+	}
+
+	statistics := metrics.NewStatistics(changefeedID, "PulsarSink")
+	// The topic manager is only used to get the partition number of the topic,
+	// pulsar hashes the message key to the partition by itself.
+	topicManager := topicmanager.NewPulsarTopicManager()
+	dmlWorker := worker.NewPulsarWorker(changefeedID, protocol, dmlProducer, encoderGroup, columnSelector, eventRouter, topicManager, statistics, errCh)
+	ddlWorker := worker.NewPulsarDDLWorker(changefeedID, protocol, ddlProducer, encoder, eventRouter, statistics, errCh)
+
+	log.Info("pulsar sink created",
+		zap.String("namespace", changefeedID.Namespace),
+		zap.String("changefeed", changefeedID.ID),
+		zap.String("topic", topic),
+		zap.String("protocol", protocol.String()))
+	return &PulsarSink{
+		changefeedID: changefeedID,
+		dmlWorker:    dmlWorker,
+		ddlWorker:    ddlWorker,
+	}, nil
+}
+
+func (s *PulsarSink) AddDMLEvent(event *commonEvent.DMLEvent, tableProgress *types.TableProgress) {
+	if event.Len() == 0 {
+		return
+	}
+	tableProgress.Add(event)
+	s.dmlWorker.GetEventChan() <- event
+}
+
+func (s *PulsarSink) PassBlockEvent(event commonEvent.BlockEvent, tableProgress *types.TableProgress) {
+	tableProgress.Pass(event)
+}
+
+func (s *PulsarSink) AddBlockEvent(event commonEvent.BlockEvent, tableProgress *types.TableProgress) {
+	tableProgress.Add(event)
+	switch e := event.(type) {
+	case *commonEvent.DDLEvent:
+		if e.TiDBOnly {
+			// run callback directly and return
+			e.PostFlush()
+			return
+		}
+		s.ddlWorker.GetDDLEventChan() <- e
+	case *commonEvent.SyncPointEvent:
+		log.Error("PulsarSink doesn't support Sync Point Event",
+			zap.String("namespace", s.changefeedID.Namespace),
+			zap.String("changefeed", s.changefeedID.ID))
+	}
+}
+
+func (s *PulsarSink) AddCheckpointTs(ts uint64) {
+	s.ddlWorker.GetCheckpointTsChan() <- ts
+}
+
+func (s *PulsarSink) SetTableSchemaStore(tableSchemaStore *sinkutil.TableSchemaStore) {
+	s.ddlWorker.SetTableSchemaStore(tableSchemaStore)
+}
+
+func (s *PulsarSink) CheckStartTs(tableId int64, startTs uint64) (int64, error) {
+	return int64(startTs), nil
+}
+
+func (s *PulsarSink) Close(removeDDLTsItem bool) error {
+	s.dmlWorker.Close()
+	s.ddlWorker.Close()
+	return nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/types"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+// mockPulsarProducer records the messages sent to each topic in memory,
+// it's used as both the DML producer and the DDL producer.
+type mockPulsarProducer struct {
+	mu       sync.Mutex
+	messages map[string][]*common.Message
+	closed   bool
+	// sendErr is returned by the sync sending if it's not nil.
+	sendErr error
+}
+
+func newMockPulsarProducer() *mockPulsarProducer {
+	return &mockPulsarProducer{messages: make(map[string][]*common.Message)}
+}
+
+func (m *mockPulsarProducer) AsyncSendMessage(_ context.Context, topic string, _ int32, message *common.Message) error {
+	m.mu.Lock()
+	m.messages[topic] = append(m.messages[topic], message)
+	m.mu.Unlock()
+	if message.Callback != nil {
+		message.Callback()
+	}
+	return nil
+}
+
+func (m *mockPulsarProducer) SyncBroadcastMessage(ctx context.Context, topic string, totalPartitionsNum int32, message *common.Message) error {
+	return m.SyncSendMessage(ctx, topic, totalPartitionsNum, message)
+}
+
+func (m *mockPulsarProducer) SyncSendMessage(_ context.Context, topic string, _ int32, message *common.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sendErr != nil {
+		return m.sendErr
+	}
+	m.messages[topic] = append(m.messages[topic], message)
+	return nil
+}
+
+func (m *mockPulsarProducer) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+}
+
+func (m *mockPulsarProducer) getMessages(topic string) []*common.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*common.Message(nil), m.messages[topic]...)
+}
+
+func newPulsarSinkForTest(t *testing.T, protocol string) (*PulsarSink, *mockPulsarProducer, *mockPulsarProducer) {
+	sinkURI, err := url.Parse("pulsar://127.0.0.1:6650/test-topic?protocol=" + protocol)
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	dmlProducer := newMockPulsarProducer()
+	ddlProducer := newMockPulsarProducer()
+	s, err := newPulsarSinkWithProducer(model.DefaultChangeFeedID("test"), sinkURI, replicaConfig.Sink, dmlProducer, ddlProducer, make(chan error, 16))
+	require.NoError(t, err)
+	return s, dmlProducer, ddlProducer
+}
+
+func TestPulsarSinkUnsupportedProtocol(t *testing.T) {
+	sinkURI, err := url.Parse("pulsar://127.0.0.1:6650/test-topic?protocol=canal-json")
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	protocol := config.ProtocolAvro.String()
+	replicaConfig.Sink.Protocol = &protocol
+
+	_, err = newPulsarSinkWithProducer(model.DefaultChangeFeedID("test"), sinkURI, replicaConfig.Sink,
+		newMockPulsarProducer(), newMockPulsarProducer(), make(chan error, 16))
+	require.Error(t, err)
+}
+
+func TestPulsarSinkBasicFunctionality(t *testing.T) {
+	s, dmlProducer, ddlProducer := newPulsarSinkForTest(t, "canal-json&enable-tidb-extension=true")
+	defer s.Close(false)
+	require.Equal(t, PulsarSinkType, s.SinkType())
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(a tinyint primary key, b int)`)
+	tableInfo := helper.GetTableInfo(job)
+
+	ddlEvent := &commonEvent.DDLEvent{
+		Query:      job.Query,
+		SchemaName: job.SchemaName,
+		TableName:  job.TableName,
+		FinishedTs: 1,
+		TableInfo:  tableInfo,
+	}
+	ddlFlushed := make(chan struct{})
+	ddlEvent.AddPostFlushFunc(func() { close(ddlFlushed) })
+
+	tableProgress := types.NewTableProgress()
+	s.AddBlockEvent(ddlEvent, tableProgress)
+	select {
+	case <-ddlFlushed:
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "ddl event is not flushed")
+	}
+	require.Len(t, ddlProducer.getMessages("test-topic"), 1)
+
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1, 1)`, `insert into test.t values (2, 2)`)
+	dmlEvent.CommitTs = 2
+	dmlFlushed := make(chan struct{})
+	dmlEvent.AddPostFlushFunc(func() { close(dmlFlushed) })
+	s.AddDMLEvent(dmlEvent, tableProgress)
+	select {
+	case <-dmlFlushed:
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "dml event is not flushed")
+	}
+	require.Len(t, dmlProducer.getMessages("test-topic"), 2)
+
+	checkpointTs, isEmpty := tableProgress.GetCheckpointTs()
+	require.True(t, isEmpty)
+	require.Equal(t, uint64(1), checkpointTs)
+
+	s.AddCheckpointTs(3)
+	require.Eventually(t, func() bool {
+		return len(ddlProducer.getMessages("test-topic")) == 2
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestPulsarSinkReportDDLError(t *testing.T) {
+	sinkURI, err := url.Parse("pulsar://127.0.0.1:6650/test-topic?protocol=canal-json")
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	ddlProducer := newMockPulsarProducer()
+	ddlProducer.sendErr = errors.New("pulsar is unavailable")
+	errCh := make(chan error, 16)
+	s, err := newPulsarSinkWithProducer(model.DefaultChangeFeedID("test"), sinkURI, replicaConfig.Sink,
+		newMockPulsarProducer(), ddlProducer, errCh)
+	require.NoError(t, err)
+	defer s.Close(false)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(a tinyint primary key, b int)`)
+	ddlEvent := &commonEvent.DDLEvent{
+		Query:      job.Query,
+		SchemaName: job.SchemaName,
+		TableName:  job.TableName,
+		FinishedTs: 1,
+		TableInfo:  helper.GetTableInfo(job),
+	}
+	flushed := atomic.NewBool(false)
+	ddlEvent.AddPostFlushFunc(func() { flushed.Store(true) })
+
+	s.AddBlockEvent(ddlEvent, types.NewTableProgress())
+	select {
+	case err := <-errCh:
+		require.ErrorContains(t, err, "pulsar is unavailable")
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "ddl error is not reported")
+	}
+	// The failed DDL must not be treated as flushed.
+	require.False(t, flushed.Load())
+}
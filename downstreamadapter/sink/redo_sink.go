@@ -0,0 +1,106 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/types"
+	"github.com/pingcap/ticdc/downstreamadapter/worker"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/redo"
+	"go.uber.org/zap"
+)
+
+// RedoSink writes the events to the redo log before they are sent to the downstream sink.
+// It is only created when the consistent level of the changefeed is `eventual`.
+// All the dispatchers of a changefeed in the same node share the same RedoSink.
+type RedoSink struct {
+	changefeedID model.ChangeFeedID
+
+	dmlWorker *worker.RedoDMLWorker
+	ddlWorker *worker.RedoDDLWorker
+
+	// errCh is used to report the errors of writing the redo log.
+	errCh chan<- error
+}
+
+// IsRedoEnabled returns whether the redo log is enabled by the consistent config.
+func IsRedoEnabled(cfg *config.ConsistentConfig) bool {
+	return cfg != nil && redo.IsConsistentEnabled(cfg.Level)
+}
+
+// NewRedoSink creates a redo sink, the errors of writing the redo log are reported to errCh.
+func NewRedoSink(changefeedID model.ChangeFeedID, cfg *config.ConsistentConfig, errCh chan<- error) (*RedoSink, error) {
+	dmlWorker, err := worker.NewRedoDMLWorker(changefeedID, cfg, errCh)
+	if err != nil {
+		return nil, err
+	}
+	ddlWorker, err := worker.NewRedoDDLWorker(changefeedID, cfg)
+	if err != nil {
+		dmlWorker.Close()
+		return nil, err
+	}
+	log.Info("redo sink is created",
+		zap.String("namespace", changefeedID.Namespace),
+		zap.String("changefeed", changefeedID.ID),
+		zap.String("storage", cfg.Storage))
+	return &RedoSink{
+		changefeedID: changefeedID,
+		dmlWorker:    dmlWorker,
+		ddlWorker:    ddlWorker,
+		errCh:        errCh,
+	}, nil
+}
+
+// AddDMLEvent writes the dml event to the redo log asynchronously.
+// The event stays in redoProgress until it is flushed to the redo storage,
+// then callback is called to send the event to the downstream sink.
+// The event is removed from redoProgress after callback, so it's always
+// tracked by either redoProgress or the tableProgress of the downstream sink.
+func (s *RedoSink) AddDMLEvent(event *commonEvent.DMLEvent, redoProgress *types.TableProgress, callback func()) {
+	redoProgress.Add(event)
+	s.dmlWorker.AddDMLEvent(event, func() {
+		callback()
+		redoProgress.Remove(event)
+	})
+}
+
+// WriteBlockEvent writes the block event to the redo log and waits for it to be flushed.
+// Only ddl events are recorded, sync point events are meaningless for the redo applier.
+// The error is also reported to errCh to fail the changefeed, the caller must not
+// write the event to the downstream if an error is returned.
+func (s *RedoSink) WriteBlockEvent(event commonEvent.BlockEvent) error {
+	if event.GetType() != commonEvent.TypeDDLEvent {
+		return nil
+	}
+	err := s.ddlWorker.WriteDDLEvent(event.(*commonEvent.DDLEvent))
+	if err != nil {
+		select {
+		case s.errCh <- err:
+		default:
+			log.Warn("redo sink error channel is full, the error is dropped",
+				zap.String("namespace", s.changefeedID.Namespace),
+				zap.String("changefeed", s.changefeedID.ID),
+				zap.Error(err))
+		}
+	}
+	return err
+}
+
+func (s *RedoSink) Close() {
+	s.dmlWorker.Close()
+	s.ddlWorker.Close()
+}
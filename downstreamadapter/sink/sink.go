@@ -31,6 +31,8 @@ type SinkType int
 const (
 	MysqlSinkType SinkType = iota
 	KafkaSinkType
+	PulsarSinkType
+	CloudStorageSinkType
 )
 
 type Sink interface {
@@ -44,7 +46,9 @@ type Sink interface {
 	SinkType() SinkType
 }
 
-func NewSink(ctx context.Context, config *config.ChangefeedConfig, changefeedID model.ChangeFeedID) (Sink, error) {
+// NewSink creates the sink of the changefeed by the scheme of the sink uri,
+// the errors met by the background workers of the sink are reported to errCh.
+func NewSink(ctx context.Context, config *config.ChangefeedConfig, changefeedID model.ChangeFeedID, errCh chan<- error) (Sink, error) {
 	sinkURI, err := url.Parse(config.SinkURI)
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
@@ -52,13 +56,25 @@ func NewSink(ctx context.Context, config *config.ChangefeedConfig, changefeedID
 	scheme := sink.GetScheme(sinkURI)
 	switch scheme {
 	case sink.MySQLScheme, sink.MySQLSSLScheme, sink.TiDBScheme, sink.TiDBSSLScheme:
-		return NewMysqlSink(changefeedID, 16, config, sinkURI)
+		return NewMysqlSink(changefeedID, config, sinkURI)
 	case sink.KafkaScheme, sink.KafkaSSLScheme:
 		sink, err := NewKafkaSink(changefeedID, sinkURI, config.SinkConfig)
 		if err != nil {
 			return nil, err
 		}
 		return sink, nil
+	case sink.PulsarScheme, sink.PulsarSSLScheme, sink.PulsarHTTPScheme, sink.PulsarHTTPSScheme:
+		sink, err := NewPulsarSink(changefeedID, sinkURI, config.SinkConfig, errCh)
+		if err != nil {
+			return nil, err
+		}
+		return sink, nil
+	case sink.S3Scheme, sink.FileScheme, sink.GCSScheme, sink.GSScheme, sink.AzblobScheme, sink.AzureScheme, sink.CloudStorageNoopScheme:
+		sink, err := NewCloudStorageSink(changefeedID, sinkURI, config.SinkConfig, errCh)
+		if err != nil {
+			return nil, err
+		}
+		return sink, nil
 	}
 	return nil, nil
 }
@@ -0,0 +1,279 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/robfig/cron"
+	"go.uber.org/zap"
+)
+
+// CloudStorageDDLWorker writes the DDL events as schema files and the checkpoint ts
+// as the metadata file to cloud storage, it also cleans up the expired files periodically.
+type CloudStorageDDLWorker struct {
+	changefeedID     model.ChangeFeedID
+	sinkURI          *url.URL
+	statistics       *metrics.Statistics
+	storage          storage.ExternalStorage
+	config           *cloudstorage.Config
+	cron             *cron.Cron
+	checkpointTsChan chan uint64
+	// errCh is used to report the errors of writing the checkpoint ts to the sink.
+	errCh chan<- error
+
+	lastCheckpointTs         atomic.Uint64
+	lastSendCheckpointTsTime time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewCloudStorageDDLWorker creates a new cloud storage DDL worker and starts it.
+func NewCloudStorageDDLWorker(
+	changefeedID model.ChangeFeedID,
+	sinkURI *url.URL,
+	config *cloudstorage.Config,
+	storage storage.ExternalStorage,
+	statistics *metrics.Statistics,
+	errCh chan<- error,
+) (*CloudStorageDDLWorker, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &CloudStorageDDLWorker{
+		changefeedID:             changefeedID,
+		sinkURI:                  sinkURI,
+		config:                   config,
+		storage:                  storage,
+		statistics:               statistics,
+		checkpointTsChan:         make(chan uint64, 16),
+		errCh:                    errCh,
+		lastSendCheckpointTsTime: time.Now(),
+		ctx:                      ctx,
+		cancel:                   cancel,
+	}
+	if err := w.initCron(); err != nil {
+		cancel()
+		return nil, errors.Trace(err)
+	}
+
+	w.wg.Add(2)
+	go w.runCheckpointTs()
+	go w.bgCleanup()
+	return w, nil
+}
+
+func (w *CloudStorageDDLWorker) GetCheckpointTsChan() chan<- uint64 {
+	return w.checkpointTsChan
+}
+
+// WriteBlockEvent writes the schema files of the DDL event to cloud storage.
+func (w *CloudStorageDDLWorker) WriteBlockEvent(event *commonEvent.DDLEvent) error {
+	var def cloudstorage.TableDefinition
+	def.FromDDLEvent(event, w.config.OutputColumnID)
+	if err := w.writeFile(event, def); err != nil {
+		return errors.Trace(err)
+	}
+
+	if event.Type == byte(timodel.ActionExchangeTablePartition) && len(event.MultipleTableInfos) > 1 {
+		// For exchange partition, we need to write the schema of the source table.
+		var sourceTableDef cloudstorage.TableDefinition
+		sourceTableDef.FromTableInfo(event.MultipleTableInfos[1], event.FinishedTs, w.config.OutputColumnID)
+		if err := w.writeFile(event, sourceTableDef); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	event.PostFlush()
+	return nil
+}
+
+func (w *CloudStorageDDLWorker) writeFile(event *commonEvent.DDLEvent, def cloudstorage.TableDefinition) error {
+	encodedDef, err := def.MarshalWithQuery()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	path, err := def.GenerateSchemaFilePath()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	log.Debug("write ddl event to external storage",
+		zap.String("path", path), zap.Any("ddl", event))
+	return w.statistics.RecordDDLExecution(func() error {
+		return w.storage.WriteFile(w.ctx, path, encodedDef)
+	})
+}
+
+func (w *CloudStorageDDLWorker) runCheckpointTs() {
+	defer w.wg.Done()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case ts := <-w.checkpointTsChan:
+			// keep consuming the checkpoint ts after the error is reported,
+			// so the sink is not blocked until the changefeed is restarted.
+			err := w.writeCheckpointTs(ts)
+			reportError(w.ctx, w.changefeedID, w.errCh,
+				errors.Annotatef(err, "failed to write checkpoint ts %d to external storage", ts))
+		}
+	}
+}
+
+func (w *CloudStorageDDLWorker) writeCheckpointTs(ts uint64) error {
+	if time.Since(w.lastSendCheckpointTsTime) < 2*time.Second {
+		log.Debug("skip write checkpoint ts to external storage",
+			zap.Any("changefeedID", w.changefeedID),
+			zap.Uint64("ts", ts))
+		return nil
+	}
+
+	w.lastSendCheckpointTsTime = time.Now()
+	ckpt, err := json.Marshal(map[string]uint64{"checkpoint-ts": ts})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.storage.WriteFile(w.ctx, "metadata", ckpt); err != nil {
+		return errors.Trace(err)
+	}
+	// the expired files are cleaned up by the checkpoint ts which consumers can see.
+	w.lastCheckpointTs.Store(ts)
+	return nil
+}
+
+func (w *CloudStorageDDLWorker) initCron() (err error) {
+	w.cron = cron.New()
+	for _, job := range w.genCleanupJob() {
+		err = w.cron.AddFunc(w.config.FileCleanupCronSpec, job)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *CloudStorageDDLWorker) bgCleanup() {
+	defer w.wg.Done()
+	if w.config.DateSeparator != config.DateSeparatorDay.String() || w.config.FileExpirationDays <= 0 {
+		log.Info("skip cleanup expired files for storage sink",
+			zap.String("namespace", w.changefeedID.Namespace),
+			zap.String("changefeed", w.changefeedID.ID),
+			zap.String("dateSeparator", w.config.DateSeparator),
+			zap.Int("expiredFileTTL", w.config.FileExpirationDays))
+		return
+	}
+
+	w.cron.Start()
+	defer w.cron.Stop()
+	log.Info("start schedule cleanup expired files for storage sink",
+		zap.String("namespace", w.changefeedID.Namespace),
+		zap.String("changefeed", w.changefeedID.ID),
+		zap.String("dateSeparator", w.config.DateSeparator),
+		zap.Int("expiredFileTTL", w.config.FileExpirationDays))
+
+	// wait for the context done
+	<-w.ctx.Done()
+	log.Info("stop schedule cleanup expired files for storage sink",
+		zap.String("namespace", w.changefeedID.Namespace),
+		zap.String("changefeed", w.changefeedID.ID),
+		zap.Error(w.ctx.Err()))
+}
+
+func (w *CloudStorageDDLWorker) genCleanupJob() []func() {
+	var ret []func()
+
+	isLocal := w.sinkURI.Scheme == "file" || w.sinkURI.Scheme == "local" || w.sinkURI.Scheme == ""
+	isRemoveEmptyDirsRunning := atomic.Bool{}
+	if isLocal {
+		ret = append(ret, func() {
+			if !isRemoveEmptyDirsRunning.CompareAndSwap(false, true) {
+				log.Warn("remove empty dirs is already running, skip this round",
+					zap.String("namespace", w.changefeedID.Namespace),
+					zap.String("changefeed", w.changefeedID.ID))
+				return
+			}
+			defer isRemoveEmptyDirsRunning.Store(false)
+
+			checkpointTs := w.lastCheckpointTs.Load()
+			start := time.Now()
+			cnt, err := cloudstorage.RemoveEmptyDirs(w.ctx, w.changefeedID, w.sinkURI.Path)
+			if err != nil {
+				log.Error("failed to remove empty dirs",
+					zap.String("namespace", w.changefeedID.Namespace),
+					zap.String("changefeed", w.changefeedID.ID),
+					zap.Uint64("checkpointTs", checkpointTs),
+					zap.Duration("cost", time.Since(start)),
+					zap.Error(err))
+				return
+			}
+			log.Info("remove empty dirs",
+				zap.String("namespace", w.changefeedID.Namespace),
+				zap.String("changefeed", w.changefeedID.ID),
+				zap.Uint64("checkpointTs", checkpointTs),
+				zap.Uint64("count", cnt),
+				zap.Duration("cost", time.Since(start)))
+		})
+	}
+
+	isCleanupRunning := atomic.Bool{}
+	ret = append(ret, func() {
+		if !isCleanupRunning.CompareAndSwap(false, true) {
+			log.Warn("cleanup expired files is already running, skip this round",
+				zap.String("namespace", w.changefeedID.Namespace),
+				zap.String("changefeed", w.changefeedID.ID))
+			return
+		}
+		defer isCleanupRunning.Store(false)
+
+		start := time.Now()
+		checkpointTs := w.lastCheckpointTs.Load()
+		cnt, err := cloudstorage.RemoveExpiredFiles(w.ctx, w.changefeedID, w.storage, w.config, checkpointTs)
+		if err != nil {
+			log.Error("failed to remove expired files",
+				zap.String("namespace", w.changefeedID.Namespace),
+				zap.String("changefeed", w.changefeedID.ID),
+				zap.Uint64("checkpointTs", checkpointTs),
+				zap.Duration("cost", time.Since(start)),
+				zap.Error(err))
+			return
+		}
+		log.Info("remove expired files",
+			zap.String("namespace", w.changefeedID.Namespace),
+			zap.String("changefeed", w.changefeedID.ID),
+			zap.Uint64("checkpointTs", checkpointTs),
+			zap.Uint64("count", cnt),
+			zap.Duration("cost", time.Since(start)))
+	})
+	return ret
+}
+
+// Close stops the worker and all the goroutines.
+func (w *CloudStorageDDLWorker) Close() {
+	w.cancel()
+	w.wg.Wait()
+}
@@ -0,0 +1,210 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
+	"github.com/pingcap/ticdc/pkg/sink/codec"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/chann"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/pdutil"
+	ticommon "github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultEncodingConcurrency = 8
+	defaultChannelSize         = 1024
+)
+
+// eventFragment is used to attach a sequence number to DMLEvent.
+type eventFragment struct {
+	event          *commonEvent.DMLEvent
+	versionedTable cloudstorage.VersionedTableName
+
+	// The sequence number is mainly useful for DMLEvent defragmentation.
+	// e.g. DMLEvent 1~5 are dispatched to a group of encoding workers, but the
+	// encoding completion time varies. Let's say the final completion sequence are 1,3,2,5,4,
+	// we can use the sequence numbers to do defragmentation so that the events can arrive
+	// at writer sequentially.
+	seqNumber uint64
+	// encodedMsgs denote the encoded messages after the event is handled in encodingWorker.
+	encodedMsgs []*ticommon.Message
+}
+
+// CloudStorageDMLWorker writes the DML events to cloud storage.
+// The data flow is as follows: **data** -> encodingWorkers -> defragmenter -> writers -> external storage
+// The defragmenter will defragment the out-of-order encoded messages and sends encoded
+// messages to individual writers.
+// The writers will write the encoded messages to external storage in parallel between different tables.
+type CloudStorageDMLWorker struct {
+	changefeedID model.ChangeFeedID
+	// last sequence number
+	lastSeqNum uint64
+	// encodingWorkers defines a group of workers for encoding events.
+	encodingWorkers []*encodingWorker
+	// defragmenter is used to defragment the out-of-order encoded messages and
+	// sends encoded messages to individual writers.
+	defragmenter *defragmenter
+	// writers defines a group of workers for writing events to external storage.
+	writers []*writer
+
+	// msgCh is a channel to hold eventFragment.
+	// The caller of AddDMLEvent will write eventFragment to msgCh and
+	// the encodingWorkers will read eventFragment from msgCh to encode events.
+	msgCh *chann.DrainableChann[eventFragment]
+
+	statistics *metrics.Statistics
+	// errCh is used to report the errors of the worker to the sink.
+	errCh chan<- error
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewCloudStorageDMLWorker creates a new cloud storage DML worker and starts it.
+func NewCloudStorageDMLWorker(
+	changefeedID model.ChangeFeedID,
+	storage storage.ExternalStorage,
+	config *cloudstorage.Config,
+	encoderConfig *newcommon.Config,
+	extension string,
+	statistics *metrics.Statistics,
+	pdClock pdutil.Clock,
+	errCh chan<- error,
+) (*CloudStorageDMLWorker, error) {
+	w := &CloudStorageDMLWorker{
+		changefeedID:    changefeedID,
+		encodingWorkers: make([]*encodingWorker, defaultEncodingConcurrency),
+		writers:         make([]*writer, config.WorkerCount),
+		msgCh:           chann.NewAutoDrainChann[eventFragment](),
+		statistics:      statistics,
+		errCh:           errCh,
+	}
+	encodedOutCh := make(chan eventFragment, defaultChannelSize)
+	workerChannels := make([]*chann.DrainableChann[eventFragment], config.WorkerCount)
+
+	// create a group of encoding workers.
+	for i := 0; i < defaultEncodingConcurrency; i++ {
+		encoder, err := codec.NewTxnEventEncoder(encoderConfig)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrStorageSinkInvalidConfig, err)
+		}
+		w.encodingWorkers[i] = newEncodingWorker(i, changefeedID, encoder, w.msgCh.Out(), encodedOutCh)
+	}
+
+	// create a group of writers.
+	for i := 0; i < config.WorkerCount; i++ {
+		inputCh := chann.NewAutoDrainChann[eventFragment]()
+		w.writers[i] = newWriter(i, changefeedID, storage, config, extension, inputCh, pdClock, statistics)
+		workerChannels[i] = inputCh
+	}
+
+	// create defragmenter.
+	// The defragmenter is used to defragment the out-of-order encoded messages from encoding workers and
+	// sends encoded messages to related writers in order. Messages of the same table will be sent to
+	// the same writer.
+	w.defragmenter = newDefragmenter(encodedOutCh, workerChannels)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		reportError(ctx, w.changefeedID, w.errCh, w.run(ctx))
+	}()
+	return w, nil
+}
+
+func (w *CloudStorageDMLWorker) run(ctx context.Context) error {
+	eg, ctx := errgroup.WithContext(ctx)
+
+	// run the encoding workers.
+	for i := 0; i < defaultEncodingConcurrency; i++ {
+		encodingWorker := w.encodingWorkers[i]
+		eg.Go(func() error {
+			return encodingWorker.run(ctx)
+		})
+	}
+
+	// run the defragmenter.
+	eg.Go(func() error {
+		return w.defragmenter.run(ctx)
+	})
+
+	// run the writers.
+	for i := 0; i < len(w.writers); i++ {
+		writer := w.writers[i]
+		eg.Go(func() error {
+			return writer.run(ctx)
+		})
+	}
+
+	log.Info("cloud storage dml worker started",
+		zap.String("namespace", w.changefeedID.Namespace),
+		zap.String("changefeed", w.changefeedID.ID),
+		zap.Int("workerCount", len(w.writers)),
+		zap.Any("config", w.writers[0].config))
+
+	return eg.Wait()
+}
+
+// AddDMLEvent dispatches the DML event to the encoding workers,
+// the event is flushed once it is written to the external storage.
+func (w *CloudStorageDMLWorker) AddDMLEvent(event *commonEvent.DMLEvent) {
+	tbl := cloudstorage.VersionedTableName{
+		TableNameWithPhysicTableID: common.TableName{
+			Schema:      event.TableInfo.GetSchemaName(),
+			Table:       event.TableInfo.GetTableName(),
+			TableID:     event.PhysicalTableID,
+			IsPartition: event.TableInfo.IsPartitionTable(),
+		},
+		TableInfoVersion: event.TableInfoVersion,
+	}
+	seq := atomic.AddUint64(&w.lastSeqNum, 1)
+
+	w.statistics.ObserveRows([]*commonEvent.DMLEvent{event})
+	// emit a DMLEvent coupled with a sequence number starting from one.
+	w.msgCh.In() <- eventFragment{
+		seqNumber:      seq,
+		versionedTable: tbl,
+		event:          event,
+	}
+}
+
+// Close stops the worker and all the goroutines.
+func (w *CloudStorageDMLWorker) Close() {
+	w.cancel()
+	w.wg.Wait()
+
+	for _, encodingWorker := range w.encodingWorkers {
+		encodingWorker.close()
+	}
+	for _, writer := range w.writers {
+		writer.close()
+	}
+	w.msgCh.CloseAndDrain()
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/sink/codec/encoder"
+	"github.com/pingcap/tiflow/cdc/model"
+	"go.uber.org/zap"
+)
+
+// encodingWorker denotes the worker responsible for encoding DMLEvents
+// to messages formatted in the specific protocol.
+type encodingWorker struct {
+	id           int
+	changeFeedID model.ChangeFeedID
+	encoder      encoder.TxnEventEncoder
+	isClosed     uint64
+	inputCh      <-chan eventFragment
+	outputCh     chan<- eventFragment
+}
+
+func newEncodingWorker(
+	workerID int,
+	changefeedID model.ChangeFeedID,
+	encoder encoder.TxnEventEncoder,
+	inputCh <-chan eventFragment,
+	outputCh chan<- eventFragment,
+) *encodingWorker {
+	return &encodingWorker{
+		id:           workerID,
+		changeFeedID: changefeedID,
+		encoder:      encoder,
+		inputCh:      inputCh,
+		outputCh:     outputCh,
+	}
+}
+
+func (w *encodingWorker) run(ctx context.Context) error {
+	log.Debug("encoding worker started", zap.Int("workerID", w.id),
+		zap.String("namespace", w.changeFeedID.Namespace),
+		zap.String("changefeed", w.changeFeedID.ID))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case frag, ok := <-w.inputCh:
+			if !ok || atomic.LoadUint64(&w.isClosed) == 1 {
+				return nil
+			}
+			err := w.encodeEvents(ctx, frag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+func (w *encodingWorker) encodeEvents(ctx context.Context, frag eventFragment) error {
+	err := w.encoder.AppendTxnEvent(frag.event, frag.event.PostFlush)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	frag.encodedMsgs = w.encoder.Build()
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case w.outputCh <- frag:
+	}
+	return nil
+}
+
+func (w *encodingWorker) close() {
+	atomic.CompareAndSwapUint64(&w.isClosed, 0, 1)
+}
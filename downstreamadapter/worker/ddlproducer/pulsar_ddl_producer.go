@@ -0,0 +1,134 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddlproducer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/config"
+	tipulsar "github.com/pingcap/ticdc/pkg/sink/pulsar"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mq/ddlproducer"
+	"github.com/pingcap/tiflow/cdc/sink/metrics/mq"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"go.uber.org/zap"
+)
+
+var _ ddlproducer.DDLProducer = (*PulsarDDLProducer)(nil)
+
+// PulsarDDLProducer is used to send DDL and checkpoint messages to pulsar synchronously.
+type PulsarDDLProducer struct {
+	id      model.ChangeFeedID
+	client  pulsar.Client
+	pConfig *config.PulsarConfig
+	// producers holds one producer for each topic.
+	producers *lru.Cache
+	// closedMu is used to protect `closed`.
+	closedMu sync.RWMutex
+	closed   bool
+}
+
+// NewPulsarDDLProducer creates a new pulsar DDL producer,
+// the producer of the default topic is created eagerly to check the connection.
+func NewPulsarDDLProducer(
+	changefeedID model.ChangeFeedID,
+	client pulsar.Client,
+	pConfig *config.PulsarConfig,
+) (*PulsarDDLProducer, error) {
+	log.Info("Starting pulsar DDL producer ...",
+		zap.String("namespace", changefeedID.Namespace),
+		zap.String("changefeed", changefeedID.ID))
+
+	topicName := pConfig.GetDefaultTopicName()
+	defaultProducer, err := tipulsar.NewProducer(pConfig, client, topicName)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+	}
+	producers, err := tipulsar.NewProducerCache(pConfig)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+	}
+	producers.Add(topicName, defaultProducer)
+	return &PulsarDDLProducer{
+		id:        changefeedID,
+		client:    client,
+		pConfig:   pConfig,
+		producers: producers,
+	}, nil
+}
+
+// SyncBroadcastMessage sends the message to the topic, pulsar consumers
+// consume all the partitions of the topic, so there is no need to broadcast.
+func (p *PulsarDDLProducer) SyncBroadcastMessage(ctx context.Context, topic string,
+	totalPartitionsNum int32, message *common.Message,
+) error {
+	return p.SyncSendMessage(ctx, topic, totalPartitionsNum, message)
+}
+
+// SyncSendMessage sends the message to the topic and waits for the ack.
+func (p *PulsarDDLProducer) SyncSendMessage(ctx context.Context, topic string,
+	_ int32, message *common.Message,
+) error {
+	p.closedMu.RLock()
+	defer p.closedMu.RUnlock()
+	if p.closed {
+		return cerror.ErrPulsarProducerClosed.GenWithStackByArgs()
+	}
+
+	mq.IncPublishedDDLCount(topic, p.id.ID, message)
+	producer, err := tipulsar.GetProducerByTopic(p.producers, p.pConfig, p.client, topic)
+	if err != nil {
+		log.Error("ddl SyncSendMessage GetProducerByTopic fail", zap.Error(err))
+		return cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+	}
+
+	data := &pulsar.ProducerMessage{
+		Payload: message.Value,
+		Key:     message.GetPartitionKey(),
+	}
+	mID, err := producer.Send(ctx, data)
+	if err != nil {
+		log.Error("ddl producer send fail", zap.Error(err))
+		mq.IncPublishedDDLFail(topic, p.id.ID, message)
+		return cerror.WrapError(cerror.ErrPulsarSendMessage, err)
+	}
+
+	if message.Type == model.MessageTypeDDL {
+		log.Info("pulsar DDL producer send message success",
+			zap.Any("mID", mID), zap.String("topic", topic),
+			zap.String("ddl", string(message.Value)))
+	}
+	mq.IncPublishedDDLSuccess(topic, p.id.ID, message)
+	return nil
+}
+
+// Close closes all the producers and the client.
+func (p *PulsarDDLProducer) Close() {
+	p.closedMu.Lock()
+	defer p.closedMu.Unlock()
+	if p.closed {
+		return
+	}
+	p.closed = true
+	for _, topic := range p.producers.Keys() {
+		// the evict callback will close the producer
+		p.producers.Remove(topic)
+	}
+	p.client.Close()
+}
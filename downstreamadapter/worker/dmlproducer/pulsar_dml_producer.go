@@ -0,0 +1,170 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlproducer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/config"
+	tipulsar "github.com/pingcap/ticdc/pkg/sink/pulsar"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/metrics/mq"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"go.uber.org/zap"
+)
+
+// PulsarDMLProducer is used to send messages to pulsar.
+type PulsarDMLProducer struct {
+	// id indicates which processor (changefeed) this sink belongs to.
+	id model.ChangeFeedID
+	// We hold the client to make close operation faster.
+	client pulsar.Client
+	// producers is used to send messages to pulsar.
+	// One topic only use one producer, it's evicted by lru.
+	producers *lru.Cache
+	pConfig   *config.PulsarConfig
+	// errCh is used to report the errors of the async sending to the sink.
+	errCh chan<- error
+
+	// closedMu is used to protect `closed`.
+	// We need to ensure that closed producers are never written to.
+	closedMu sync.RWMutex
+	// closed is used to indicate whether the producer is closed.
+	// We also use it to guard against double closes.
+	closed bool
+}
+
+// NewPulsarDMLProducer creates a new pulsar DML producer,
+// the producer of the default topic is created eagerly to check the connection.
+func NewPulsarDMLProducer(
+	changefeedID model.ChangeFeedID,
+	client pulsar.Client,
+	pConfig *config.PulsarConfig,
+	errCh chan<- error,
+) (*PulsarDMLProducer, error) {
+	log.Info("Creating pulsar DML producer ...",
+		zap.String("namespace", changefeedID.Namespace),
+		zap.String("changefeed", changefeedID.ID))
+	start := time.Now()
+
+	defaultTopicName := pConfig.GetDefaultTopicName()
+	defaultProducer, err := tipulsar.NewProducer(pConfig, client, defaultTopicName)
+	if err != nil {
+		go client.Close()
+		return nil, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+	}
+	producers, err := tipulsar.NewProducerCache(pConfig)
+	if err != nil {
+		go client.Close()
+		return nil, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+	}
+	producers.Add(defaultTopicName, defaultProducer)
+
+	p := &PulsarDMLProducer{
+		id:        changefeedID,
+		client:    client,
+		producers: producers,
+		pConfig:   pConfig,
+		errCh:     errCh,
+	}
+	log.Info("Pulsar DML producer created", zap.Stringer("changefeed", p.id),
+		zap.Duration("duration", time.Since(start)))
+	return p, nil
+}
+
+// AsyncSendMessage sends the message to pulsar asynchronously,
+// the partition is ignored since pulsar hashes the message key to a partition by itself.
+func (p *PulsarDMLProducer) AsyncSendMessage(
+	ctx context.Context, topic string,
+	_ int32, message *common.Message,
+) error {
+	// We have to hold the lock to avoid writing to a closed producer.
+	// Close may be blocked for a long time.
+	p.closedMu.RLock()
+	defer p.closedMu.RUnlock()
+
+	// If producers are closed, we should skip the message and return an error.
+	if p.closed {
+		return cerror.ErrPulsarProducerClosed.GenWithStackByArgs()
+	}
+
+	producer, err := tipulsar.GetProducerByTopic(p.producers, p.pConfig, p.client, topic)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+	}
+
+	data := &pulsar.ProducerMessage{
+		Payload: message.Value,
+		Key:     message.GetPartitionKey(),
+	}
+	producer.SendAsync(ctx, data,
+		func(id pulsar.MessageID, m *pulsar.ProducerMessage, err error) {
+			if err != nil {
+				log.Error("Pulsar DML producer async send error",
+					zap.String("namespace", p.id.Namespace),
+					zap.String("changefeed", p.id.ID),
+					zap.Int("messageSize", len(m.Payload)),
+					zap.String("topic", topic),
+					zap.Error(err))
+				mq.IncPublishedDMLFail(topic, p.id.ID, message.GetSchema())
+				// The callback of the message is never called, so the dispatcher
+				// can't advance until the changefeed is restarted by the error.
+				select {
+				case p.errCh <- cerror.WrapError(cerror.ErrPulsarAsyncSendMessage, err):
+				default:
+					log.Warn("Error channel is full in pulsar DML producer",
+						zap.String("namespace", p.id.Namespace),
+						zap.String("changefeed", p.id.ID),
+						zap.Error(err))
+				}
+				return
+			}
+			if message.Callback != nil {
+				message.Callback()
+			}
+			mq.IncPublishedDMLSuccess(topic, p.id.ID, message.GetSchema())
+		})
+	mq.IncPublishedDMLCount(topic, p.id.ID, message.GetSchema())
+	return nil
+}
+
+func (p *PulsarDMLProducer) Close() {
+	// We have to hold the lock to synchronize closing with writing.
+	p.closedMu.Lock()
+	defer p.closedMu.Unlock()
+	// If the producer has already been closed, we should skip this close operation.
+	if p.closed {
+		log.Warn("Pulsar DML producer already closed",
+			zap.String("namespace", p.id.Namespace),
+			zap.String("changefeed", p.id.ID))
+		return
+	}
+	p.closed = true
+	start := time.Now()
+	for _, topic := range p.producers.Keys() {
+		// the evict callback will close the producer
+		p.producers.Remove(topic)
+	}
+	p.client.Close()
+	log.Info("Pulsar DML producer closed",
+		zap.String("namespace", p.id.Namespace),
+		zap.String("changefeed", p.id.ID),
+		zap.Duration("duration", time.Since(start)))
+}
@@ -0,0 +1,46 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"go.uber.org/zap"
+)
+
+// reportError sends the error of a worker to the error channel of the sink,
+// the event dispatcher manager reports it to the maintainer to fail the changefeed.
+// The error is dropped if the worker is closing or the channel is full,
+// since an error of the same changefeed is already on the way.
+func reportError(ctx context.Context, changefeedID model.ChangeFeedID, errCh chan<- error, err error) {
+	if err == nil || errors.Cause(err) == context.Canceled {
+		return
+	}
+	log.Error("sink worker meets error",
+		zap.String("namespace", changefeedID.Namespace),
+		zap.String("changefeed", changefeedID.ID),
+		zap.Error(err))
+	select {
+	case <-ctx.Done():
+	case errCh <- err:
+	default:
+		log.Warn("sink error channel is full, the error is dropped",
+			zap.String("namespace", changefeedID.Namespace),
+			zap.String("changefeed", changefeedID.ID),
+			zap.Error(err))
+	}
+}
@@ -54,7 +54,7 @@ type DDLDispatchRule int
 
 const (
 	// PartitionZero means the DDL event will be dispatched to partition 0.
-	// NOTICE: Only for canal and canal-json protocol.
+	// NOTICE: Only for canal, canal-json and debezium protocol.
 	PartitionZero DDLDispatchRule = iota
 	// PartitionAll means the DDL event will be broadcast to all the partitions.
 	PartitionAll
@@ -62,7 +62,7 @@ const (
 
 func getDDLDispatchRule(protocol config.Protocol) DDLDispatchRule {
 	switch protocol {
-	case config.ProtocolCanal, config.ProtocolCanalJSON:
+	case config.ProtocolCanal, config.ProtocolCanalJSON, config.ProtocolDebezium:
 		return PartitionZero
 	default:
 	}
@@ -134,6 +134,10 @@ func (w *KafkaDDLWorker) encodeAndSendDDLEvents() error {
 					zap.Error(err))
 				continue
 			}
+			// some protocols don't emit the DDL event
+			if message == nil {
+				continue
+			}
 
 			topic := w.eventRouter.GetTopicForDDL(event)
 			partitionNum, err := w.topicManager.GetPartitionNum(w.ctx, topic)
@@ -193,6 +197,10 @@ func (w *KafkaDDLWorker) encodeAndSendCheckpointEvents() error {
 			if err != nil {
 				return errors.Trace(err)
 			}
+			// some protocols don't emit the checkpoint event
+			if msg == nil {
+				continue
+			}
 			// NOTICE: When there are no tables to replicate,
 			// we need to send checkpoint ts to the default topic.
 			// This will be compatible with the old behavior.
@@ -0,0 +1,221 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/eventrouter"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/codec/encoder"
+	"github.com/pingcap/ticdc/pkg/sink/util"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mq/ddlproducer"
+	"go.uber.org/zap"
+)
+
+// PulsarDDLWorker sends the DDL events and checkpoint ts to pulsar.
+// Pulsar consumers consume all the partitions of a topic,
+// so each message is only sent once to the topic.
+type PulsarDDLWorker struct {
+	// changeFeedID indicates this sink belongs to which processor(changefeed).
+	changeFeedID model.ChangeFeedID
+	// protocol indicates the protocol used by this sink.
+	protocol         config.Protocol
+	ddlEventChan     chan *commonEvent.DDLEvent
+	checkpointTsChan chan uint64
+
+	encoder encoder.EventEncoder
+	// eventRouter used to route events to the right topic.
+	eventRouter *eventrouter.EventRouter
+	// producer is used to send the messages to the pulsar broker.
+	producer ddlproducer.DDLProducer
+
+	tableSchemaStore *util.TableSchemaStore
+	// tableSchemaStoreMu protects tableSchemaStore, it's set by the dispatcher
+	// and read by the checkpoint goroutine.
+	tableSchemaStoreMu sync.RWMutex
+
+	statistics *metrics.Statistics
+	ctx        context.Context
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+
+	// errCh is used to report the errors of sending messages to the sink.
+	errCh chan<- error
+}
+
+// NewPulsarDDLWorker creates a new pulsar DDL worker and starts it.
+func NewPulsarDDLWorker(
+	id model.ChangeFeedID,
+	protocol config.Protocol,
+	producer ddlproducer.DDLProducer,
+	encoder encoder.EventEncoder,
+	eventRouter *eventrouter.EventRouter,
+	statistics *metrics.Statistics,
+	errCh chan<- error,
+) *PulsarDDLWorker {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &PulsarDDLWorker{
+		ctx:              ctx,
+		changeFeedID:     id,
+		protocol:         protocol,
+		ddlEventChan:     make(chan *commonEvent.DDLEvent, 16),
+		checkpointTsChan: make(chan uint64, 16),
+		encoder:          encoder,
+		producer:         producer,
+		eventRouter:      eventRouter,
+		statistics:       statistics,
+		errCh:            errCh,
+		cancel:           cancel,
+	}
+
+	w.wg.Add(2)
+	go w.encodeAndSendDDLEvents()
+	go w.encodeAndSendCheckpointEvents()
+	return w
+}
+
+func (w *PulsarDDLWorker) GetDDLEventChan() chan<- *commonEvent.DDLEvent {
+	return w.ddlEventChan
+}
+
+func (w *PulsarDDLWorker) GetCheckpointTsChan() chan<- uint64 {
+	return w.checkpointTsChan
+}
+
+func (w *PulsarDDLWorker) SetTableSchemaStore(tableSchemaStore *util.TableSchemaStore) {
+	w.tableSchemaStoreMu.Lock()
+	defer w.tableSchemaStoreMu.Unlock()
+	w.tableSchemaStore = tableSchemaStore
+}
+
+func (w *PulsarDDLWorker) getTableSchemaStore() *util.TableSchemaStore {
+	w.tableSchemaStoreMu.RLock()
+	defer w.tableSchemaStoreMu.RUnlock()
+	return w.tableSchemaStore
+}
+
+func (w *PulsarDDLWorker) encodeAndSendDDLEvents() {
+	defer w.wg.Done()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case event := <-w.ddlEventChan:
+			if err := w.sendDDLEvent(event); err != nil {
+				log.Error("Failed to send ddl event to pulsar",
+					zap.String("namespace", w.changeFeedID.Namespace),
+					zap.String("changefeed", w.changeFeedID.ID),
+					zap.String("query", event.Query),
+					zap.Error(err))
+				// The DDL is not flushed, so the dispatcher keeps blocking on it
+				// until the changefeed is restarted by the error.
+				reportError(w.ctx, w.changeFeedID, w.errCh, err)
+				return
+			}
+			for _, cb := range event.PostTxnFlushed {
+				cb()
+			}
+		}
+	}
+}
+
+func (w *PulsarDDLWorker) sendDDLEvent(event *commonEvent.DDLEvent) error {
+	message, err := w.encoder.EncodeDDLEvent(event)
+	if err != nil {
+		return err
+	}
+	// some protocols don't emit the DDL event
+	if message == nil {
+		return nil
+	}
+	topic := w.eventRouter.GetTopicForDDL(event)
+	return w.statistics.RecordDDLExecution(func() error {
+		return w.producer.SyncSendMessage(w.ctx, topic, 0, message)
+	})
+}
+
+func (w *PulsarDDLWorker) encodeAndSendCheckpointEvents() {
+	defer w.wg.Done()
+
+	checkpointTsMessageDuration := metrics.CheckpointTsMessageDuration.WithLabelValues(w.changeFeedID.Namespace, w.changeFeedID.ID)
+	checkpointTsMessageCount := metrics.CheckpointTsMessageCount.WithLabelValues(w.changeFeedID.Namespace, w.changeFeedID.ID)
+	defer func() {
+		metrics.CheckpointTsMessageDuration.DeleteLabelValues(w.changeFeedID.Namespace, w.changeFeedID.ID)
+		metrics.CheckpointTsMessageCount.DeleteLabelValues(w.changeFeedID.Namespace, w.changeFeedID.ID)
+	}()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case ts := <-w.checkpointTsChan:
+			start := time.Now()
+			if err := w.sendCheckpointEvent(ts); err != nil {
+				log.Error("Failed to send checkpoint ts to pulsar",
+					zap.String("namespace", w.changeFeedID.Namespace),
+					zap.String("changefeed", w.changeFeedID.ID),
+					zap.Uint64("checkpointTs", ts),
+					zap.Error(err))
+				reportError(w.ctx, w.changeFeedID, w.errCh, err)
+				return
+			}
+			checkpointTsMessageCount.Inc()
+			checkpointTsMessageDuration.Observe(time.Since(start).Seconds())
+		}
+	}
+}
+
+func (w *PulsarDDLWorker) sendCheckpointEvent(ts uint64) error {
+	msg, err := w.encoder.EncodeCheckpointEvent(ts)
+	if err != nil {
+		return err
+	}
+	if msg == nil {
+		return nil
+	}
+
+	var tableNames []*commonEvent.SchemaTableName
+	if tableSchemaStore := w.getTableSchemaStore(); tableSchemaStore != nil {
+		tableNames = tableSchemaStore.GetAllTableNames(ts)
+	}
+	// NOTICE: When there are no tables to replicate,
+	// we need to send checkpoint ts to the default topic.
+	if len(tableNames) == 0 {
+		topic := w.eventRouter.GetDefaultTopic()
+		log.Debug("Emit checkpointTs to default topic",
+			zap.String("topic", topic), zap.Uint64("checkpointTs", ts))
+		return w.producer.SyncBroadcastMessage(w.ctx, topic, 1, msg)
+	}
+
+	for _, topic := range w.eventRouter.GetActiveTopics(tableNames) {
+		if err := w.producer.SyncBroadcastMessage(w.ctx, topic, 1, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close stops the worker and closes the producer.
+func (w *PulsarDDLWorker) Close() {
+	w.cancel()
+	w.wg.Wait()
+	w.producer.Close()
+}
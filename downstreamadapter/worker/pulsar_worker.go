@@ -0,0 +1,341 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/eventrouter"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/topicmanager"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/codec"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dmlproducer"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+// PulsarWorker will send messages to the pulsar DML producer on a batch basis.
+// Pulsar hashes the message key to a partition by itself, so the worker only
+// calculates the topic and the key of each row.
+type PulsarWorker struct {
+	// changeFeedID indicates this sink belongs to which processor(changefeed).
+	changeFeedID model.ChangeFeedID
+	// protocol indicates the protocol used by this sink.
+	protocol config.Protocol
+
+	eventChan chan *commonEvent.DMLEvent
+	rowChan   chan *commonEvent.MQRowEvent
+	// ticker used to force flush the batched messages when the interval is reached.
+	ticker *time.Ticker
+
+	columnSelector *common.ColumnSelectors
+	// eventRouter used to route events to the right topic and partition key.
+	eventRouter *eventrouter.EventRouter
+	// topicManager is only used to get the partition number,
+	// which is always 1 for pulsar.
+	topicManager topicmanager.TopicManager
+	encoderGroup codec.EncoderGroup
+
+	// producer is used to send the messages to the pulsar broker.
+	producer dmlproducer.DMLProducer
+
+	// statistics is used to record DML metrics.
+	statistics *metrics.Statistics
+	// errCh is used to report the errors of the worker to the sink.
+	errCh chan<- error
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewPulsarWorker creates a new pulsar DML worker and starts it.
+func NewPulsarWorker(
+	id model.ChangeFeedID,
+	protocol config.Protocol,
+	producer dmlproducer.DMLProducer,
+	encoderGroup codec.EncoderGroup,
+	columnSelector *common.ColumnSelectors,
+	eventRouter *eventrouter.EventRouter,
+	topicManager topicmanager.TopicManager,
+	statistics *metrics.Statistics,
+	errCh chan<- error,
+) *PulsarWorker {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &PulsarWorker{
+		changeFeedID:   id,
+		protocol:       protocol,
+		eventChan:      make(chan *commonEvent.DMLEvent, 32),
+		rowChan:        make(chan *commonEvent.MQRowEvent, 32),
+		ticker:         time.NewTicker(batchInterval),
+		encoderGroup:   encoderGroup,
+		columnSelector: columnSelector,
+		eventRouter:    eventRouter,
+		topicManager:   topicManager,
+		producer:       producer,
+		statistics:     statistics,
+		errCh:          errCh,
+		cancel:         cancel,
+	}
+
+	w.wg.Add(4)
+	go w.calculateKeyPartitions(ctx)
+	go func() {
+		defer w.wg.Done()
+		reportError(ctx, w.changeFeedID, w.errCh, w.encoderGroup.Run(ctx))
+	}()
+	go func() {
+		defer w.wg.Done()
+		var err error
+		if w.protocol.IsBatchEncode() {
+			err = w.batchEncodeRun(ctx)
+		} else {
+			err = w.nonBatchEncodeRun(ctx)
+		}
+		reportError(ctx, w.changeFeedID, w.errCh, err)
+	}()
+	go w.sendMessages(ctx)
+	return w
+}
+
+func (w *PulsarWorker) GetEventChan() chan<- *commonEvent.DMLEvent {
+	return w.eventChan
+}
+
+// calculateKeyPartitions splits the DML events into rows,
+// and generates the topic and partition key for each row.
+func (w *PulsarWorker) calculateKeyPartitions(ctx context.Context) {
+	defer w.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-w.eventChan:
+			topic := w.eventRouter.GetTopicForRowChange(event.TableInfo)
+			partitionNum, err := w.topicManager.GetPartitionNum(ctx, topic)
+			if err != nil {
+				log.Error("failed to get partition number for topic", zap.String("topic", topic), zap.Error(err))
+				reportError(ctx, w.changeFeedID, w.errCh, err)
+				return
+			}
+			partitionGenerator := w.eventRouter.GetPartitionGeneratorForRowChange(event.TableInfo)
+			selector := w.columnSelector.GetSelector(event.TableInfo.TableName.Schema, event.TableInfo.TableName.Table)
+
+			// The callback of the last row will trigger the callback of the txn.
+			totalCount := uint64(event.Len())
+			var calledCount atomic.Uint64
+			postTxnFlushed := event.PostTxnFlushed
+			rowCallback := func() {
+				if calledCount.Inc() == totalCount {
+					for _, callback := range postTxnFlushed {
+						callback()
+					}
+				}
+			}
+
+			for {
+				row, ok := event.GetNextRow()
+				if !ok {
+					break
+				}
+				index, key, err := partitionGenerator.GeneratePartitionIndexAndKey(&row, partitionNum, event.TableInfo, event.CommitTs)
+				if err != nil {
+					log.Error("failed to generate partition index and key for row", zap.Error(err))
+					reportError(ctx, w.changeFeedID, w.errCh, err)
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case w.rowChan <- &commonEvent.MQRowEvent{
+					Key: model.TopicPartitionKey{
+						Topic:          topic,
+						Partition:      index,
+						PartitionKey:   key,
+						TotalPartition: partitionNum,
+					},
+					RowEvent: commonEvent.RowEvent{
+						PhysicalTableID: event.PhysicalTableID,
+						TableInfo:       event.TableInfo,
+						CommitTs:        event.CommitTs,
+						Event:           row,
+						Callback:        rowCallback,
+						ColumnSelector:  selector,
+					},
+				}:
+				}
+			}
+		}
+	}
+}
+
+// nonBatchEncodeRun add events to the encoder group immediately.
+func (w *PulsarWorker) nonBatchEncodeRun(ctx context.Context) error {
+	log.Info("Pulsar sink non batch worker started",
+		zap.String("namespace", w.changeFeedID.Namespace),
+		zap.String("changefeed", w.changeFeedID.ID),
+		zap.String("protocol", w.protocol.String()),
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case event := <-w.rowChan:
+			if err := w.encoderGroup.AddEvents(ctx, event.Key, &event.RowEvent); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// batchEncodeRun collect messages into batch and add them to the encoder group.
+func (w *PulsarWorker) batchEncodeRun(ctx context.Context) error {
+	log.Info("Pulsar sink batch worker started",
+		zap.String("namespace", w.changeFeedID.Namespace),
+		zap.String("changefeed", w.changeFeedID.ID),
+		zap.String("protocol", w.protocol.String()),
+	)
+
+	metricBatchDuration := metrics.WorkerBatchDuration.WithLabelValues(w.changeFeedID.Namespace, w.changeFeedID.ID)
+	metricBatchSize := metrics.WorkerBatchSize.WithLabelValues(w.changeFeedID.Namespace, w.changeFeedID.ID)
+	defer func() {
+		metrics.WorkerBatchDuration.DeleteLabelValues(w.changeFeedID.Namespace, w.changeFeedID.ID)
+		metrics.WorkerBatchSize.DeleteLabelValues(w.changeFeedID.Namespace, w.changeFeedID.ID)
+	}()
+
+	msgsBuf := make([]*commonEvent.MQRowEvent, batchSize)
+	for {
+		start := time.Now()
+		msgCount, err := w.batch(ctx, msgsBuf)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if msgCount == 0 {
+			continue
+		}
+
+		metricBatchSize.Observe(float64(msgCount))
+		metricBatchDuration.Observe(time.Since(start).Seconds())
+
+		// Group messages by its TopicPartitionKey before adding them to the encoder group.
+		groupedMsgs := make(map[model.TopicPartitionKey][]*commonEvent.RowEvent)
+		for _, msg := range msgsBuf[:msgCount] {
+			groupedMsgs[msg.Key] = append(groupedMsgs[msg.Key], &msg.RowEvent)
+		}
+		for key, msg := range groupedMsgs {
+			if err := w.encoderGroup.AddEvents(ctx, key, msg...); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// batch collects a batch of messages from w.rowChan into buffer.
+// It returns the number of messages collected.
+// Note: It will block until at least one message is received.
+func (w *PulsarWorker) batch(ctx context.Context, buffer []*commonEvent.MQRowEvent) (int, error) {
+	msgCount := 0
+	maxBatchSize := len(buffer)
+	// We need to receive at least one message or be interrupted,
+	// otherwise it will lead to idling.
+	select {
+	case <-ctx.Done():
+		return msgCount, ctx.Err()
+	case msg := <-w.rowChan:
+		buffer[msgCount] = msg
+		msgCount++
+	}
+
+	// Reset the ticker to start a new batching.
+	// We need to stop batching when the interval is reached.
+	w.ticker.Reset(batchInterval)
+	for {
+		select {
+		case <-ctx.Done():
+			return msgCount, ctx.Err()
+		case msg := <-w.rowChan:
+			buffer[msgCount] = msg
+			msgCount++
+			if msgCount >= maxBatchSize {
+				return msgCount, nil
+			}
+		case <-w.ticker.C:
+			return msgCount, nil
+		}
+	}
+}
+
+func (w *PulsarWorker) sendMessages(ctx context.Context) {
+	defer w.wg.Done()
+	metricSendMessageDuration := metrics.WorkerSendMessageDuration.WithLabelValues(w.changeFeedID.Namespace, w.changeFeedID.ID)
+	defer metrics.WorkerSendMessageDuration.DeleteLabelValues(w.changeFeedID.Namespace, w.changeFeedID.ID)
+
+	outCh := w.encoderGroup.Output()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case future, ok := <-outCh:
+			if !ok {
+				log.Warn("Pulsar sink encoder's output channel closed",
+					zap.String("namespace", w.changeFeedID.Namespace),
+					zap.String("changefeed", w.changeFeedID.ID))
+				return
+			}
+			if err := future.Ready(ctx); err != nil {
+				reportError(ctx, w.changeFeedID, w.errCh, err)
+				return
+			}
+			for _, message := range future.Messages {
+				start := time.Now()
+				err := w.statistics.RecordBatchExecution(func() (int, int64, error) {
+					message.SetPartitionKey(future.Key.PartitionKey)
+					if err := w.producer.AsyncSendMessage(
+						ctx,
+						future.Key.Topic,
+						future.Key.Partition,
+						message); err != nil {
+						return 0, 0, err
+					}
+					return message.GetRowsCount(), int64(message.Length()), nil
+				})
+				if err != nil {
+					log.Error("pulsar dml worker failed to send message",
+						zap.String("namespace", w.changeFeedID.Namespace),
+						zap.String("changefeed", w.changeFeedID.ID),
+						zap.String("topic", future.Key.Topic),
+						zap.Error(err))
+					reportError(ctx, w.changeFeedID, w.errCh, err)
+					return
+				}
+				metricSendMessageDuration.Observe(time.Since(start).Seconds())
+			}
+		}
+	}
+}
+
+// Close stops the worker and closes the producer.
+func (w *PulsarWorker) Close() {
+	w.cancel()
+	w.wg.Wait()
+	w.ticker.Stop()
+	w.producer.Close()
+}
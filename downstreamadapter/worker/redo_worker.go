@@ -0,0 +1,231 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/cdc/model"
+	redoWriter "github.com/pingcap/tiflow/cdc/redo/writer"
+	"github.com/pingcap/tiflow/cdc/redo/writer/factory"
+	"github.com/pingcap/tiflow/pkg/redo"
+	"go.uber.org/zap"
+)
+
+const (
+	// redoBatchSize is the max number of dml events flushed to redo log in one batch.
+	redoBatchSize = 1024
+	// redoBatchInterval is the max time to wait for collecting a batch of dml events.
+	redoBatchInterval = 10 * time.Millisecond
+)
+
+// redoDMLTask is a dml event waiting to be written to redo log.
+// The callback is called after the event is flushed to the redo storage.
+type redoDMLTask struct {
+	event    *commonEvent.DMLEvent
+	callback func()
+}
+
+// RedoDMLWorker writes the dml events to the redo log storage.
+// The events are written and flushed in batch, and the callbacks
+// are called in the same order as the events are added.
+type RedoDMLWorker struct {
+	changefeedID model.ChangeFeedID
+	logWriter    redoWriter.RedoLogWriter
+	taskChan     chan redoDMLTask
+	// errCh is used to report the errors of flushing events to the redo log.
+	errCh chan<- error
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewRedoDMLWorker creates a new redo dml worker and starts it.
+func NewRedoDMLWorker(
+	changefeedID model.ChangeFeedID,
+	cfg *config.ConsistentConfig,
+	errCh chan<- error,
+) (*RedoDMLWorker, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	logWriter, err := newRedoLogWriter(ctx, changefeedID, cfg, redo.RedoRowLogFileType)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	w := &RedoDMLWorker{
+		changefeedID: changefeedID,
+		logWriter:    logWriter,
+		taskChan:     make(chan redoDMLTask, redoBatchSize),
+		errCh:        errCh,
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		w.run()
+	}()
+	return w, nil
+}
+
+// AddDMLEvent adds a dml event to the worker, callback is called
+// after the event is flushed to the redo storage.
+func (w *RedoDMLWorker) AddDMLEvent(event *commonEvent.DMLEvent, callback func()) {
+	select {
+	case <-w.ctx.Done():
+	case w.taskChan <- redoDMLTask{event: event, callback: callback}:
+	}
+}
+
+func (w *RedoDMLWorker) run() {
+	tasks := make([]redoDMLTask, 0, redoBatchSize)
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case task := <-w.taskChan:
+			tasks = append(tasks, task)
+			delay := time.NewTimer(redoBatchInterval)
+		loop:
+			for len(tasks) < redoBatchSize {
+				select {
+				case task := <-w.taskChan:
+					tasks = append(tasks, task)
+				case <-delay.C:
+					break loop
+				}
+			}
+			if !delay.Stop() {
+				select {
+				case <-delay.C:
+				default:
+				}
+			}
+
+			if err := w.flush(tasks); err != nil {
+				log.Error("redo dml worker failed to flush events",
+					zap.String("namespace", w.changefeedID.Namespace),
+					zap.String("changefeed", w.changefeedID.ID),
+					zap.Error(err))
+				// The callbacks of the tasks are never called, so the events are not
+				// sent to the downstream until the changefeed is restarted by the error.
+				reportError(w.ctx, w.changefeedID, w.errCh, err)
+				return
+			}
+			for _, task := range tasks {
+				task.callback()
+			}
+			tasks = tasks[:0]
+		}
+	}
+}
+
+func (w *RedoDMLWorker) flush(tasks []redoDMLTask) error {
+	events := make([]redoWriter.RedoEvent, 0, len(tasks))
+	for _, task := range tasks {
+		rows, err := task.event.ToRedoEvents()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, row := range rows {
+			events = append(events, row)
+		}
+	}
+	if err := w.logWriter.WriteEvents(w.ctx, events...); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(w.logWriter.FlushLog(w.ctx))
+}
+
+// Close stops the worker and closes the redo log writer.
+func (w *RedoDMLWorker) Close() {
+	w.cancel()
+	w.wg.Wait()
+	if err := w.logWriter.Close(); err != nil && errors.Cause(err) != context.Canceled {
+		log.Warn("failed to close redo dml log writer",
+			zap.String("namespace", w.changefeedID.Namespace),
+			zap.String("changefeed", w.changefeedID.ID),
+			zap.Error(err))
+	}
+}
+
+// RedoDDLWorker writes the ddl events to the redo log storage synchronously.
+type RedoDDLWorker struct {
+	changefeedID model.ChangeFeedID
+	logWriter    redoWriter.RedoLogWriter
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewRedoDDLWorker creates a new redo ddl worker.
+func NewRedoDDLWorker(
+	changefeedID model.ChangeFeedID,
+	cfg *config.ConsistentConfig,
+) (*RedoDDLWorker, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	logWriter, err := newRedoLogWriter(ctx, changefeedID, cfg, redo.RedoDDLLogFileType)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &RedoDDLWorker{
+		changefeedID: changefeedID,
+		logWriter:    logWriter,
+		ctx:          ctx,
+		cancel:       cancel,
+	}, nil
+}
+
+// WriteDDLEvent writes the ddl event to redo log and waits for it to be flushed.
+func (w *RedoDDLWorker) WriteDDLEvent(event *commonEvent.DDLEvent) error {
+	if err := w.logWriter.WriteEvents(w.ctx, event.ToRedoEvent()); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(w.logWriter.FlushLog(w.ctx))
+}
+
+// Close closes the redo log writer.
+func (w *RedoDDLWorker) Close() {
+	w.cancel()
+	if err := w.logWriter.Close(); err != nil && errors.Cause(err) != context.Canceled {
+		log.Warn("failed to close redo ddl log writer",
+			zap.String("namespace", w.changefeedID.Namespace),
+			zap.String("changefeed", w.changefeedID.ID),
+			zap.Error(err))
+	}
+}
+
+func newRedoLogWriter(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	cfg *config.ConsistentConfig,
+	logType string,
+) (redoWriter.RedoLogWriter, error) {
+	tiflowCfg := cfg.ToTiflowConfig()
+	return factory.NewRedoLogWriter(ctx, &redoWriter.LogWriterConfig{
+		ConsistentConfig:  *tiflowCfg,
+		LogType:           logType,
+		CaptureID:         config.GetGlobalServerConfig().AdvertiseAddr,
+		ChangeFeedID:      changefeedID,
+		MaxLogSizeInBytes: cfg.MaxLogSize * redo.Megabyte,
+	})
+}
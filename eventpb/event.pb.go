@@ -528,6 +528,12 @@ type RegisterDispatcherRequest struct {
 	EnableSyncPoint   bool                      `protobuf:"varint,9,opt,name=enable_sync_point,json=enableSyncPoint,proto3" json:"enable_sync_point,omitempty"`
 	SyncPointTs       uint64                    `protobuf:"varint,10,opt,name=sync_point_ts,json=syncPointTs,proto3" json:"sync_point_ts,omitempty"`
 	SyncPointInterval uint64                    `protobuf:"varint,11,opt,name=sync_point_interval,json=syncPointInterval,proto3" json:"sync_point_interval,omitempty"`
+	// limits of a single scan of the dispatcher, 0 means no limit.
+	ScanMaxBytes      uint64 `protobuf:"varint,12,opt,name=scan_max_bytes,json=scanMaxBytes,proto3" json:"scan_max_bytes,omitempty"`
+	ScanMaxRows       uint64 `protobuf:"varint,13,opt,name=scan_max_rows,json=scanMaxRows,proto3" json:"scan_max_rows,omitempty"`
+	ScanMaxDurationMs uint64 `protobuf:"varint,14,opt,name=scan_max_duration_ms,json=scanMaxDurationMs,proto3" json:"scan_max_duration_ms,omitempty"`
+	// the timezone used to decode the row values, the local timezone of the event service is used if it is empty.
+	Timezone string `protobuf:"bytes,15,opt,name=timezone,proto3" json:"timezone,omitempty"`
 }
 
 func (m *RegisterDispatcherRequest) Reset()         { *m = RegisterDispatcherRequest{} }
@@ -640,6 +646,88 @@ func (m *RegisterDispatcherRequest) GetSyncPointInterval() uint64 {
 	return 0
 }
 
+func (m *RegisterDispatcherRequest) GetScanMaxBytes() uint64 {
+	if m != nil {
+		return m.ScanMaxBytes
+	}
+	return 0
+}
+
+func (m *RegisterDispatcherRequest) GetScanMaxRows() uint64 {
+	if m != nil {
+		return m.ScanMaxRows
+	}
+	return 0
+}
+
+func (m *RegisterDispatcherRequest) GetScanMaxDurationMs() uint64 {
+	if m != nil {
+		return m.ScanMaxDurationMs
+	}
+	return 0
+}
+
+func (m *RegisterDispatcherRequest) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+// DispatcherError is sent by the event service to the event collector when it fails to
+// send the events of a dispatcher, the changefeed of the dispatcher is failed with the error.
+type DispatcherError struct {
+	DispatcherId *heartbeatpb.DispatcherID `protobuf:"bytes,1,opt,name=dispatcher_id,json=dispatcherId,proto3" json:"dispatcher_id,omitempty"`
+	Err          *heartbeatpb.RunningError `protobuf:"bytes,2,opt,name=err,proto3" json:"err,omitempty"`
+}
+
+func (m *DispatcherError) Reset()         { *m = DispatcherError{} }
+func (m *DispatcherError) String() string { return proto.CompactTextString(m) }
+func (*DispatcherError) ProtoMessage()    {}
+func (*DispatcherError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d7fb2554dfcf7f7d, []int{8}
+}
+func (m *DispatcherError) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DispatcherError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DispatcherError.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DispatcherError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DispatcherError.Merge(m, src)
+}
+func (m *DispatcherError) XXX_Size() int {
+	return m.Size()
+}
+func (m *DispatcherError) XXX_DiscardUnknown() {
+	xxx_messageInfo_DispatcherError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DispatcherError proto.InternalMessageInfo
+
+func (m *DispatcherError) GetDispatcherId() *heartbeatpb.DispatcherID {
+	if m != nil {
+		return m.DispatcherId
+	}
+	return nil
+}
+
+func (m *DispatcherError) GetErr() *heartbeatpb.RunningError {
+	if m != nil {
+		return m.Err
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("eventpb.OpType", OpType_name, OpType_value)
 	proto.RegisterEnum("eventpb.ActionType", ActionType_name, ActionType_value)
@@ -651,71 +739,78 @@ func init() {
 	proto.RegisterType((*TableInfo)(nil), "eventpb.TableInfo")
 	proto.RegisterType((*EventFeed)(nil), "eventpb.EventFeed")
 	proto.RegisterType((*RegisterDispatcherRequest)(nil), "eventpb.RegisterDispatcherRequest")
+	proto.RegisterType((*DispatcherError)(nil), "eventpb.DispatcherError")
 }
 
 func init() { proto.RegisterFile("eventpb/event.proto", fileDescriptor_d7fb2554dfcf7f7d) }
 
 var fileDescriptor_d7fb2554dfcf7f7d = []byte{
-	// 930 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xdd, 0x6e, 0xe3, 0x44,
-	0x14, 0xae, 0x9b, 0xfe, 0xc4, 0xc7, 0xe9, 0xd6, 0x9d, 0x6e, 0x17, 0x77, 0xbb, 0x84, 0x12, 0x04,
-	0x0a, 0x95, 0x48, 0x21, 0x80, 0x90, 0x10, 0x5a, 0x54, 0x5a, 0x2f, 0xf2, 0xc5, 0xb6, 0xd5, 0xc4,
-	0x5d, 0x09, 0x6e, 0x2c, 0x27, 0x3e, 0x49, 0x0d, 0xce, 0xd8, 0xf5, 0x4c, 0xd2, 0xe4, 0x2d, 0xe0,
-	0x11, 0x78, 0x1b, 0x2e, 0xf7, 0x92, 0x3b, 0x50, 0x7b, 0xc1, 0x63, 0x80, 0x3c, 0xe3, 0xd8, 0xce,
-	0x56, 0x5a, 0x69, 0xaf, 0x32, 0x73, 0xbe, 0xef, 0xcc, 0x7c, 0xe7, 0x3b, 0x73, 0x1c, 0xd8, 0xc5,
-	0x29, 0x32, 0x91, 0xf4, 0x8f, 0xe5, 0x6f, 0x27, 0x49, 0x63, 0x11, 0x93, 0xcd, 0x3c, 0xf8, 0xf4,
-	0xe0, 0x1a, 0xfd, 0x54, 0xf4, 0xd1, 0xcf, 0x18, 0xc5, 0x5a, 0xb1, 0x5a, 0x7f, 0xaf, 0xc2, 0xb6,
-	0x9d, 0x11, 0x5f, 0x84, 0x91, 0xc0, 0x94, 0x4e, 0x22, 0x24, 0x16, 0x6c, 0x8e, 0x7d, 0x31, 0xb8,
-	0xc6, 0xd4, 0xd2, 0x0e, 0x6b, 0x6d, 0x9d, 0x2e, 0xb6, 0xe4, 0x43, 0x68, 0x84, 0x23, 0x16, 0xa7,
-	0xe8, 0xc9, 0xc3, 0xad, 0x55, 0x09, 0x1b, 0x2a, 0x26, 0x8f, 0x21, 0xef, 0x03, 0xe4, 0x14, 0x7e,
-	0x13, 0x59, 0x35, 0x49, 0xd0, 0x55, 0xa4, 0x77, 0x13, 0x91, 0x6f, 0xc0, 0xca, 0xe1, 0x90, 0x71,
-	0x4c, 0x85, 0x37, 0xf5, 0xa3, 0x09, 0x7a, 0x38, 0x4b, 0x52, 0x6b, 0xed, 0x50, 0x6b, 0xeb, 0x74,
-	0x4f, 0xe1, 0x8e, 0x84, 0x5f, 0x65, 0xa8, 0x3d, 0x4b, 0x52, 0xf2, 0x1c, 0x9e, 0xe5, 0x89, 0x93,
-	0x24, 0xf0, 0x05, 0x7a, 0x0c, 0x6f, 0xab, 0xc9, 0xeb, 0x32, 0x39, 0x3f, 0xfc, 0x4a, 0x52, 0xce,
-	0xf1, 0xf6, 0x2d, 0xf9, 0x71, 0x14, 0x54, 0xf3, 0x37, 0x1e, 0xe6, 0x5f, 0x44, 0x41, 0x99, 0x5f,
-	0x0a, 0x0f, 0x30, 0x42, 0x81, 0xd5, 0xdc, 0xcd, 0xaa, 0xf0, 0x33, 0x09, 0x17, 0x89, 0xad, 0xdf,
-	0x35, 0x68, 0x28, 0x73, 0x4f, 0x63, 0x36, 0x0c, 0x47, 0xe4, 0x31, 0xac, 0xa7, 0x93, 0x08, 0x79,
-	0x6e, 0xae, 0xda, 0x90, 0xcf, 0x60, 0x37, 0x3f, 0x5f, 0xcc, 0x98, 0xc7, 0x85, 0x9f, 0x0a, 0x4f,
-	0x70, 0xe9, 0xf0, 0x1a, 0x35, 0x15, 0xe4, 0xce, 0x58, 0x2f, 0x03, 0x5c, 0x4e, 0xbe, 0x83, 0x46,
-	0xa5, 0x6d, 0x5c, 0x1a, 0x6d, 0x74, 0xad, 0x4e, 0xde, 0xf4, 0xce, 0x1b, 0x3d, 0xa5, 0x4b, 0xec,
-	0x56, 0x03, 0x80, 0x22, 0x8f, 0xa3, 0x29, 0x06, 0x2e, 0x6f, 0x4d, 0x60, 0x5d, 0xf5, 0xce, 0x84,
-	0xda, 0xaf, 0x38, 0xb7, 0xb4, 0x43, 0xad, 0xdd, 0xa0, 0xd9, 0x32, 0xd3, 0x2a, 0xeb, 0xb4, 0x56,
-	0x65, 0x4c, 0x6d, 0xc8, 0x53, 0xa8, 0x2f, 0xbc, 0xb1, 0x6a, 0x12, 0x28, 0xf6, 0xa4, 0x0d, 0x9b,
-	0x71, 0xe2, 0x89, 0x79, 0x82, 0xb2, 0x9f, 0x8f, 0xba, 0xdb, 0x85, 0xa6, 0x8b, 0xc4, 0x9d, 0x27,
-	0x48, 0x37, 0x62, 0xf9, 0xdb, 0xfa, 0x05, 0xea, 0xee, 0x8c, 0xa9, 0x9b, 0x3f, 0x81, 0x0d, 0xc9,
-	0x52, 0xa6, 0x18, 0xdd, 0x47, 0xcb, 0x85, 0xd0, 0x1c, 0x25, 0x07, 0xa0, 0x0f, 0xe2, 0xf1, 0x38,
-	0xcc, 0xbd, 0xd1, 0xda, 0x6b, 0xb4, 0xae, 0x02, 0x2e, 0x27, 0xfb, 0x50, 0x2f, 0x7c, 0xab, 0x49,
-	0x6c, 0x93, 0x2b, 0xbb, 0x5a, 0x06, 0xe8, 0xae, 0xdf, 0x8f, 0xd0, 0x61, 0xc3, 0xb8, 0xf5, 0xaf,
-	0x06, 0xba, 0xb2, 0x03, 0x31, 0x20, 0x9f, 0x03, 0x64, 0x8e, 0x2f, 0x5d, 0xbf, 0x53, 0x5c, 0xbf,
-	0x50, 0x48, 0x75, 0x91, 0xaf, 0x38, 0xf9, 0x00, 0x8c, 0x34, 0x77, 0xaf, 0x94, 0x01, 0x69, 0x61,
-	0x28, 0x79, 0x0e, 0x5b, 0x41, 0xc8, 0x13, 0x35, 0x34, 0x5e, 0x18, 0x48, 0x35, 0x46, 0x77, 0xbf,
-	0x53, 0x99, 0xc4, 0xce, 0x59, 0xc1, 0x70, 0xce, 0x68, 0xa3, 0xe4, 0x3b, 0x81, 0x7c, 0x21, 0xbe,
-	0x08, 0x63, 0xe9, 0xe0, 0x2a, 0x55, 0x1b, 0xf2, 0x05, 0x80, 0xc8, 0x6a, 0xf0, 0x42, 0x36, 0x8c,
-	0xe5, 0x7b, 0x37, 0xba, 0xa4, 0x14, 0xba, 0x28, 0x8f, 0xea, 0xa2, 0xa8, 0xf4, 0xbf, 0x1a, 0xec,
-	0x53, 0x1c, 0x85, 0x5c, 0x60, 0x5a, 0xde, 0x47, 0xf1, 0x66, 0x82, 0x5c, 0x3c, 0x94, 0xa9, 0xbd,
-	0x9b, 0xcc, 0xaf, 0x17, 0x82, 0x78, 0xe2, 0x33, 0x69, 0x83, 0xd1, 0x7d, 0xb2, 0x94, 0x2c, 0x45,
-	0xf5, 0x12, 0x9f, 0xe5, 0xa2, 0xb2, 0xe5, 0x5b, 0xda, 0x94, 0xb5, 0x97, 0x63, 0x3a, 0x55, 0x6a,
-	0xd4, 0xe7, 0xa0, 0xae, 0x02, 0x4e, 0x40, 0xbe, 0x02, 0xc3, 0x1f, 0x88, 0x30, 0x66, 0xea, 0x75,
-	0xad, 0xcb, 0xd7, 0xb5, 0x5b, 0x18, 0x70, 0x22, 0x31, 0xf9, 0xc2, 0xc0, 0x2f, 0xd6, 0xe4, 0x19,
-	0xe8, 0xcc, 0x1f, 0x23, 0x4f, 0xfc, 0x01, 0xe6, 0x43, 0x5e, 0x06, 0xc8, 0x47, 0xb0, 0x35, 0xb8,
-	0xf6, 0xd9, 0x08, 0x87, 0x88, 0x41, 0x76, 0xa9, 0x1a, 0xe5, 0x46, 0x19, 0x74, 0x02, 0xf2, 0x2d,
-	0x6c, 0x0d, 0xe5, 0xe0, 0x78, 0x03, 0x39, 0xc1, 0x56, 0x5d, 0x96, 0xba, 0x57, 0x5c, 0x5d, 0x1d,
-	0x6f, 0xda, 0x18, 0x56, 0x87, 0xfd, 0x08, 0x76, 0x90, 0x29, 0x93, 0xe6, 0x6c, 0xe0, 0x25, 0x71,
-	0xc8, 0x84, 0xa5, 0x1f, 0x6a, 0xed, 0x3a, 0xdd, 0x56, 0x40, 0x6f, 0xce, 0x06, 0x97, 0x59, 0x98,
-	0xb4, 0x60, 0xab, 0x24, 0x65, 0xee, 0x80, 0x74, 0xc7, 0xe0, 0x0b, 0x86, 0xcb, 0x49, 0x07, 0x76,
-	0x2b, 0x9c, 0x90, 0x09, 0x4c, 0xa7, 0x7e, 0x64, 0x19, 0x92, 0xb9, 0x53, 0x30, 0x9d, 0x1c, 0x38,
-	0xfa, 0x14, 0x36, 0xd4, 0xd8, 0x91, 0x2d, 0xd0, 0xd5, 0xea, 0x72, 0x22, 0xcc, 0x15, 0x62, 0x42,
-	0x43, 0x6d, 0xd5, 0xf7, 0xca, 0xd4, 0x8e, 0xfe, 0xd0, 0x00, 0x4a, 0x13, 0xc9, 0x01, 0xbc, 0x77,
-	0x72, 0xea, 0x3a, 0x17, 0xe7, 0x9e, 0xfb, 0xd3, 0xa5, 0xed, 0x5d, 0x9d, 0xf7, 0x2e, 0xed, 0x53,
-	0xe7, 0x85, 0x63, 0x9f, 0x99, 0x2b, 0xc4, 0x82, 0xc7, 0x55, 0x90, 0xda, 0x3f, 0x3a, 0x3d, 0xd7,
-	0xa6, 0xa6, 0x46, 0x9e, 0x00, 0x59, 0x46, 0x5e, 0x5e, 0xbc, 0xb2, 0xcd, 0x55, 0xb2, 0x07, 0x3b,
-	0xd5, 0xf8, 0xe5, 0xc9, 0x55, 0xcf, 0x36, 0x6b, 0x0f, 0xe9, 0xbd, 0xab, 0x97, 0xb6, 0xb9, 0xf6,
-	0x26, 0x9d, 0xda, 0x3d, 0xdb, 0x35, 0xd7, 0x7f, 0xf8, 0xfe, 0xcf, 0xbb, 0xa6, 0xf6, 0xfa, 0xae,
-	0xa9, 0xfd, 0x73, 0xd7, 0xd4, 0x7e, 0xbb, 0x6f, 0xae, 0xbc, 0xbe, 0x6f, 0xae, 0xfc, 0x75, 0xdf,
-	0x5c, 0xf9, 0xf9, 0xe3, 0x51, 0x28, 0xae, 0x27, 0xfd, 0xce, 0x20, 0x1e, 0x1f, 0x0f, 0xa3, 0xf8,
-	0xb6, 0x8f, 0xd7, 0x7e, 0x92, 0xcc, 0x8f, 0x45, 0x38, 0xf2, 0x05, 0x1e, 0xe7, 0xad, 0xea, 0x6f,
-	0xc8, 0xbf, 0xbd, 0x2f, 0xff, 0x0f, 0x00, 0x00, 0xff, 0xff, 0xfe, 0xe5, 0x21, 0x99, 0x33, 0x07,
-	0x00, 0x00,
+	// 1036 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x4f, 0x6f, 0xe3, 0x44,
+	0x14, 0xaf, 0x93, 0xa6, 0x4d, 0x5e, 0x92, 0xd6, 0x9d, 0xb6, 0x8b, 0xbb, 0x5d, 0x42, 0x09, 0x7f,
+	0x14, 0x8a, 0x48, 0x21, 0x80, 0x90, 0x10, 0x5a, 0xd4, 0x6d, 0xbd, 0xc8, 0x87, 0xfe, 0xd1, 0xc4,
+	0x5d, 0x09, 0x2e, 0x96, 0x13, 0xbf, 0xa4, 0x06, 0x67, 0xec, 0x7a, 0x26, 0x6d, 0xc2, 0x81, 0xcf,
+	0x00, 0x17, 0xee, 0x7c, 0x1b, 0x8e, 0x7b, 0xe4, 0x06, 0x6a, 0x0f, 0x7c, 0x0d, 0xe4, 0x19, 0xc7,
+	0x76, 0xb7, 0x68, 0x25, 0xc4, 0x29, 0xf3, 0xde, 0xef, 0xf7, 0xfc, 0xde, 0xfc, 0xde, 0x7b, 0xa3,
+	0xc0, 0x26, 0x5e, 0x23, 0x13, 0xd1, 0xe0, 0x40, 0xfe, 0x76, 0xa3, 0x38, 0x14, 0x21, 0x59, 0x4d,
+	0x9d, 0x8f, 0x77, 0x2f, 0xd1, 0x8d, 0xc5, 0x00, 0xdd, 0x84, 0x91, 0x9d, 0x15, 0xab, 0xfd, 0x67,
+	0x09, 0xd6, 0xcd, 0x84, 0xf8, 0xdc, 0x0f, 0x04, 0xc6, 0x74, 0x1a, 0x20, 0x31, 0x60, 0x75, 0xe2,
+	0x8a, 0xe1, 0x25, 0xc6, 0x86, 0xb6, 0x57, 0xee, 0xd4, 0xe8, 0xc2, 0x24, 0x6f, 0x43, 0xc3, 0x1f,
+	0xb3, 0x30, 0x46, 0x47, 0x7e, 0xdc, 0x28, 0x49, 0xb8, 0xae, 0x7c, 0xf2, 0x33, 0xe4, 0x4d, 0x80,
+	0x94, 0xc2, 0xaf, 0x02, 0xa3, 0x2c, 0x09, 0x35, 0xe5, 0xe9, 0x5f, 0x05, 0xe4, 0x0b, 0x30, 0x52,
+	0xd8, 0x67, 0x1c, 0x63, 0xe1, 0x5c, 0xbb, 0xc1, 0x14, 0x1d, 0x9c, 0x45, 0xb1, 0xb1, 0xbc, 0xa7,
+	0x75, 0x6a, 0x74, 0x5b, 0xe1, 0x96, 0x84, 0x5f, 0x24, 0xa8, 0x39, 0x8b, 0x62, 0xf2, 0x14, 0x9e,
+	0xa4, 0x81, 0xd3, 0xc8, 0x73, 0x05, 0x3a, 0x0c, 0x6f, 0x8a, 0xc1, 0x15, 0x19, 0x9c, 0x7e, 0xfc,
+	0x42, 0x52, 0x4e, 0xf1, 0xe6, 0x35, 0xf1, 0x61, 0xe0, 0x15, 0xe3, 0x57, 0x1e, 0xc6, 0x9f, 0x05,
+	0x5e, 0x1e, 0x9f, 0x17, 0xee, 0x61, 0x80, 0x02, 0x8b, 0xb1, 0xab, 0xc5, 0xc2, 0x8f, 0x25, 0x9c,
+	0x05, 0xb6, 0x7f, 0xd1, 0xa0, 0xa1, 0xc4, 0x3d, 0x0a, 0xd9, 0xc8, 0x1f, 0x93, 0x2d, 0xa8, 0xc4,
+	0xd3, 0x00, 0x79, 0x2a, 0xae, 0x32, 0xc8, 0x47, 0xb0, 0x99, 0x7e, 0x5f, 0xcc, 0x98, 0xc3, 0x85,
+	0x1b, 0x0b, 0x47, 0x70, 0xa9, 0xf0, 0x32, 0xd5, 0x15, 0x64, 0xcf, 0x58, 0x3f, 0x01, 0x6c, 0x4e,
+	0xbe, 0x82, 0x46, 0xa1, 0x6d, 0x5c, 0x0a, 0x5d, 0xef, 0x19, 0xdd, 0xb4, 0xe9, 0xdd, 0x57, 0x7a,
+	0x4a, 0xef, 0xb1, 0xdb, 0x0d, 0x00, 0x8a, 0x3c, 0x0c, 0xae, 0xd1, 0xb3, 0x79, 0x7b, 0x0a, 0x15,
+	0xd5, 0x3b, 0x1d, 0xca, 0x3f, 0xe0, 0xdc, 0xd0, 0xf6, 0xb4, 0x4e, 0x83, 0x26, 0xc7, 0xa4, 0x56,
+	0x79, 0x4f, 0xa3, 0x24, 0x7d, 0xca, 0x20, 0x8f, 0xa1, 0xba, 0xd0, 0xc6, 0x28, 0x4b, 0x20, 0xb3,
+	0x49, 0x07, 0x56, 0xc3, 0xc8, 0x11, 0xf3, 0x08, 0x65, 0x3f, 0xd7, 0x7a, 0xeb, 0x59, 0x4d, 0x67,
+	0x91, 0x3d, 0x8f, 0x90, 0xae, 0x84, 0xf2, 0xb7, 0xfd, 0x3d, 0x54, 0xed, 0x19, 0x53, 0x99, 0xdf,
+	0x87, 0x15, 0xc9, 0x52, 0xa2, 0xd4, 0x7b, 0x6b, 0xf7, 0x2f, 0x42, 0x53, 0x94, 0xec, 0x42, 0x6d,
+	0x18, 0x4e, 0x26, 0x7e, 0xaa, 0x8d, 0xd6, 0x59, 0xa6, 0x55, 0xe5, 0xb0, 0x39, 0xd9, 0x81, 0x6a,
+	0xa6, 0x5b, 0x59, 0x62, 0xab, 0x5c, 0xc9, 0xd5, 0xae, 0x43, 0xcd, 0x76, 0x07, 0x01, 0x5a, 0x6c,
+	0x14, 0xb6, 0xff, 0xd6, 0xa0, 0xa6, 0xe4, 0x40, 0xf4, 0xc8, 0xc7, 0x00, 0x89, 0xe2, 0xf7, 0xd2,
+	0x6f, 0x64, 0xe9, 0x17, 0x15, 0xd2, 0x9a, 0x48, 0x4f, 0x9c, 0xbc, 0x05, 0xf5, 0x38, 0x55, 0x2f,
+	0x2f, 0x03, 0xe2, 0x4c, 0x50, 0xf2, 0x14, 0x9a, 0x9e, 0xcf, 0x23, 0xb5, 0x34, 0x8e, 0xef, 0xc9,
+	0x6a, 0xea, 0xbd, 0x9d, 0x6e, 0x61, 0x13, 0xbb, 0xc7, 0x19, 0xc3, 0x3a, 0xa6, 0x8d, 0x9c, 0x6f,
+	0x79, 0x72, 0x42, 0x5c, 0xe1, 0x87, 0x52, 0xc1, 0x12, 0x55, 0x06, 0xf9, 0x04, 0x40, 0x24, 0x77,
+	0x70, 0x7c, 0x36, 0x0a, 0xe5, 0xbc, 0xd7, 0x7b, 0x24, 0x2f, 0x74, 0x71, 0x3d, 0x5a, 0x13, 0xd9,
+	0x4d, 0x7f, 0xad, 0xc0, 0x0e, 0xc5, 0xb1, 0xcf, 0x05, 0xc6, 0x79, 0x3e, 0x8a, 0x57, 0x53, 0xe4,
+	0xe2, 0x61, 0x99, 0xda, 0x7f, 0x2b, 0xf3, 0xf3, 0x45, 0x41, 0x3c, 0x72, 0x99, 0x94, 0xa1, 0xde,
+	0x7b, 0x74, 0x2f, 0x58, 0x16, 0xd5, 0x8f, 0x5c, 0x96, 0x16, 0x95, 0x1c, 0x5f, 0xd3, 0xa6, 0xa4,
+	0xbd, 0x1c, 0xe3, 0x6b, 0x55, 0x8d, 0x7a, 0x0e, 0xaa, 0xca, 0x61, 0x79, 0xe4, 0x33, 0xa8, 0xbb,
+	0x43, 0xe1, 0x87, 0x4c, 0x4d, 0x57, 0x45, 0x4e, 0xd7, 0x66, 0x26, 0xc0, 0xa1, 0xc4, 0xe4, 0x84,
+	0x81, 0x9b, 0x9d, 0xc9, 0x13, 0xa8, 0x31, 0x77, 0x82, 0x3c, 0x72, 0x87, 0x98, 0x2e, 0x79, 0xee,
+	0x20, 0xef, 0x40, 0x73, 0x78, 0xe9, 0xb2, 0x31, 0x8e, 0x10, 0xbd, 0x24, 0xa9, 0x5a, 0xe5, 0x46,
+	0xee, 0xb4, 0x3c, 0xf2, 0x25, 0x34, 0x47, 0x72, 0x71, 0x9c, 0xa1, 0xdc, 0x60, 0xa3, 0x2a, 0xaf,
+	0xba, 0x9d, 0xa5, 0x2e, 0xae, 0x37, 0x6d, 0x8c, 0x0a, 0x16, 0xd9, 0x87, 0x0d, 0x64, 0x4a, 0xa4,
+	0x39, 0x1b, 0x3a, 0x51, 0xe8, 0x33, 0x61, 0xd4, 0xf6, 0xb4, 0x4e, 0x95, 0xae, 0x2b, 0xa0, 0x3f,
+	0x67, 0xc3, 0xf3, 0xc4, 0x4d, 0xda, 0xd0, 0xcc, 0x49, 0x89, 0x3a, 0x20, 0xd5, 0xa9, 0xf3, 0x05,
+	0xc3, 0xe6, 0xa4, 0x0b, 0x9b, 0x05, 0x8e, 0xcf, 0x04, 0xc6, 0xd7, 0x6e, 0x60, 0xd4, 0x25, 0x73,
+	0x23, 0x63, 0x5a, 0x29, 0x40, 0xde, 0x85, 0x35, 0x3e, 0x74, 0x99, 0x33, 0x71, 0x67, 0xce, 0x60,
+	0x2e, 0x90, 0x1b, 0x0d, 0x49, 0x6d, 0x24, 0xde, 0x13, 0x77, 0xf6, 0x2c, 0xf1, 0xc9, 0xcc, 0x0b,
+	0x56, 0x1c, 0xde, 0x70, 0xa3, 0x99, 0x66, 0x56, 0x24, 0x1a, 0xde, 0x70, 0x72, 0x00, 0x5b, 0x19,
+	0xc7, 0x9b, 0xca, 0x91, 0x64, 0xce, 0x84, 0x1b, 0x6b, 0x69, 0x6a, 0x45, 0x3d, 0x4e, 0x91, 0x13,
+	0x9e, 0xbc, 0x12, 0xc2, 0x9f, 0xe0, 0x8f, 0x21, 0x43, 0x63, 0x5d, 0xf5, 0x72, 0x61, 0xb7, 0x7f,
+	0x82, 0xf5, 0x7c, 0xb0, 0xcc, 0x38, 0x0e, 0xe3, 0xff, 0x3d, 0x8d, 0x1f, 0x42, 0x19, 0xe3, 0xd8,
+	0x28, 0xfd, 0x4b, 0x14, 0x9d, 0x32, 0xe6, 0xb3, 0xb1, 0xcc, 0x43, 0x13, 0xd6, 0xfe, 0x07, 0xb0,
+	0xa2, 0x5e, 0x23, 0xd2, 0x84, 0x9a, 0x3a, 0x9d, 0x4f, 0x85, 0xbe, 0x44, 0x74, 0x68, 0x28, 0x53,
+	0x3d, 0xe3, 0xba, 0xb6, 0xff, 0x9b, 0x06, 0x90, 0xcf, 0x16, 0xd9, 0x85, 0x37, 0x0e, 0x8f, 0x6c,
+	0xeb, 0xec, 0xd4, 0xb1, 0xbf, 0x3d, 0x37, 0x9d, 0x8b, 0xd3, 0xfe, 0xb9, 0x79, 0x64, 0x3d, 0xb7,
+	0xcc, 0x63, 0x7d, 0x89, 0x18, 0xb0, 0x55, 0x04, 0xa9, 0xf9, 0x8d, 0xd5, 0xb7, 0x4d, 0xaa, 0x6b,
+	0xe4, 0x11, 0x90, 0xfb, 0xc8, 0xc9, 0xd9, 0x0b, 0x53, 0x2f, 0x91, 0x6d, 0xd8, 0x28, 0xfa, 0xcf,
+	0x0f, 0x2f, 0xfa, 0xa6, 0x5e, 0x7e, 0x48, 0xef, 0x5f, 0x9c, 0x98, 0xfa, 0xf2, 0xab, 0x74, 0x6a,
+	0xf6, 0x4d, 0x5b, 0xaf, 0x3c, 0xfb, 0xfa, 0xf7, 0xdb, 0x96, 0xf6, 0xf2, 0xb6, 0xa5, 0xfd, 0x75,
+	0xdb, 0xd2, 0x7e, 0xbe, 0x6b, 0x2d, 0xbd, 0xbc, 0x6b, 0x2d, 0xfd, 0x71, 0xd7, 0x5a, 0xfa, 0xee,
+	0xbd, 0xb1, 0x2f, 0x2e, 0xa7, 0x83, 0xee, 0x30, 0x9c, 0x1c, 0x8c, 0x82, 0xf0, 0x66, 0x80, 0x97,
+	0x6e, 0x14, 0xcd, 0x0f, 0x84, 0x3f, 0x76, 0x05, 0x1e, 0xa4, 0x13, 0x3c, 0x58, 0x91, 0xff, 0x06,
+	0x3e, 0xfd, 0x67, 0x00, 0x6a, 0x51, 0x1f, 0x29, 0x4a, 0x08, 0x00, 0x00,
 }
 
 func (m *EventFilterRule) Marshal() (dAtA []byte, err error) {
@@ -1094,6 +1189,28 @@ func (m *RegisterDispatcherRequest) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	_ = i
 	var l int
 	_ = l
+	if len(m.Timezone) > 0 {
+		i -= len(m.Timezone)
+		copy(dAtA[i:], m.Timezone)
+		i = encodeVarintEvent(dAtA, i, uint64(len(m.Timezone)))
+		i--
+		dAtA[i] = 0x7a
+	}
+	if m.ScanMaxDurationMs != 0 {
+		i = encodeVarintEvent(dAtA, i, uint64(m.ScanMaxDurationMs))
+		i--
+		dAtA[i] = 0x70
+	}
+	if m.ScanMaxRows != 0 {
+		i = encodeVarintEvent(dAtA, i, uint64(m.ScanMaxRows))
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.ScanMaxBytes != 0 {
+		i = encodeVarintEvent(dAtA, i, uint64(m.ScanMaxBytes))
+		i--
+		dAtA[i] = 0x60
+	}
 	if m.SyncPointInterval != 0 {
 		i = encodeVarintEvent(dAtA, i, uint64(m.SyncPointInterval))
 		i--
@@ -1184,6 +1301,53 @@ func (m *RegisterDispatcherRequest) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	return len(dAtA) - i, nil
 }
 
+func (m *DispatcherError) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DispatcherError) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DispatcherError) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Err != nil {
+		{
+			size, err := m.Err.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvent(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.DispatcherId != nil {
+		{
+			size, err := m.DispatcherId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvent(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintEvent(dAtA []byte, offset int, v uint64) int {
 	offset -= sovEvent(v)
 	base := offset
@@ -1403,6 +1567,36 @@ func (m *RegisterDispatcherRequest) Size() (n int) {
 	if m.SyncPointInterval != 0 {
 		n += 1 + sovEvent(uint64(m.SyncPointInterval))
 	}
+	if m.ScanMaxBytes != 0 {
+		n += 1 + sovEvent(uint64(m.ScanMaxBytes))
+	}
+	if m.ScanMaxRows != 0 {
+		n += 1 + sovEvent(uint64(m.ScanMaxRows))
+	}
+	if m.ScanMaxDurationMs != 0 {
+		n += 1 + sovEvent(uint64(m.ScanMaxDurationMs))
+	}
+	l = len(m.Timezone)
+	if l > 0 {
+		n += 1 + l + sovEvent(uint64(l))
+	}
+	return n
+}
+
+func (m *DispatcherError) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DispatcherId != nil {
+		l = m.DispatcherId.Size()
+		n += 1 + l + sovEvent(uint64(l))
+	}
+	if m.Err != nil {
+		l = m.Err.Size()
+		n += 1 + l + sovEvent(uint64(l))
+	}
 	return n
 }
 
@@ -2786,6 +2980,217 @@ func (m *RegisterDispatcherRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScanMaxBytes", wireType)
+			}
+			m.ScanMaxBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ScanMaxBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScanMaxRows", wireType)
+			}
+			m.ScanMaxRows = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ScanMaxRows |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScanMaxDurationMs", wireType)
+			}
+			m.ScanMaxDurationMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ScanMaxDurationMs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timezone", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Timezone = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DispatcherError) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DispatcherError: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DispatcherError: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DispatcherId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DispatcherId == nil {
+				m.DispatcherId = &heartbeatpb.DispatcherID{}
+			}
+			if err := m.DispatcherId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Err", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Err == nil {
+				m.Err = &heartbeatpb.RunningError{}
+			}
+			if err := m.Err.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvent(dAtA[iNdEx:])
@@ -52,16 +52,20 @@ type ScheduleAction int32
 const (
 	ScheduleAction_Create ScheduleAction = 0
 	ScheduleAction_Remove ScheduleAction = 1
+	// Hold holds the dispatcher at the holdTs of the request, it's used to merge spans at a common checkpoint ts.
+	ScheduleAction_Hold ScheduleAction = 2
 )
 
 var ScheduleAction_name = map[int32]string{
 	0: "Create",
 	1: "Remove",
+	2: "Hold",
 }
 
 var ScheduleAction_value = map[string]int32{
 	"Create": 0,
 	"Remove": 1,
+	"Hold":   2,
 }
 
 func (x ScheduleAction) String() string {
@@ -774,6 +778,9 @@ type ScheduleDispatcherRequest struct {
 	ChangefeedID   string            `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
 	Config         *DispatcherConfig `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
 	ScheduleAction ScheduleAction    `protobuf:"varint,3,opt,name=scheduleAction,proto3,enum=heartbeatpb.ScheduleAction" json:"scheduleAction,omitempty"`
+	// only used by the hold action, the dispatcher doesn't write the events whose commit ts is larger than holdTs,
+	// 0 means releasing the dispatcher.
+	HoldTs uint64 `protobuf:"varint,4,opt,name=holdTs,proto3" json:"holdTs,omitempty"`
 }
 
 func (m *ScheduleDispatcherRequest) Reset()         { *m = ScheduleDispatcherRequest{} }
@@ -830,6 +837,13 @@ func (m *ScheduleDispatcherRequest) GetScheduleAction() ScheduleAction {
 	return ScheduleAction_Create
 }
 
+func (m *ScheduleDispatcherRequest) GetHoldTs() uint64 {
+	if m != nil {
+		return m.HoldTs
+	}
+	return 0
+}
+
 type MaintainerHeartbeat struct {
 	Statuses []*MaintainerStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
 }
@@ -881,6 +895,12 @@ type MaintainerStatus struct {
 	CheckpointTs uint64          `protobuf:"varint,4,opt,name=checkpoint_ts,json=checkpointTs,proto3" json:"checkpoint_ts,omitempty"`
 	Warning      []*RunningError `protobuf:"bytes,5,rep,name=warning,proto3" json:"warning,omitempty"`
 	Err          []*RunningError `protobuf:"bytes,6,rep,name=err,proto3" json:"err,omitempty"`
+	// the number of spans scheduled to each node
+	NodeSpanCounts []*NodeSpanCount `protobuf:"bytes,7,rep,name=node_span_counts,json=nodeSpanCounts,proto3" json:"node_span_counts,omitempty"`
+	// the resource indicators of the maintainer, the coordinator balances the maintainers by them
+	TableCount      uint32 `protobuf:"varint,8,opt,name=table_count,json=tableCount,proto3" json:"table_count,omitempty"`
+	SpanCount       uint32 `protobuf:"varint,9,opt,name=span_count,json=spanCount,proto3" json:"span_count,omitempty"`
+	CheckpointLagMs uint64 `protobuf:"varint,10,opt,name=checkpoint_lag_ms,json=checkpointLagMs,proto3" json:"checkpoint_lag_ms,omitempty"`
 }
 
 func (m *MaintainerStatus) Reset()         { *m = MaintainerStatus{} }
@@ -958,6 +978,34 @@ func (m *MaintainerStatus) GetErr() []*RunningError {
 	return nil
 }
 
+func (m *MaintainerStatus) GetNodeSpanCounts() []*NodeSpanCount {
+	if m != nil {
+		return m.NodeSpanCounts
+	}
+	return nil
+}
+
+func (m *MaintainerStatus) GetTableCount() uint32 {
+	if m != nil {
+		return m.TableCount
+	}
+	return 0
+}
+
+func (m *MaintainerStatus) GetSpanCount() uint32 {
+	if m != nil {
+		return m.SpanCount
+	}
+	return 0
+}
+
+func (m *MaintainerStatus) GetCheckpointLagMs() uint64 {
+	if m != nil {
+		return m.CheckpointLagMs
+	}
+	return 0
+}
+
 type CoordinatorBootstrapRequest struct {
 	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
 }
@@ -1828,6 +1876,16 @@ type TableSpanStatus struct {
 	ID              *DispatcherID  `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
 	ComponentStatus ComponentState `protobuf:"varint,2,opt,name=component_status,json=componentStatus,proto3,enum=heartbeatpb.ComponentState" json:"component_status,omitempty"`
 	CheckpointTs    uint64         `protobuf:"varint,3,opt,name=checkpoint_ts,json=checkpointTs,proto3" json:"checkpoint_ts,omitempty"`
+	// the number of rows written to the sink by the dispatcher per second.
+	EventRowsPerSecond uint64 `protobuf:"varint,4,opt,name=event_rows_per_second,json=eventRowsPerSecond,proto3" json:"event_rows_per_second,omitempty"`
+	// the size of the events written to the sink by the dispatcher per second.
+	EventBytesPerSecond uint64 `protobuf:"varint,5,opt,name=event_bytes_per_second,json=eventBytesPerSecond,proto3" json:"event_bytes_per_second,omitempty"`
+	// the lag of the sink in milliseconds, which is the duration between now and the checkpoint ts.
+	SinkLagMs uint64 `protobuf:"varint,6,opt,name=sink_lag_ms,json=sinkLagMs,proto3" json:"sink_lag_ms,omitempty"`
+	// the ts the dispatcher is held at, 0 if it's not held.
+	HoldTs uint64 `protobuf:"varint,7,opt,name=hold_ts,json=holdTs,proto3" json:"hold_ts,omitempty"`
+	// the commit ts of the block event the dispatcher is waiting for, 0 if there is no such event.
+	BlockTs uint64 `protobuf:"varint,8,opt,name=block_ts,json=blockTs,proto3" json:"block_ts,omitempty"`
 }
 
 func (m *TableSpanStatus) Reset()         { *m = TableSpanStatus{} }
@@ -1884,6 +1942,41 @@ func (m *TableSpanStatus) GetCheckpointTs() uint64 {
 	return 0
 }
 
+func (m *TableSpanStatus) GetEventRowsPerSecond() uint64 {
+	if m != nil {
+		return m.EventRowsPerSecond
+	}
+	return 0
+}
+
+func (m *TableSpanStatus) GetEventBytesPerSecond() uint64 {
+	if m != nil {
+		return m.EventBytesPerSecond
+	}
+	return 0
+}
+
+func (m *TableSpanStatus) GetSinkLagMs() uint64 {
+	if m != nil {
+		return m.SinkLagMs
+	}
+	return 0
+}
+
+func (m *TableSpanStatus) GetHoldTs() uint64 {
+	if m != nil {
+		return m.HoldTs
+	}
+	return 0
+}
+
+func (m *TableSpanStatus) GetBlockTs() uint64 {
+	if m != nil {
+		return m.BlockTs
+	}
+	return 0
+}
+
 type BlockStatusRequest struct {
 	ChangefeedID  string                  `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
 	BlockStatuses []*TableSpanBlockStatus `protobuf:"bytes,2,rep,name=blockStatuses,proto3" json:"blockStatuses,omitempty"`
@@ -2056,6 +2149,324 @@ func (m *DispatcherID) GetLow() uint64 {
 	return 0
 }
 
+// MoveTableRequest is sent by the coordinator to the maintainer of the changefeed
+// to move all spans of a table to the target node.
+// If the target node is empty, the request only queries the move status of the table.
+type MoveTableRequest struct {
+	ChangefeedID string `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
+	RequestId    uint64 `protobuf:"varint,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	TableId      int64  `protobuf:"varint,3,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	TargetNode   string `protobuf:"bytes,4,opt,name=target_node,json=targetNode,proto3" json:"target_node,omitempty"`
+}
+
+func (m *MoveTableRequest) Reset()         { *m = MoveTableRequest{} }
+func (m *MoveTableRequest) String() string { return proto.CompactTextString(m) }
+func (*MoveTableRequest) ProtoMessage()    {}
+func (*MoveTableRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{31}
+}
+func (m *MoveTableRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MoveTableRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MoveTableRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MoveTableRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveTableRequest.Merge(m, src)
+}
+func (m *MoveTableRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *MoveTableRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveTableRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MoveTableRequest proto.InternalMessageInfo
+
+func (m *MoveTableRequest) GetChangefeedID() string {
+	if m != nil {
+		return m.ChangefeedID
+	}
+	return ""
+}
+
+func (m *MoveTableRequest) GetRequestId() uint64 {
+	if m != nil {
+		return m.RequestId
+	}
+	return 0
+}
+
+func (m *MoveTableRequest) GetTableId() int64 {
+	if m != nil {
+		return m.TableId
+	}
+	return 0
+}
+
+func (m *MoveTableRequest) GetTargetNode() string {
+	if m != nil {
+		return m.TargetNode
+	}
+	return ""
+}
+
+type TableSpanMoveStatus struct {
+	Id   *DispatcherID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Span *TableSpan    `protobuf:"bytes,2,opt,name=span,proto3" json:"span,omitempty"`
+	// the node the span is scheduled to
+	Node string `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
+	// the operator which is scheduling the span, empty if there is no operator
+	Operator string `protobuf:"bytes,4,opt,name=operator,proto3" json:"operator,omitempty"`
+}
+
+func (m *TableSpanMoveStatus) Reset()         { *m = TableSpanMoveStatus{} }
+func (m *TableSpanMoveStatus) String() string { return proto.CompactTextString(m) }
+func (*TableSpanMoveStatus) ProtoMessage()    {}
+func (*TableSpanMoveStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{32}
+}
+func (m *TableSpanMoveStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TableSpanMoveStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TableSpanMoveStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TableSpanMoveStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TableSpanMoveStatus.Merge(m, src)
+}
+func (m *TableSpanMoveStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *TableSpanMoveStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_TableSpanMoveStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TableSpanMoveStatus proto.InternalMessageInfo
+
+func (m *TableSpanMoveStatus) GetId() *DispatcherID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *TableSpanMoveStatus) GetSpan() *TableSpan {
+	if m != nil {
+		return m.Span
+	}
+	return nil
+}
+
+func (m *TableSpanMoveStatus) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *TableSpanMoveStatus) GetOperator() string {
+	if m != nil {
+		return m.Operator
+	}
+	return ""
+}
+
+// MoveTableResponse is the response of MoveTableRequest.
+type MoveTableResponse struct {
+	ChangefeedID string                 `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
+	RequestId    uint64                 `protobuf:"varint,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	TableId      int64                  `protobuf:"varint,3,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	Spans        []*TableSpanMoveStatus `protobuf:"bytes,4,rep,name=spans,proto3" json:"spans,omitempty"`
+	Error        string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *MoveTableResponse) Reset()         { *m = MoveTableResponse{} }
+func (m *MoveTableResponse) String() string { return proto.CompactTextString(m) }
+func (*MoveTableResponse) ProtoMessage()    {}
+func (*MoveTableResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{33}
+}
+func (m *MoveTableResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MoveTableResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MoveTableResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MoveTableResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveTableResponse.Merge(m, src)
+}
+func (m *MoveTableResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *MoveTableResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveTableResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MoveTableResponse proto.InternalMessageInfo
+
+func (m *MoveTableResponse) GetChangefeedID() string {
+	if m != nil {
+		return m.ChangefeedID
+	}
+	return ""
+}
+
+func (m *MoveTableResponse) GetRequestId() uint64 {
+	if m != nil {
+		return m.RequestId
+	}
+	return 0
+}
+
+func (m *MoveTableResponse) GetTableId() int64 {
+	if m != nil {
+		return m.TableId
+	}
+	return 0
+}
+
+func (m *MoveTableResponse) GetSpans() []*TableSpanMoveStatus {
+	if m != nil {
+		return m.Spans
+	}
+	return nil
+}
+
+func (m *MoveTableResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type NodeSpanCount struct {
+	NodeId    string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	SpanCount uint32 `protobuf:"varint,2,opt,name=span_count,json=spanCount,proto3" json:"span_count,omitempty"`
+}
+
+func (m *NodeSpanCount) Reset()         { *m = NodeSpanCount{} }
+func (m *NodeSpanCount) String() string { return proto.CompactTextString(m) }
+func (*NodeSpanCount) ProtoMessage()    {}
+func (*NodeSpanCount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{34}
+}
+func (m *NodeSpanCount) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NodeSpanCount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NodeSpanCount.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *NodeSpanCount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeSpanCount.Merge(m, src)
+}
+func (m *NodeSpanCount) XXX_Size() int {
+	return m.Size()
+}
+func (m *NodeSpanCount) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeSpanCount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeSpanCount proto.InternalMessageInfo
+
+func (m *NodeSpanCount) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *NodeSpanCount) GetSpanCount() uint32 {
+	if m != nil {
+		return m.SpanCount
+	}
+	return 0
+}
+
+// DrainNodeRequest is broadcast by the coordinator to all nodes when a node is drained or undrained,
+// and when the alive nodes change, so the nodes joining later are notified too.
+// The maintainers move the dispatchers away from the unschedulable nodes.
+type DrainNodeRequest struct {
+	// all unschedulable nodes, the receiver replaces its unschedulable nodes with them
+	NodeIds []string `protobuf:"bytes,1,rep,name=node_ids,json=nodeIds,proto3" json:"node_ids,omitempty"`
+}
+
+func (m *DrainNodeRequest) Reset()         { *m = DrainNodeRequest{} }
+func (m *DrainNodeRequest) String() string { return proto.CompactTextString(m) }
+func (*DrainNodeRequest) ProtoMessage()    {}
+func (*DrainNodeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{35}
+}
+func (m *DrainNodeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DrainNodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DrainNodeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DrainNodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DrainNodeRequest.Merge(m, src)
+}
+func (m *DrainNodeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DrainNodeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DrainNodeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DrainNodeRequest proto.InternalMessageInfo
+
+func (m *DrainNodeRequest) GetNodeIds() []string {
+	if m != nil {
+		return m.NodeIds
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("heartbeatpb.Action", Action_name, Action_value)
 	proto.RegisterEnum("heartbeatpb.ScheduleAction", ScheduleAction_name, ScheduleAction_value)
@@ -2093,113 +2504,141 @@ func init() {
 	proto.RegisterType((*BlockStatusRequest)(nil), "heartbeatpb.BlockStatusRequest")
 	proto.RegisterType((*RunningError)(nil), "heartbeatpb.RunningError")
 	proto.RegisterType((*DispatcherID)(nil), "heartbeatpb.DispatcherID")
+	proto.RegisterType((*MoveTableRequest)(nil), "heartbeatpb.MoveTableRequest")
+	proto.RegisterType((*TableSpanMoveStatus)(nil), "heartbeatpb.TableSpanMoveStatus")
+	proto.RegisterType((*MoveTableResponse)(nil), "heartbeatpb.MoveTableResponse")
+	proto.RegisterType((*NodeSpanCount)(nil), "heartbeatpb.NodeSpanCount")
+	proto.RegisterType((*DrainNodeRequest)(nil), "heartbeatpb.DrainNodeRequest")
 }
 
 func init() { proto.RegisterFile("heartbeatpb/heartbeat.proto", fileDescriptor_6d584080fdadb670) }
 
 var fileDescriptor_6d584080fdadb670 = []byte{
-	// 1608 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x4f, 0x4f, 0x1c, 0xc7,
-	0x12, 0x67, 0x66, 0x60, 0xd9, 0xad, 0xe5, 0xcf, 0xb8, 0xb1, 0xf1, 0x1a, 0x0c, 0x0f, 0xcf, 0xbb,
-	0x60, 0xfc, 0x1e, 0x28, 0xd8, 0x96, 0x93, 0x28, 0x96, 0x03, 0x0b, 0xb1, 0x57, 0xc8, 0xd8, 0x6a,
-	0x48, 0x88, 0x23, 0x59, 0x68, 0x76, 0xa6, 0xd9, 0x1d, 0xb1, 0x3b, 0x33, 0x99, 0xee, 0x85, 0x70,
-	0xc8, 0x21, 0x8a, 0x94, 0x53, 0xa4, 0xe4, 0x2b, 0xe4, 0xe8, 0x6b, 0x72, 0xcc, 0x07, 0x48, 0x94,
-	0x93, 0x8f, 0x3e, 0x46, 0xf6, 0x17, 0x89, 0xba, 0xa7, 0xe7, 0xef, 0x0e, 0xb0, 0xab, 0xf8, 0xd6,
-	0xd5, 0x5d, 0x55, 0xdd, 0x5d, 0xfd, 0xab, 0x5f, 0xd5, 0x0c, 0xcc, 0xb7, 0x89, 0x19, 0xb0, 0x26,
-	0x31, 0x99, 0xdf, 0x5c, 0x8b, 0xc7, 0xab, 0x7e, 0xe0, 0x31, 0x0f, 0x55, 0x53, 0x8b, 0xc6, 0x0b,
-	0xa8, 0xec, 0x9b, 0xcd, 0x0e, 0xd9, 0xf3, 0x4d, 0x17, 0xd5, 0x60, 0x5c, 0x08, 0x8d, 0xad, 0x9a,
-	0xb2, 0xa4, 0x2c, 0x6b, 0x38, 0x12, 0xd1, 0x1c, 0x94, 0xf7, 0x98, 0x19, 0xb0, 0x1d, 0x72, 0x56,
-	0x53, 0x97, 0x94, 0xe5, 0x09, 0x1c, 0xcb, 0x68, 0x16, 0x4a, 0xdb, 0xae, 0xcd, 0x57, 0x34, 0xb1,
-	0x22, 0x25, 0xe3, 0x57, 0x15, 0xf4, 0x27, 0x7c, 0xab, 0x4d, 0x62, 0x32, 0x4c, 0xbe, 0xee, 0x11,
-	0xca, 0x90, 0x01, 0x13, 0x56, 0xdb, 0x74, 0x5b, 0xe4, 0x88, 0x10, 0x5b, 0xee, 0x53, 0xc1, 0x99,
-	0x39, 0x74, 0x0f, 0x2a, 0xa7, 0x26, 0x23, 0x41, 0xd7, 0x0c, 0x8e, 0xc5, 0x6e, 0xd5, 0xf5, 0xd9,
-	0xd5, 0xd4, 0xa1, 0x57, 0x0f, 0xa2, 0x55, 0x9c, 0x28, 0xa2, 0x0f, 0xa1, 0x4c, 0x99, 0xc9, 0x7a,
-	0x94, 0xd0, 0x9a, 0xb6, 0xa4, 0x2d, 0x57, 0xd7, 0x6f, 0x66, 0x8c, 0xe2, 0x6b, 0xee, 0x09, 0x2d,
-	0x1c, 0x6b, 0xa3, 0x65, 0x98, 0xb6, 0xbc, 0xae, 0x4f, 0x3a, 0x84, 0x91, 0x70, 0xb1, 0x36, 0xba,
-	0xa4, 0x2c, 0x97, 0x71, 0x7e, 0x1a, 0xdd, 0x85, 0xf1, 0x53, 0x33, 0x70, 0x1d, 0xb7, 0x55, 0x1b,
-	0x13, 0xe7, 0xba, 0x91, 0xd9, 0x02, 0xf7, 0x5c, 0xbe, 0xb6, 0x1d, 0x04, 0x5e, 0x80, 0x23, 0x4d,
-	0x74, 0x07, 0x34, 0x12, 0x04, 0xb5, 0xd2, 0x65, 0x06, 0x5c, 0xcb, 0x78, 0x06, 0x95, 0xf8, 0x76,
-	0x61, 0xb0, 0x88, 0x75, 0xec, 0x7b, 0x8e, 0xcb, 0xf6, 0xa9, 0x08, 0xd6, 0x28, 0xce, 0xcc, 0xa1,
-	0x45, 0x80, 0x80, 0x50, 0xaf, 0x73, 0x42, 0xec, 0x7d, 0x2a, 0xa2, 0x35, 0x8a, 0x53, 0x33, 0xc6,
-	0xb7, 0xa0, 0x6f, 0x39, 0xd4, 0x37, 0x99, 0xd5, 0x26, 0xc1, 0x86, 0xc5, 0x1c, 0xcf, 0x45, 0x77,
-	0xa0, 0x64, 0x8a, 0x91, 0xf0, 0x38, 0xb5, 0x3e, 0x93, 0x39, 0x54, 0xa8, 0x84, 0xa5, 0x0a, 0x7f,
-	0xfa, 0xba, 0xd7, 0xed, 0x3a, 0x2c, 0x76, 0x1f, 0xcb, 0x68, 0x09, 0xaa, 0x0d, 0xba, 0x77, 0xe6,
-	0x5a, 0xcf, 0xf9, 0x69, 0xc4, 0xfb, 0x97, 0x71, 0x7a, 0xca, 0xa8, 0x83, 0xb6, 0x51, 0xdf, 0xc9,
-	0x38, 0x51, 0x2e, 0x76, 0xa2, 0xf6, 0x3b, 0xf9, 0x5e, 0x85, 0x6b, 0x0d, 0xf7, 0xa8, 0xd3, 0x23,
-	0xae, 0x45, 0xec, 0xe4, 0x3a, 0x14, 0x7d, 0x0a, 0x93, 0xf1, 0xc2, 0xfe, 0x99, 0x4f, 0xe4, 0x85,
-	0xe6, 0x32, 0x17, 0xca, 0x68, 0xe0, 0xac, 0x01, 0x7a, 0x04, 0x93, 0x89, 0xc3, 0xc6, 0x16, 0xbf,
-	0xa3, 0xd6, 0xf7, 0x4e, 0x69, 0x0d, 0x9c, 0xd5, 0x17, 0xa9, 0x61, 0xb5, 0x49, 0xd7, 0x6c, 0x6c,
-	0x89, 0x00, 0x68, 0x38, 0x96, 0xd1, 0x0e, 0xcc, 0x90, 0x6f, 0xac, 0x4e, 0xcf, 0x26, 0x29, 0x1b,
-	0x5b, 0xa0, 0xeb, 0xc2, 0x2d, 0x8a, 0xac, 0x8c, 0x3f, 0x94, 0xf4, 0x53, 0x4a, 0x44, 0x7e, 0x09,
-	0xd7, 0x9c, 0xa2, 0xc8, 0x88, 0x40, 0x54, 0xd7, 0x8d, 0xe2, 0x40, 0xa4, 0x35, 0x71, 0xb1, 0x03,
-	0x74, 0x3f, 0x06, 0x49, 0x98, 0x82, 0x0b, 0xe7, 0x1c, 0x37, 0x07, 0x17, 0x03, 0x34, 0xd3, 0x3a,
-	0x16, 0x91, 0xa8, 0xae, 0xeb, 0x59, 0x60, 0xd5, 0x77, 0x30, 0x5f, 0x34, 0x7e, 0x50, 0xe0, 0x4a,
-	0x8a, 0x19, 0xa8, 0xef, 0xb9, 0x94, 0x0c, 0x44, 0x0d, 0x4f, 0x01, 0xd9, 0xb9, 0x10, 0x90, 0xe8,
-	0xc9, 0xce, 0x3b, 0xa0, 0xcc, 0xf7, 0x02, 0x43, 0xe3, 0x25, 0xcc, 0xd4, 0x53, 0xc9, 0xf4, 0x94,
-	0x50, 0x6a, 0xb6, 0x06, 0x3b, 0x49, 0x3e, 0x37, 0xd5, 0xfe, 0xdc, 0x34, 0x7e, 0xcb, 0xbc, 0x58,
-	0xdd, 0x73, 0x8f, 0x9c, 0x16, 0x5a, 0x81, 0x51, 0xea, 0x9b, 0xae, 0x7c, 0xa0, 0xd9, 0x62, 0x8e,
-	0xc2, 0x42, 0x87, 0x13, 0x32, 0xe5, 0x34, 0x1b, 0xfb, 0x8f, 0x44, 0xf4, 0x10, 0x26, 0xec, 0x14,
-	0x62, 0x64, 0xbc, 0x2f, 0x80, 0x54, 0x46, 0x9d, 0x83, 0x96, 0x46, 0xa0, 0x1d, 0x0d, 0x41, 0x1b,
-	0xc9, 0xc6, 0xef, 0x0a, 0xdc, 0xe0, 0x08, 0xb6, 0x7b, 0x9d, 0x14, 0x00, 0x87, 0x21, 0xf0, 0xfb,
-	0x50, 0xb2, 0xc4, 0x65, 0x2f, 0x81, 0x4e, 0x18, 0x11, 0x2c, 0x95, 0x51, 0x1d, 0xa6, 0xa8, 0xdc,
-	0x37, 0x04, 0x95, 0xb8, 0xd5, 0xd4, 0xfa, 0x7c, 0xc6, 0x7c, 0x2f, 0xa3, 0x82, 0x73, 0x26, 0xc6,
-	0x73, 0x98, 0x79, 0x6a, 0x3a, 0x2e, 0x33, 0x1d, 0x97, 0x04, 0x4f, 0x22, 0x3b, 0xf4, 0x51, 0xaa,
-	0x3a, 0x28, 0x05, 0x70, 0x49, 0x6c, 0xf2, 0xe5, 0xc1, 0xf8, 0x49, 0x05, 0x3d, 0xbf, 0x3c, 0x50,
-	0x18, 0x16, 0x00, 0xf8, 0xe8, 0x90, 0x7b, 0x22, 0x22, 0x14, 0x15, 0x5c, 0xe1, 0x33, 0xdc, 0x07,
-	0x41, 0x1f, 0xc0, 0x58, 0xb8, 0x52, 0x74, 0xcb, 0xba, 0xd7, 0xf5, 0x3d, 0x97, 0xb8, 0x4c, 0xe8,
-	0xe2, 0x50, 0x13, 0xfd, 0x17, 0x26, 0x13, 0x80, 0x1d, 0xb2, 0xb0, 0x4e, 0xe5, 0x2b, 0x42, 0xa6,
-	0x48, 0x69, 0xc3, 0x16, 0x29, 0x6d, 0x80, 0x22, 0xf5, 0x00, 0xe6, 0xeb, 0x9e, 0x17, 0xd8, 0x8e,
-	0x6b, 0x32, 0x2f, 0xd8, 0xf4, 0x3c, 0x46, 0x59, 0x60, 0xfa, 0x11, 0x44, 0x6a, 0x30, 0x7e, 0x42,
-	0x02, 0x1a, 0xd5, 0x17, 0x0d, 0x47, 0xa2, 0xf1, 0x02, 0x6e, 0x16, 0x1b, 0x4a, 0x0a, 0xf8, 0x17,
-	0xaf, 0x64, 0xc1, 0xd5, 0x0d, 0xdb, 0x4e, 0x14, 0xa2, 0xc3, 0x4c, 0x81, 0xea, 0xd8, 0xf2, 0x79,
-	0x54, 0xc7, 0xe6, 0xdd, 0x4a, 0x0a, 0x9b, 0x13, 0x31, 0xf8, 0xfa, 0x42, 0xab, 0x15, 0x24, 0xf4,
-	0x4b, 0xb8, 0x8e, 0x49, 0xd7, 0x3b, 0x21, 0x97, 0xef, 0x53, 0x83, 0x71, 0xcb, 0xa4, 0x96, 0x69,
-	0x13, 0x59, 0xd1, 0x22, 0x91, 0xaf, 0x04, 0xc2, 0x89, 0x2d, 0x0b, 0x66, 0x24, 0x1a, 0x6f, 0x14,
-	0x98, 0x4b, 0x3c, 0xf7, 0xc5, 0x75, 0x10, 0xcc, 0x9d, 0x77, 0xbd, 0x1b, 0x22, 0xb2, 0x41, 0xea,
-	0x66, 0x31, 0x95, 0x58, 0x70, 0x8b, 0x71, 0xde, 0x39, 0x64, 0x81, 0xd3, 0x6a, 0x91, 0xe0, 0x90,
-	0x9c, 0x10, 0x97, 0x1d, 0x26, 0x7c, 0x71, 0xe8, 0x0c, 0x50, 0xb2, 0x16, 0x84, 0x8f, 0xfd, 0xd0,
-	0xc5, 0x36, 0xf7, 0x90, 0x29, 0x5e, 0x7f, 0x29, 0x30, 0x5f, 0x78, 0xb5, 0x21, 0xc8, 0xff, 0x3e,
-	0x8c, 0x71, 0x56, 0x8c, 0xf8, 0xfe, 0x3f, 0x99, 0xc3, 0xc4, 0x2e, 0x13, 0x0e, 0x0d, 0xb5, 0x23,
-	0x68, 0x6b, 0x83, 0xf4, 0x5f, 0x03, 0x65, 0x98, 0xf1, 0x4a, 0x05, 0xd4, 0xbf, 0x1f, 0xba, 0x0d,
-	0xaa, 0x3c, 0xf9, 0x85, 0x91, 0x52, 0x65, 0x3f, 0x1d, 0xf1, 0xaf, 0x9a, 0x6b, 0x1a, 0xa2, 0x02,
-	0xa1, 0x0d, 0x50, 0x20, 0x3e, 0x03, 0xdd, 0x8a, 0x98, 0xe2, 0x90, 0x26, 0xbd, 0xeb, 0x25, 0x74,
-	0x32, 0x6d, 0xa5, 0xe5, 0x1e, 0xed, 0xbf, 0xf6, 0x58, 0x21, 0xb1, 0x54, 0x9b, 0x1d, 0xcf, 0x3a,
-	0x96, 0x84, 0x16, 0x36, 0xb4, 0x28, 0x4b, 0xce, 0xc2, 0x3d, 0x08, 0x35, 0x31, 0x36, 0xbe, 0x80,
-	0xd9, 0xe4, 0xdd, 0xeb, 0x1d, 0x8f, 0x92, 0x61, 0xe0, 0x9c, 0xca, 0x15, 0x35, 0x9b, 0x2b, 0x07,
-	0x70, 0xbd, 0xcf, 0xef, 0x10, 0x58, 0xe2, 0x95, 0xb5, 0x67, 0x59, 0x84, 0xd2, 0xc8, 0xb1, 0x14,
-	0x8d, 0x1f, 0x15, 0xd0, 0x93, 0x46, 0x49, 0x04, 0xfc, 0x7d, 0xf4, 0x99, 0x73, 0x50, 0x96, 0x1f,
-	0x53, 0x21, 0x7e, 0x35, 0x1c, 0xcb, 0x17, 0xb5, 0x90, 0xc6, 0x43, 0x18, 0x13, 0x7a, 0x97, 0x7c,
-	0x9c, 0x9d, 0x03, 0x26, 0xc3, 0x85, 0xa9, 0x68, 0x5c, 0x17, 0xf7, 0xbf, 0xc0, 0xcf, 0x12, 0x54,
-	0x9f, 0x75, 0xec, 0x9c, 0xab, 0xf4, 0x14, 0xd7, 0xd8, 0x25, 0xa7, 0xb9, 0xb3, 0xa6, 0xa7, 0x8c,
-	0x5f, 0x34, 0x18, 0x0b, 0xcb, 0xdb, 0x4d, 0xa8, 0x34, 0xe8, 0x26, 0x07, 0x02, 0x09, 0x79, 0xb1,
-	0x8c, 0x93, 0x09, 0x7e, 0x0a, 0x31, 0x4c, 0x3a, 0x1b, 0x29, 0xa2, 0x47, 0x50, 0x0d, 0x87, 0x22,
-	0xf2, 0x32, 0x0b, 0x16, 0xce, 0xe9, 0x63, 0x43, 0x25, 0x9c, 0xb6, 0x40, 0x3b, 0x70, 0x65, 0x97,
-	0x10, 0x7b, 0x2b, 0xf0, 0x7c, 0x3f, 0xd2, 0x90, 0xfc, 0x75, 0x89, 0x9b, 0x7e, 0x3b, 0xf4, 0x09,
-	0x4c, 0xf3, 0xc9, 0x0d, 0xdb, 0x8e, 0x5d, 0x85, 0x45, 0x15, 0xf5, 0xe7, 0x25, 0xce, 0xab, 0xf2,
-	0x8e, 0xe6, 0x73, 0xdf, 0x36, 0x19, 0x91, 0x21, 0xa4, 0xb2, 0xc0, 0xf6, 0x77, 0x34, 0xc9, 0x03,
-	0xe1, 0x9c, 0x49, 0xfe, 0xfb, 0x68, 0xbc, 0xef, 0xfb, 0x08, 0xfd, 0x5f, 0x74, 0x12, 0x2d, 0x52,
-	0x2b, 0x0b, 0x54, 0x5e, 0xcf, 0x12, 0xa3, 0xcc, 0xc5, 0x56, 0xd8, 0x45, 0xb4, 0x88, 0x71, 0x0c,
-	0x57, 0x63, 0x1e, 0x89, 0x56, 0x39, 0x09, 0x0c, 0xc1, 0x5f, 0xcb, 0x51, 0xef, 0xa2, 0x9e, 0x4b,
-	0x02, 0xa1, 0x82, 0xf1, 0x4a, 0x81, 0xe9, 0xdc, 0xa7, 0xf7, 0x30, 0x1b, 0x15, 0x11, 0x9c, 0xfa,
-	0x3e, 0x08, 0xae, 0xa8, 0xbc, 0x7f, 0xa7, 0x00, 0x4a, 0x05, 0x64, 0x18, 0xa2, 0x7a, 0x0c, 0x93,
-	0xcd, 0xc4, 0x32, 0xfe, 0x26, 0xb9, 0x55, 0xcc, 0xde, 0xe9, 0x4d, 0xb2, 0x76, 0x86, 0x0d, 0x13,
-	0xe9, 0xaa, 0x84, 0x10, 0x8c, 0x32, 0xa7, 0x4b, 0xe4, 0xa6, 0x62, 0xcc, 0xe7, 0x5c, 0xcf, 0x8e,
-	0x5a, 0x4a, 0x31, 0xe6, 0x73, 0x16, 0x9f, 0xd3, 0xc2, 0x39, 0x3e, 0xe6, 0x49, 0xd6, 0x0d, 0x3f,
-	0x69, 0x04, 0xfe, 0x2b, 0x38, 0x12, 0x8d, 0x7b, 0x30, 0x91, 0x0e, 0x35, 0xb7, 0x6e, 0x3b, 0xad,
-	0xb6, 0xfc, 0x36, 0x17, 0x63, 0xa4, 0x83, 0xd6, 0xf1, 0x4e, 0x65, 0x7a, 0xf2, 0xe1, 0xca, 0x02,
-	0x94, 0xe4, 0x1f, 0x84, 0x0a, 0x8c, 0x1d, 0x04, 0x0e, 0x23, 0xfa, 0x08, 0x2a, 0xc3, 0xe8, 0x73,
-	0x93, 0x52, 0x5d, 0x59, 0x59, 0x0e, 0xb9, 0x26, 0x69, 0xc6, 0x11, 0x40, 0xa9, 0x1e, 0x10, 0x53,
-	0xe8, 0x01, 0x94, 0xc2, 0xde, 0x49, 0x57, 0x56, 0x3e, 0x06, 0x48, 0x60, 0xc9, 0x3d, 0xec, 0x3e,
-	0xdb, 0xdd, 0xd6, 0x47, 0x50, 0x15, 0xc6, 0x0f, 0x36, 0x1a, 0xfb, 0x8d, 0xdd, 0xc7, 0xba, 0x22,
-	0x04, 0x1c, 0x0a, 0x2a, 0xd7, 0xd9, 0xe2, 0x3a, 0xda, 0xca, 0xff, 0x72, 0x54, 0x8c, 0xc6, 0x41,
-	0xdb, 0xe8, 0x74, 0xf4, 0x11, 0x54, 0x02, 0x75, 0x6b, 0x53, 0x57, 0xf8, 0x4e, 0xbb, 0x5e, 0xd0,
-	0x35, 0x3b, 0xba, 0xba, 0xf2, 0x00, 0xa6, 0xb2, 0xd0, 0x10, 0x6e, 0xbd, 0xe0, 0xd8, 0x71, 0x5b,
-	0xe1, 0x86, 0x7b, 0x4c, 0xe4, 0x7b, 0xb8, 0x61, 0x78, 0x42, 0x5b, 0x57, 0x37, 0xeb, 0x7f, 0xbe,
-	0x5d, 0x54, 0x5e, 0xbf, 0x5d, 0x54, 0xfe, 0x7e, 0xbb, 0xa8, 0xfc, 0xfc, 0x6e, 0x71, 0xe4, 0xf5,
-	0xbb, 0xc5, 0x91, 0x37, 0xef, 0x16, 0x47, 0xbe, 0xba, 0xdd, 0x72, 0x58, 0xbb, 0xd7, 0x5c, 0xb5,
-	0xbc, 0xee, 0xda, 0x51, 0xc7, 0x3b, 0x6d, 0x92, 0xb6, 0xe9, 0xfb, 0x67, 0x6b, 0xcc, 0x69, 0x99,
-	0x8c, 0xac, 0xa5, 0x1e, 0xbc, 0x59, 0x12, 0x7f, 0xdc, 0xee, 0xfe, 0x13, 0x00, 0x00, 0xff, 0xff,
-	0xa6, 0x50, 0x09, 0x02, 0x90, 0x13, 0x00, 0x00,
+	// 1970 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xcd, 0x6f, 0x1c, 0x49,
+	0x15, 0x77, 0x77, 0x8f, 0xe7, 0xe3, 0x8d, 0x3f, 0x3a, 0xe5, 0x7c, 0x4c, 0x9c, 0xd8, 0xeb, 0x6d,
+	0x38, 0x18, 0x2f, 0x9b, 0x68, 0x9d, 0x0d, 0x0b, 0x88, 0x65, 0xb1, 0x67, 0x42, 0x32, 0x32, 0x76,
+	0xa2, 0xb2, 0x21, 0x2c, 0xd2, 0x6a, 0xd4, 0xd3, 0x5d, 0x19, 0xb7, 0x3c, 0xd3, 0xd5, 0x74, 0xd5,
+	0xd8, 0xe4, 0xc0, 0x01, 0x21, 0x71, 0xe2, 0x00, 0x77, 0x2e, 0x1c, 0xb9, 0xc2, 0x1f, 0x80, 0x38,
+	0x81, 0x38, 0xad, 0xc4, 0x65, 0x8f, 0x28, 0x11, 0xff, 0x07, 0xaa, 0x8f, 0xfe, 0x9c, 0xb1, 0x3d,
+	0x03, 0xb9, 0xf5, 0x7b, 0xf5, 0xde, 0xab, 0x7a, 0xaf, 0xde, 0xc7, 0xaf, 0x1a, 0xee, 0x9d, 0x12,
+	0x37, 0xe6, 0x7d, 0xe2, 0xf2, 0xa8, 0xff, 0x30, 0xfd, 0x7e, 0x10, 0xc5, 0x94, 0x53, 0xd4, 0xcc,
+	0x2d, 0x3a, 0x9f, 0x43, 0xe3, 0xc4, 0xed, 0x0f, 0xc9, 0x71, 0xe4, 0x86, 0xa8, 0x05, 0x35, 0x49,
+	0x74, 0x3b, 0x2d, 0x63, 0xcb, 0xd8, 0xb6, 0x70, 0x42, 0xa2, 0x75, 0xa8, 0x1f, 0x73, 0x37, 0xe6,
+	0x07, 0xe4, 0x75, 0xcb, 0xdc, 0x32, 0xb6, 0x97, 0x70, 0x4a, 0xa3, 0xdb, 0x50, 0x7d, 0x12, 0xfa,
+	0x62, 0xc5, 0x92, 0x2b, 0x9a, 0x72, 0xfe, 0x6c, 0x82, 0xfd, 0x4c, 0x6c, 0xb5, 0x4f, 0x5c, 0x8e,
+	0xc9, 0xcf, 0xc7, 0x84, 0x71, 0xe4, 0xc0, 0x92, 0x77, 0xea, 0x86, 0x03, 0xf2, 0x8a, 0x10, 0x5f,
+	0xef, 0xd3, 0xc0, 0x05, 0x1e, 0xfa, 0x18, 0x1a, 0x17, 0x2e, 0x27, 0xf1, 0xc8, 0x8d, 0xcf, 0xe4,
+	0x6e, 0xcd, 0xdd, 0xdb, 0x0f, 0x72, 0x87, 0x7e, 0xf0, 0x32, 0x59, 0xc5, 0x99, 0x20, 0xfa, 0x36,
+	0xd4, 0x19, 0x77, 0xf9, 0x98, 0x11, 0xd6, 0xb2, 0xb6, 0xac, 0xed, 0xe6, 0xee, 0xfd, 0x82, 0x52,
+	0xea, 0xe6, 0xb1, 0x94, 0xc2, 0xa9, 0x34, 0xda, 0x86, 0x55, 0x8f, 0x8e, 0x22, 0x32, 0x24, 0x9c,
+	0xa8, 0xc5, 0x56, 0x65, 0xcb, 0xd8, 0xae, 0xe3, 0x32, 0x1b, 0x3d, 0x82, 0xda, 0x85, 0x1b, 0x87,
+	0x41, 0x38, 0x68, 0x2d, 0xca, 0x73, 0xdd, 0x2d, 0x6c, 0x81, 0xc7, 0xa1, 0x58, 0x7b, 0x12, 0xc7,
+	0x34, 0xc6, 0x89, 0x24, 0xfa, 0x00, 0x2c, 0x12, 0xc7, 0xad, 0xea, 0x75, 0x0a, 0x42, 0xca, 0x79,
+	0x0e, 0x8d, 0xd4, 0x3b, 0x15, 0x2c, 0xe2, 0x9d, 0x45, 0x34, 0x08, 0xf9, 0x09, 0x93, 0xc1, 0xaa,
+	0xe0, 0x02, 0x0f, 0x6d, 0x02, 0xc4, 0x84, 0xd1, 0xe1, 0x39, 0xf1, 0x4f, 0x98, 0x8c, 0x56, 0x05,
+	0xe7, 0x38, 0xce, 0x2f, 0xc1, 0xee, 0x04, 0x2c, 0x72, 0xb9, 0x77, 0x4a, 0xe2, 0x3d, 0x8f, 0x07,
+	0x34, 0x44, 0x1f, 0x40, 0xd5, 0x95, 0x5f, 0xd2, 0xe2, 0xca, 0xee, 0x5a, 0xe1, 0x50, 0x4a, 0x08,
+	0x6b, 0x11, 0x71, 0xf5, 0x6d, 0x3a, 0x1a, 0x05, 0x3c, 0x35, 0x9f, 0xd2, 0x68, 0x0b, 0x9a, 0x5d,
+	0x76, 0xfc, 0x3a, 0xf4, 0x5e, 0x88, 0xd3, 0xc8, 0xfb, 0xaf, 0xe3, 0x3c, 0xcb, 0x69, 0x83, 0xb5,
+	0xd7, 0x3e, 0x28, 0x18, 0x31, 0xae, 0x36, 0x62, 0x4e, 0x1a, 0xf9, 0xb5, 0x09, 0xb7, 0xba, 0xe1,
+	0xab, 0xe1, 0x98, 0x84, 0x1e, 0xf1, 0x33, 0x77, 0x18, 0xfa, 0x01, 0x2c, 0xa7, 0x0b, 0x27, 0xaf,
+	0x23, 0xa2, 0x1d, 0x5a, 0x2f, 0x38, 0x54, 0x90, 0xc0, 0x45, 0x05, 0xf4, 0x19, 0x2c, 0x67, 0x06,
+	0xbb, 0x1d, 0xe1, 0xa3, 0x35, 0x71, 0x4f, 0x79, 0x09, 0x5c, 0x94, 0x97, 0xa5, 0xe1, 0x9d, 0x92,
+	0x91, 0xdb, 0xed, 0xc8, 0x00, 0x58, 0x38, 0xa5, 0xd1, 0x01, 0xac, 0x91, 0x5f, 0x78, 0xc3, 0xb1,
+	0x4f, 0x72, 0x3a, 0xbe, 0xcc, 0xae, 0x2b, 0xb7, 0x98, 0xa6, 0xe5, 0xfc, 0xdd, 0xc8, 0x5f, 0xa5,
+	0xce, 0xc8, 0x9f, 0xc2, 0xad, 0x60, 0x5a, 0x64, 0x64, 0x20, 0x9a, 0xbb, 0xce, 0xf4, 0x40, 0xe4,
+	0x25, 0xf1, 0x74, 0x03, 0xe8, 0x71, 0x9a, 0x24, 0xaa, 0x04, 0x37, 0x2e, 0x39, 0x6e, 0x29, 0x5d,
+	0x1c, 0xb0, 0x5c, 0xef, 0x4c, 0x46, 0xa2, 0xb9, 0x6b, 0x17, 0x13, 0xab, 0x7d, 0x80, 0xc5, 0xa2,
+	0xf3, 0x1b, 0x03, 0x6e, 0xe4, 0x3a, 0x03, 0x8b, 0x68, 0xc8, 0xc8, 0x4c, 0xad, 0xe1, 0x10, 0x90,
+	0x5f, 0x0a, 0x01, 0x49, 0xae, 0xec, 0xb2, 0x03, 0xea, 0x7a, 0x9f, 0xa2, 0xe8, 0x7c, 0x01, 0x6b,
+	0xed, 0x5c, 0x31, 0x1d, 0x12, 0xc6, 0xdc, 0xc1, 0x6c, 0x27, 0x29, 0xd7, 0xa6, 0x39, 0x59, 0x9b,
+	0xce, 0x5f, 0x0a, 0x37, 0xd6, 0xa6, 0xe1, 0xab, 0x60, 0x80, 0x76, 0xa0, 0xc2, 0x22, 0x37, 0x6c,
+	0x19, 0x53, 0x1a, 0x5b, 0xda, 0xa3, 0x70, 0x85, 0xe9, 0x86, 0xcc, 0x44, 0x9b, 0x4d, 0xed, 0x27,
+	0x24, 0xfa, 0x14, 0x96, 0xfc, 0x5c, 0xc6, 0xb4, 0xac, 0xeb, 0x52, 0xaa, 0x20, 0x2e, 0x92, 0x96,
+	0x25, 0x49, 0x5b, 0x51, 0x49, 0x9b, 0xd0, 0xce, 0xbf, 0x0c, 0xb8, 0x2b, 0x32, 0xd8, 0x1f, 0x0f,
+	0x73, 0x09, 0x38, 0x4f, 0x03, 0x7f, 0x0c, 0x55, 0x4f, 0x3a, 0x7b, 0x4d, 0xea, 0xa8, 0x88, 0x60,
+	0x2d, 0x8c, 0xda, 0xb0, 0xc2, 0xf4, 0xbe, 0x2a, 0xa9, 0xa4, 0x57, 0x2b, 0xbb, 0xf7, 0x0a, 0xea,
+	0xc7, 0x05, 0x11, 0x5c, 0x52, 0x11, 0xd3, 0xe8, 0x94, 0x0e, 0x45, 0x2f, 0xac, 0xc8, 0x88, 0x69,
+	0xca, 0x79, 0x01, 0x6b, 0x87, 0x6e, 0x10, 0x72, 0x37, 0x08, 0x49, 0xfc, 0x2c, 0xb1, 0x87, 0xbe,
+	0x93, 0x9b, 0x1a, 0xc6, 0x94, 0x34, 0xca, 0x74, 0xca, 0x63, 0xc3, 0xf9, 0x9b, 0x05, 0x76, 0x79,
+	0x79, 0xa6, 0xf0, 0x6c, 0x00, 0x88, 0xaf, 0x9e, 0xb0, 0x44, 0x64, 0x88, 0x1a, 0xb8, 0x21, 0x38,
+	0xc2, 0x06, 0x41, 0x1f, 0xc1, 0xa2, 0x5a, 0x99, 0xe6, 0x7d, 0x9b, 0x8e, 0x22, 0x1a, 0x92, 0x90,
+	0x4b, 0x59, 0xac, 0x24, 0xd1, 0xd7, 0x60, 0x39, 0x4b, 0xbc, 0x1e, 0x4f, 0x7c, 0x2f, 0x4e, 0x8a,
+	0xc2, 0xf0, 0xb2, 0xe6, 0x1d, 0x5e, 0xd6, 0xf5, 0xc3, 0x0b, 0x75, 0xc0, 0x0e, 0xa9, 0x4f, 0x7a,
+	0x22, 0x77, 0x7b, 0x1e, 0x1d, 0x87, 0x9c, 0xb5, 0x6a, 0x52, 0xb3, 0xd8, 0x90, 0x8f, 0xa8, 0x2f,
+	0xb3, 0xbc, 0x2d, 0x44, 0xf0, 0x4a, 0x98, 0x27, 0x19, 0x7a, 0x0f, 0x9a, 0x5c, 0xd4, 0x81, 0xb2,
+	0xd0, 0xaa, 0x6f, 0x19, 0xdb, 0xcb, 0x18, 0x24, 0x4b, 0x4a, 0x88, 0xf8, 0x65, 0x3b, 0xb4, 0x1a,
+	0x72, 0xbd, 0xc1, 0x12, 0x03, 0x68, 0x07, 0x6e, 0xe4, 0x82, 0x31, 0x74, 0x07, 0xbd, 0x11, 0x6b,
+	0x81, 0x0c, 0xc8, 0x6a, 0xb6, 0xf0, 0x23, 0x77, 0x70, 0xc8, 0x9c, 0x4f, 0xe0, 0x5e, 0x9b, 0xd2,
+	0xd8, 0x0f, 0x42, 0x97, 0xd3, 0x78, 0x9f, 0x52, 0xce, 0x78, 0xec, 0x46, 0x49, 0xb2, 0xb7, 0xa0,
+	0x76, 0x4e, 0x62, 0x96, 0x4c, 0x4a, 0x0b, 0x27, 0xa4, 0xf3, 0x39, 0xdc, 0x9f, 0xae, 0xa8, 0x9b,
+	0xd9, 0xff, 0x91, 0x57, 0x1e, 0xdc, 0xdc, 0xf3, 0xfd, 0x4c, 0x20, 0x39, 0xcc, 0x0a, 0x98, 0x81,
+	0xaf, 0x13, 0xca, 0x0c, 0x7c, 0x91, 0xe9, 0xb9, 0x2a, 0x5b, 0x4a, 0xcb, 0x68, 0x22, 0x19, 0xac,
+	0x29, 0xad, 0xe9, 0x0b, 0xb8, 0x83, 0xc9, 0x88, 0x9e, 0x93, 0xeb, 0xf7, 0x69, 0x41, 0xcd, 0x73,
+	0x99, 0xe7, 0xfa, 0x44, 0xcf, 0xe6, 0x84, 0x14, 0x2b, 0xb1, 0x34, 0xe2, 0xeb, 0xd1, 0x9f, 0x90,
+	0xce, 0x57, 0x06, 0xac, 0x67, 0x96, 0x27, 0xe2, 0x3a, 0x4b, 0x95, 0x5c, 0xe6, 0xde, 0x5d, 0x19,
+	0xd9, 0x38, 0xe7, 0x59, 0xda, 0x14, 0x3d, 0x78, 0x5f, 0x65, 0x0e, 0x8f, 0x83, 0xc1, 0x80, 0xc4,
+	0x3d, 0x72, 0x4e, 0x42, 0xde, 0xcb, 0x3a, 0x5f, 0x2f, 0x98, 0x61, 0xf8, 0x6e, 0x48, 0x1b, 0x27,
+	0xca, 0xc4, 0x13, 0x61, 0xa1, 0x30, 0x86, 0xff, 0x69, 0xc0, 0xbd, 0xa9, 0xae, 0xcd, 0x31, 0xc6,
+	0x1e, 0xc3, 0xa2, 0xc8, 0xd7, 0x64, 0x72, 0xbd, 0x57, 0x38, 0x4c, 0x6a, 0x32, 0x9b, 0x06, 0x4a,
+	0x3a, 0x29, 0x46, 0x6b, 0x16, 0x24, 0x39, 0x53, 0x4f, 0x70, 0xfe, 0x64, 0x02, 0x9a, 0xdc, 0x0f,
+	0x7d, 0x03, 0x4c, 0x7d, 0xf2, 0x2b, 0x23, 0x65, 0xea, 0x97, 0x41, 0x32, 0x49, 0xcc, 0x12, 0xfc,
+	0x49, 0x46, 0x9d, 0x35, 0xc3, 0xa8, 0xfb, 0x21, 0xd8, 0x5e, 0xd2, 0xdb, 0x7a, 0x2c, 0x43, 0xe1,
+	0xd7, 0x34, 0xc0, 0x55, 0x2f, 0x4f, 0x8f, 0xd9, 0xa4, 0xdb, 0x8b, 0x53, 0x5b, 0x61, 0xb3, 0x3f,
+	0xa4, 0xde, 0x99, 0x6e, 0xc1, 0x0a, 0x9a, 0xa3, 0xe2, 0x98, 0x91, 0xe6, 0x41, 0x8a, 0xc9, 0x6f,
+	0xe7, 0x27, 0x70, 0x3b, 0xbb, 0xf7, 0xf6, 0x90, 0x32, 0x32, 0x4f, 0x3a, 0xe7, 0x6a, 0xc5, 0x2c,
+	0xd6, 0xca, 0x4b, 0xb8, 0x33, 0x61, 0x77, 0x8e, 0x5c, 0x12, 0x18, 0x61, 0xec, 0x79, 0x84, 0xb1,
+	0xc4, 0xb0, 0x26, 0x9d, 0xdf, 0x1a, 0x60, 0x67, 0x90, 0x4f, 0x06, 0xfc, 0x5d, 0x20, 0xe6, 0x75,
+	0xa8, 0xeb, 0x67, 0xa1, 0xca, 0x5f, 0x0b, 0xa7, 0xf4, 0x55, 0x60, 0xd8, 0xf9, 0x14, 0x16, 0xa5,
+	0xdc, 0x35, 0xcf, 0xcc, 0x4b, 0x92, 0xc9, 0x09, 0x61, 0x25, 0xf9, 0x6e, 0x4b, 0xff, 0xaf, 0xb0,
+	0xb3, 0x05, 0xcd, 0xe7, 0x43, 0xbf, 0x64, 0x2a, 0xcf, 0x12, 0x12, 0x47, 0xe4, 0xa2, 0x74, 0xd6,
+	0x3c, 0xcb, 0xf9, 0xa3, 0x05, 0x8b, 0x6a, 0x20, 0xdf, 0x87, 0x46, 0x97, 0xed, 0x8b, 0x44, 0x20,
+	0xaa, 0x2f, 0xd6, 0x71, 0xc6, 0x10, 0xa7, 0x90, 0x9f, 0x19, 0x46, 0xd3, 0x24, 0xfa, 0x0c, 0x9a,
+	0xea, 0x53, 0x46, 0x5e, 0x57, 0xc1, 0xc6, 0x25, 0x88, 0x5c, 0x09, 0xe1, 0xbc, 0x06, 0x3a, 0x80,
+	0x1b, 0x47, 0x84, 0xf8, 0x9d, 0x98, 0x46, 0x51, 0x22, 0xd1, 0xaa, 0xcc, 0x62, 0x66, 0x52, 0x0f,
+	0x7d, 0x0f, 0x56, 0x05, 0x73, 0xcf, 0xf7, 0x53, 0x53, 0x0a, 0x06, 0xa0, 0xc9, 0xba, 0xc4, 0x65,
+	0x51, 0x81, 0xcd, 0x7e, 0x1c, 0xf9, 0x2e, 0x27, 0x3a, 0x84, 0x4c, 0x43, 0x82, 0x49, 0x6c, 0x96,
+	0x5d, 0x10, 0x2e, 0xa9, 0x94, 0x5f, 0x7a, 0xb5, 0x89, 0x97, 0x1e, 0xfa, 0x50, 0x62, 0x9f, 0x01,
+	0x91, 0x53, 0x7f, 0x65, 0xf7, 0x4e, 0xb1, 0x31, 0xea, 0x5a, 0x1c, 0x28, 0xdc, 0x33, 0x20, 0xce,
+	0x19, 0xdc, 0x4c, 0xfb, 0x48, 0xb2, 0x2a, 0x9a, 0xc0, 0x1c, 0xfd, 0x6b, 0x3b, 0x41, 0x5b, 0xe6,
+	0xa5, 0x4d, 0x40, 0x09, 0x38, 0xff, 0x31, 0x61, 0xb5, 0xf4, 0x13, 0x61, 0x9e, 0x8d, 0xa6, 0x35,
+	0x38, 0xf3, 0x5d, 0x34, 0xb8, 0x29, 0xe3, 0x1d, 0x7d, 0x04, 0xb7, 0xd4, 0xec, 0x8b, 0xe9, 0x05,
+	0xeb, 0x45, 0x24, 0xee, 0x31, 0xe2, 0xd1, 0xd0, 0xd7, 0x43, 0x00, 0xc9, 0x45, 0x4c, 0x2f, 0xd8,
+	0x0b, 0x12, 0x1f, 0xcb, 0x15, 0xf4, 0x08, 0x6e, 0x2b, 0x95, 0xfe, 0x6b, 0x4e, 0x0a, 0x3a, 0xaa,
+	0x83, 0xae, 0xc9, 0xd5, 0x7d, 0xb1, 0x98, 0x29, 0x6d, 0x42, 0x93, 0x05, 0xe1, 0x59, 0x82, 0xb2,
+	0xaa, 0x52, 0xb2, 0x21, 0x58, 0x12, 0x5f, 0xa1, 0x3b, 0x50, 0x13, 0xf8, 0xbb, 0x27, 0x81, 0x60,
+	0x0e, 0x8e, 0x8b, 0x29, 0xae, 0x3a, 0x30, 0x67, 0xf2, 0xae, 0x2b, 0xb8, 0xd6, 0x57, 0x65, 0xe3,
+	0xfc, 0xca, 0x00, 0x94, 0xbb, 0xcc, 0x79, 0x9a, 0xec, 0x53, 0x58, 0xee, 0x67, 0x9a, 0xe9, 0xcb,
+	0xf0, 0xfd, 0xe9, 0x93, 0x27, 0xbf, 0x49, 0x51, 0xcf, 0xf1, 0x61, 0x29, 0x3f, 0x51, 0x11, 0x82,
+	0x0a, 0x0f, 0x46, 0x44, 0x6f, 0x2a, 0xbf, 0x05, 0x4f, 0x20, 0x57, 0x0d, 0xe0, 0xe5, 0xb7, 0xe0,
+	0x79, 0x82, 0x67, 0x29, 0x9e, 0xf8, 0x16, 0x0d, 0x62, 0xa4, 0x1e, 0x96, 0x32, 0xfa, 0x0d, 0x9c,
+	0x90, 0xce, 0xc7, 0xb0, 0x94, 0x4f, 0x13, 0xa1, 0x7d, 0x1a, 0x0c, 0x4e, 0xf5, 0x1f, 0x12, 0xf9,
+	0x8d, 0x6c, 0xb0, 0x86, 0xf4, 0x42, 0xb7, 0x16, 0xf1, 0xe9, 0xfc, 0xde, 0x00, 0xfb, 0x90, 0x9e,
+	0x13, 0x55, 0xa9, 0x73, 0x44, 0x67, 0x43, 0xfc, 0x2a, 0x92, 0xe2, 0x02, 0x07, 0x29, 0x8b, 0x0d,
+	0xcd, 0xe9, 0xfa, 0xe2, 0x4a, 0x14, 0x7a, 0x0a, 0x7c, 0xdd, 0x0f, 0x6b, 0x92, 0xee, 0xfa, 0x0a,
+	0x92, 0xc7, 0x03, 0xc2, 0x7b, 0xd2, 0x63, 0xe5, 0x06, 0x28, 0x96, 0x00, 0xf3, 0xce, 0x1f, 0x0c,
+	0x58, 0x4b, 0xe3, 0x2a, 0x0e, 0x97, 0xd5, 0x47, 0xe0, 0xcf, 0x50, 0x1f, 0x81, 0x9f, 0x82, 0x05,
+	0x73, 0x06, 0xb0, 0x90, 0x84, 0xde, 0xca, 0x85, 0x7e, 0x1d, 0xea, 0x34, 0x22, 0xb1, 0x80, 0xe3,
+	0xfa, 0x80, 0x29, 0xed, 0xfc, 0xd5, 0x80, 0x1b, 0xb9, 0x90, 0xcd, 0x31, 0x5d, 0xff, 0xf7, 0x98,
+	0x7d, 0x2b, 0xc1, 0x78, 0x15, 0x99, 0x83, 0x5b, 0xd3, 0x1d, 0xca, 0x62, 0x95, 0x80, 0xbc, 0x9b,
+	0xb0, 0x48, 0x44, 0xce, 0xc9, 0xb2, 0x6b, 0x60, 0x45, 0x38, 0x4f, 0x61, 0xb9, 0xf0, 0x6a, 0x12,
+	0x95, 0x25, 0xdf, 0x5a, 0x29, 0x54, 0xaf, 0x0a, 0xb2, 0xeb, 0x97, 0x5e, 0x47, 0x66, 0xe9, 0x75,
+	0xe4, 0x7c, 0x08, 0x76, 0x27, 0x76, 0x83, 0x50, 0x58, 0x4b, 0x92, 0xe7, 0x2e, 0xd4, 0xb5, 0x2d,
+	0xf5, 0x58, 0x69, 0xe0, 0x9a, 0x32, 0xc6, 0x76, 0x36, 0xa0, 0xaa, 0x1f, 0xd6, 0x0d, 0x58, 0x7c,
+	0x19, 0x07, 0x9c, 0xd8, 0x0b, 0xa8, 0x0e, 0x95, 0x17, 0x2e, 0x63, 0xb6, 0xb1, 0xb3, 0xab, 0x86,
+	0x72, 0xee, 0xfd, 0x0d, 0x50, 0x6d, 0xc7, 0xc4, 0x95, 0x72, 0x00, 0x55, 0xf5, 0xc8, 0xb0, 0x0d,
+	0xa1, 0xf3, 0x8c, 0x0e, 0x7d, 0xdb, 0xdc, 0xf9, 0x2e, 0x40, 0xd6, 0xc9, 0x05, 0xff, 0xe8, 0xf9,
+	0xd1, 0x13, 0x7b, 0x01, 0x35, 0xa1, 0xf6, 0x72, 0xaf, 0x7b, 0xd2, 0x3d, 0x7a, 0x6a, 0x1b, 0x92,
+	0xc0, 0x8a, 0x30, 0x85, 0x4c, 0x47, 0xc8, 0x58, 0x3b, 0xdf, 0x2c, 0xa1, 0x17, 0x54, 0x03, 0x6b,
+	0x6f, 0x38, 0xb4, 0x17, 0x50, 0x15, 0xcc, 0xce, 0xbe, 0x6d, 0x88, 0x3d, 0x8f, 0x68, 0x3c, 0x72,
+	0x87, 0xb6, 0xb9, 0xf3, 0x09, 0xac, 0x14, 0xbb, 0xa9, 0x34, 0x4b, 0xe3, 0xb3, 0x20, 0x1c, 0xa8,
+	0x0d, 0x8f, 0xb9, 0x1c, 0x91, 0x6a, 0x43, 0x75, 0x56, 0xdf, 0x36, 0xf7, 0xbf, 0xff, 0x8f, 0x37,
+	0x9b, 0xc6, 0x97, 0x6f, 0x36, 0x8d, 0x7f, 0xbf, 0xd9, 0x34, 0x7e, 0xf7, 0x76, 0x73, 0xe1, 0xcb,
+	0xb7, 0x9b, 0x0b, 0x5f, 0xbd, 0xdd, 0x5c, 0xf8, 0xd9, 0xd7, 0x07, 0x01, 0x3f, 0x1d, 0xf7, 0x1f,
+	0x78, 0x74, 0xf4, 0x30, 0x0a, 0xc2, 0x81, 0xe7, 0x46, 0x0f, 0x79, 0xe0, 0xf9, 0xde, 0xc3, 0xdc,
+	0xf5, 0xf6, 0xab, 0xf2, 0x4f, 0xfb, 0xa3, 0xff, 0x0e, 0x00, 0x8f, 0x14, 0xc7, 0xf3, 0x88, 0x17,
+	0x00, 0x00,
 }
 
 func (m *TableSpan) Marshal() (dAtA []byte, err error) {
@@ -2722,6 +3161,11 @@ func (m *ScheduleDispatcherRequest) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	_ = i
 	var l int
 	_ = l
+	if m.HoldTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.HoldTs))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.ScheduleAction != 0 {
 		i = encodeVarintHeartbeat(dAtA, i, uint64(m.ScheduleAction))
 		i--
@@ -2806,10 +3250,39 @@ func (m *MaintainerStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
-	if len(m.Err) > 0 {
-		for iNdEx := len(m.Err) - 1; iNdEx >= 0; iNdEx-- {
+	if m.CheckpointLagMs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.CheckpointLagMs))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.SpanCount != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.SpanCount))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.TableCount != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.TableCount))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.NodeSpanCounts) > 0 {
+		for iNdEx := len(m.NodeSpanCounts) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Err[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.NodeSpanCounts[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.Err) > 0 {
+		for iNdEx := len(m.Err) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Err[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -3582,6 +4055,31 @@ func (m *TableSpanStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.BlockTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.BlockTs))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.HoldTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.HoldTs))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.SinkLagMs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.SinkLagMs))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.EventBytesPerSecond != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.EventBytesPerSecond))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.EventRowsPerSecond != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.EventRowsPerSecond))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.CheckpointTs != 0 {
 		i = encodeVarintHeartbeat(dAtA, i, uint64(m.CheckpointTs))
 		i--
@@ -3735,6 +4233,242 @@ func (m *DispatcherID) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *MoveTableRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MoveTableRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MoveTableRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.TargetNode) > 0 {
+		i -= len(m.TargetNode)
+		copy(dAtA[i:], m.TargetNode)
+		i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.TargetNode)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.TableId != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.TableId))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.RequestId != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.RequestId))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ChangefeedID) > 0 {
+		i -= len(m.ChangefeedID)
+		copy(dAtA[i:], m.ChangefeedID)
+		i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.ChangefeedID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TableSpanMoveStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TableSpanMoveStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TableSpanMoveStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Operator) > 0 {
+		i -= len(m.Operator)
+		copy(dAtA[i:], m.Operator)
+		i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.Operator)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Node) > 0 {
+		i -= len(m.Node)
+		copy(dAtA[i:], m.Node)
+		i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.Node)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Span != nil {
+		{
+			size, err := m.Span.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Id != nil {
+		{
+			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MoveTableResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MoveTableResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MoveTableResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Spans) > 0 {
+		for iNdEx := len(m.Spans) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Spans[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.TableId != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.TableId))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.RequestId != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.RequestId))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ChangefeedID) > 0 {
+		i -= len(m.ChangefeedID)
+		copy(dAtA[i:], m.ChangefeedID)
+		i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.ChangefeedID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *NodeSpanCount) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NodeSpanCount) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NodeSpanCount) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.SpanCount != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.SpanCount))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.NodeId) > 0 {
+		i -= len(m.NodeId)
+		copy(dAtA[i:], m.NodeId)
+		i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.NodeId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DrainNodeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DrainNodeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DrainNodeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.NodeIds) > 0 {
+		for iNdEx := len(m.NodeIds) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.NodeIds[iNdEx])
+			copy(dAtA[i:], m.NodeIds[iNdEx])
+			i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.NodeIds[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintHeartbeat(dAtA []byte, offset int, v uint64) int {
 	offset -= sovHeartbeat(v)
 	base := offset
@@ -3969,6 +4703,9 @@ func (m *ScheduleDispatcherRequest) Size() (n int) {
 	if m.ScheduleAction != 0 {
 		n += 1 + sovHeartbeat(uint64(m.ScheduleAction))
 	}
+	if m.HoldTs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.HoldTs))
+	}
 	return n
 }
 
@@ -4019,6 +4756,21 @@ func (m *MaintainerStatus) Size() (n int) {
 			n += 1 + l + sovHeartbeat(uint64(l))
 		}
 	}
+	if len(m.NodeSpanCounts) > 0 {
+		for _, e := range m.NodeSpanCounts {
+			l = e.Size()
+			n += 1 + l + sovHeartbeat(uint64(l))
+		}
+	}
+	if m.TableCount != 0 {
+		n += 1 + sovHeartbeat(uint64(m.TableCount))
+	}
+	if m.SpanCount != 0 {
+		n += 1 + sovHeartbeat(uint64(m.SpanCount))
+	}
+	if m.CheckpointLagMs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.CheckpointLagMs))
+	}
 	return n
 }
 
@@ -4329,6 +5081,21 @@ func (m *TableSpanStatus) Size() (n int) {
 	if m.CheckpointTs != 0 {
 		n += 1 + sovHeartbeat(uint64(m.CheckpointTs))
 	}
+	if m.EventRowsPerSecond != 0 {
+		n += 1 + sovHeartbeat(uint64(m.EventRowsPerSecond))
+	}
+	if m.EventBytesPerSecond != 0 {
+		n += 1 + sovHeartbeat(uint64(m.EventBytesPerSecond))
+	}
+	if m.SinkLagMs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.SinkLagMs))
+	}
+	if m.HoldTs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.HoldTs))
+	}
+	if m.BlockTs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.BlockTs))
+	}
 	return n
 }
 
@@ -4391,17 +5158,125 @@ func (m *DispatcherID) Size() (n int) {
 	return n
 }
 
-func sovHeartbeat(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozHeartbeat(x uint64) (n int) {
-	return sovHeartbeat(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+func (m *MoveTableRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChangefeedID)
+	if l > 0 {
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if m.RequestId != 0 {
+		n += 1 + sovHeartbeat(uint64(m.RequestId))
+	}
+	if m.TableId != 0 {
+		n += 1 + sovHeartbeat(uint64(m.TableId))
+	}
+	l = len(m.TargetNode)
+	if l > 0 {
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	return n
 }
-func (m *TableSpan) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
+
+func (m *TableSpanMoveStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if m.Span != nil {
+		l = m.Span.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	l = len(m.Node)
+	if l > 0 {
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	l = len(m.Operator)
+	if l > 0 {
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	return n
+}
+
+func (m *MoveTableResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChangefeedID)
+	if l > 0 {
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if m.RequestId != 0 {
+		n += 1 + sovHeartbeat(uint64(m.RequestId))
+	}
+	if m.TableId != 0 {
+		n += 1 + sovHeartbeat(uint64(m.TableId))
+	}
+	if len(m.Spans) > 0 {
+		for _, e := range m.Spans {
+			l = e.Size()
+			n += 1 + l + sovHeartbeat(uint64(l))
+		}
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	return n
+}
+
+func (m *NodeSpanCount) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.NodeId)
+	if l > 0 {
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if m.SpanCount != 0 {
+		n += 1 + sovHeartbeat(uint64(m.SpanCount))
+	}
+	return n
+}
+
+func (m *DrainNodeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.NodeIds) > 0 {
+		for _, s := range m.NodeIds {
+			l = len(s)
+			n += 1 + l + sovHeartbeat(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovHeartbeat(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozHeartbeat(x uint64) (n int) {
+	return sovHeartbeat(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *TableSpan) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
 			if shift >= 64 {
@@ -5872,6 +6747,25 @@ func (m *ScheduleDispatcherRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HoldTs", wireType)
+			}
+			m.HoldTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HoldTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -6176,6 +7070,97 @@ func (m *MaintainerStatus) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeSpanCounts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeSpanCounts = append(m.NodeSpanCounts, &NodeSpanCount{})
+			if err := m.NodeSpanCounts[len(m.NodeSpanCounts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableCount", wireType)
+			}
+			m.TableCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableCount |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpanCount", wireType)
+			}
+			m.SpanCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SpanCount |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointLagMs", wireType)
+			}
+			m.CheckpointLagMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CheckpointLagMs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -8220,6 +9205,101 @@ func (m *TableSpanStatus) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventRowsPerSecond", wireType)
+			}
+			m.EventRowsPerSecond = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EventRowsPerSecond |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventBytesPerSecond", wireType)
+			}
+			m.EventBytesPerSecond = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EventBytesPerSecond |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SinkLagMs", wireType)
+			}
+			m.SinkLagMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SinkLagMs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HoldTs", wireType)
+			}
+			m.HoldTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HoldTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockTs", wireType)
+			}
+			m.BlockTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -8623,6 +9703,713 @@ func (m *DispatcherID) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *MoveTableRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MoveTableRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MoveTableRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChangefeedID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestId", wireType)
+			}
+			m.RequestId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RequestId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableId", wireType)
+			}
+			m.TableId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableId |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetNode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TargetNode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TableSpanMoveStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TableSpanMoveStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TableSpanMoveStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Id == nil {
+				m.Id = &DispatcherID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Span", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Span == nil {
+				m.Span = &TableSpan{}
+			}
+			if err := m.Span.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Node", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Node = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Operator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Operator = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MoveTableResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MoveTableResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MoveTableResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChangefeedID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestId", wireType)
+			}
+			m.RequestId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RequestId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableId", wireType)
+			}
+			m.TableId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableId |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Spans", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Spans = append(m.Spans, &TableSpanMoveStatus{})
+			if err := m.Spans[len(m.Spans)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NodeSpanCount) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NodeSpanCount: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NodeSpanCount: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpanCount", wireType)
+			}
+			m.SpanCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SpanCount |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DrainNodeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DrainNodeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DrainNodeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeIds", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeIds = append(m.NodeIds, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipHeartbeat(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
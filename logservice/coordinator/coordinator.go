@@ -15,10 +15,14 @@ package logcoordinator
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/logservicepb"
+	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
@@ -26,8 +30,20 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	// eventStoreStateTTL is the time to keep the state of an event store after its last heartbeat.
+	// The state is ignored when answering placement queries after it expires.
+	eventStoreStateTTL = 10 * time.Second
+	// fullStateInterval is the interval to ask the event stores for all their subscriptions,
+	// the event stores only report the changes between two full states.
+	fullStateInterval = time.Minute
+)
+
 type LogCoordinator interface {
 	Run(ctx context.Context) error
+	// GetCandidateNodes returns the nodes whose event store has the data of the span after startTs,
+	// the nodes are sorted by the resolved ts of their subscriptions in descending order.
+	GetCandidateNodes(span *heartbeatpb.TableSpan, startTs uint64) []node.ID
 }
 
 type logCoordinator struct {
@@ -35,6 +51,57 @@ type logCoordinator struct {
 
 	mu    sync.RWMutex
 	nodes map[node.ID]*node.Info
+	// pendingBroadcasts are the nodes whose event collector is not told where the log coordinator is,
+	// the broadcast is sent when the log coordinator starts or the nodes are added.
+	pendingBroadcasts map[node.ID]struct{}
+
+	// eventStoreStates are the subscriptions in the event store of each node,
+	// which are reported by the event stores in response to the heartbeats.
+	eventStoreStates struct {
+		sync.RWMutex
+		m map[node.ID]*eventStoreState
+	}
+}
+
+type eventStoreState struct {
+	// tableID -> subID -> subscription of the table
+	subscriptions map[int64]map[uint64]*logservicepb.SubscriptionState
+	// subID -> tableID
+	tableIDs map[uint64]int64
+	// the time when the state is received
+	updateTime time.Time
+	// the time when the full state is received
+	fullStateTime time.Time
+}
+
+func newEventStoreState() *eventStoreState {
+	return &eventStoreState{
+		subscriptions: make(map[int64]map[uint64]*logservicepb.SubscriptionState),
+		tableIDs:      make(map[uint64]int64),
+	}
+}
+
+func (s *eventStoreState) updateSubscription(sub *logservicepb.SubscriptionState) {
+	tableID := sub.Span.TableID
+	subs, ok := s.subscriptions[tableID]
+	if !ok {
+		subs = make(map[uint64]*logservicepb.SubscriptionState)
+		s.subscriptions[tableID] = subs
+	}
+	subs[sub.SubId] = sub
+	s.tableIDs[sub.SubId] = tableID
+}
+
+func (s *eventStoreState) removeSubscription(subID uint64) {
+	tableID, ok := s.tableIDs[subID]
+	if !ok {
+		return
+	}
+	delete(s.tableIDs, subID)
+	delete(s.subscriptions[tableID], subID)
+	if len(s.subscriptions[tableID]) == 0 {
+		delete(s.subscriptions, tableID)
+	}
 }
 
 func New() LogCoordinator {
@@ -42,8 +109,10 @@ func New() LogCoordinator {
 	c := &logCoordinator{
 		messageCenter: messageCenter,
 
-		nodes: make(map[node.ID]*node.Info),
+		nodes:             make(map[node.ID]*node.Info),
+		pendingBroadcasts: make(map[node.ID]struct{}),
 	}
+	c.eventStoreStates.m = make(map[node.ID]*eventStoreState)
 	// recv and handle messages
 	messageCenter.RegisterHandler(messaging.LogCoordinatorTopic, c.handleMessage)
 	// watch node changes
@@ -51,6 +120,7 @@ func New() LogCoordinator {
 	nodes := nodeManager.GetAliveNodes()
 	for id, n := range nodes {
 		c.nodes[id] = n
+		c.pendingBroadcasts[id] = struct{}{}
 	}
 	nodeManager.RegisterNodeChangeHandler("log-coordinator", c.handleNodeChange)
 	return c
@@ -58,33 +128,160 @@ func New() LogCoordinator {
 
 func (c *logCoordinator) Run(ctx context.Context) error {
 	tick := time.NewTicker(time.Second)
+	defer tick.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-tick.C:
-			// TODO: send heartbeat messages
+			c.sendHeartbeats()
+		}
+	}
+}
+
+// sendHeartbeats asks the event store of all nodes to report their subscriptions,
+// and tells the event collector of the new nodes where the log coordinator is.
+func (c *logCoordinator) sendHeartbeats() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id := range c.nodes {
+		req := &logservicepb.EventStoreHeartbeatRequest{FullState: c.needFullState(id)}
+		msg := messaging.NewSingleTargetMessage(id, messaging.EventStoreTopic, req)
+		if err := c.messageCenter.SendCommand(msg); err != nil {
+			log.Warn("send heartbeat to event store failed",
+				zap.Stringer("nodeID", id), zap.Error(err))
 		}
 	}
+	for id := range c.pendingBroadcasts {
+		msg := messaging.NewSingleTargetMessage(id, messaging.EventCollectorTopic, &logservicepb.LogCoordinatorBroadcastRequest{})
+		if err := c.messageCenter.SendCommand(msg); err != nil {
+			// retry in the next round
+			log.Warn("send broadcast request to event collector failed",
+				zap.Stringer("nodeID", id), zap.Error(err))
+			continue
+		}
+		delete(c.pendingBroadcasts, id)
+	}
+}
+
+// needFullState returns true if the log coordinator doesn't have the full state of the event store,
+// or the full state is received a long time ago, the changes may be lost since then.
+func (c *logCoordinator) needFullState(id node.ID) bool {
+	c.eventStoreStates.RLock()
+	defer c.eventStoreStates.RUnlock()
+	state, ok := c.eventStoreStates.m[id]
+	return !ok || time.Since(state.fullStateTime) >= fullStateInterval
 }
 
 func (c *logCoordinator) handleMessage(_ context.Context, msg *messaging.TargetMessage) error {
-	// TODO: implement this
+	switch msg.Type {
+	case messaging.TypeEventStoreState:
+		state := msg.Message[0].(*logservicepb.EventStoreState)
+		c.updateEventStoreState(msg.From, state)
+	case messaging.TypeReusableEventServiceRequest:
+		req := msg.Message[0].(*logservicepb.ReusableEventServiceRequest)
+		nodes := c.GetCandidateNodes(req.Span, req.StartTs)
+		resp := &logservicepb.ReusableEventServiceResponse{
+			Id:    req.Id,
+			Nodes: make([]string, 0, len(nodes)),
+		}
+		for _, id := range nodes {
+			resp.Nodes = append(resp.Nodes, string(id))
+		}
+		err := c.messageCenter.SendCommand(messaging.NewSingleTargetMessage(msg.From, messaging.EventCollectorTopic, resp))
+		if err != nil {
+			log.Warn("send reusable event service response failed",
+				zap.Stringer("nodeID", msg.From), zap.Error(err))
+		}
+	default:
+		log.Panic("unknown message type", zap.Any("message", msg.Message))
+	}
 	return nil
 }
 
+// updateEventStoreState replaces the state of the event store with the full state,
+// or applies the changes in the state to it.
+func (c *logCoordinator) updateEventStoreState(id node.ID, state *logservicepb.EventStoreState) {
+	c.eventStoreStates.Lock()
+	defer c.eventStoreStates.Unlock()
+	now := time.Now()
+	s, ok := c.eventStoreStates.m[id]
+	if state.FullState {
+		s = newEventStoreState()
+		s.fullStateTime = now
+		c.eventStoreStates.m[id] = s
+	} else if !ok {
+		// the changes can't be applied without the full state, wait for the full state
+		return
+	}
+	for _, sub := range state.Subscriptions {
+		s.updateSubscription(sub)
+	}
+	for _, subID := range state.RemovedSubIds {
+		s.removeSubscription(subID)
+	}
+	s.updateTime = now
+}
+
+func (c *logCoordinator) GetCandidateNodes(span *heartbeatpb.TableSpan, startTs uint64) []node.ID {
+	type candidate struct {
+		id         node.ID
+		resolvedTs uint64
+	}
+	var candidates []candidate
+
+	c.eventStoreStates.RLock()
+	now := time.Now()
+	for id, state := range c.eventStoreStates.m {
+		if now.Sub(state.updateTime) > eventStoreStateTTL {
+			continue
+		}
+		found := false
+		maxResolvedTs := uint64(0)
+		for _, sub := range state.subscriptions[span.TableID] {
+			// the events before the checkpoint ts are deleted from the subscription,
+			// so it can only serve the span which starts after the checkpoint ts.
+			if sub.CheckpointTs > startTs || !common.IsSubSpan(*span, *sub.Span) {
+				continue
+			}
+			found = true
+			if sub.ResolvedTs > maxResolvedTs {
+				maxResolvedTs = sub.ResolvedTs
+			}
+		}
+		if found {
+			candidates = append(candidates, candidate{id: id, resolvedTs: maxResolvedTs})
+		}
+	}
+	c.eventStoreStates.RUnlock()
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].resolvedTs > candidates[j].resolvedTs
+	})
+	nodes := make([]node.ID, 0, len(candidates))
+	for _, candidate := range candidates {
+		nodes = append(nodes, candidate.id)
+	}
+	return nodes
+}
+
 func (c *logCoordinator) handleNodeChange(allNodes map[node.ID]*node.Info) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	for id := range c.nodes {
 		if _, ok := allNodes[id]; !ok {
 			delete(c.nodes, id)
+			delete(c.pendingBroadcasts, id)
+			c.eventStoreStates.Lock()
+			delete(c.eventStoreStates.m, id)
+			c.eventStoreStates.Unlock()
 			log.Info("log coordinaotr detect node removed", zap.String("nodeId", id.String()))
 		}
 	}
 	for id, n := range allNodes {
 		if _, ok := c.nodes[id]; !ok {
 			c.nodes[id] = n
+			c.pendingBroadcasts[id] = struct{}{}
 			log.Info("log coordinaotr detect node added", zap.String("nodeId", id.String()))
 		}
 	}
@@ -0,0 +1,181 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logcoordinator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/logservicepb"
+	"github.com/pingcap/ticdc/pkg/messaging"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLogCoordinator() *logCoordinator {
+	c := &logCoordinator{
+		nodes:             make(map[node.ID]*node.Info),
+		pendingBroadcasts: make(map[node.ID]struct{}),
+	}
+	c.eventStoreStates.m = make(map[node.ID]*eventStoreState)
+	return c
+}
+
+func TestGetCandidateNodes(t *testing.T) {
+	c := newTestLogCoordinator()
+	wide := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("z")}
+	narrow := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("c"), EndKey: []byte("f")}
+	c.nodes["node1"] = &node.Info{ID: "node1"}
+	c.nodes["node2"] = &node.Info{ID: "node2"}
+	c.nodes["node3"] = &node.Info{ID: "node3"}
+	c.updateEventStoreState("node1", &logservicepb.EventStoreState{
+		FullState: true,
+		Subscriptions: []*logservicepb.SubscriptionState{
+			{SubId: 1, Span: wide, CheckpointTs: 100, ResolvedTs: 200},
+		},
+	})
+	c.updateEventStoreState("node2", &logservicepb.EventStoreState{
+		FullState: true,
+		Subscriptions: []*logservicepb.SubscriptionState{
+			{SubId: 1, Span: narrow, CheckpointTs: 100, ResolvedTs: 300},
+		},
+	})
+	c.updateEventStoreState("node3", &logservicepb.EventStoreState{
+		FullState: true,
+		Subscriptions: []*logservicepb.SubscriptionState{
+			{SubId: 1, Span: wide, CheckpointTs: 150, ResolvedTs: 400},
+		},
+	})
+
+	// the node with larger resolved ts is preferred.
+	span := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("d"), EndKey: []byte("e")}
+	require.Equal(t, []node.ID{"node2", "node1"}, c.GetCandidateNodes(span, 120))
+	require.Equal(t, []node.ID{"node3", "node2", "node1"}, c.GetCandidateNodes(span, 160))
+	// the span must be contained in the subscription.
+	span = &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("b"), EndKey: []byte("e")}
+	require.Equal(t, []node.ID{"node1"}, c.GetCandidateNodes(span, 120))
+	// the events before checkpoint ts are deleted.
+	require.Empty(t, c.GetCandidateNodes(span, 90))
+	require.Empty(t, c.GetCandidateNodes(&heartbeatpb.TableSpan{TableID: 2}, 120))
+
+	// the state of a removed node is dropped.
+	c.handleNodeChange(map[node.ID]*node.Info{"node2": c.nodes["node2"], "node3": c.nodes["node3"]})
+	require.Empty(t, c.GetCandidateNodes(span, 120))
+	require.NotContains(t, c.eventStoreStates.m, node.ID("node1"))
+
+	// the expired state is ignored.
+	c.eventStoreStates.m["node3"].updateTime = time.Now().Add(-2 * eventStoreStateTTL)
+	span = &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("d"), EndKey: []byte("e")}
+	require.Equal(t, []node.ID{"node2"}, c.GetCandidateNodes(span, 160))
+}
+
+func TestUpdateEventStoreStateWithChanges(t *testing.T) {
+	c := newTestLogCoordinator()
+	span1 := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("z")}
+	span2 := &heartbeatpb.TableSpan{TableID: 2, StartKey: []byte("a"), EndKey: []byte("z")}
+	c.nodes["node1"] = &node.Info{ID: "node1"}
+
+	// the changes are ignored before the full state is received
+	c.updateEventStoreState("node1", &logservicepb.EventStoreState{
+		Subscriptions: []*logservicepb.SubscriptionState{
+			{SubId: 1, Span: span1, CheckpointTs: 100, ResolvedTs: 200},
+		},
+	})
+	require.Empty(t, c.GetCandidateNodes(span1, 120))
+	require.True(t, c.needFullState("node1"))
+
+	c.updateEventStoreState("node1", &logservicepb.EventStoreState{
+		FullState: true,
+		Subscriptions: []*logservicepb.SubscriptionState{
+			{SubId: 1, Span: span1, CheckpointTs: 100, ResolvedTs: 200},
+		},
+	})
+	require.False(t, c.needFullState("node1"))
+	require.Equal(t, []node.ID{"node1"}, c.GetCandidateNodes(span1, 120))
+
+	// the changes are applied to the full state
+	c.updateEventStoreState("node1", &logservicepb.EventStoreState{
+		Subscriptions: []*logservicepb.SubscriptionState{
+			{SubId: 1, Span: span1, CheckpointTs: 150, ResolvedTs: 300},
+			{SubId: 2, Span: span2, CheckpointTs: 100, ResolvedTs: 200},
+		},
+	})
+	require.Empty(t, c.GetCandidateNodes(span1, 120))
+	require.Equal(t, []node.ID{"node1"}, c.GetCandidateNodes(span2, 120))
+	c.updateEventStoreState("node1", &logservicepb.EventStoreState{RemovedSubIds: []uint64{2, 3}})
+	require.Empty(t, c.GetCandidateNodes(span2, 120))
+	require.Equal(t, []node.ID{"node1"}, c.GetCandidateNodes(span1, 160))
+
+	// the full state replaces all subscriptions
+	c.updateEventStoreState("node1", &logservicepb.EventStoreState{
+		FullState: true,
+		Subscriptions: []*logservicepb.SubscriptionState{
+			{SubId: 2, Span: span2, CheckpointTs: 100, ResolvedTs: 200},
+		},
+	})
+	require.Empty(t, c.GetCandidateNodes(span1, 160))
+	require.Equal(t, []node.ID{"node1"}, c.GetCandidateNodes(span2, 120))
+
+	// the full state is required again after a long time
+	c.eventStoreStates.m["node1"].fullStateTime = time.Now().Add(-fullStateInterval)
+	require.True(t, c.needFullState("node1"))
+}
+
+type recordMessageCenter struct {
+	messaging.MessageCenter
+	msgs []*messaging.TargetMessage
+}
+
+func (m *recordMessageCenter) SendCommand(msg *messaging.TargetMessage) error {
+	m.msgs = append(m.msgs, msg)
+	return nil
+}
+
+func TestSendHeartbeats(t *testing.T) {
+	c := newTestLogCoordinator()
+	mc := &recordMessageCenter{}
+	c.messageCenter = mc
+	c.handleNodeChange(map[node.ID]*node.Info{"node1": {ID: "node1"}, "node2": {ID: "node2"}})
+	c.updateEventStoreState("node1", &logservicepb.EventStoreState{FullState: true})
+
+	collect := func() (heartbeats map[node.ID]bool, broadcasts []node.ID) {
+		heartbeats = make(map[node.ID]bool)
+		c.sendHeartbeats()
+		for _, msg := range mc.msgs {
+			switch msg.Type {
+			case messaging.TypeEventStoreHeartbeatRequest:
+				heartbeats[msg.To] = msg.Message[0].(*logservicepb.EventStoreHeartbeatRequest).FullState
+			case messaging.TypeLogCoordinatorBroadcastRequest:
+				broadcasts = append(broadcasts, msg.To)
+			}
+		}
+		mc.msgs = nil
+		return
+	}
+
+	// the full state is only required from the node without the state,
+	// and the broadcast is sent to the new nodes once
+	heartbeats, broadcasts := collect()
+	require.Equal(t, map[node.ID]bool{"node1": false, "node2": true}, heartbeats)
+	require.ElementsMatch(t, []node.ID{"node1", "node2"}, broadcasts)
+	heartbeats, broadcasts = collect()
+	require.Len(t, heartbeats, 2)
+	require.Empty(t, broadcasts)
+
+	c.handleNodeChange(map[node.ID]*node.Info{"node1": {ID: "node1"}, "node3": {ID: "node3"}})
+	heartbeats, broadcasts = collect()
+	require.Equal(t, map[node.ID]bool{"node1": false, "node3": true}, heartbeats)
+	require.Equal(t, []node.ID{"node3"}, broadcasts)
+}
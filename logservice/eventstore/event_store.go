@@ -3,7 +3,7 @@ package eventstore
 import (
 	"context"
 	"fmt"
-	"os"
+	"math"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -13,10 +13,12 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/logservice/logpuller"
+	"github.com/pingcap/ticdc/logservice/logservicepb"
 	"github.com/pingcap/ticdc/logservice/txnutil"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
-	"github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tiflow/pkg/pdutil"
@@ -88,30 +90,47 @@ func genUniqueID() uint64 {
 	return atomic.AddUint64(&uniqueIDGen, 1)
 }
 
-type dispatcherStat struct {
-	dispatcherID common.DispatcherID
-	// called when new resolved ts event come
-	notifier ResolvedTsNotifier
-
+type subscriptionStat struct {
 	subID logpuller.SubscriptionID
+	// the span subscribed from upstream
+	span *heartbeatpb.TableSpan
 
 	tableID int64
-	// an id encode in the event key of this dispatcher
-	// used to seperate data between dispatchers with overlapping spans
+	// an id encode in the event key of this subscription
+	// used to seperate data between subscriptions with overlapping spans
 	uniqueKeyID uint64
-	// the max ts of events which is not needed by this dispatcher
+	chIndex     int
+	// the dispatchers which read events of this subscription.
+	// It is protected by the lock of eventStore.dispatcherStates.
+	dispatchers map[common.DispatcherID]*dispatcherStat
+	// mu serializes the advances of checkpointTs by the dispatchers of this subscription,
+	// which only hold the read lock of eventStore.dispatcherStates.
+	mu sync.Mutex
+	// the max ts of events which is not needed by all dispatchers of this subscription
 	checkpointTs atomic.Uint64
 	// the max commit ts of dml event in the store
 	maxEventCommitTs atomic.Uint64
 	// the resolveTs persisted in the store
-	// just used in metrics now. maybe can be removed in the future.
 	resolvedTs atomic.Uint64
-	chIndex    int
+}
+
+type dispatcherStat struct {
+	dispatcherID common.DispatcherID
+	// called when new resolved ts event come
+	notifier ResolvedTsNotifier
+	// the span of the dispatcher, it is equal to or contained in the span of the subscription.
+	span *heartbeatpb.TableSpan
+	// the subscription which the dispatcher reads events from
+	subStat *subscriptionStat
+	// the max ts of events which is not needed by this dispatcher
+	checkpointTs atomic.Uint64
 }
 
 type eventStore struct {
 	pdClock pdutil.Clock
 
+	messageCenter messaging.MessageCenter
+
 	dbs      []*pebble.DB
 	eventChs []chan eventWithState
 
@@ -126,18 +145,59 @@ type eventStore struct {
 	// To manage background goroutines.
 	wg sync.WaitGroup
 
+	// gcCtx is used to stop the gc manager and the orphan data sweeper,
+	// they also write to the dbs, so they must exit before the dbs are closed.
+	gcCtx    context.Context
+	gcCancel context.CancelFunc
+	wgGC     sync.WaitGroup
+
 	dispatcherStates struct {
 		sync.RWMutex
 		m map[common.DispatcherID]*dispatcherStat
-		n map[logpuller.SubscriptionID]common.DispatcherID
+		n map[logpuller.SubscriptionID]*subscriptionStat
+		// tableID -> subscriptions of the table, used to find a subscription to share
+		t map[int64]map[logpuller.SubscriptionID]*subscriptionStat
+	}
+
+	// persistedSubscriptions are the subscriptions restored from the disk,
+	// which are not reused by any dispatcher yet.
+	persistedSubscriptions struct {
+		sync.Mutex
+		m map[uint64]*persistedSubscription
+		// the time when the subscriptions are loaded
+		loadTime time.Time
 	}
 
+	// reportedStates are the subscriptions in the last state reported to the log coordinator,
+	// only the changes since the last report are sent if the full state is not required.
+	reportedStates struct {
+		sync.Mutex
+		m map[uint64]*logservicepb.SubscriptionState
+	}
+
+	// encoder is nil if the value compression is disabled.
 	encoder *zstd.Encoder
 	decoder *zstd.Decoder
+
+	// diskQuota is the max bytes of disk space used by the dbs, 0 means no limit.
+	diskQuota uint64
 }
 
 const dataDir = "event_store"
-const dbCount = 32
+
+// diskQuotaCheckInterval is the interval to check whether the disk usage exceeds the quota.
+const diskQuotaCheckInterval = time.Second
+
+// stateReportTsThreshold is the min advance of the checkpoint ts or the resolved ts of a subscription
+// to report it to the log coordinator again, the smaller advances are reported in the next full state.
+const stateReportTsThreshold = 5 * time.Second
+
+const (
+	// orphanDataSweepInterval is the interval to check the data which doesn't belong to any subscription.
+	orphanDataSweepInterval = 10 * time.Minute
+	// persistedSubscriptionTTL is the time to keep the persisted subscriptions which are not reused.
+	persistedSubscriptionTTL = 30 * time.Minute
+)
 
 func New(
 	ctx context.Context,
@@ -146,6 +206,7 @@ func New(
 	regionCache *tikv.RegionCache,
 	pdClock pdutil.Clock,
 	kvStorage kv.Storage,
+	cfg *config.EventStoreConfig,
 ) EventStore {
 	clientConfig := &logpuller.SubscriptionClientConfig{
 		RegionRequestWorkerPerStore:   16,
@@ -164,15 +225,14 @@ func New(
 
 	dbPath := fmt.Sprintf("%s/%s", root, dataDir)
 
-	// FIXME: avoid remove
-	err := os.RemoveAll(dbPath)
-	if err != nil {
-		log.Panic("fail to remove path")
-	}
 	// Create the zstd encoder
-	encoder, err := zstd.NewWriter(nil)
-	if err != nil {
-		log.Panic("Failed to create zstd encoder", zap.Error(err))
+	var encoder *zstd.Encoder
+	if cfg.EnableValueCompression {
+		var err error
+		encoder, err = zstd.NewWriter(nil)
+		if err != nil {
+			log.Panic("Failed to create zstd encoder", zap.Error(err))
+		}
 	}
 
 	decoder, err := zstd.NewReader(nil)
@@ -181,32 +241,24 @@ func New(
 	}
 
 	store := &eventStore{
-		pdClock:  pdClock,
-		dbs:      make([]*pebble.DB, 0, dbCount),
-		eventChs: make([]chan eventWithState, 0, dbCount),
+		pdClock:       pdClock,
+		messageCenter: appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter),
+
+		dbs:      make([]*pebble.DB, 0, cfg.DBCount),
+		eventChs: make([]chan eventWithState, 0, cfg.DBCount),
 
 		gcManager: newGCManager(),
 		encoder:   encoder,
 		decoder:   decoder,
+		diskQuota: cfg.DiskQuota,
+	}
+	store.gcCtx, store.gcCancel = context.WithCancel(ctx)
+	log.Info("event store config", zap.Any("config", cfg))
+	if err := removeExtraDBs(dbPath, cfg.DBCount); err != nil {
+		log.Fatal("remove extra db failed", zap.Error(err))
 	}
-	// TODO: update pebble options
-	for i := 0; i < dbCount; i++ {
-		opts := &pebble.Options{
-			DisableWAL:   true,
-			MemTableSize: 8 << 20,
-		}
-		// opts.Levels = make([]pebble.LevelOptions, 7)
-		// for i := 0; i < len(opts.Levels); i++ {
-		// 	l := &opts.Levels[i]
-		// 	l.BlockSize = 64 << 10       // 64 KB
-		// 	l.IndexBlockSize = 256 << 10 // 256 KB
-		// 	l.FilterPolicy = bloom.FilterPolicy(10)
-		// 	l.FilterType = pebble.TableFilter
-		// 	l.TargetFileSize = 8 << 20 // 8 MB
-		// 	// 	l.Compression = pebble.ZstdCompression // TODO: choose the right compression
-		// 	l.EnsureDefaults()
-		// }
-		db, err := pebble.Open(fmt.Sprintf("%s/%d", dbPath, i), opts)
+	for i := 0; i < cfg.DBCount; i++ {
+		db, err := pebble.Open(fmt.Sprintf("%s/%d", dbPath, i), newPebbleOptions(cfg))
 		if err != nil {
 			log.Fatal("open db failed", zap.Error(err))
 		}
@@ -214,7 +266,9 @@ func New(
 		store.eventChs = append(store.eventChs, make(chan eventWithState, 8192))
 	}
 	store.dispatcherStates.m = make(map[common.DispatcherID]*dispatcherStat)
-	store.dispatcherStates.n = make(map[logpuller.SubscriptionID]common.DispatcherID)
+	store.dispatcherStates.n = make(map[logpuller.SubscriptionID]*subscriptionStat)
+	store.dispatcherStates.t = make(map[int64]map[logpuller.SubscriptionID]*subscriptionStat)
+	store.loadPersistedSubscriptions()
 
 	// start background goroutines to handle events from puller
 	for i := range store.dbs {
@@ -241,9 +295,81 @@ func New(
 	puller := logpuller.NewLogPuller(client, pdClock, consume)
 	store.puller = puller
 
+	// report the subscriptions to the log coordinator
+	store.messageCenter.RegisterHandler(messaging.EventStoreTopic, store.handleMessage)
 	return store
 }
 
+func newPebbleOptions(cfg *config.EventStoreConfig) *pebble.Options {
+	// The WAL is required to keep the events in memtables across restarts.
+	opts := &pebble.Options{
+		MemTableSize: uint64(cfg.MemTableSize),
+	}
+	compression := pebble.SnappyCompression
+	switch cfg.Compression {
+	case "none":
+		compression = pebble.NoCompression
+	case "zstd":
+		compression = pebble.ZstdCompression
+	}
+	opts.Levels = make([]pebble.LevelOptions, 7)
+	for i := range opts.Levels {
+		l := &opts.Levels[i]
+		l.BlockSize = cfg.BlockSize
+		l.Compression = compression
+		l.EnsureDefaults()
+	}
+	return opts
+}
+
+// loadPersistedSubscriptions restores the subscriptions persisted by the previous process,
+// so their data can be reused by the dispatchers registered later.
+func (e *eventStore) loadPersistedSubscriptions() {
+	e.persistedSubscriptions.m = make(map[uint64]*persistedSubscription)
+	e.persistedSubscriptions.loadTime = time.Now()
+	maxUniqueKeyID := uint64(0)
+	for i, db := range e.dbs {
+		subs, maxID, err := loadPersistedSubscriptions(db, i)
+		if err != nil {
+			log.Panic("load persisted subscriptions failed", zap.Int("dbIndex", i), zap.Error(err))
+		}
+		for _, sub := range subs {
+			e.persistedSubscriptions.m[sub.uniqueKeyID] = sub
+		}
+		if maxID > maxUniqueKeyID {
+			maxUniqueKeyID = maxID
+		}
+	}
+	// uniqueKeyID of new subscriptions must not overlap with the keys on disk.
+	if maxUniqueKeyID > atomic.LoadUint64(&uniqueIDGen) {
+		atomic.StoreUint64(&uniqueIDGen, maxUniqueKeyID)
+	}
+	log.Info("event store load persisted subscriptions",
+		zap.Int("count", len(e.persistedSubscriptions.m)),
+		zap.Uint64("maxUniqueKeyID", maxUniqueKeyID))
+}
+
+// takePersistedSubscription finds a persisted subscription whose span contains the span
+// and which contains all data after startTs, and removes it from the persisted subscriptions.
+func (e *eventStore) takePersistedSubscription(span *heartbeatpb.TableSpan, startTs uint64) *persistedSubscription {
+	e.persistedSubscriptions.Lock()
+	defer e.persistedSubscriptions.Unlock()
+	var candidate *persistedSubscription
+	for _, sub := range e.persistedSubscriptions.m {
+		if !common.IsSubSpan(*span, *sub.span) || sub.checkpointTs > startTs {
+			continue
+		}
+		// prefer the subscription with more data on disk.
+		if candidate == nil || sub.resolvedTs > candidate.resolvedTs {
+			candidate = sub
+		}
+	}
+	if candidate != nil {
+		delete(e.persistedSubscriptions.m, candidate.uniqueKeyID)
+	}
+	return candidate
+}
+
 func (e *eventStore) Name() string {
 	return appcontext.EventStore
 }
@@ -255,9 +381,21 @@ func (e *eventStore) Run(ctx context.Context) error {
 		return e.puller.Run(ctx)
 	})
 
-	// TODO: manage gcManager exit
+	// the gc routines are stopped by Close or the exit of Run
+	context.AfterFunc(ctx, e.gcCancel)
+	e.wgGC.Add(2)
 	eg.Go(func() error {
-		return e.gcManager.run(ctx, e.deleteEvents)
+		defer e.wgGC.Done()
+		return e.gcManager.run(e.gcCtx, e.deleteEvents, e.deleteSubscription, e.persistCheckpointTs)
+	})
+
+	eg.Go(func() error {
+		defer e.wgGC.Done()
+		return e.sweepOrphanData(e.gcCtx)
+	})
+
+	eg.Go(func() error {
+		return e.checkDiskQuota(ctx)
 	})
 
 	eg.Go(func() error {
@@ -268,6 +406,7 @@ func (e *eventStore) Run(ctx context.Context) error {
 }
 
 func (e *eventStore) Close(ctx context.Context) error {
+	e.messageCenter.DeRegisterHandler(messaging.EventStoreTopic)
 	// notify and wait the background routine for sending batch signal to exit
 	e.closed.Store(true)
 	e.wgBatchSignal.Wait()
@@ -282,8 +421,10 @@ func (e *eventStore) Close(ctx context.Context) error {
 	for i := range e.eventChs {
 		close(e.eventChs[i])
 	}
-	// TODO: wait gc manager, because it may also write data to pebble db
 	e.wg.Wait()
+	// stop and wait the gc routines, because they may also write data to pebble db
+	e.gcCancel()
+	e.wgGC.Wait()
 
 	for _, db := range e.dbs {
 		if err := db.Close(); err != nil {
@@ -310,37 +451,117 @@ func (e *eventStore) RegisterDispatcher(
 		zap.String("span", tableSpan.String()),
 		zap.Uint64("startTs", startTs))
 
+	stat := &dispatcherStat{
+		dispatcherID: dispatcherID,
+		notifier:     notifier,
+		span:         tableSpan,
+	}
+	stat.checkpointTs.Store(startTs)
+	if e.tryShareSubscription(stat, startTs) {
+		return nil
+	}
+
+	span := tableSpan
 	chIndex := common.HashTableSpan(tableSpan, len(e.eventChs))
-	uniqueKeyID := genUniqueID()
+	uniqueKeyID := uint64(0)
+	// the ts to start pulling events from upstream
+	subscribeTs := startTs
+	if sub := e.takePersistedSubscription(tableSpan, startTs); sub != nil {
+		span = sub.span
+		chIndex = sub.dbIndex
+		uniqueKeyID = sub.uniqueKeyID
+		if sub.resolvedTs > subscribeTs {
+			subscribeTs = sub.resolvedTs
+		}
+		// the data before startTs is not needed anymore.
+		if sub.checkpointTs < startTs {
+			e.gcManager.addGCItem(chIndex, uniqueKeyID, span.TableID, sub.checkpointTs, startTs)
+		}
+		log.Info("reuse persisted subscription",
+			zap.Any("dispatcherID", dispatcherID),
+			zap.Uint64("uniqueKeyID", uniqueKeyID),
+			zap.String("subscriptionSpan", span.String()),
+			zap.Uint64("checkpointTs", sub.checkpointTs),
+			zap.Uint64("resolvedTs", sub.resolvedTs))
+	} else {
+		uniqueKeyID = genUniqueID()
+	}
+	// persist the metadata before pulling events, so the resolved ts is
+	// always written after the span of the subscription.
+	err := writeSubscriptionMeta(e.dbs[chIndex], uniqueKeyID, span, startTs, subscribeTs)
+	if err != nil {
+		return err
+	}
 	// Note: don'w hold any lock when call Subscribe
-	subID := e.puller.Subscribe(*tableSpan, startTs, subscriptionTag{
+	subID := e.puller.Subscribe(*span, subscribeTs, subscriptionTag{
 		chIndex:     chIndex,
-		tableID:     tableSpan.TableID,
+		tableID:     span.TableID,
 		uniqueKeyID: uniqueKeyID,
 	})
 
-	// initialize dispatcherStat
-	// TODO: if puller event come before we initialize dispatcherStat,
+	// initialize subscriptionStat
+	// TODO: if puller event come before we initialize subscriptionStat,
 	// maxEventCommitTs may not be updated correctly and cause data loss.(lost resolved ts is harmless)
-	// To fix it, we need to alloc subID and initialize dispatcherStat before puller may send events.
+	// To fix it, we need to alloc subID and initialize subscriptionStat before puller may send events.
 	// That is allocate subID in a separate method.
+	subStat := &subscriptionStat{
+		subID:       subID,
+		span:        span,
+		tableID:     span.TableID,
+		uniqueKeyID: uniqueKeyID,
+		chIndex:     chIndex,
+		dispatchers: map[common.DispatcherID]*dispatcherStat{dispatcherID: stat},
+	}
+	subStat.checkpointTs.Store(startTs)
+	// The max commit ts of the reused events is unknown,
+	// use the resolved ts which is not smaller than it.
+	subStat.maxEventCommitTs.Store(subscribeTs)
+	subStat.resolvedTs.Store(subscribeTs)
+	stat.subStat = subStat
+
 	e.dispatcherStates.Lock()
 	defer e.dispatcherStates.Unlock()
-	stat := &dispatcherStat{
-		dispatcherID: dispatcherID,
-		notifier:     notifier,
-		tableID:      tableSpan.TableID,
-		uniqueKeyID:  uniqueKeyID,
-		chIndex:      chIndex,
-	}
-	stat.checkpointTs.Store(startTs)
-	stat.maxEventCommitTs.Store(startTs)
-	stat.resolvedTs.Store(startTs)
 	e.dispatcherStates.m[dispatcherID] = stat
-	e.dispatcherStates.n[subID] = dispatcherID
+	e.dispatcherStates.n[subID] = subStat
+	subStats, ok := e.dispatcherStates.t[span.TableID]
+	if !ok {
+		subStats = make(map[logpuller.SubscriptionID]*subscriptionStat)
+		e.dispatcherStates.t[span.TableID] = subStats
+	}
+	subStats[subID] = subStat
 	return nil
 }
 
+// tryShareSubscription attaches the dispatcher to an existing subscription,
+// if the span of the subscription contains the span of the dispatcher,
+// and the events after startTs are not deleted from the subscription.
+func (e *eventStore) tryShareSubscription(stat *dispatcherStat, startTs uint64) bool {
+	e.dispatcherStates.Lock()
+	defer e.dispatcherStates.Unlock()
+	var candidate *subscriptionStat
+	for _, subStat := range e.dispatcherStates.t[stat.span.TableID] {
+		if !common.IsSubSpan(*stat.span, *subStat.span) || subStat.checkpointTs.Load() > startTs {
+			continue
+		}
+		// prefer the subscription with a smaller span, so there are less events to skip when reading.
+		if candidate == nil || common.IsSubSpan(*subStat.span, *candidate.span) {
+			candidate = subStat
+		}
+	}
+	if candidate == nil {
+		return false
+	}
+	stat.subStat = candidate
+	candidate.dispatchers[stat.dispatcherID] = stat
+	e.dispatcherStates.m[stat.dispatcherID] = stat
+	log.Info("dispatcher shares subscription",
+		zap.Any("dispatcherID", stat.dispatcherID),
+		zap.Uint64("subID", uint64(candidate.subID)),
+		zap.String("subscriptionSpan", candidate.span.String()),
+		zap.Int("dispatcherCount", len(candidate.dispatchers)))
+	return true
+}
+
 func (e *eventStore) UnregisterDispatcher(dispatcherID common.DispatcherID) error {
 	log.Info("unregister dispatcher", zap.Stringer("dispatcherID", dispatcherID))
 	e.dispatcherStates.Lock()
@@ -349,9 +570,25 @@ func (e *eventStore) UnregisterDispatcher(dispatcherID common.DispatcherID) erro
 	if !ok {
 		return nil
 	}
-	subID := stat.subID
 	delete(e.dispatcherStates.m, dispatcherID)
+	subStat := stat.subStat
+	delete(subStat.dispatchers, dispatcherID)
+	if len(subStat.dispatchers) > 0 {
+		// the removed dispatcher may hold the min checkpoint ts of the subscription.
+		e.advanceCheckpointTs(subStat)
+		return nil
+	}
+
+	// the subscription is not used by any dispatcher.
+	subID := subStat.subID
 	delete(e.dispatcherStates.n, subID)
+	subStats := e.dispatcherStates.t[subStat.tableID]
+	delete(subStats, subID)
+	if len(subStats) == 0 {
+		delete(e.dispatcherStates.t, subStat.tableID)
+	}
+	// the data of the subscription is not reusable after it is removed.
+	e.gcManager.addSubscriptionGCItem(subStat.chIndex, subStat.uniqueKeyID, gcReasonUnregister)
 
 	// TODO: do we need unlock before puller.Unsubscribe?
 	e.puller.Unsubscribe(subID)
@@ -362,18 +599,50 @@ func (e *eventStore) UpdateDispatcherSendTs(
 	dispatcherID common.DispatcherID,
 	sendTs uint64,
 ) error {
+	// the read lock keeps the dispatchers of the subscription unchanged,
+	// and the lock of the subscription serializes the updates of its checkpoint ts.
 	e.dispatcherStates.RLock()
 	defer e.dispatcherStates.RUnlock()
-	if stat, ok := e.dispatcherStates.m[dispatcherID]; ok {
-		oldSendTs := stat.checkpointTs.Load()
-		if sendTs > oldSendTs {
-			stat.checkpointTs.Store(sendTs)
-			e.gcManager.addGCItem(stat.chIndex, stat.uniqueKeyID, stat.tableID, oldSendTs, sendTs)
-		}
+	stat, ok := e.dispatcherStates.m[dispatcherID]
+	if !ok {
+		return nil
+	}
+	subStat := stat.subStat
+	subStat.mu.Lock()
+	defer subStat.mu.Unlock()
+	if sendTs > stat.checkpointTs.Load() {
+		stat.checkpointTs.Store(sendTs)
+		e.advanceCheckpointTs(subStat)
 	}
 	return nil
 }
 
+// advanceCheckpointTs advances the checkpoint ts of the subscription to the min
+// checkpoint ts of its dispatchers, and deletes the events which are not needed anymore.
+// The new checkpoint ts is persisted by the gc manager before deleting the events.
+// The caller must hold the write lock of e.dispatcherStates,
+// or the read lock of e.dispatcherStates and the lock of the subscription.
+func (e *eventStore) advanceCheckpointTs(subStat *subscriptionStat) {
+	newCheckpointTs := uint64(math.MaxUint64)
+	for _, stat := range subStat.dispatchers {
+		if checkpointTs := stat.checkpointTs.Load(); checkpointTs < newCheckpointTs {
+			newCheckpointTs = checkpointTs
+		}
+	}
+	oldCheckpointTs := subStat.checkpointTs.Load()
+	if newCheckpointTs <= oldCheckpointTs {
+		return
+	}
+	subStat.checkpointTs.Store(newCheckpointTs)
+	e.gcManager.addGCItem(subStat.chIndex, subStat.uniqueKeyID, subStat.tableID, oldCheckpointTs, newCheckpointTs)
+}
+
+// persistCheckpointTs writes the checkpoint ts of the subscriptions in the db,
+// it's called by the gc manager before deleting the events.
+func (e *eventStore) persistCheckpointTs(dbIndex int, checkpointTs map[uint64]uint64) error {
+	return writeCheckpointTs(e.dbs[dbIndex], checkpointTs)
+}
+
 func (e *eventStore) GetDispatcherDMLEventState(dispatcherID common.DispatcherID) DMLEventState {
 	e.dispatcherStates.RLock()
 	defer e.dispatcherStates.RUnlock()
@@ -384,10 +653,75 @@ func (e *eventStore) GetDispatcherDMLEventState(dispatcherID common.DispatcherID
 
 	return DMLEventState{
 		// ResolvedTs:       stat.resolvedTs.Load(),
-		MaxEventCommitTs: stat.maxEventCommitTs.Load(),
+		MaxEventCommitTs: stat.subStat.maxEventCommitTs.Load(),
 	}
 }
 
+func (e *eventStore) handleMessage(_ context.Context, msg *messaging.TargetMessage) error {
+	switch msg.Type {
+	case messaging.TypeEventStoreHeartbeatRequest:
+		// the heartbeat request is sent by the log coordinator, reply to the sender.
+		req := msg.Message[0].(*logservicepb.EventStoreHeartbeatRequest)
+		resp := messaging.NewSingleTargetMessage(msg.From, messaging.LogCoordinatorTopic, e.getState(req.FullState))
+		if err := e.messageCenter.SendCommand(resp); err != nil {
+			log.Warn("send event store state to log coordinator failed",
+				zap.Stringer("coordinator", msg.From), zap.Error(err))
+		}
+	default:
+		log.Panic("unknown message type", zap.Any("message", msg.Message))
+	}
+	return nil
+}
+
+// getState returns the span and the range of data of all subscriptions in the event store if full is true,
+// otherwise it only returns the subscriptions added, removed or advanced since the last report.
+func (e *eventStore) getState(full bool) *logservicepb.EventStoreState {
+	e.dispatcherStates.RLock()
+	subscriptions := make([]*logservicepb.SubscriptionState, 0, len(e.dispatcherStates.n))
+	for subID, subStat := range e.dispatcherStates.n {
+		subscriptions = append(subscriptions, &logservicepb.SubscriptionState{
+			SubId:        uint64(subID),
+			Span:         subStat.span,
+			CheckpointTs: subStat.checkpointTs.Load(),
+			ResolvedTs:   subStat.resolvedTs.Load(),
+		})
+	}
+	e.dispatcherStates.RUnlock()
+
+	e.reportedStates.Lock()
+	defer e.reportedStates.Unlock()
+	if full || e.reportedStates.m == nil {
+		e.reportedStates.m = make(map[uint64]*logservicepb.SubscriptionState, len(subscriptions))
+		for _, sub := range subscriptions {
+			e.reportedStates.m[sub.SubId] = sub
+		}
+		return &logservicepb.EventStoreState{Subscriptions: subscriptions, FullState: true}
+	}
+	state := &logservicepb.EventStoreState{}
+	current := make(map[uint64]struct{}, len(subscriptions))
+	for _, sub := range subscriptions {
+		current[sub.SubId] = struct{}{}
+		reported, ok := e.reportedStates.m[sub.SubId]
+		if ok && !tsAdvanced(reported.CheckpointTs, sub.CheckpointTs) && !tsAdvanced(reported.ResolvedTs, sub.ResolvedTs) {
+			continue
+		}
+		state.Subscriptions = append(state.Subscriptions, sub)
+		e.reportedStates.m[sub.SubId] = sub
+	}
+	for subID := range e.reportedStates.m {
+		if _, ok := current[subID]; !ok {
+			state.RemovedSubIds = append(state.RemovedSubIds, subID)
+			delete(e.reportedStates.m, subID)
+		}
+	}
+	return state
+}
+
+// tsAdvanced returns true if newTs is at least stateReportTsThreshold later than oldTs.
+func tsAdvanced(oldTs, newTs uint64) bool {
+	return oracle.GetTimeFromTS(newTs).Sub(oracle.GetTimeFromTS(oldTs)) >= stateReportTsThreshold
+}
+
 func (e *eventStore) GetIterator(dispatcherID common.DispatcherID, dataRange common.DataRange) (EventIterator, error) {
 	e.dispatcherStates.RLock()
 	stat, ok := e.dispatcherStates.m[dispatcherID]
@@ -400,12 +734,13 @@ func (e *eventStore) GetIterator(dispatcherID common.DispatcherID, dataRange com
 			zap.Uint64("checkpointTs", stat.checkpointTs.Load()),
 			zap.Uint64("startTs", dataRange.StartTs))
 	}
-	db := e.dbs[stat.chIndex]
+	subStat := stat.subStat
+	db := e.dbs[subStat.chIndex]
 	e.dispatcherStates.RUnlock()
 
 	// convert range before pass it to pebble: (startTs, endTs] is equal to [startTs + 1, endTs + 1)
-	start := EncodeKeyPrefix(stat.uniqueKeyID, stat.tableID, dataRange.StartTs+1)
-	end := EncodeKeyPrefix(stat.uniqueKeyID, stat.tableID, dataRange.EndTs+1)
+	start := EncodeKeyPrefix(subStat.uniqueKeyID, subStat.tableID, dataRange.StartTs+1)
+	end := EncodeKeyPrefix(subStat.uniqueKeyID, subStat.tableID, dataRange.EndTs+1)
 	// TODO: optimize read performance
 	iter, err := db.NewIter(&pebble.IterOptions{
 		LowerBound: start,
@@ -418,12 +753,18 @@ func (e *eventStore) GetIterator(dispatcherID common.DispatcherID, dataRange com
 
 	metrics.EventStoreScanRequestsCount.Inc()
 
+	// The subscription may be shared with other dispatchers with larger spans,
+	// the events out of the dispatcher span should be skipped.
+	var span *heartbeatpb.TableSpan
+	if !subStat.span.Equal(stat.span) {
+		span = stat.span
+	}
 	return &eventStoreIter{
-		tableID:      stat.tableID,
+		tableID:      subStat.tableID,
+		span:         span,
 		innerIter:    iter,
 		prevStartTs:  0,
 		prevCommitTs: 0,
-		iterMounter:  event.NewMounter(time.Local), // FIXME
 		startTs:      dataRange.StartTs,
 		endTs:        dataRange.EndTs,
 		rowCount:     0,
@@ -431,6 +772,51 @@ func (e *eventStore) GetIterator(dispatcherID common.DispatcherID, dataRange com
 	}, nil
 }
 
+// checkDiskQuota pauses the puller when the disk usage reaches the quota,
+// and resumes it after enough space is reclaimed by gc.
+func (e *eventStore) checkDiskQuota(ctx context.Context) error {
+	ticker := time.NewTicker(diskQuotaCheckInterval)
+	defer ticker.Stop()
+	paused := false
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			usage := e.diskUsage()
+			metrics.EventStoreOnDiskDataSizeGauge.Set(float64(usage))
+			if e.diskQuota == 0 {
+				continue
+			}
+			// resume at 90% of the quota to avoid pausing and resuming frequently.
+			if !paused && usage >= e.diskQuota {
+				paused = true
+				log.Warn("event store disk usage reaches the quota, pause pulling events",
+					zap.Uint64("usage", usage), zap.Uint64("quota", e.diskQuota))
+				e.puller.Pause()
+			} else if paused && usage < e.diskQuota/10*9 {
+				paused = false
+				log.Info("event store disk usage drops below the quota, resume pulling events",
+					zap.Uint64("usage", usage), zap.Uint64("quota", e.diskQuota))
+				e.puller.Resume()
+			}
+			if paused {
+				metrics.EventStorePausedGauge.Set(1)
+			} else {
+				metrics.EventStorePausedGauge.Set(0)
+			}
+		}
+	}
+}
+
+func (e *eventStore) diskUsage() uint64 {
+	usage := uint64(0)
+	for _, db := range e.dbs {
+		usage += db.Metrics().DiskSpaceUsage()
+	}
+	return usage
+}
+
 func (e *eventStore) updateMetrics(ctx context.Context) error {
 	ticker := time.NewTicker(5 * time.Second)
 	for {
@@ -444,7 +830,7 @@ func (e *eventStore) updateMetrics(ctx context.Context) error {
 			e.dispatcherStates.RLock()
 			for _, stat := range e.dispatcherStates.m {
 				// resolved ts lag
-				resolvedTs := stat.resolvedTs.Load()
+				resolvedTs := stat.subStat.resolvedTs.Load()
 				resolvedPhyTs := oracle.ExtractPhysical(resolvedTs)
 				resolvedLag := float64(currentPhyTs-resolvedPhyTs) / 1e3
 				metrics.EventStoreDispatcherResolvedTsLagHist.Observe(float64(resolvedLag))
@@ -502,32 +888,35 @@ func (e *eventStore) batchAndWriteEvents(ctx context.Context, db *pebble.DB, inp
 
 			for subID, maxEventCommitTs := range batchEvent.maxEventCommitTsMap {
 				e.dispatcherStates.RLock()
-				dispatcherID, ok := e.dispatcherStates.n[subID]
+				subStat, ok := e.dispatcherStates.n[subID]
+				e.dispatcherStates.RUnlock()
 				if !ok {
-					// the dispatcher is removed?
+					// the subscription is removed?
 					log.Warn("unknown subscriptionID", zap.Uint64("subID", uint64(subID)))
-					e.dispatcherStates.RUnlock()
 					continue
 				}
-				stat := e.dispatcherStates.m[dispatcherID]
-				e.dispatcherStates.RUnlock()
-				stat.maxEventCommitTs.Store(maxEventCommitTs)
+				subStat.maxEventCommitTs.Store(maxEventCommitTs)
 			}
 
 			// update resolved ts after commit successfully
 			for subID, resolvedTs := range batchEvent.resolvedTsMap {
 				e.dispatcherStates.RLock()
-				dispatcherID, ok := e.dispatcherStates.n[subID]
+				subStat, ok := e.dispatcherStates.n[subID]
 				if !ok {
-					// the dispatcher is removed?
+					// the subscription is removed?
 					log.Warn("unknown subscriptionID", zap.Uint64("subID", uint64(subID)))
 					e.dispatcherStates.RUnlock()
 					continue
 				}
-				stat := e.dispatcherStates.m[dispatcherID]
+				subStat.resolvedTs.Store(resolvedTs)
+				notifiers := make([]ResolvedTsNotifier, 0, len(subStat.dispatchers))
+				for _, stat := range subStat.dispatchers {
+					notifiers = append(notifiers, stat.notifier)
+				}
 				e.dispatcherStates.RUnlock()
-				stat.resolvedTs.Store(resolvedTs)
-				stat.notifier(resolvedTs)
+				for _, notifier := range notifiers {
+					notifier(resolvedTs)
+				}
 			}
 		}
 	}()
@@ -535,10 +924,13 @@ func (e *eventStore) batchAndWriteEvents(ctx context.Context, db *pebble.DB, inp
 	addEvent2Batch := func(batch *pebble.Batch, item eventWithState) {
 		key := EncodeKey(item.uniqueID, item.tableID, item.raw)
 		value := item.raw.Encode()
-		compressedValue := e.encoder.EncodeAll(value, nil)
-		ratio := float64(len(value)) / float64(len(compressedValue))
-		metrics.EventStoreCompressRatio.Set(ratio)
-		if err := batch.Set(key, compressedValue, pebble.NoSync); err != nil {
+		if e.encoder != nil {
+			compressedValue := e.encoder.EncodeAll(value, nil)
+			ratio := float64(len(value)) / float64(len(compressedValue))
+			metrics.EventStoreCompressRatio.Set(ratio)
+			value = compressedValue
+		}
+		if err := batch.Set(key, value, pebble.NoSync); err != nil {
 			log.Panic("failed to update pebble batch", zap.Error(err))
 		}
 	}
@@ -560,13 +952,15 @@ func (e *eventStore) batchAndWriteEvents(ctx context.Context, db *pebble.DB, inp
 				continue
 			}
 
+			if batch == nil {
+				batch = db.NewBatch()
+			}
 			if item.raw.IsResolved() {
 				resolvedTsMap[item.subID] = item.raw.CRTs
+				// persist the resolved ts in the same batch as the events before it.
+				writeResolvedTsToBatch(batch, item.uniqueID, item.raw.CRTs)
 				continue
 			} else {
-				if batch == nil {
-					batch = db.NewBatch()
-				}
 				if item.raw.CRTs > maxEventCommitTsMap[item.subID] {
 					maxEventCommitTsMap[item.subID] = item.raw.CRTs
 				}
@@ -632,12 +1026,104 @@ func (e *eventStore) deleteEvents(dbIndex int, uniqueKeyID uint64, tableID int64
 	return db.DeleteRange(start, end, pebble.NoSync)
 }
 
+func (e *eventStore) deleteSubscription(dbIndex int, uniqueKeyID uint64, reason string) error {
+	reclaimed, err := deleteSubscriptionData(e.dbs[dbIndex], uniqueKeyID)
+	if err != nil {
+		return err
+	}
+	metrics.EventStoreReclaimedBytes.WithLabelValues(reason).Add(float64(reclaimed))
+	metrics.EventStoreReclaimedSubscriptionCount.WithLabelValues(reason).Inc()
+	log.Info("event store delete subscription data",
+		zap.Int("dbIndex", dbIndex),
+		zap.Uint64("uniqueKeyID", uniqueKeyID),
+		zap.String("reason", reason),
+		zap.Uint64("reclaimedBytes", reclaimed))
+	return nil
+}
+
+// sweepOrphanData periodically deletes the data which doesn't belong to any subscription.
+// It may be left by a crash before the gc of a removed subscription is done,
+// or by the persisted subscriptions which are not reused.
+func (e *eventStore) sweepOrphanData(ctx context.Context) error {
+	ticker := time.NewTicker(orphanDataSweepInterval)
+	defer ticker.Stop()
+	candidates := make([]map[uint64]struct{}, len(e.dbs))
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			e.expirePersistedSubscriptions(persistedSubscriptionTTL)
+			candidates = e.sweepOrphanDataOnce(candidates)
+		}
+	}
+}
+
+// expirePersistedSubscriptions deletes the persisted subscriptions which are not reused after ttl.
+func (e *eventStore) expirePersistedSubscriptions(ttl time.Duration) {
+	e.persistedSubscriptions.Lock()
+	defer e.persistedSubscriptions.Unlock()
+	if time.Since(e.persistedSubscriptions.loadTime) < ttl {
+		return
+	}
+	for uniqueKeyID, sub := range e.persistedSubscriptions.m {
+		e.gcManager.addSubscriptionGCItem(sub.dbIndex, uniqueKeyID, gcReasonExpired)
+		delete(e.persistedSubscriptions.m, uniqueKeyID)
+	}
+}
+
+// sweepOrphanDataOnce deletes the data of the uniqueKeyIDs which are not used by any subscription
+// in this round and the previous round. The uniqueKeyIDs found in this round are returned.
+// A subscription being registered may be not known yet, checking it twice avoid deleting its data.
+func (e *eventStore) sweepOrphanDataOnce(prevCandidates []map[uint64]struct{}) []map[uint64]struct{} {
+	// collect the known uniqueKeyIDs before scanning the dbs,
+	// so the subscriptions registered during the scan are also checked twice.
+	known := make(map[uint64]struct{})
+	e.dispatcherStates.RLock()
+	for _, subStat := range e.dispatcherStates.n {
+		known[subStat.uniqueKeyID] = struct{}{}
+	}
+	e.dispatcherStates.RUnlock()
+	e.persistedSubscriptions.Lock()
+	for uniqueKeyID := range e.persistedSubscriptions.m {
+		known[uniqueKeyID] = struct{}{}
+	}
+	e.persistedSubscriptions.Unlock()
+
+	candidates := make([]map[uint64]struct{}, len(e.dbs))
+	for i, db := range e.dbs {
+		ids, err := collectUniqueKeyIDs(db)
+		if err != nil {
+			log.Warn("collect unique key ids failed", zap.Int("dbIndex", i), zap.Error(err))
+			continue
+		}
+		candidates[i] = make(map[uint64]struct{})
+		for uniqueKeyID := range ids {
+			if _, ok := known[uniqueKeyID]; ok {
+				continue
+			}
+			if _, ok := prevCandidates[i][uniqueKeyID]; !ok {
+				candidates[i][uniqueKeyID] = struct{}{}
+				continue
+			}
+			if err := e.deleteSubscription(i, uniqueKeyID, gcReasonOrphan); err != nil {
+				log.Warn("delete orphan data failed",
+					zap.Int("dbIndex", i), zap.Uint64("uniqueKeyID", uniqueKeyID), zap.Error(err))
+				candidates[i][uniqueKeyID] = struct{}{}
+			}
+		}
+	}
+	return candidates
+}
+
 type eventStoreIter struct {
-	tableID      common.TableID
+	tableID common.TableID
+	// span is used to skip the events out of the dispatcher span,
+	// it is nil if all events in the subscription are needed.
+	span         *heartbeatpb.TableSpan
 	innerIter    *pebble.Iterator
 	prevStartTs  uint64
 	prevCommitTs uint64
-	iterMounter  event.Mounter
 
 	// for debug
 	startTs  uint64
@@ -651,18 +1137,25 @@ func (iter *eventStoreIter) Next() (*common.RawKVEntry, bool, error) {
 		log.Panic("iter is nil")
 	}
 
-	if !iter.innerIter.Valid() {
-		return nil, false, nil
-	}
+	var rawKV *common.RawKVEntry
+	for {
+		if !iter.innerIter.Valid() {
+			return nil, false, nil
+		}
 
-	value := iter.innerIter.Value()
-	decompressedValue, err := iter.decoder.DecodeAll(value, nil)
-	if err != nil {
-		log.Panic("failed to decompress value", zap.Error(err))
+		value := iter.innerIter.Value()
+		decompressedValue, err := DecodeValue(iter.decoder, value)
+		if err != nil {
+			log.Panic("failed to decompress value", zap.Error(err))
+		}
+		metrics.EventStoreScanBytes.Add(float64(len(decompressedValue)))
+		rawKV = &common.RawKVEntry{}
+		rawKV.Decode(decompressedValue)
+		if iter.span == nil || common.KeyInSpan(common.ToComparableKey(rawKV.Key), *iter.span) {
+			break
+		}
+		iter.innerIter.Next()
 	}
-	metrics.EventStoreScanBytes.Add(float64(len(decompressedValue)))
-	rawKV := &common.RawKVEntry{}
-	rawKV.Decode(decompressedValue)
 	isNewTxn := false
 	if iter.prevCommitTs == 0 || (rawKV.StartTs != iter.prevStartTs || rawKV.CRTs != iter.prevCommitTs) {
 		isNewTxn = true
@@ -0,0 +1,303 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/logpuller"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func newTestEventStore(t *testing.T) *eventStore {
+	db, err := pebble.Open(t.TempDir(), &pebble.Options{})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	store := &eventStore{
+		dbs:       []*pebble.DB{db},
+		gcManager: newGCManager(),
+	}
+	store.dispatcherStates.m = make(map[common.DispatcherID]*dispatcherStat)
+	store.dispatcherStates.n = make(map[logpuller.SubscriptionID]*subscriptionStat)
+	store.dispatcherStates.t = make(map[int64]map[logpuller.SubscriptionID]*subscriptionStat)
+	return store
+}
+
+func addTestSubscription(store *eventStore, subID logpuller.SubscriptionID, span *heartbeatpb.TableSpan, checkpointTs uint64) *subscriptionStat {
+	subStat := &subscriptionStat{
+		subID:       subID,
+		span:        span,
+		tableID:     span.TableID,
+		uniqueKeyID: uint64(subID),
+		dispatchers: make(map[common.DispatcherID]*dispatcherStat),
+	}
+	subStat.checkpointTs.Store(checkpointTs)
+	store.dispatcherStates.n[subID] = subStat
+	if _, ok := store.dispatcherStates.t[span.TableID]; !ok {
+		store.dispatcherStates.t[span.TableID] = make(map[logpuller.SubscriptionID]*subscriptionStat)
+	}
+	store.dispatcherStates.t[span.TableID][subID] = subStat
+	return subStat
+}
+
+func TestShareSubscription(t *testing.T) {
+	store := newTestEventStore(t)
+	wide := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("z")}
+	narrow := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("c"), EndKey: []byte("f")}
+	addTestSubscription(store, 1, wide, 100)
+	addTestSubscription(store, 2, narrow, 100)
+
+	newStat := func(span *heartbeatpb.TableSpan) *dispatcherStat {
+		stat := &dispatcherStat{dispatcherID: common.NewDispatcherID(), span: span}
+		stat.checkpointTs.Store(120)
+		return stat
+	}
+	// the smallest subscription which contains the span is preferred.
+	stat := newStat(&heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("d"), EndKey: []byte("e")})
+	require.True(t, store.tryShareSubscription(stat, 120))
+	require.Equal(t, logpuller.SubscriptionID(2), stat.subStat.subID)
+
+	stat = newStat(&heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("b"), EndKey: []byte("e")})
+	require.True(t, store.tryShareSubscription(stat, 120))
+	require.Equal(t, logpuller.SubscriptionID(1), stat.subStat.subID)
+	require.Len(t, store.dispatcherStates.m, 2)
+
+	// the events before start ts are deleted from the subscription.
+	require.False(t, store.tryShareSubscription(newStat(narrow), 90))
+	// the span is not contained by any subscription.
+	require.False(t, store.tryShareSubscription(newStat(&heartbeatpb.TableSpan{TableID: 2, StartKey: []byte("c"), EndKey: []byte("f")}), 120))
+}
+
+func TestSharedSubscriptionCheckpointTs(t *testing.T) {
+	store := newTestEventStore(t)
+	span := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("z")}
+	subStat := addTestSubscription(store, 1, span, 100)
+	for _, startTs := range []uint64{100, 110} {
+		stat := &dispatcherStat{dispatcherID: common.NewDispatcherID(), span: span, subStat: subStat}
+		stat.checkpointTs.Store(startTs)
+		subStat.dispatchers[stat.dispatcherID] = stat
+		store.dispatcherStates.m[stat.dispatcherID] = stat
+	}
+	var slow, fast common.DispatcherID
+	for id, stat := range subStat.dispatchers {
+		if stat.checkpointTs.Load() == 100 {
+			slow = id
+		} else {
+			fast = id
+		}
+	}
+
+	// the checkpoint ts of the subscription is held by the slowest dispatcher.
+	require.NoError(t, store.UpdateDispatcherSendTs(fast, 200))
+	require.Equal(t, uint64(100), subStat.checkpointTs.Load())
+	require.Empty(t, store.gcManager.fetchAllGCItems())
+
+	require.NoError(t, store.UpdateDispatcherSendTs(slow, 150))
+	require.Equal(t, uint64(150), subStat.checkpointTs.Load())
+	items := store.gcManager.fetchAllGCItems()
+	require.Len(t, items, 1)
+	require.Equal(t, uint64(100), items[0].startTs)
+	require.Equal(t, uint64(150), items[0].endTs)
+
+	// the checkpoint ts advances after the slowest dispatcher is removed.
+	require.NoError(t, store.UnregisterDispatcher(slow))
+	require.Equal(t, uint64(200), subStat.checkpointTs.Load())
+	require.Len(t, subStat.dispatchers, 1)
+	require.Contains(t, store.dispatcherStates.n, subStat.subID)
+}
+
+func TestPersistCheckpointTsByGC(t *testing.T) {
+	store := newTestEventStore(t)
+	span := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("z")}
+	subStat := addTestSubscription(store, 1, span, 100)
+	require.NoError(t, writeSubscriptionMeta(store.dbs[0], subStat.uniqueKeyID, span, 100, 300))
+	stat := &dispatcherStat{dispatcherID: common.NewDispatcherID(), span: span, subStat: subStat}
+	stat.checkpointTs.Store(100)
+	subStat.dispatchers[stat.dispatcherID] = stat
+	store.dispatcherStates.m[stat.dispatcherID] = stat
+
+	loadCheckpointTs := func() uint64 {
+		subs, _, err := loadPersistedSubscriptions(store.dbs[0], 0)
+		require.NoError(t, err)
+		require.Len(t, subs, 1)
+		return subs[0].checkpointTs
+	}
+
+	// the checkpoint ts is not written when it advances.
+	require.NoError(t, store.UpdateDispatcherSendTs(stat.dispatcherID, 150))
+	require.NoError(t, store.UpdateDispatcherSendTs(stat.dispatcherID, 200))
+	require.Equal(t, uint64(200), subStat.checkpointTs.Load())
+	require.Equal(t, uint64(100), loadCheckpointTs())
+
+	// the last checkpoint ts is written by the gc before deleting the events.
+	deleted := 0
+	deleteRange := func(int, uint64, int64, uint64, uint64) error {
+		require.Equal(t, uint64(200), loadCheckpointTs())
+		deleted++
+		return nil
+	}
+	require.NoError(t, store.gcManager.gc(deleteRange, store.deleteSubscription, store.persistCheckpointTs))
+	require.Equal(t, 2, deleted)
+	require.Equal(t, uint64(200), loadCheckpointTs())
+}
+
+func TestGCSkipDeletedSubscription(t *testing.T) {
+	store := newTestEventStore(t)
+	db := store.dbs[0]
+	span := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("z")}
+	require.NoError(t, writeSubscriptionMeta(db, 1, span, 100, 300))
+	require.NoError(t, writeSubscriptionMeta(db, 2, span, 100, 300))
+
+	// the checkpoint ts of both subscriptions advance, and then subscription 1 is removed.
+	store.gcManager.addGCItem(0, 1, 1, 100, 200)
+	store.gcManager.addGCItem(0, 2, 1, 100, 200)
+	store.gcManager.addSubscriptionGCItem(0, 1, gcReasonUnregister)
+
+	deletedRanges := make(map[uint64]struct{})
+	deleteRange := func(_ int, uniqueKeyID uint64, _ int64, _ uint64, _ uint64) error {
+		deletedRanges[uniqueKeyID] = struct{}{}
+		return nil
+	}
+	require.NoError(t, store.gcManager.gc(deleteRange, store.deleteSubscription, store.persistCheckpointTs))
+	require.Equal(t, map[uint64]struct{}{2: {}}, deletedRanges)
+
+	// the metadata of the deleted subscription is not written again.
+	ids, err := collectUniqueKeyIDs(db)
+	require.NoError(t, err)
+	require.Equal(t, map[uint64]struct{}{2: {}}, ids)
+	subs, _, err := loadPersistedSubscriptions(db, 0)
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+	require.Equal(t, uint64(200), subs[0].checkpointTs)
+}
+
+func TestSweepOrphanData(t *testing.T) {
+	store := newTestEventStore(t)
+	store.persistedSubscriptions.m = make(map[uint64]*persistedSubscription)
+	db := store.dbs[0]
+	span := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("z")}
+	for uniqueKeyID := uint64(1); uniqueKeyID <= 3; uniqueKeyID++ {
+		require.NoError(t, writeSubscriptionMeta(db, uniqueKeyID, span, 100, 100))
+		for _, ts := range []uint64{110, 120} {
+			key := EncodeKey(uniqueKeyID, 1, &common.RawKVEntry{CRTs: ts, StartTs: ts - 1, Key: []byte("b")})
+			require.NoError(t, db.Set(key, []byte("v"), pebble.NoSync))
+		}
+	}
+	// the resolved ts written after the subscription is removed.
+	batch := db.NewBatch()
+	writeResolvedTsToBatch(batch, 4, 200)
+	require.NoError(t, batch.Commit(pebble.NoSync))
+
+	// subscription 1 is used by a dispatcher and subscription 2 is persisted.
+	addTestSubscription(store, 1, span, 100)
+	store.persistedSubscriptions.m[2] = &persistedSubscription{uniqueKeyID: 2, span: span}
+
+	ids, err := collectUniqueKeyIDs(db)
+	require.NoError(t, err)
+	require.Equal(t, map[uint64]struct{}{1: {}, 2: {}, 3: {}, 4: {}}, ids)
+
+	// the orphan data is only deleted when it is found twice.
+	candidates := store.sweepOrphanDataOnce(make([]map[uint64]struct{}, 1))
+	require.Equal(t, map[uint64]struct{}{3: {}, 4: {}}, candidates[0])
+	ids, err = collectUniqueKeyIDs(db)
+	require.NoError(t, err)
+	require.Len(t, ids, 4)
+
+	candidates = store.sweepOrphanDataOnce(candidates)
+	require.Empty(t, candidates[0])
+	ids, err = collectUniqueKeyIDs(db)
+	require.NoError(t, err)
+	require.Equal(t, map[uint64]struct{}{1: {}, 2: {}}, ids)
+
+	// the persisted subscription is deleted after it expires.
+	store.expirePersistedSubscriptions(0)
+	require.Empty(t, store.persistedSubscriptions.m)
+	items := store.gcManager.fetchAllSubscriptionGCItems()
+	require.Len(t, items, 1)
+	require.NoError(t, store.deleteSubscription(items[0].dbIndex, items[0].uniqueKeyID, items[0].reason))
+	ids, err = collectUniqueKeyIDs(db)
+	require.NoError(t, err)
+	require.Equal(t, map[uint64]struct{}{1: {}}, ids)
+}
+
+func TestDecodeValue(t *testing.T) {
+	encoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	decoder, err := zstd.NewReader(nil)
+	require.NoError(t, err)
+
+	for _, opType := range []common.OpType{common.OpTypePut, common.OpTypeDelete, common.OpTypeResolved} {
+		raw := &common.RawKVEntry{OpType: opType, CRTs: 100, StartTs: 90, Key: []byte("k"), Value: []byte("v")}
+		value := raw.Encode()
+		// the values written with or without compression can both be read.
+		for _, stored := range [][]byte{value, encoder.EncodeAll(value, nil)} {
+			decoded, err := DecodeValue(decoder, stored)
+			require.NoError(t, err)
+			require.Equal(t, value, decoded)
+		}
+	}
+}
+
+func TestGetState(t *testing.T) {
+	store := newTestEventStore(t)
+	span := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("z")}
+	now := time.Now()
+	checkpointTs := oracle.GoTimeToTS(now)
+	resolvedTs := oracle.GoTimeToTS(now.Add(time.Second))
+	subStat := addTestSubscription(store, 1, span, checkpointTs)
+	subStat.resolvedTs.Store(resolvedTs)
+
+	state := store.getState(true)
+	require.True(t, state.FullState)
+	require.Len(t, state.Subscriptions, 1)
+	require.Equal(t, uint64(1), state.Subscriptions[0].SubId)
+	require.True(t, span.Equal(state.Subscriptions[0].Span))
+	require.Equal(t, checkpointTs, state.Subscriptions[0].CheckpointTs)
+	require.Equal(t, resolvedTs, state.Subscriptions[0].ResolvedTs)
+
+	// nothing is reported if no subscription changes
+	state = store.getState(false)
+	require.False(t, state.FullState)
+	require.Empty(t, state.Subscriptions)
+	require.Empty(t, state.RemovedSubIds)
+
+	// the small advance of the resolved ts is not reported
+	subStat.resolvedTs.Store(oracle.GoTimeToTS(now.Add(2 * time.Second)))
+	require.Empty(t, store.getState(false).Subscriptions)
+	// the new subscription and the large advance are reported
+	subStat.resolvedTs.Store(oracle.GoTimeToTS(now.Add(stateReportTsThreshold + time.Second)))
+	addTestSubscription(store, 2, span, checkpointTs)
+	state = store.getState(false)
+	require.Len(t, state.Subscriptions, 2)
+	require.Empty(t, store.getState(false).Subscriptions)
+
+	// the removed subscription is reported once
+	delete(store.dispatcherStates.n, 1)
+	state = store.getState(false)
+	require.Empty(t, state.Subscriptions)
+	require.Equal(t, []uint64{1}, state.RemovedSubIds)
+	require.Empty(t, store.getState(false).RemovedSubIds)
+
+	// the full state contains all subscriptions
+	state = store.getState(true)
+	require.True(t, state.FullState)
+	require.Len(t, state.Subscriptions, 1)
+	require.Equal(t, uint64(2), state.Subscriptions[0].SubId)
+}
@@ -1,8 +1,10 @@
 package eventstore
 
 import (
+	"bytes"
 	"encoding/binary"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
 	"go.uber.org/zap"
@@ -83,3 +85,16 @@ func getDMLOrder(rowKV *common.RawKVEntry) uint16 {
 	}
 	return typeInsert
 }
+
+// zstdMagic is the magic number at the beginning of a zstd frame.
+// An uncompressed value starts with a small OpType in little endian, which never equals to it.
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// DecodeValue decodes a value which may be compressed by zstd or not,
+// so the data written with different compression configurations can be read.
+func DecodeValue(decoder *zstd.Decoder, value []byte) ([]byte, error) {
+	if !bytes.HasPrefix(value, zstdMagic) {
+		return value, nil
+	}
+	return decoder.DecodeAll(value, nil)
+}
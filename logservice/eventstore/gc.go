@@ -2,9 +2,12 @@ package eventstore
 
 import (
 	"context"
+	"encoding/binary"
 	"sync"
 	"time"
 
+	"github.com/cockroachdb/pebble"
+	"github.com/pingcap/errors"
 	"github.com/pingcap/ticdc/pkg/metrics"
 
 	"github.com/pingcap/log"
@@ -21,9 +24,17 @@ type gcRangeItem struct {
 	endTs   uint64
 }
 
+// gcSubscriptionItem is used to delete all data of a subscription which is removed.
+type gcSubscriptionItem struct {
+	dbIndex     int
+	uniqueKeyID uint64
+	reason      string
+}
+
 type gcManager struct {
-	mu     sync.Mutex
-	ranges []gcRangeItem
+	mu            sync.Mutex
+	ranges        []gcRangeItem
+	subscriptions []gcSubscriptionItem
 }
 
 func newGCManager() *gcManager {
@@ -43,6 +54,17 @@ func (d *gcManager) addGCItem(dbIndex int, uniqueKeyID uint64, tableID int64, st
 	})
 }
 
+// add an item to delete all data with `uniqueKeyID`.
+func (d *gcManager) addSubscriptionGCItem(dbIndex int, uniqueKeyID uint64, reason string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subscriptions = append(d.subscriptions, gcSubscriptionItem{
+		dbIndex:     dbIndex,
+		uniqueKeyID: uniqueKeyID,
+		reason:      reason,
+	})
+}
+
 func (d *gcManager) fetchAllGCItems() []gcRangeItem {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -51,29 +73,154 @@ func (d *gcManager) fetchAllGCItems() []gcRangeItem {
 	return ranges
 }
 
+func (d *gcManager) fetchAllSubscriptionGCItems() []gcSubscriptionItem {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	subscriptions := d.subscriptions
+	d.subscriptions = nil
+	return subscriptions
+}
+
 type deleteFunc func(dbIndex int, uniqueKeyID uint64, tableID int64, startCommitTS uint64, endCommitTS uint64) error
 
-func (d *gcManager) run(ctx context.Context, deleteDataRange deleteFunc) error {
+type deleteSubscriptionFunc func(dbIndex int, uniqueKeyID uint64, reason string) error
+
+// persistCheckpointTsFunc writes the checkpoint ts of the subscriptions(uniqueKeyID -> checkpoint ts) in the db.
+type persistCheckpointTsFunc func(dbIndex int, checkpointTs map[uint64]uint64) error
+
+func (d *gcManager) run(
+	ctx context.Context,
+	deleteDataRange deleteFunc,
+	deleteSubscription deleteSubscriptionFunc,
+	persistCheckpointTs persistCheckpointTsFunc,
+) error {
 	ticker := time.NewTicker(20 * time.Millisecond)
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
 		case <-ticker.C:
-			ranges := d.fetchAllGCItems()
-			if len(ranges) == 0 {
-				continue
+			if err := d.gc(deleteDataRange, deleteSubscription, persistCheckpointTs); err != nil {
+				log.Fatal("event store gc fail", zap.Error(err))
+				return err
 			}
-			for _, r := range ranges {
-				// TODO: delete in batch?
-				err := deleteDataRange(r.dbIndex, r.uniqueKeyID, r.tableID, r.startTs, r.endTs)
-				if err != nil {
-					// TODO: add the data range back?
-					log.Fatal("delete fail", zap.Error(err))
-					return err
-				}
+		}
+	}
+}
+
+func (d *gcManager) gc(
+	deleteDataRange deleteFunc,
+	deleteSubscription deleteSubscriptionFunc,
+	persistCheckpointTs persistCheckpointTsFunc,
+) error {
+	// uniqueKeyIDs are unique among all dbs
+	deleted := make(map[uint64]struct{})
+	for _, s := range d.fetchAllSubscriptionGCItems() {
+		if err := deleteSubscription(s.dbIndex, s.uniqueKeyID, s.reason); err != nil {
+			return errors.Annotate(err, "delete subscription fail")
+		}
+		deleted[s.uniqueKeyID] = struct{}{}
+	}
+	ranges := d.fetchAllGCItems()
+	if len(deleted) > 0 {
+		// all data and metadata of the deleted subscriptions are gone,
+		// writing their checkpoint ts would create the metadata again.
+		n := 0
+		for _, r := range ranges {
+			if _, ok := deleted[r.uniqueKeyID]; !ok {
+				ranges[n] = r
+				n++
 			}
-			metrics.EventStoreDeleteRangeCount.Add(float64(len(ranges)))
 		}
+		ranges = ranges[:n]
+	}
+	if len(ranges) == 0 {
+		return nil
+	}
+	// The checkpoint ts is persisted before deleting the data, so the deleted data
+	// will not be reused after restart. The checkpoint ts may advance many times
+	// between two gcs, only the last one of a subscription is written.
+	checkpointTs := make(map[int]map[uint64]uint64)
+	for _, r := range ranges {
+		m, ok := checkpointTs[r.dbIndex]
+		if !ok {
+			m = make(map[uint64]uint64)
+			checkpointTs[r.dbIndex] = m
+		}
+		if r.endTs > m[r.uniqueKeyID] {
+			m[r.uniqueKeyID] = r.endTs
+		}
+	}
+	for dbIndex, m := range checkpointTs {
+		if err := persistCheckpointTs(dbIndex, m); err != nil {
+			return errors.Annotate(err, "persist checkpoint ts fail")
+		}
+	}
+	for _, r := range ranges {
+		// TODO: delete in batch?
+		if err := deleteDataRange(r.dbIndex, r.uniqueKeyID, r.tableID, r.startTs, r.endTs); err != nil {
+			// TODO: add the data range back?
+			return errors.Annotate(err, "delete fail")
+		}
+	}
+	metrics.EventStoreDeleteRangeCount.Add(float64(len(ranges)))
+	return nil
+}
+
+const (
+	gcReasonUnregister = "unregister"
+	gcReasonOrphan     = "orphan"
+	gcReasonExpired    = "expired"
+)
+
+// deleteSubscriptionData deletes all events and metadata with `uniqueKeyID` in the db.
+// It returns the estimated bytes reclaimed on disk.
+func deleteSubscriptionData(db *pebble.DB, uniqueKeyID uint64) (uint64, error) {
+	start := binary.BigEndian.AppendUint64(nil, uniqueKeyID)
+	end := binary.BigEndian.AppendUint64(nil, uniqueKeyID+1)
+	// the data in memtables is not counted, it is just an estimation.
+	reclaimed, err := db.EstimateDiskUsage(start, end)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if err := db.DeleteRange(start, end, pebble.NoSync); err != nil {
+		return 0, errors.Trace(err)
+	}
+	if err := deleteSubscriptionMeta(db, uniqueKeyID); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return reclaimed, nil
+}
+
+// collectUniqueKeyIDs returns all uniqueKeyIDs used by the events or the metadata in the db.
+func collectUniqueKeyIDs(db *pebble.DB) (map[uint64]struct{}, error) {
+	iter, err := db.NewIter(&pebble.IterOptions{})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer iter.Close()
+
+	ids := make(map[uint64]struct{})
+	for valid := iter.First(); valid; {
+		key := iter.Key()
+		if len(key) < 8 {
+			valid = iter.Next()
+			continue
+		}
+		prefix := binary.BigEndian.Uint64(key[:8])
+		if prefix != metaKeyPrefix {
+			ids[prefix] = struct{}{}
+			// skip all events of the subscription
+			valid = iter.SeekGE(binary.BigEndian.AppendUint64(nil, prefix+1))
+			continue
+		}
+		if len(key) == 8+8+1 {
+			uniqueKeyID := binary.BigEndian.Uint64(key[8:16])
+			ids[uniqueKeyID] = struct{}{}
+			valid = iter.SeekGE(encodeMetaKey(uniqueKeyID+1, 0))
+			continue
+		}
+		valid = iter.Next()
 	}
+	return ids, errors.Trace(iter.Error())
 }
@@ -0,0 +1,208 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"go.uber.org/zap"
+)
+
+// Metadata format:
+//
+//	{metaKeyPrefix}{uniqueKeyID}{metaTypeSpan} -> table span of the subscription
+//	{metaKeyPrefix}{uniqueKeyID}{metaTypeCheckpointTs} -> checkpoint ts
+//	{metaKeyPrefix}{uniqueKeyID}{metaTypeResolvedTs} -> resolved ts
+//
+// The metadata of a subscription is stored in the same db as its events.
+// Events of a subscription are complete in the range (checkpoint ts, resolved ts].
+// The resolved ts is written in the same batch as the events, so the events
+// before the persisted resolved ts are always on disk.
+//
+// uniqueKeyID of events always starts from 1, so metadata keys never overlap with event keys.
+const metaKeyPrefix uint64 = 0
+
+const (
+	metaTypeSpan byte = iota + 1
+	metaTypeCheckpointTs
+	metaTypeResolvedTs
+)
+
+// persistedSubscription is a subscription restored from the disk,
+// which can be reused by a dispatcher registered with a span contained in it.
+type persistedSubscription struct {
+	dbIndex      int
+	uniqueKeyID  uint64
+	span         *heartbeatpb.TableSpan
+	checkpointTs uint64
+	resolvedTs   uint64
+}
+
+func encodeMetaKey(uniqueKeyID uint64, metaType byte) []byte {
+	buf := make([]byte, 0, 8+8+1)
+	buf = binary.BigEndian.AppendUint64(buf, metaKeyPrefix)
+	buf = binary.BigEndian.AppendUint64(buf, uniqueKeyID)
+	return append(buf, metaType)
+}
+
+// metaKeyRange returns the range [start, end) which contains all metadata of the subscription.
+func metaKeyRange(uniqueKeyID uint64) ([]byte, []byte) {
+	return encodeMetaKey(uniqueKeyID, 0), encodeMetaKey(uniqueKeyID+1, 0)
+}
+
+func encodeTs(ts uint64) []byte {
+	return binary.BigEndian.AppendUint64(make([]byte, 0, 8), ts)
+}
+
+func writeSubscriptionMeta(db *pebble.DB, uniqueKeyID uint64, span *heartbeatpb.TableSpan, checkpointTs uint64, resolvedTs uint64) error {
+	spanValue, err := span.Marshal()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	batch := db.NewBatch()
+	defer batch.Close()
+	if err := batch.Set(encodeMetaKey(uniqueKeyID, metaTypeSpan), spanValue, pebble.NoSync); err != nil {
+		return errors.Trace(err)
+	}
+	if err := batch.Set(encodeMetaKey(uniqueKeyID, metaTypeCheckpointTs), encodeTs(checkpointTs), pebble.NoSync); err != nil {
+		return errors.Trace(err)
+	}
+	if err := batch.Set(encodeMetaKey(uniqueKeyID, metaTypeResolvedTs), encodeTs(resolvedTs), pebble.NoSync); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(batch.Commit(pebble.NoSync))
+}
+
+// writeCheckpointTs writes the checkpoint ts of the subscriptions(uniqueKeyID -> checkpoint ts) in a batch.
+func writeCheckpointTs(db *pebble.DB, checkpointTs map[uint64]uint64) error {
+	batch := db.NewBatch()
+	defer batch.Close()
+	for uniqueKeyID, ts := range checkpointTs {
+		if err := batch.Set(encodeMetaKey(uniqueKeyID, metaTypeCheckpointTs), encodeTs(ts), pebble.NoSync); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(batch.Commit(pebble.NoSync))
+}
+
+func writeResolvedTsToBatch(batch *pebble.Batch, uniqueKeyID uint64, resolvedTs uint64) {
+	if err := batch.Set(encodeMetaKey(uniqueKeyID, metaTypeResolvedTs), encodeTs(resolvedTs), pebble.NoSync); err != nil {
+		log.Panic("failed to update pebble batch", zap.Error(err))
+	}
+}
+
+func deleteSubscriptionMeta(db *pebble.DB, uniqueKeyID uint64) error {
+	start, end := metaKeyRange(uniqueKeyID)
+	return errors.Trace(db.DeleteRange(start, end, pebble.NoSync))
+}
+
+// loadPersistedSubscriptions reads the metadata of all subscriptions in the db.
+// It also returns the max uniqueKeyID used by the keys in the db,
+// new subscriptions must use a larger uniqueKeyID to avoid reading stale data.
+func loadPersistedSubscriptions(db *pebble.DB, dbIndex int) ([]*persistedSubscription, uint64, error) {
+	iter, err := db.NewIter(&pebble.IterOptions{})
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	maxUniqueKeyID := uint64(0)
+	if iter.Last() {
+		maxUniqueKeyID = binary.BigEndian.Uint64(iter.Key()[:8])
+	}
+	if err := iter.Close(); err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+
+	iter, err = db.NewIter(&pebble.IterOptions{
+		LowerBound: binary.BigEndian.AppendUint64(nil, metaKeyPrefix),
+		UpperBound: binary.BigEndian.AppendUint64(nil, metaKeyPrefix+1),
+	})
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	defer iter.Close()
+
+	subs := make(map[uint64]*persistedSubscription)
+	for iter.First(); iter.Valid(); iter.Next() {
+		key := iter.Key()
+		if len(key) != 8+8+1 {
+			log.Warn("unknown meta key in event store", zap.Binary("key", key))
+			continue
+		}
+		uniqueKeyID := binary.BigEndian.Uint64(key[8:16])
+		if uniqueKeyID > maxUniqueKeyID {
+			maxUniqueKeyID = uniqueKeyID
+		}
+		sub, ok := subs[uniqueKeyID]
+		if !ok {
+			sub = &persistedSubscription{dbIndex: dbIndex, uniqueKeyID: uniqueKeyID}
+			subs[uniqueKeyID] = sub
+		}
+		value := iter.Value()
+		switch key[16] {
+		case metaTypeSpan:
+			span := &heartbeatpb.TableSpan{}
+			if err := span.Unmarshal(value); err != nil {
+				return nil, 0, errors.Trace(err)
+			}
+			sub.span = span
+		case metaTypeCheckpointTs:
+			sub.checkpointTs = binary.BigEndian.Uint64(value)
+		case metaTypeResolvedTs:
+			sub.resolvedTs = binary.BigEndian.Uint64(value)
+		}
+	}
+
+	result := make([]*persistedSubscription, 0, len(subs))
+	for _, sub := range subs {
+		// The metadata is incomplete if the resolved ts is written after
+		// the subscription is unregistered, its data is not reusable.
+		if sub.span == nil || sub.checkpointTs == 0 {
+			continue
+		}
+		result = append(result, sub)
+	}
+	return result, maxUniqueKeyID, nil
+}
+
+// removeExtraDBs removes the dbs whose index is not less than dbCount under dbPath.
+// They are left by a previous process with a larger db count, and no subscription
+// can reuse them, so they must be removed to reclaim the disk space.
+func removeExtraDBs(dbPath string, dbCount int) error {
+	entries, err := os.ReadDir(dbPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Trace(err)
+	}
+	for _, entry := range entries {
+		index, err := strconv.Atoi(entry.Name())
+		if err != nil || !entry.IsDir() || index < dbCount {
+			continue
+		}
+		log.Info("remove the db not used by the current db count",
+			zap.Int("dbIndex", index), zap.Int("dbCount", dbCount))
+		if err := os.RemoveAll(filepath.Join(dbPath, entry.Name())); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadPersistedSubscriptions(t *testing.T) {
+	dir := t.TempDir()
+	db, err := pebble.Open(dir, &pebble.Options{})
+	require.NoError(t, err)
+
+	span1 := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("b")}
+	span2 := &heartbeatpb.TableSpan{TableID: 2, StartKey: []byte("c"), EndKey: []byte("d")}
+	require.NoError(t, writeSubscriptionMeta(db, 1, span1, 100, 100))
+	require.NoError(t, writeSubscriptionMeta(db, 2, span2, 100, 100))
+	require.NoError(t, writeSubscriptionMeta(db, 3, span2, 100, 100))
+
+	// the resolved ts is written with the events in a batch.
+	batch := db.NewBatch()
+	require.NoError(t, batch.Set(EncodeKey(1, 1, &common.RawKVEntry{CRTs: 150, StartTs: 140, Key: []byte("a1")}), []byte("v"), pebble.NoSync))
+	writeResolvedTsToBatch(batch, 1, 200)
+	require.NoError(t, batch.Commit(pebble.NoSync))
+	require.NoError(t, writeCheckpointTs(db, map[uint64]uint64{1: 120}))
+
+	// the subscription is unregistered, and then a resolved ts is written.
+	require.NoError(t, deleteSubscriptionMeta(db, 3))
+	batch = db.NewBatch()
+	writeResolvedTsToBatch(batch, 3, 300)
+	require.NoError(t, batch.Commit(pebble.NoSync))
+
+	// the events of an unknown subscription.
+	require.NoError(t, db.Set(EncodeKey(5, 1, &common.RawKVEntry{CRTs: 150, StartTs: 140, Key: []byte("a1")}), []byte("v"), pebble.NoSync))
+
+	// reopen the db to make sure the data survives the restart.
+	require.NoError(t, db.Close())
+	db, err = pebble.Open(dir, &pebble.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	subs, maxUniqueKeyID, err := loadPersistedSubscriptions(db, 7)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), maxUniqueKeyID)
+	require.Len(t, subs, 2)
+	sort.Slice(subs, func(i, j int) bool { return subs[i].uniqueKeyID < subs[j].uniqueKeyID })
+	require.Equal(t, 7, subs[0].dbIndex)
+	require.Equal(t, uint64(1), subs[0].uniqueKeyID)
+	require.True(t, span1.Equal(subs[0].span))
+	require.Equal(t, uint64(120), subs[0].checkpointTs)
+	require.Equal(t, uint64(200), subs[0].resolvedTs)
+	require.Equal(t, uint64(2), subs[1].uniqueKeyID)
+	require.True(t, span2.Equal(subs[1].span))
+	require.Equal(t, uint64(100), subs[1].checkpointTs)
+	require.Equal(t, uint64(100), subs[1].resolvedTs)
+}
+
+func TestTakePersistedSubscription(t *testing.T) {
+	span := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("b")}
+	store := &eventStore{}
+	store.persistedSubscriptions.m = map[uint64]*persistedSubscription{
+		1: {uniqueKeyID: 1, span: span, checkpointTs: 100, resolvedTs: 200},
+		2: {uniqueKeyID: 2, span: span, checkpointTs: 150, resolvedTs: 300},
+	}
+
+	// the data before checkpoint ts is deleted, so it cannot be reused.
+	require.Nil(t, store.takePersistedSubscription(span, 90))
+	// the span must be contained in the persisted span.
+	require.Nil(t, store.takePersistedSubscription(&heartbeatpb.TableSpan{TableID: 2}, 160))
+
+	// the subscription with more data is preferred.
+	sub := store.takePersistedSubscription(span, 160)
+	require.NotNil(t, sub)
+	require.Equal(t, uint64(2), sub.uniqueKeyID)
+	sub = store.takePersistedSubscription(span, 160)
+	require.NotNil(t, sub)
+	require.Equal(t, uint64(1), sub.uniqueKeyID)
+	// a persisted subscription can only be reused once.
+	require.Nil(t, store.takePersistedSubscription(span, 160))
+}
+
+func TestRemoveExtraDBs(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, removeExtraDBs(filepath.Join(dir, "not-exist"), 2))
+	for i := 0; i < 4; i++ {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, strconv.Itoa(i)), 0o755))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "other"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "5"), []byte("file"), 0o644))
+
+	// the dbs whose index is not less than the db count are removed
+	require.NoError(t, removeExtraDBs(dir, 2))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	require.ElementsMatch(t, []string{"0", "1", "5", "other"}, names)
+}
@@ -85,6 +85,12 @@ type LogPuller struct {
 		spanProgressMap map[SubscriptionID]*spanProgress
 	}
 
+	pause struct {
+		sync.Mutex
+		// resumeCh is closed when the puller is resumed, it is nil if the puller is not paused.
+		resumeCh chan struct{}
+	}
+
 	CounterKv       prometheus.Counter
 	CounterResolved prometheus.Counter
 }
@@ -114,6 +120,10 @@ func (p *LogPuller) Run(ctx context.Context) (err error) {
 	}()
 
 	consumeLogEvent := func(ctx context.Context, e LogEvent) error {
+		// block the event processors to stop pulling events from upstream.
+		if err := p.waitResumed(ctx); err != nil {
+			return errors.Trace(err)
+		}
 		progress := p.getProgress(e.SubscriptionID)
 		// There is a chance that some stale events are received after
 		// the subscription is removed. We can just ignore them.
@@ -209,6 +219,43 @@ func (p *LogPuller) Unsubscribe(subID SubscriptionID) {
 	p.client.Unsubscribe(progress.subID)
 }
 
+// Pause stops consuming events of all subscriptions until Resume is called.
+// The events are not dropped, so the upstream will be throttled by the flow control.
+func (p *LogPuller) Pause() {
+	p.pause.Lock()
+	defer p.pause.Unlock()
+	if p.pause.resumeCh == nil {
+		p.pause.resumeCh = make(chan struct{})
+		log.Info("LogPuller is paused")
+	}
+}
+
+// Resume continues consuming events after Pause.
+func (p *LogPuller) Resume() {
+	p.pause.Lock()
+	defer p.pause.Unlock()
+	if p.pause.resumeCh != nil {
+		close(p.pause.resumeCh)
+		p.pause.resumeCh = nil
+		log.Info("LogPuller is resumed")
+	}
+}
+
+func (p *LogPuller) waitResumed(ctx context.Context) error {
+	p.pause.Lock()
+	resumeCh := p.pause.resumeCh
+	p.pause.Unlock()
+	if resumeCh == nil {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-resumeCh:
+		return nil
+	}
+}
+
 func (p *LogPuller) getProgress(subID SubscriptionID) *spanProgress {
 	p.subscriptions.RLock()
 	defer p.subscriptions.RUnlock()
@@ -0,0 +1,50 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logpuller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogPullerPause(t *testing.T) {
+	puller := NewLogPuller(nil, nil, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, puller.waitResumed(ctx))
+
+	puller.Pause()
+	puller.Pause()
+	done := make(chan error, 1)
+	go func() {
+		done <- puller.waitResumed(ctx)
+	}()
+	select {
+	case <-done:
+		require.FailNow(t, "should be blocked when the puller is paused")
+	case <-time.After(50 * time.Millisecond):
+	}
+	puller.Resume()
+	require.NoError(t, <-done)
+	puller.Resume()
+	require.NoError(t, puller.waitResumed(ctx))
+
+	// the waiting is canceled with the context.
+	puller.Pause()
+	cancel()
+	require.ErrorIs(t, puller.waitResumed(ctx), context.Canceled)
+}
@@ -0,0 +1,1581 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: logservice/logservicepb/logservice.proto
+
+package logservicepb
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	heartbeatpb "github.com/pingcap/ticdc/heartbeatpb"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// EventStoreHeartbeatRequest is sent by the log coordinator to the event store of every node periodically.
+type EventStoreHeartbeatRequest struct {
+	// the event store reports all subscriptions if full_state is true,
+	// otherwise it only reports the subscriptions changed since its last report.
+	FullState bool `protobuf:"varint,1,opt,name=full_state,json=fullState,proto3" json:"full_state,omitempty"`
+}
+
+func (m *EventStoreHeartbeatRequest) Reset()         { *m = EventStoreHeartbeatRequest{} }
+func (m *EventStoreHeartbeatRequest) String() string { return proto.CompactTextString(m) }
+func (*EventStoreHeartbeatRequest) ProtoMessage()    {}
+func (*EventStoreHeartbeatRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a1db670929506a40, []int{0}
+}
+func (m *EventStoreHeartbeatRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EventStoreHeartbeatRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EventStoreHeartbeatRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *EventStoreHeartbeatRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventStoreHeartbeatRequest.Merge(m, src)
+}
+func (m *EventStoreHeartbeatRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *EventStoreHeartbeatRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventStoreHeartbeatRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventStoreHeartbeatRequest proto.InternalMessageInfo
+
+func (m *EventStoreHeartbeatRequest) GetFullState() bool {
+	if m != nil {
+		return m.FullState
+	}
+	return false
+}
+
+type SubscriptionState struct {
+	SubId uint64                 `protobuf:"varint,1,opt,name=sub_id,json=subId,proto3" json:"sub_id,omitempty"`
+	Span  *heartbeatpb.TableSpan `protobuf:"bytes,2,opt,name=span,proto3" json:"span,omitempty"`
+	// events of the subscription are complete in the range (checkpoint_ts, resolved_ts]
+	CheckpointTs uint64 `protobuf:"varint,3,opt,name=checkpoint_ts,json=checkpointTs,proto3" json:"checkpoint_ts,omitempty"`
+	ResolvedTs   uint64 `protobuf:"varint,4,opt,name=resolved_ts,json=resolvedTs,proto3" json:"resolved_ts,omitempty"`
+}
+
+func (m *SubscriptionState) Reset()         { *m = SubscriptionState{} }
+func (m *SubscriptionState) String() string { return proto.CompactTextString(m) }
+func (*SubscriptionState) ProtoMessage()    {}
+func (*SubscriptionState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a1db670929506a40, []int{1}
+}
+func (m *SubscriptionState) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscriptionState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubscriptionState.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubscriptionState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscriptionState.Merge(m, src)
+}
+func (m *SubscriptionState) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscriptionState) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscriptionState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscriptionState proto.InternalMessageInfo
+
+func (m *SubscriptionState) GetSubId() uint64 {
+	if m != nil {
+		return m.SubId
+	}
+	return 0
+}
+
+func (m *SubscriptionState) GetSpan() *heartbeatpb.TableSpan {
+	if m != nil {
+		return m.Span
+	}
+	return nil
+}
+
+func (m *SubscriptionState) GetCheckpointTs() uint64 {
+	if m != nil {
+		return m.CheckpointTs
+	}
+	return 0
+}
+
+func (m *SubscriptionState) GetResolvedTs() uint64 {
+	if m != nil {
+		return m.ResolvedTs
+	}
+	return 0
+}
+
+// EventStoreState is the response of EventStoreHeartbeatRequest,
+// which contains all subscriptions in the event store of a node, or the changes since the last report.
+type EventStoreState struct {
+	// the added and updated subscriptions, or all subscriptions if full_state is true
+	Subscriptions []*SubscriptionState `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	FullState     bool                 `protobuf:"varint,2,opt,name=full_state,json=fullState,proto3" json:"full_state,omitempty"`
+	// the subscriptions removed since the last report, it's empty if full_state is true
+	RemovedSubIds []uint64 `protobuf:"varint,3,rep,packed,name=removed_sub_ids,json=removedSubIds,proto3" json:"removed_sub_ids,omitempty"`
+}
+
+func (m *EventStoreState) Reset()         { *m = EventStoreState{} }
+func (m *EventStoreState) String() string { return proto.CompactTextString(m) }
+func (*EventStoreState) ProtoMessage()    {}
+func (*EventStoreState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a1db670929506a40, []int{2}
+}
+func (m *EventStoreState) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EventStoreState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EventStoreState.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *EventStoreState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventStoreState.Merge(m, src)
+}
+func (m *EventStoreState) XXX_Size() int {
+	return m.Size()
+}
+func (m *EventStoreState) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventStoreState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventStoreState proto.InternalMessageInfo
+
+func (m *EventStoreState) GetSubscriptions() []*SubscriptionState {
+	if m != nil {
+		return m.Subscriptions
+	}
+	return nil
+}
+
+func (m *EventStoreState) GetFullState() bool {
+	if m != nil {
+		return m.FullState
+	}
+	return false
+}
+
+func (m *EventStoreState) GetRemovedSubIds() []uint64 {
+	if m != nil {
+		return m.RemovedSubIds
+	}
+	return nil
+}
+
+// LogCoordinatorBroadcastRequest is sent by the log coordinator to the event collector of every node
+// when the log coordinator starts or the node is added, so the event collectors know where to send
+// ReusableEventServiceRequest.
+type LogCoordinatorBroadcastRequest struct {
+}
+
+func (m *LogCoordinatorBroadcastRequest) Reset()         { *m = LogCoordinatorBroadcastRequest{} }
+func (m *LogCoordinatorBroadcastRequest) String() string { return proto.CompactTextString(m) }
+func (*LogCoordinatorBroadcastRequest) ProtoMessage()    {}
+func (*LogCoordinatorBroadcastRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a1db670929506a40, []int{3}
+}
+func (m *LogCoordinatorBroadcastRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogCoordinatorBroadcastRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LogCoordinatorBroadcastRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LogCoordinatorBroadcastRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogCoordinatorBroadcastRequest.Merge(m, src)
+}
+func (m *LogCoordinatorBroadcastRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogCoordinatorBroadcastRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogCoordinatorBroadcastRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogCoordinatorBroadcastRequest proto.InternalMessageInfo
+
+// ReusableEventServiceRequest asks the log coordinator which nodes have the data of the span after start_ts.
+type ReusableEventServiceRequest struct {
+	Id      *heartbeatpb.DispatcherID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Span    *heartbeatpb.TableSpan    `protobuf:"bytes,2,opt,name=span,proto3" json:"span,omitempty"`
+	StartTs uint64                    `protobuf:"varint,3,opt,name=start_ts,json=startTs,proto3" json:"start_ts,omitempty"`
+}
+
+func (m *ReusableEventServiceRequest) Reset()         { *m = ReusableEventServiceRequest{} }
+func (m *ReusableEventServiceRequest) String() string { return proto.CompactTextString(m) }
+func (*ReusableEventServiceRequest) ProtoMessage()    {}
+func (*ReusableEventServiceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a1db670929506a40, []int{4}
+}
+func (m *ReusableEventServiceRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReusableEventServiceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReusableEventServiceRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReusableEventServiceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReusableEventServiceRequest.Merge(m, src)
+}
+func (m *ReusableEventServiceRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReusableEventServiceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReusableEventServiceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReusableEventServiceRequest proto.InternalMessageInfo
+
+func (m *ReusableEventServiceRequest) GetId() *heartbeatpb.DispatcherID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *ReusableEventServiceRequest) GetSpan() *heartbeatpb.TableSpan {
+	if m != nil {
+		return m.Span
+	}
+	return nil
+}
+
+func (m *ReusableEventServiceRequest) GetStartTs() uint64 {
+	if m != nil {
+		return m.StartTs
+	}
+	return 0
+}
+
+type ReusableEventServiceResponse struct {
+	Id *heartbeatpb.DispatcherID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// the nodes are sorted by the resolved ts of their subscriptions in descending order
+	Nodes []string `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+}
+
+func (m *ReusableEventServiceResponse) Reset()         { *m = ReusableEventServiceResponse{} }
+func (m *ReusableEventServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*ReusableEventServiceResponse) ProtoMessage()    {}
+func (*ReusableEventServiceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a1db670929506a40, []int{5}
+}
+func (m *ReusableEventServiceResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReusableEventServiceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReusableEventServiceResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReusableEventServiceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReusableEventServiceResponse.Merge(m, src)
+}
+func (m *ReusableEventServiceResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReusableEventServiceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReusableEventServiceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReusableEventServiceResponse proto.InternalMessageInfo
+
+func (m *ReusableEventServiceResponse) GetId() *heartbeatpb.DispatcherID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *ReusableEventServiceResponse) GetNodes() []string {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*EventStoreHeartbeatRequest)(nil), "logservicepb.EventStoreHeartbeatRequest")
+	proto.RegisterType((*SubscriptionState)(nil), "logservicepb.SubscriptionState")
+	proto.RegisterType((*EventStoreState)(nil), "logservicepb.EventStoreState")
+	proto.RegisterType((*LogCoordinatorBroadcastRequest)(nil), "logservicepb.LogCoordinatorBroadcastRequest")
+	proto.RegisterType((*ReusableEventServiceRequest)(nil), "logservicepb.ReusableEventServiceRequest")
+	proto.RegisterType((*ReusableEventServiceResponse)(nil), "logservicepb.ReusableEventServiceResponse")
+}
+
+func init() {
+	proto.RegisterFile("logservice/logservicepb/logservice.proto", fileDescriptor_a1db670929506a40)
+}
+
+var fileDescriptor_a1db670929506a40 = []byte{
+	// 456 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0xc1, 0x6e, 0xd3, 0x40,
+	0x10, 0x86, 0xb3, 0x4e, 0x5a, 0xda, 0x49, 0xa3, 0x0a, 0x0b, 0x90, 0xdb, 0x82, 0x6b, 0x19, 0x09,
+	0x19, 0x0e, 0x36, 0x0a, 0x47, 0x6e, 0xa5, 0x95, 0x28, 0xe2, 0x64, 0xe7, 0xc4, 0x25, 0x5a, 0xaf,
+	0x97, 0x64, 0x85, 0xbb, 0xbb, 0xec, 0xac, 0xf3, 0x1a, 0xf0, 0x00, 0x48, 0xbc, 0x0e, 0xc7, 0x1e,
+	0x39, 0xa2, 0xe4, 0x45, 0x90, 0xed, 0x86, 0xb8, 0x05, 0x0e, 0xb9, 0xed, 0xfc, 0x3b, 0x63, 0xfd,
+	0xff, 0x37, 0x5e, 0x88, 0x4a, 0x35, 0x43, 0x6e, 0x16, 0x82, 0xf1, 0x64, 0x73, 0xd4, 0x79, 0xa7,
+	0x88, 0xb5, 0x51, 0x56, 0xb9, 0x07, 0xdd, 0xeb, 0xe3, 0x93, 0x39, 0xa7, 0xc6, 0xe6, 0x9c, 0x5a,
+	0x9d, 0x27, 0x7f, 0xce, 0x6d, 0x6b, 0xf8, 0x1a, 0x8e, 0x2f, 0x16, 0x5c, 0xda, 0xcc, 0x2a, 0xc3,
+	0xdf, 0xae, 0x2f, 0x53, 0xfe, 0xb9, 0xe2, 0x68, 0xdd, 0x27, 0x00, 0x1f, 0xab, 0xb2, 0x9c, 0xa2,
+	0xa5, 0x96, 0x7b, 0x24, 0x20, 0xd1, 0x5e, 0xba, 0x5f, 0x2b, 0x59, 0x2d, 0x84, 0xdf, 0x08, 0xdc,
+	0xcf, 0xaa, 0x1c, 0x99, 0x11, 0xda, 0x0a, 0x25, 0x1b, 0xd5, 0x7d, 0x08, 0xbb, 0x58, 0xe5, 0x53,
+	0x51, 0x34, 0x03, 0x83, 0x74, 0x07, 0xab, 0xfc, 0xb2, 0x70, 0x5f, 0xc0, 0x00, 0x35, 0x95, 0x9e,
+	0x13, 0x90, 0x68, 0x38, 0x7e, 0x14, 0x77, 0x5c, 0xc5, 0x13, 0x9a, 0x97, 0x3c, 0xd3, 0x54, 0xa6,
+	0x4d, 0x8f, 0xfb, 0x14, 0x46, 0x6c, 0xce, 0xd9, 0x27, 0xad, 0x84, 0xb4, 0x53, 0x8b, 0x5e, 0xbf,
+	0xf9, 0xd2, 0xc1, 0x46, 0x9c, 0xa0, 0x7b, 0x0a, 0x43, 0xc3, 0x51, 0x95, 0x0b, 0x5e, 0xd4, 0x2d,
+	0x83, 0xa6, 0x05, 0xd6, 0xd2, 0x04, 0xc3, 0xef, 0x04, 0x0e, 0x37, 0xe1, 0x5a, 0x73, 0x17, 0x30,
+	0xc2, 0x8e, 0x63, 0xf4, 0x48, 0xd0, 0x8f, 0x86, 0xe3, 0xd3, 0xb8, 0x8b, 0x2c, 0xfe, 0x2b, 0x54,
+	0x7a, 0x7b, 0xea, 0x0e, 0x18, 0xe7, 0x0e, 0x18, 0xf7, 0x19, 0x1c, 0x1a, 0x7e, 0xa5, 0x6a, 0x67,
+	0x2d, 0x8a, 0x3a, 0x41, 0x3f, 0x1a, 0xa4, 0xa3, 0x1b, 0x39, 0xab, 0x91, 0x60, 0x18, 0x80, 0xff,
+	0x5e, 0xcd, 0xde, 0x28, 0x65, 0x0a, 0x21, 0xa9, 0x55, 0xe6, 0xcc, 0x28, 0x5a, 0x30, 0x8a, 0xeb,
+	0x0d, 0x84, 0x5f, 0x08, 0x9c, 0xa4, 0xbc, 0xc2, 0x1a, 0x50, 0x9b, 0xa5, 0x35, 0xb9, 0xde, 0xd0,
+	0x73, 0x70, 0x6e, 0x40, 0x0f, 0xc7, 0x47, 0xb7, 0x98, 0x9e, 0x0b, 0xd4, 0xd4, 0xb2, 0x39, 0x37,
+	0x97, 0xe7, 0xa9, 0x23, 0xb6, 0x5b, 0xc0, 0x11, 0xec, 0xa1, 0xa5, 0xa6, 0xc3, 0xfe, 0x5e, 0x53,
+	0x4f, 0x30, 0x9c, 0xc2, 0xe3, 0x7f, 0x1b, 0x42, 0xad, 0x24, 0xf2, 0x6d, 0x1c, 0x3d, 0x80, 0x1d,
+	0xa9, 0x0a, 0x8e, 0x9e, 0x13, 0xf4, 0xa3, 0xfd, 0xb4, 0x2d, 0xce, 0xde, 0xfd, 0x58, 0xfa, 0xe4,
+	0x7a, 0xe9, 0x93, 0x5f, 0x4b, 0x9f, 0x7c, 0x5d, 0xf9, 0xbd, 0xeb, 0x95, 0xdf, 0xfb, 0xb9, 0xf2,
+	0x7b, 0x1f, 0x5e, 0xce, 0x84, 0x9d, 0x57, 0x79, 0xcc, 0xd4, 0x55, 0xa2, 0x85, 0x9c, 0x31, 0xaa,
+	0x13, 0x2b, 0x58, 0xc1, 0x92, 0xff, 0x3c, 0x8d, 0x7c, 0xb7, 0xf9, 0xcb, 0x5f, 0xfd, 0x1e, 0x00,
+	0x85, 0x35, 0xae, 0x86, 0x3c, 0x03, 0x00, 0x00,
+}
+
+func (m *EventStoreHeartbeatRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventStoreHeartbeatRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventStoreHeartbeatRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.FullState {
+		i--
+		if m.FullState {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SubscriptionState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscriptionState) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscriptionState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ResolvedTs != 0 {
+		i = encodeVarintLogservice(dAtA, i, uint64(m.ResolvedTs))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.CheckpointTs != 0 {
+		i = encodeVarintLogservice(dAtA, i, uint64(m.CheckpointTs))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Span != nil {
+		{
+			size, err := m.Span.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLogservice(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.SubId != 0 {
+		i = encodeVarintLogservice(dAtA, i, uint64(m.SubId))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EventStoreState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventStoreState) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventStoreState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.RemovedSubIds) > 0 {
+		dAtA3 := make([]byte, len(m.RemovedSubIds)*10)
+		var j2 int
+		for _, num := range m.RemovedSubIds {
+			for num >= 1<<7 {
+				dAtA3[j2] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j2++
+			}
+			dAtA3[j2] = uint8(num)
+			j2++
+		}
+		i -= j2
+		copy(dAtA[i:], dAtA3[:j2])
+		i = encodeVarintLogservice(dAtA, i, uint64(j2))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.FullState {
+		i--
+		if m.FullState {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Subscriptions) > 0 {
+		for iNdEx := len(m.Subscriptions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Subscriptions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLogservice(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LogCoordinatorBroadcastRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogCoordinatorBroadcastRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogCoordinatorBroadcastRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *ReusableEventServiceRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReusableEventServiceRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReusableEventServiceRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.StartTs != 0 {
+		i = encodeVarintLogservice(dAtA, i, uint64(m.StartTs))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Span != nil {
+		{
+			size, err := m.Span.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLogservice(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Id != nil {
+		{
+			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLogservice(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReusableEventServiceResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReusableEventServiceResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReusableEventServiceResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Nodes) > 0 {
+		for iNdEx := len(m.Nodes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Nodes[iNdEx])
+			copy(dAtA[i:], m.Nodes[iNdEx])
+			i = encodeVarintLogservice(dAtA, i, uint64(len(m.Nodes[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Id != nil {
+		{
+			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLogservice(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintLogservice(dAtA []byte, offset int, v uint64) int {
+	offset -= sovLogservice(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *EventStoreHeartbeatRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FullState {
+		n += 2
+	}
+	return n
+}
+
+func (m *SubscriptionState) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SubId != 0 {
+		n += 1 + sovLogservice(uint64(m.SubId))
+	}
+	if m.Span != nil {
+		l = m.Span.Size()
+		n += 1 + l + sovLogservice(uint64(l))
+	}
+	if m.CheckpointTs != 0 {
+		n += 1 + sovLogservice(uint64(m.CheckpointTs))
+	}
+	if m.ResolvedTs != 0 {
+		n += 1 + sovLogservice(uint64(m.ResolvedTs))
+	}
+	return n
+}
+
+func (m *EventStoreState) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Subscriptions) > 0 {
+		for _, e := range m.Subscriptions {
+			l = e.Size()
+			n += 1 + l + sovLogservice(uint64(l))
+		}
+	}
+	if m.FullState {
+		n += 2
+	}
+	if len(m.RemovedSubIds) > 0 {
+		l = 0
+		for _, e := range m.RemovedSubIds {
+			l += sovLogservice(uint64(e))
+		}
+		n += 1 + sovLogservice(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *LogCoordinatorBroadcastRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ReusableEventServiceRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovLogservice(uint64(l))
+	}
+	if m.Span != nil {
+		l = m.Span.Size()
+		n += 1 + l + sovLogservice(uint64(l))
+	}
+	if m.StartTs != 0 {
+		n += 1 + sovLogservice(uint64(m.StartTs))
+	}
+	return n
+}
+
+func (m *ReusableEventServiceResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovLogservice(uint64(l))
+	}
+	if len(m.Nodes) > 0 {
+		for _, s := range m.Nodes {
+			l = len(s)
+			n += 1 + l + sovLogservice(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovLogservice(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozLogservice(x uint64) (n int) {
+	return sovLogservice(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *EventStoreHeartbeatRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogservice
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EventStoreHeartbeatRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EventStoreHeartbeatRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FullState", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.FullState = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogservice(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubscriptionState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogservice
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscriptionState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscriptionState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SubId", wireType)
+			}
+			m.SubId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SubId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Span", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Span == nil {
+				m.Span = &heartbeatpb.TableSpan{}
+			}
+			if err := m.Span.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointTs", wireType)
+			}
+			m.CheckpointTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CheckpointTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResolvedTs", wireType)
+			}
+			m.ResolvedTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResolvedTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogservice(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EventStoreState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogservice
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EventStoreState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EventStoreState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subscriptions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subscriptions = append(m.Subscriptions, &SubscriptionState{})
+			if err := m.Subscriptions[len(m.Subscriptions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FullState", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.FullState = bool(v != 0)
+		case 3:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowLogservice
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.RemovedSubIds = append(m.RemovedSubIds, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowLogservice
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthLogservice
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthLogservice
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.RemovedSubIds) == 0 {
+					m.RemovedSubIds = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowLogservice
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.RemovedSubIds = append(m.RemovedSubIds, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemovedSubIds", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogservice(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LogCoordinatorBroadcastRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogservice
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogCoordinatorBroadcastRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogCoordinatorBroadcastRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogservice(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReusableEventServiceRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogservice
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReusableEventServiceRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReusableEventServiceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Id == nil {
+				m.Id = &heartbeatpb.DispatcherID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Span", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Span == nil {
+				m.Span = &heartbeatpb.TableSpan{}
+			}
+			if err := m.Span.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTs", wireType)
+			}
+			m.StartTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogservice(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReusableEventServiceResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogservice
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReusableEventServiceResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReusableEventServiceResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Id == nil {
+				m.Id = &heartbeatpb.DispatcherID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nodes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Nodes = append(m.Nodes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogservice(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipLogservice(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowLogservice
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthLogservice
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupLogservice
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthLogservice
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthLogservice        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowLogservice          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupLogservice = fmt.Errorf("proto: unexpected end of group")
+)
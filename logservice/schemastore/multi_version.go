@@ -196,8 +196,12 @@ func (v *versionedTableInfoStore) applyDDLFromPersistStorage(event *PersistedDDL
 func (v *versionedTableInfoStore) applyDDL(event *PersistedDDLEvent) {
 	v.mu.Lock()
 	defer v.mu.Unlock()
-	// delete table should not receive more ddl
-	assertNonDeleted(v)
+	// delete table should not receive more ddl except recover table/schema
+	switch model.ActionType(event.Type) {
+	case model.ActionRecoverTable, model.ActionRecoverSchema:
+	default:
+		assertNonDeleted(v)
+	}
 
 	if !v.initialized {
 		// The usage of the parameter `event` may outlive the function call, so we copy it.
@@ -237,10 +241,15 @@ func (v *versionedTableInfoStore) doApplyDDL(event *PersistedDDLEvent) {
 		}
 		assertEmpty(v.infos, event)
 		appendTableInfo()
-	case model.ActionDropTable:
+	case model.ActionDropTable,
+		model.ActionDropSchema:
 		v.deleteVersion = uint64(event.FinishedTs)
+	case model.ActionRecoverTable:
+		v.deleteVersion = math.MaxUint64
+		appendTableInfo()
 	case model.ActionAddColumn,
-		model.ActionDropColumn:
+		model.ActionDropColumn,
+		model.ActionMultiSchemaChange:
 		assertNonEmpty(v.infos, event)
 		appendTableInfo()
 	case model.ActionTruncateTable:
@@ -287,7 +296,8 @@ func (v *versionedTableInfoStore) doApplyDDL(event *PersistedDDLEvent) {
 				break
 			}
 		}
-	case model.ActionCreateView:
+	case model.ActionCreateView,
+		model.ActionFlashbackCluster:
 		// create view is add to all table's ddl history, so it will be read when build store, just ignore it
 	case model.ActionTruncateTablePartition:
 		physicalIDs := getAllPartitionIDs(event.TableInfo)
@@ -366,6 +376,46 @@ func (v *versionedTableInfoStore) doApplyDDL(event *PersistedDDLEvent) {
 				}
 			}
 		}
+	case model.ActionRecoverSchema:
+		for _, tableInfo := range event.MultipleTableInfos {
+			if containsPhysicalID(tableInfo, v.tableID) {
+				v.deleteVersion = math.MaxUint64
+				info := common.WrapTableInfo(event.CurrentSchemaID, event.CurrentSchemaName, tableInfo)
+				info.InitPreSQLs()
+				v.infos = append(v.infos, &tableInfoItem{version: uint64(event.FinishedTs), info: info})
+				break
+			}
+		}
+	case model.ActionRenameTables:
+		assertNonEmpty(v.infos, event)
+		for i, tableInfo := range event.MultipleTableInfos {
+			if containsPhysicalID(tableInfo, v.tableID) {
+				info := common.WrapTableInfo(event.CurrentSchemaIDs[i], event.CurrentSchemaNames[i], tableInfo)
+				info.InitPreSQLs()
+				v.infos = append(v.infos, &tableInfoItem{version: uint64(event.FinishedTs), info: info})
+				break
+			}
+		}
+	case model.ActionAlterTablePartitioning,
+		model.ActionRemovePartitioning:
+		prevPhysicalIDs := getPrevPhysicalIDs(event)
+		currentPhysicalIDs := getCurrentPhysicalIDs(event)
+		dropped := false
+		for _, id := range getDroppedIDs(prevPhysicalIDs, currentPhysicalIDs) {
+			if v.tableID == id {
+				v.deleteVersion = uint64(event.FinishedTs)
+				dropped = true
+				break
+			}
+		}
+		if !dropped {
+			for _, id := range getCreatedIDs(prevPhysicalIDs, currentPhysicalIDs) {
+				if v.tableID == id {
+					appendTableInfo()
+					break
+				}
+			}
+		}
 	default:
 		log.Panic("not supported ddl type",
 			zap.Any("ddlType", event.Type),
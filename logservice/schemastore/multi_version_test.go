@@ -18,6 +18,7 @@ import (
 
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/tidb/pkg/meta/model"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/stretchr/testify/require"
 )
 
@@ -35,7 +36,7 @@ func TestCreateTruncateAndDropTable(t *testing.T) {
 			CurrentTableName:  "t",
 			TableInfo: &model.TableInfo{
 				ID:   tableID1,
-				Name: model.NewCIStr("t"),
+				Name: pmodel.NewCIStr("t"),
 			},
 			FinishedTs: createVersion,
 		}
@@ -56,7 +57,7 @@ func TestCreateTruncateAndDropTable(t *testing.T) {
 			PrevTableID:       tableID1,
 			TableInfo: &model.TableInfo{
 				ID:   tableID1,
-				Name: model.NewCIStr("t"),
+				Name: pmodel.NewCIStr("t"),
 			},
 			FinishedTs: truncateVersion,
 		}
@@ -74,7 +75,7 @@ func TestCreateTruncateAndDropTable(t *testing.T) {
 			CurrentTableName:  "t",
 			TableInfo: &model.TableInfo{
 				ID:   tableID2,
-				Name: model.NewCIStr("t"),
+				Name: pmodel.NewCIStr("t"),
 			},
 			FinishedTs: dropVersion,
 		}
@@ -114,7 +115,7 @@ func TestRenameTable(t *testing.T) {
 			CurrentTableName:  "t",
 			TableInfo: &model.TableInfo{
 				ID:   tableID,
-				Name: model.NewCIStr("t"),
+				Name: pmodel.NewCIStr("t"),
 			},
 			FinishedTs: createVersion,
 		}
@@ -135,7 +136,7 @@ func TestRenameTable(t *testing.T) {
 			PrevTableName:     "t",
 			TableInfo: &model.TableInfo{
 				ID:   tableID,
-				Name: model.NewCIStr("t2"),
+				Name: pmodel.NewCIStr("t2"),
 			},
 			FinishedTs: renameVersion,
 		}
@@ -26,6 +26,7 @@ import (
 	"github.com/cockroachdb/pebble/bloom"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/logpuller"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/filter"
@@ -374,9 +375,7 @@ func (p *persistentStorage) fetchTableDDLEvents(tableID int64, tableFilter filte
 	for _, ts := range allTargetTs {
 		rawEvent := readPersistedDDLEvent(storageSnap, ts)
 		// TODO: if ExtraSchemaName and other fields are empty, does it cause any problem?
-		if tableFilter != nil &&
-			tableFilter.ShouldDiscardDDL(model.ActionType(rawEvent.Type), rawEvent.CurrentSchemaName, rawEvent.CurrentTableName) &&
-			tableFilter.ShouldDiscardDDL(model.ActionType(rawEvent.Type), rawEvent.PrevSchemaName, rawEvent.PrevTableName) {
+		if shouldDiscardDDL(&rawEvent, tableFilter) {
 			continue
 		}
 		events = append(events, buildDDLEvent(&rawEvent, tableFilter))
@@ -438,24 +437,8 @@ func (p *persistentStorage) fetchTableTriggerDDLEvents(tableFilter filter.Filter
 		}
 		for _, ts := range allTargetTs {
 			rawEvent := readPersistedDDLEvent(storageSnap, ts)
-			if tableFilter != nil {
-				if rawEvent.Type == byte(model.ActionCreateTables) {
-					allFiltered := true
-					for _, tableInfo := range rawEvent.MultipleTableInfos {
-						if !tableFilter.ShouldDiscardDDL(model.ActionType(rawEvent.Type), rawEvent.CurrentSchemaName, tableInfo.Name.O) {
-							allFiltered = false
-							break
-						}
-					}
-					if allFiltered {
-						continue
-					}
-				} else {
-					if tableFilter.ShouldDiscardDDL(model.ActionType(rawEvent.Type), rawEvent.CurrentSchemaName, rawEvent.CurrentTableName) &&
-						tableFilter.ShouldDiscardDDL(model.ActionType(rawEvent.Type), rawEvent.PrevSchemaName, rawEvent.PrevTableName) {
-						continue
-					}
-				}
+			if shouldDiscardDDL(&rawEvent, tableFilter) {
+				continue
 			}
 			events = append(events, buildDDLEvent(&rawEvent, tableFilter))
 		}
@@ -466,6 +449,37 @@ func (p *persistentStorage) fetchTableTriggerDDLEvents(tableFilter filter.Filter
 	}
 }
 
+// shouldDiscardDDL returns true if the ddl doesn't involve any table replicated by the changefeed.
+func shouldDiscardDDL(rawEvent *PersistedDDLEvent, tableFilter filter.Filter) bool {
+	if tableFilter == nil {
+		return false
+	}
+	ddlType := model.ActionType(rawEvent.Type)
+	switch ddlType {
+	case model.ActionCreateTables:
+		for _, tableInfo := range rawEvent.MultipleTableInfos {
+			if !tableFilter.ShouldDiscardDDL(ddlType, rawEvent.CurrentSchemaName, tableInfo.Name.O) {
+				return false
+			}
+		}
+		return true
+	case model.ActionRenameTables:
+		for i, tableInfo := range rawEvent.MultipleTableInfos {
+			if !tableFilter.ShouldDiscardDDL(ddlType, rawEvent.PrevSchemaNames[i], rawEvent.PrevTableNames[i]) ||
+				!tableFilter.ShouldDiscardDDL(ddlType, rawEvent.CurrentSchemaNames[i], tableInfo.Name.O) {
+				return false
+			}
+		}
+		return true
+	case model.ActionRecoverSchema:
+		// Note: recover schema is not a ddl type known by the filter, so only check the schema name
+		return tableFilter.ShouldIgnoreSchema(rawEvent.CurrentSchemaName)
+	default:
+		return tableFilter.ShouldDiscardDDL(ddlType, rawEvent.CurrentSchemaName, rawEvent.CurrentTableName) &&
+			tableFilter.ShouldDiscardDDL(ddlType, rawEvent.PrevSchemaName, rawEvent.PrevTableName)
+	}
+}
+
 func (p *persistentStorage) buildVersionedTableInfoStore(
 	store *versionedTableInfoStore,
 ) error {
@@ -648,6 +662,10 @@ func (p *persistentStorage) handleDDLJob(job *model.Job) error {
 	}
 
 	p.mu.Unlock()
+
+	if model.ActionType(ddlEvent.Type) == model.ActionRecoverSchema && ddlEvent.MultipleTableInfos == nil {
+		ddlEvent.MultipleTableInfos = p.loadRecoveredTables(ddlEvent.CurrentSchemaID, ddlEvent.FinishedTs)
+	}
 	// log.Info("handle resolved ddl event",
 	// 	zap.Int64("schemaID", ddlEvent.CurrentSchemaID),
 	// 	zap.Int64("tableID", ddlEvent.CurrentTableID),
@@ -686,6 +704,27 @@ func (p *persistentStorage) handleDDLJob(job *model.Job) error {
 	return nil
 }
 
+// loadRecoveredTables reads the tables recovered by recover schema from the kv snapshot.
+// The recovered tables are not in the ddl job if they are loaded when the job is executed.
+func (p *persistentStorage) loadRecoveredTables(schemaID int64, finishedTs uint64) []*model.TableInfo {
+	snapMeta := logpuller.GetSnapshotMeta(p.kvStorage, finishedTs)
+	tableInfos, err := snapMeta.ListTables(schemaID)
+	if err != nil {
+		log.Panic("list recovered tables failed",
+			zap.Int64("schemaID", schemaID),
+			zap.Uint64("finishedTs", finishedTs),
+			zap.Error(err))
+	}
+	result := make([]*model.TableInfo, 0, len(tableInfos))
+	for _, tableInfo := range tableInfos {
+		if tableInfo.IsView() || tableInfo.IsSequence() {
+			continue
+		}
+		result = append(result, tableInfo)
+	}
+	return result
+}
+
 func buildPersistedDDLEventFromJob(
 	job *model.Job,
 	databaseMap map[int64]*BasicDatabaseInfo,
@@ -739,7 +778,8 @@ func buildPersistedDDLEventFromJob(
 			zap.Int64("schemaID", event.CurrentSchemaID),
 			zap.String("schemaName", event.DBInfo.Name.O))
 		event.CurrentSchemaName = event.DBInfo.Name.O
-	case model.ActionCreateTable:
+	case model.ActionCreateTable,
+		model.ActionRecoverTable:
 		event.CurrentSchemaName = getSchemaName(event.CurrentSchemaID)
 		event.CurrentTableName = event.TableInfo.Name.O
 	case model.ActionDropTable,
@@ -763,7 +803,8 @@ func buildPersistedDDLEventFromJob(
 			}
 		}
 	case model.ActionModifyColumn,
-		model.ActionRebaseAutoID:
+		model.ActionRebaseAutoID,
+		model.ActionMultiSchemaChange:
 		event.CurrentSchemaName = getSchemaName(event.CurrentSchemaID)
 		event.CurrentTableName = getTableName(event.CurrentTableID)
 	case model.ActionRenameTable:
@@ -817,6 +858,63 @@ func buildPersistedDDLEventFromJob(
 		for id := range partitionMap[event.CurrentTableID] {
 			event.PrevPartitions = append(event.PrevPartitions, id)
 		}
+	case model.ActionRenameTables:
+		args, err := model.GetRenameTablesArgs(job)
+		if err != nil {
+			log.Panic("decode rename tables args failed",
+				zap.String("DDL", event.Query),
+				zap.Error(err))
+		}
+		event.MultipleTableInfos = job.BinlogInfo.MultipleTableInfos
+		if len(event.MultipleTableInfos) != len(args.RenameTableInfos) {
+			log.Panic("table infos mismatch with rename tables args",
+				zap.String("DDL", event.Query),
+				zap.Int("tableInfoCount", len(event.MultipleTableInfos)),
+				zap.Int("argsCount", len(args.RenameTableInfos)))
+		}
+		// Note: use the names in the args instead of the table map,
+		// because a table may be renamed more than once in one ddl.
+		for _, info := range args.RenameTableInfos {
+			event.PrevSchemaIDs = append(event.PrevSchemaIDs, info.OldSchemaID)
+			event.PrevSchemaNames = append(event.PrevSchemaNames, info.OldSchemaName.O)
+			event.PrevTableNames = append(event.PrevTableNames, info.OldTableName.O)
+			event.CurrentSchemaIDs = append(event.CurrentSchemaIDs, info.NewSchemaID)
+			event.CurrentSchemaNames = append(event.CurrentSchemaNames, getSchemaName(info.NewSchemaID))
+		}
+	case model.ActionRecoverSchema:
+		event.CurrentSchemaName = event.DBInfo.Name.O
+		args, err := model.GetRecoverArgs(job)
+		if err != nil {
+			log.Panic("decode recover schema args failed",
+				zap.String("DDL", event.Query),
+				zap.Error(err))
+		}
+		// if the tables are loaded when the job is executed,
+		// leave MultipleTableInfos nil and the caller should load them.
+		if !args.RecoverInfo.LoadTablesOnExecute {
+			event.MultipleTableInfos = make([]*model.TableInfo, 0, len(args.RecoverTableInfos()))
+			for _, info := range args.RecoverTableInfos() {
+				event.MultipleTableInfos = append(event.MultipleTableInfos, info.TableInfo)
+			}
+		}
+	case model.ActionModifySchemaCharsetAndCollate:
+		event.CurrentSchemaName = getSchemaName(event.CurrentSchemaID)
+	case model.ActionDropView:
+		// Note: views created after the gc ts are not in tableMap
+		event.CurrentSchemaName = getSchemaName(event.CurrentSchemaID)
+		event.CurrentTableName = event.TableInfo.Name.O
+	case model.ActionFlashbackCluster:
+		// ignore
+	case model.ActionAlterTablePartitioning,
+		model.ActionRemovePartitioning:
+		// the logical table id changes after the ddl
+		event.PrevTableID = event.CurrentTableID
+		event.CurrentTableID = event.TableInfo.ID
+		event.CurrentSchemaName = getSchemaName(event.CurrentSchemaID)
+		event.CurrentTableName = getTableName(event.PrevTableID)
+		for id := range partitionMap[event.PrevTableID] {
+			event.PrevPartitions = append(event.PrevPartitions, id)
+		}
 	default:
 		log.Panic("unknown ddl type",
 			zap.Any("ddlType", event.Type),
@@ -832,7 +930,8 @@ func shouldSkipDDL(
 ) bool {
 	switch model.ActionType(event.Type) {
 	// TODO: add some comment to explain why and when we should skip ActionCreateSchema/ActionCreateTable
-	case model.ActionCreateSchema:
+	case model.ActionCreateSchema,
+		model.ActionRecoverSchema:
 		if _, ok := databaseMap[event.CurrentSchemaID]; ok {
 			log.Warn("database already exists. ignore DDL ",
 				zap.String("DDL", event.Query),
@@ -842,7 +941,8 @@ func shouldSkipDDL(
 				zap.Int64("jobSchemaVersion", event.SchemaVersion))
 			return true
 		}
-	case model.ActionCreateTable:
+	case model.ActionCreateTable,
+		model.ActionRecoverTable:
 		// Note: partition table's logical table id is also in tableMap
 		if _, ok := tableMap[event.CurrentTableID]; ok {
 			log.Warn("table already exists. ignore DDL ",
@@ -876,6 +976,39 @@ func getAllPartitionIDs(tableInfo *model.TableInfo) []int64 {
 	return physicalIDs
 }
 
+// getPhysicalIDs returns the partition ids of a partition table, or the table id of a normal table.
+func getPhysicalIDs(tableInfo *model.TableInfo) []int64 {
+	if isPartitionTable(tableInfo) {
+		return getAllPartitionIDs(tableInfo)
+	}
+	return []int64{tableInfo.ID}
+}
+
+func containsPhysicalID(tableInfo *model.TableInfo, physicalID int64) bool {
+	for _, id := range getPhysicalIDs(tableInfo) {
+		if id == physicalID {
+			return true
+		}
+	}
+	return false
+}
+
+// getPrevPhysicalIDs returns the physical ids of the table before alter table partitioning or remove partitioning.
+func getPrevPhysicalIDs(event *PersistedDDLEvent) []int64 {
+	if len(event.PrevPartitions) > 0 {
+		return event.PrevPartitions
+	}
+	return []int64{event.PrevTableID}
+}
+
+// getCurrentPhysicalIDs returns the physical ids of the table after alter table partitioning or remove partitioning.
+func getCurrentPhysicalIDs(event *PersistedDDLEvent) []int64 {
+	if isPartitionTable(event.TableInfo) {
+		return getAllPartitionIDs(event.TableInfo)
+	}
+	return []int64{event.CurrentTableID}
+}
+
 func updateDDLHistory(
 	ddlEvent *PersistedDDLEvent,
 	databaseMap map[int64]*BasicDatabaseInfo,
@@ -893,7 +1026,12 @@ func updateDDLHistory(
 	}
 
 	switch model.ActionType(ddlEvent.Type) {
-	case model.ActionCreateSchema:
+	case model.ActionCreateSchema,
+		model.ActionModifySchemaCharsetAndCollate,
+		model.ActionDropView,
+		model.ActionFlashbackCluster:
+		// Note: flashback cluster is not sent to the downstream,
+		// it is only added to keep the ddl history complete.
 		tableTriggerDDLHistory = append(tableTriggerDDLHistory, ddlEvent.FinishedTs)
 	case model.ActionDropSchema:
 		tableTriggerDDLHistory = append(tableTriggerDDLHistory, ddlEvent.FinishedTs)
@@ -901,7 +1039,8 @@ func updateDDLHistory(
 			appendTableHistory(tableID)
 		}
 	case model.ActionCreateTable,
-		model.ActionDropTable:
+		model.ActionDropTable,
+		model.ActionRecoverTable:
 		tableTriggerDDLHistory = append(tableTriggerDDLHistory, ddlEvent.FinishedTs)
 		// Note: for create table, this ddl event will not be sent to table dispatchers.
 		// add it to ddl history is just for building table info store.
@@ -932,7 +1071,8 @@ func updateDDLHistory(
 			appendTableHistory(ddlEvent.PrevTableID)
 		}
 	case model.ActionModifyColumn,
-		model.ActionRebaseAutoID:
+		model.ActionRebaseAutoID,
+		model.ActionMultiSchemaChange:
 		if isPartitionTable(ddlEvent.TableInfo) {
 			appendPartitionsHistory(getAllPartitionIDs(ddlEvent.TableInfo))
 		} else {
@@ -980,7 +1120,8 @@ func updateDDLHistory(
 		}
 		appendTableHistory(ddlEvent.PrevTableID)
 		appendPartitionsHistory(droppedIDs)
-	case model.ActionCreateTables:
+	case model.ActionCreateTables,
+		model.ActionRecoverSchema:
 		tableTriggerDDLHistory = append(tableTriggerDDLHistory, ddlEvent.FinishedTs)
 		// it won't be send to table dispatchers, just for build version store
 		for _, info := range ddlEvent.MultipleTableInfos {
@@ -996,6 +1137,17 @@ func updateDDLHistory(
 		appendPartitionsHistory(ddlEvent.PrevPartitions)
 		newCreateIDs := getCreatedIDs(ddlEvent.PrevPartitions, getAllPartitionIDs(ddlEvent.TableInfo))
 		appendPartitionsHistory(newCreateIDs)
+	case model.ActionRenameTables:
+		tableTriggerDDLHistory = append(tableTriggerDDLHistory, ddlEvent.FinishedTs)
+		for _, info := range ddlEvent.MultipleTableInfos {
+			appendPartitionsHistory(getPhysicalIDs(info))
+		}
+	case model.ActionAlterTablePartitioning,
+		model.ActionRemovePartitioning:
+		tableTriggerDDLHistory = append(tableTriggerDDLHistory, ddlEvent.FinishedTs)
+		prevPhysicalIDs := getPrevPhysicalIDs(ddlEvent)
+		appendPartitionsHistory(prevPhysicalIDs)
+		appendPartitionsHistory(getCreatedIDs(prevPhysicalIDs, getCurrentPhysicalIDs(ddlEvent)))
 	default:
 		log.Panic("unknown ddl type",
 			zap.Any("ddlType", ddlEvent.Type),
@@ -1052,6 +1204,23 @@ func updateDatabaseInfoAndTableInfo(
 		delete(tableMap, tableID)
 	}
 
+	createTables := func(schemaID int64, tableInfos []*model.TableInfo) {
+		for _, info := range tableInfos {
+			addTableToDB(schemaID, info.ID)
+			tableMap[info.ID] = &BasicTableInfo{
+				SchemaID: schemaID,
+				Name:     info.Name.O,
+			}
+			if isPartitionTable(info) {
+				partitionInfo := make(BasicPartitionInfo)
+				for _, id := range getAllPartitionIDs(info) {
+					partitionInfo[id] = nil
+				}
+				partitionMap[info.ID] = partitionInfo
+			}
+		}
+	}
+
 	switch model.ActionType(event.Type) {
 	case model.ActionCreateSchema:
 		databaseMap[event.CurrentSchemaID] = &BasicDatabaseInfo{
@@ -1065,7 +1234,8 @@ func updateDatabaseInfoAndTableInfo(
 			delete(partitionMap, tableID)
 		}
 		delete(databaseMap, event.CurrentSchemaID)
-	case model.ActionCreateTable:
+	case model.ActionCreateTable,
+		model.ActionRecoverTable:
 		createTable(event.CurrentSchemaID, event.CurrentTableID)
 		if isPartitionTable(event.TableInfo) {
 			partitionInfo := make(BasicPartitionInfo)
@@ -1098,7 +1268,8 @@ func updateDatabaseInfoAndTableInfo(
 			partitionMap[event.CurrentTableID] = partitionInfo
 		}
 	case model.ActionModifyColumn,
-		model.ActionRebaseAutoID:
+		model.ActionRebaseAutoID,
+		model.ActionMultiSchemaChange:
 		// ignore
 	case model.ActionRenameTable:
 		if event.PrevSchemaID != event.CurrentSchemaID {
@@ -1150,21 +1321,7 @@ func updateDatabaseInfoAndTableInfo(
 		if event.MultipleTableInfos == nil {
 			log.Panic("multiple table infos should not be nil")
 		}
-		for _, info := range event.MultipleTableInfos {
-			addTableToDB(event.CurrentSchemaID, info.ID)
-			tableMap[info.ID] = &BasicTableInfo{
-				SchemaID: event.CurrentSchemaID,
-				Name:     info.Name.O,
-			}
-			if isPartitionTable(info) {
-				partitionInfo := make(BasicPartitionInfo)
-				for _, id := range getAllPartitionIDs(info) {
-					partitionInfo[id] = nil
-				}
-				partitionMap[info.ID] = partitionInfo
-			}
-		}
-
+		createTables(event.CurrentSchemaID, event.MultipleTableInfos)
 	case model.ActionReorganizePartition:
 		physicalIDs := getAllPartitionIDs(event.TableInfo)
 		droppedIDs := getDroppedIDs(event.PrevPartitions, physicalIDs)
@@ -1175,6 +1332,41 @@ func updateDatabaseInfoAndTableInfo(
 		for _, id := range newCreatedIDs {
 			partitionMap[event.CurrentTableID][id] = nil
 		}
+	case model.ActionRenameTables:
+		for i, info := range event.MultipleTableInfos {
+			if event.PrevSchemaIDs[i] != event.CurrentSchemaIDs[i] {
+				tableMap[info.ID].SchemaID = event.CurrentSchemaIDs[i]
+				removeTableFromDB(event.PrevSchemaIDs[i], info.ID)
+				addTableToDB(event.CurrentSchemaIDs[i], info.ID)
+			}
+			tableMap[info.ID].Name = info.Name.O
+		}
+	case model.ActionRecoverSchema:
+		databaseMap[event.CurrentSchemaID] = &BasicDatabaseInfo{
+			Name:   event.CurrentSchemaName,
+			Tables: make(map[int64]bool),
+		}
+		createTables(event.CurrentSchemaID, event.MultipleTableInfos)
+	case model.ActionModifySchemaCharsetAndCollate,
+		model.ActionFlashbackCluster:
+		// ignore
+	case model.ActionDropView:
+		// views in the kv snapshot are loaded as tables
+		if _, ok := tableMap[event.CurrentTableID]; ok {
+			dropTable(event.CurrentSchemaID, event.CurrentTableID)
+		}
+	case model.ActionAlterTablePartitioning,
+		model.ActionRemovePartitioning:
+		dropTable(event.CurrentSchemaID, event.PrevTableID)
+		delete(partitionMap, event.PrevTableID)
+		createTable(event.CurrentSchemaID, event.CurrentTableID)
+		if isPartitionTable(event.TableInfo) {
+			partitionInfo := make(BasicPartitionInfo)
+			for _, id := range getAllPartitionIDs(event.TableInfo) {
+				partitionInfo[id] = nil
+			}
+			partitionMap[event.CurrentTableID] = partitionInfo
+		}
 	default:
 		log.Panic("unknown ddl type",
 			zap.Any("ddlType", event.Type),
@@ -1203,6 +1395,14 @@ func updateRegisteredTableInfoStore(
 		}
 	}
 
+	tryApplyDDLToStores := func(physicalIDs []int64) {
+		for _, id := range physicalIDs {
+			if store, ok := tableInfoStoreMap[id]; ok {
+				store.applyDDL(event)
+			}
+		}
+	}
+
 	switch model.ActionType(event.Type) {
 	case model.ActionCreateSchema,
 		model.ActionDropSchema:
@@ -1254,7 +1454,8 @@ func updateRegisteredTableInfoStore(
 					zap.Int64("tableID", event.CurrentTableID))
 			}
 		}
-	case model.ActionModifyColumn:
+	case model.ActionModifyColumn,
+		model.ActionMultiSchemaChange:
 		tryApplyDDLToStore()
 	case model.ActionRebaseAutoID:
 		// TODO: verify can be ignored
@@ -1344,6 +1545,32 @@ func updateRegisteredTableInfoStore(
 					zap.Int64("partitionID", id))
 			}
 		}
+	case model.ActionRecoverTable:
+		// the store of the dropped table may be still registered
+		tryApplyDDLToStore()
+	case model.ActionRecoverSchema:
+		for _, info := range event.MultipleTableInfos {
+			tryApplyDDLToStores(getPhysicalIDs(info))
+		}
+	case model.ActionRenameTables:
+		for _, info := range event.MultipleTableInfos {
+			tryApplyDDLToStores(getPhysicalIDs(info))
+		}
+	case model.ActionModifySchemaCharsetAndCollate,
+		model.ActionDropView,
+		model.ActionFlashbackCluster:
+		// ignore
+	case model.ActionAlterTablePartitioning,
+		model.ActionRemovePartitioning:
+		prevPhysicalIDs := getPrevPhysicalIDs(event)
+		currentPhysicalIDs := getCurrentPhysicalIDs(event)
+		tryApplyDDLToStores(getDroppedIDs(prevPhysicalIDs, currentPhysicalIDs))
+		for _, id := range getCreatedIDs(prevPhysicalIDs, currentPhysicalIDs) {
+			if _, ok := tableInfoStoreMap[id]; ok {
+				log.Panic("newly created tables should not be registered",
+					zap.Int64("tableID", id))
+			}
+		}
 	default:
 		log.Panic("unknown ddl type",
 			zap.Any("ddlType", event.Type),
@@ -1370,10 +1597,14 @@ func getDroppedIDs(oldIDs []int64, newIDs []int64) []int64 {
 }
 
 func buildDDLEvent(rawEvent *PersistedDDLEvent, tableFilter filter.Filter) commonEvent.DDLEvent {
-	wrapTableInfo := common.WrapTableInfo(
-		rawEvent.CurrentSchemaID,
-		rawEvent.CurrentSchemaName,
-		rawEvent.TableInfo)
+	var wrapTableInfo *common.TableInfo
+	// Note: schema ddls and multiple table ddls don't have table info
+	if rawEvent.TableInfo != nil {
+		wrapTableInfo = common.WrapTableInfo(
+			rawEvent.CurrentSchemaID,
+			rawEvent.CurrentSchemaName,
+			rawEvent.TableInfo)
+	}
 
 	ddlEvent := commonEvent.DDLEvent{
 		Type: rawEvent.Type,
@@ -1390,7 +1621,10 @@ func buildDDLEvent(rawEvent *PersistedDDLEvent, tableFilter filter.Filter) commo
 	}
 
 	switch model.ActionType(rawEvent.Type) {
-	case model.ActionCreateSchema:
+	case model.ActionCreateSchema,
+		model.ActionModifySchemaCharsetAndCollate,
+		model.ActionDropView,
+		model.ActionFlashbackCluster:
 		ddlEvent.BlockedTables = &commonEvent.InfluencedTables{
 			InfluenceType: commonEvent.InfluenceTypeNormal,
 			TableIDs:      []int64{heartbeatpb.DDLSpan.TableID},
@@ -1407,7 +1641,8 @@ func buildDDLEvent(rawEvent *PersistedDDLEvent, tableFilter filter.Filter) commo
 		ddlEvent.TableNameChange = &commonEvent.TableNameChange{
 			DropDatabaseName: rawEvent.CurrentSchemaName,
 		}
-	case model.ActionCreateTable:
+	case model.ActionCreateTable,
+		model.ActionRecoverTable:
 		ddlEvent.BlockedTables = &commonEvent.InfluencedTables{
 			InfluenceType: commonEvent.InfluenceTypeNormal,
 			TableIDs:      []int64{heartbeatpb.DDLSpan.TableID},
@@ -1525,6 +1760,12 @@ func buildDDLEvent(rawEvent *PersistedDDLEvent, tableFilter filter.Filter) commo
 			InfluenceType: commonEvent.InfluenceTypeNormal,
 			TableIDs:      []int64{rawEvent.CurrentTableID},
 		}
+	case model.ActionMultiSchemaChange:
+		// all physical tables of a partition table are changed
+		ddlEvent.BlockedTables = &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      getPhysicalIDs(rawEvent.TableInfo),
+		}
 	case model.ActionRenameTable:
 		ignorePrevTable := tableFilter != nil && tableFilter.ShouldIgnoreTable(rawEvent.PrevSchemaName, rawEvent.PrevTableName)
 		ignoreCurrentTable := tableFilter != nil && tableFilter.ShouldIgnoreTable(rawEvent.CurrentSchemaName, rawEvent.CurrentTableName)
@@ -1850,6 +2091,113 @@ func buildDDLEvent(rawEvent *PersistedDDLEvent, tableFilter filter.Filter) commo
 			InfluenceType: commonEvent.InfluenceTypeNormal,
 			TableIDs:      droppedIDs,
 		}
+	case model.ActionAlterTablePartitioning,
+		model.ActionRemovePartitioning:
+		prevPhysicalIDs := getPrevPhysicalIDs(rawEvent)
+		prevPhysicalIDsAndDDLSpanID := make([]int64, 0, len(prevPhysicalIDs)+1)
+		prevPhysicalIDsAndDDLSpanID = append(prevPhysicalIDsAndDDLSpanID, prevPhysicalIDs...)
+		prevPhysicalIDsAndDDLSpanID = append(prevPhysicalIDsAndDDLSpanID, heartbeatpb.DDLSpan.TableID)
+		ddlEvent.BlockedTables = &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      prevPhysicalIDsAndDDLSpanID,
+		}
+		currentPhysicalIDs := getCurrentPhysicalIDs(rawEvent)
+		for _, id := range getCreatedIDs(prevPhysicalIDs, currentPhysicalIDs) {
+			ddlEvent.NeedAddedTables = append(ddlEvent.NeedAddedTables, commonEvent.Table{
+				SchemaID: rawEvent.CurrentSchemaID,
+				TableID:  id,
+			})
+		}
+		ddlEvent.NeedDroppedTables = &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      getDroppedIDs(prevPhysicalIDs, currentPhysicalIDs),
+		}
+	case model.ActionRecoverSchema:
+		ddlEvent.BlockedTables = &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      []int64{heartbeatpb.DDLSpan.TableID},
+		}
+		addName := make([]commonEvent.SchemaTableName, 0, len(rawEvent.MultipleTableInfos))
+		for _, info := range rawEvent.MultipleTableInfos {
+			if tableFilter != nil && tableFilter.ShouldIgnoreTable(rawEvent.CurrentSchemaName, info.Name.O) {
+				continue
+			}
+			for _, id := range getPhysicalIDs(info) {
+				ddlEvent.NeedAddedTables = append(ddlEvent.NeedAddedTables, commonEvent.Table{
+					SchemaID: rawEvent.CurrentSchemaID,
+					TableID:  id,
+				})
+			}
+			addName = append(addName, commonEvent.SchemaTableName{
+				SchemaName: rawEvent.CurrentSchemaName,
+				TableName:  info.Name.O,
+			})
+		}
+		ddlEvent.TableNameChange = &commonEvent.TableNameChange{
+			AddName: addName,
+		}
+	case model.ActionRenameTables:
+		ddlEvent.BlockedTables = &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      []int64{heartbeatpb.DDLSpan.TableID},
+		}
+		ddlEvent.TableNameChange = &commonEvent.TableNameChange{}
+		renameQuerys := make([]string, 0, len(rawEvent.MultipleTableInfos))
+		for i, info := range rawEvent.MultipleTableInfos {
+			prevSchemaName, prevTableName := rawEvent.PrevSchemaNames[i], rawEvent.PrevTableNames[i]
+			currentSchemaName, currentTableName := rawEvent.CurrentSchemaNames[i], info.Name.O
+			ignorePrevTable := tableFilter != nil && tableFilter.ShouldIgnoreTable(prevSchemaName, prevTableName)
+			ignoreCurrentTable := tableFilter != nil && tableFilter.ShouldIgnoreTable(currentSchemaName, currentTableName)
+			physicalIDs := getPhysicalIDs(info)
+			if !ignorePrevTable {
+				ddlEvent.BlockedTables.TableIDs = append(ddlEvent.BlockedTables.TableIDs, physicalIDs...)
+				if !ignoreCurrentTable {
+					if rawEvent.PrevSchemaIDs[i] != rawEvent.CurrentSchemaIDs[i] {
+						for _, id := range physicalIDs {
+							ddlEvent.UpdatedSchemas = append(ddlEvent.UpdatedSchemas, commonEvent.SchemaIDChange{
+								TableID:     id,
+								OldSchemaID: rawEvent.PrevSchemaIDs[i],
+								NewSchemaID: rawEvent.CurrentSchemaIDs[i],
+							})
+						}
+					}
+				} else {
+					// the table is filtered out after rename table, we need drop the table
+					if ddlEvent.NeedDroppedTables == nil {
+						ddlEvent.NeedDroppedTables = &commonEvent.InfluencedTables{
+							InfluenceType: commonEvent.InfluenceTypeNormal,
+						}
+					}
+					ddlEvent.NeedDroppedTables.TableIDs = append(ddlEvent.NeedDroppedTables.TableIDs, physicalIDs...)
+					ddlEvent.TableNameChange.DropName = append(ddlEvent.TableNameChange.DropName, commonEvent.SchemaTableName{
+						SchemaName: prevSchemaName,
+						TableName:  prevTableName,
+					})
+				}
+			} else if !ignoreCurrentTable {
+				// the table is filtered out before rename table, we need add table here
+				for _, id := range physicalIDs {
+					ddlEvent.NeedAddedTables = append(ddlEvent.NeedAddedTables, commonEvent.Table{
+						SchemaID: rawEvent.CurrentSchemaIDs[i],
+						TableID:  id,
+					})
+				}
+				ddlEvent.TableNameChange.AddName = append(ddlEvent.TableNameChange.AddName, commonEvent.SchemaTableName{
+					SchemaName: currentSchemaName,
+					TableName:  currentTableName,
+				})
+			} else {
+				// the table is filtered out both before and after rename table, skip it
+				continue
+			}
+			renameQuerys = append(renameQuerys, fmt.Sprintf("%s TO %s",
+				common.QuoteSchema(prevSchemaName, prevTableName),
+				common.QuoteSchema(currentSchemaName, currentTableName)))
+		}
+		if len(renameQuerys) != len(rawEvent.MultipleTableInfos) {
+			// some tables are filtered out, only rename the other tables
+			ddlEvent.Query = fmt.Sprintf("RENAME TABLE %s", strings.Join(renameQuerys, ", "))
+		}
 	default:
 		log.Panic("unknown ddl type",
 			zap.Any("ddlType", rawEvent.Type),
@@ -16,6 +16,7 @@ package schemastore
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"testing"
 
@@ -23,9 +24,10 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/tidb/pkg/meta/model"
-	"github.com/pingcap/tiflow/pkg/config"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
@@ -87,7 +89,7 @@ func mockWriteKVSnapOnDisk(db *pebble.DB, snapTs uint64, dbInfos map[int64]*mode
 	defer batch.Close()
 	for _, dbInfo := range dbInfos {
 		writeSchemaInfoToBatch(batch, snapTs, dbInfo)
-		for _, tableInfo := range dbInfo.Tables {
+		for _, tableInfo := range dbInfo.Deprecated.Tables {
 			tableInfoValue, err := json.Marshal(tableInfo)
 			if err != nil {
 				log.Panic("marshal table info fail", zap.Error(err))
@@ -167,12 +169,12 @@ func TestBuildVersionedTableInfoStore(t *testing.T) {
 	databaseInfo := make(map[int64]*model.DBInfo)
 	databaseInfo[schemaID] = &model.DBInfo{
 		ID:   schemaID,
-		Name: model.NewCIStr("test"),
-		Tables: []*model.TableInfo{
-			{
-				ID:   tableID,
-				Name: model.NewCIStr("t1"),
-			},
+		Name: pmodel.NewCIStr("test"),
+	}
+	databaseInfo[schemaID].Deprecated.Tables = []*model.TableInfo{
+		{
+			ID:   tableID,
+			Name: pmodel.NewCIStr("t1"),
 		},
 	}
 	pStorage := newPersistentStorageForTest(dbPath, gcTs, databaseInfo)
@@ -200,7 +202,7 @@ func TestBuildVersionedTableInfoStore(t *testing.T) {
 				SchemaVersion: 3000,
 				TableInfo: &model.TableInfo{
 					ID:   tableID,
-					Name: model.NewCIStr("t2"),
+					Name: pmodel.NewCIStr("t2"),
 				},
 				FinishedTS: renameVersion,
 			},
@@ -221,7 +223,7 @@ func TestBuildVersionedTableInfoStore(t *testing.T) {
 				SchemaVersion: 3500,
 				TableInfo: &model.TableInfo{
 					ID:   tableID2,
-					Name: model.NewCIStr("t3"),
+					Name: pmodel.NewCIStr("t3"),
 				},
 				FinishedTS: createVersion,
 			},
@@ -256,7 +258,7 @@ func TestBuildVersionedTableInfoStore(t *testing.T) {
 			SchemaVersion:   3000,
 			TableInfo: &model.TableInfo{
 				ID:   tableID,
-				Name: model.NewCIStr("t3"),
+				Name: pmodel.NewCIStr("t3"),
 			},
 			FinishedTs: renameVersion2,
 		})
@@ -287,7 +289,7 @@ func TestBuildVersionedTableInfoStore(t *testing.T) {
 				SchemaVersion: 3600,
 				TableInfo: &model.TableInfo{
 					ID:   tableID3,
-					Name: model.NewCIStr("t4"),
+					Name: pmodel.NewCIStr("t4"),
 				},
 				FinishedTS: truncateVersion,
 			},
@@ -325,7 +327,7 @@ func TestBuildVersionedTableInfoStoreWithPartitionTable(t *testing.T) {
 	databaseInfo := make(map[int64]*model.DBInfo)
 	databaseInfo[schemaID] = &model.DBInfo{
 		ID:   schemaID,
-		Name: model.NewCIStr("test"),
+		Name: pmodel.NewCIStr("test"),
 	}
 	pStorage := newPersistentStorageForTest(dbPath, gcTs, databaseInfo)
 
@@ -341,7 +343,7 @@ func TestBuildVersionedTableInfoStoreWithPartitionTable(t *testing.T) {
 				SchemaVersion: 2000,
 				TableInfo: &model.TableInfo{
 					ID:   tableID,
-					Name: model.NewCIStr("t"),
+					Name: pmodel.NewCIStr("t"),
 					Partition: &model.PartitionInfo{
 						Definitions: []model.PartitionDefinition{
 							{
@@ -389,7 +391,7 @@ func TestBuildVersionedTableInfoStoreWithPartitionTable(t *testing.T) {
 				SchemaVersion: 2100,
 				TableInfo: &model.TableInfo{
 					ID:   tableID2,
-					Name: model.NewCIStr("t"),
+					Name: pmodel.NewCIStr("t"),
 					Partition: &model.PartitionInfo{
 						Definitions: []model.PartitionDefinition{
 							{
@@ -447,7 +449,7 @@ func TestHandleCreateDropSchemaTableDDL(t *testing.T) {
 				SchemaVersion: 100,
 				DBInfo: &model.DBInfo{
 					ID:   schemaID,
-					Name: model.NewCIStr("test"),
+					Name: pmodel.NewCIStr("test"),
 				},
 				TableInfo:  nil,
 				FinishedTS: 200,
@@ -471,7 +473,7 @@ func TestHandleCreateDropSchemaTableDDL(t *testing.T) {
 			BinlogInfo: &model.HistoryInfo{
 				SchemaVersion: 101,
 				TableInfo: &model.TableInfo{
-					Name: model.NewCIStr("t1"),
+					Name: pmodel.NewCIStr("t1"),
 				},
 				FinishedTS: 201,
 			},
@@ -496,7 +498,7 @@ func TestHandleCreateDropSchemaTableDDL(t *testing.T) {
 			BinlogInfo: &model.HistoryInfo{
 				SchemaVersion: 103,
 				TableInfo: &model.TableInfo{
-					Name: model.NewCIStr("t2"),
+					Name: pmodel.NewCIStr("t2"),
 				},
 
 				FinishedTS: 203,
@@ -574,7 +576,7 @@ func TestHandleCreateDropSchemaTableDDL(t *testing.T) {
 				SchemaVersion: 200,
 				DBInfo: &model.DBInfo{
 					ID:   schemaID,
-					Name: model.NewCIStr("test"),
+					Name: pmodel.NewCIStr("test"),
 				},
 				TableInfo:  nil,
 				FinishedTS: 300,
@@ -605,7 +607,7 @@ func TestPartitionTableDDL(t *testing.T) {
 	databaseInfo := make(map[int64]*model.DBInfo)
 	databaseInfo[schemaID] = &model.DBInfo{
 		ID:   schemaID,
-		Name: model.NewCIStr("test"),
+		Name: pmodel.NewCIStr("test"),
 	}
 	pStorage := newPersistentStorageForTest(dbPath, gcTs, databaseInfo)
 
@@ -623,7 +625,7 @@ func TestPartitionTableDDL(t *testing.T) {
 				SchemaVersion: 101,
 				TableInfo: &model.TableInfo{
 					ID:   tableID,
-					Name: model.NewCIStr("t"),
+					Name: pmodel.NewCIStr("t"),
 					Partition: &model.PartitionInfo{
 						Definitions: []model.PartitionDefinition{
 							{
@@ -665,7 +667,7 @@ func TestPartitionTableDDL(t *testing.T) {
 				SchemaVersion: 107,
 				TableInfo: &model.TableInfo{
 					ID:   tableID2,
-					Name: model.NewCIStr("t"),
+					Name: pmodel.NewCIStr("t"),
 					Partition: &model.PartitionInfo{
 						Definitions: []model.PartitionDefinition{
 							{
@@ -708,7 +710,7 @@ func TestPartitionTableDDL(t *testing.T) {
 				SchemaVersion: 109,
 				TableInfo: &model.TableInfo{
 					ID:   tableID2,
-					Name: model.NewCIStr("t"),
+					Name: pmodel.NewCIStr("t"),
 					Partition: &model.PartitionInfo{
 						Definitions: []model.PartitionDefinition{
 							{
@@ -748,7 +750,7 @@ func TestPartitionTableDDL(t *testing.T) {
 				SchemaVersion: 111,
 				TableInfo: &model.TableInfo{
 					ID:   tableID2,
-					Name: model.NewCIStr("t"),
+					Name: pmodel.NewCIStr("t"),
 					Partition: &model.PartitionInfo{
 						Definitions: []model.PartitionDefinition{
 							{
@@ -786,7 +788,7 @@ func TestPartitionTableDDL(t *testing.T) {
 				SchemaVersion: 113,
 				TableInfo: &model.TableInfo{
 					ID:   tableID2,
-					Name: model.NewCIStr("t"),
+					Name: pmodel.NewCIStr("t"),
 					Partition: &model.PartitionInfo{
 						Definitions: []model.PartitionDefinition{
 							{
@@ -826,7 +828,7 @@ func TestPartitionTableDDL(t *testing.T) {
 				SchemaVersion: 240,
 				TableInfo: &model.TableInfo{
 					ID:   tableID2,
-					Name: model.NewCIStr("t"),
+					Name: pmodel.NewCIStr("t"),
 					Partition: &model.PartitionInfo{
 						Definitions: []model.PartitionDefinition{
 							{
@@ -975,7 +977,7 @@ func TestPartitionTableDDL(t *testing.T) {
 				SchemaVersion: 255,
 				TableInfo: &model.TableInfo{
 					ID:   tableID + 1000,
-					Name: model.NewCIStr("t100"),
+					Name: pmodel.NewCIStr("t100"),
 					Partition: &model.PartitionInfo{
 						Definitions: []model.PartitionDefinition{
 							{
@@ -999,7 +1001,7 @@ func TestPartitionTableDDL(t *testing.T) {
 				SchemaVersion: 260,
 				DBInfo: &model.DBInfo{
 					ID:   schemaID,
-					Name: model.NewCIStr("test"),
+					Name: pmodel.NewCIStr("test"),
 				},
 				TableInfo:  nil,
 				FinishedTS: 360,
@@ -1022,11 +1024,11 @@ func TestExchangePartitionTable(t *testing.T) {
 	databaseInfo := make(map[int64]*model.DBInfo)
 	databaseInfo[schemaID1] = &model.DBInfo{
 		ID:   schemaID1,
-		Name: model.NewCIStr("test"),
+		Name: pmodel.NewCIStr("test"),
 	}
 	databaseInfo[schemaID2] = &model.DBInfo{
 		ID:   schemaID2,
-		Name: model.NewCIStr("test2"),
+		Name: pmodel.NewCIStr("test2"),
 	}
 	pStorage := newPersistentStorageForTest(dbPath, gcTs, databaseInfo)
 
@@ -1044,7 +1046,7 @@ func TestExchangePartitionTable(t *testing.T) {
 				SchemaVersion: 101,
 				TableInfo: &model.TableInfo{
 					ID:   tableID,
-					Name: model.NewCIStr("t"),
+					Name: pmodel.NewCIStr("t"),
 					Partition: &model.PartitionInfo{
 						Definitions: []model.PartitionDefinition{
 							{
@@ -1076,7 +1078,7 @@ func TestExchangePartitionTable(t *testing.T) {
 				SchemaVersion: 103,
 				TableInfo: &model.TableInfo{
 					ID:   tableID,
-					Name: model.NewCIStr("t2"),
+					Name: pmodel.NewCIStr("t2"),
 				},
 				FinishedTS: 203,
 			},
@@ -1099,7 +1101,7 @@ func TestExchangePartitionTable(t *testing.T) {
 				SchemaVersion: 105,
 				TableInfo: &model.TableInfo{
 					ID:   tableID,
-					Name: model.NewCIStr("t"),
+					Name: pmodel.NewCIStr("t"),
 					Partition: &model.PartitionInfo{
 						Definitions: []model.PartitionDefinition{
 							{
@@ -1207,7 +1209,166 @@ func TestExchangePartitionTable(t *testing.T) {
 }
 
 func TestAlterBetweenPartitionTableAndNonPartitionTable(t *testing.T) {
+	dbPath := fmt.Sprintf("/tmp/testdb-%s", t.Name())
+	err := os.RemoveAll(dbPath)
+	require.Nil(t, err)
+
+	gcTs := uint64(100)
+	schemaID := int64(300)
+	databaseInfo := make(map[int64]*model.DBInfo)
+	databaseInfo[schemaID] = &model.DBInfo{
+		ID:   schemaID,
+		Name: pmodel.NewCIStr("test"),
+	}
+	pStorage := newPersistentStorageForTest(dbPath, gcTs, databaseInfo)
+
+	// create a normal table
+	tableID := int64(100)
+	{
+		job := &model.Job{
+			Type:     model.ActionCreateTable,
+			SchemaID: schemaID,
+			TableID:  tableID,
+			BinlogInfo: &model.HistoryInfo{
+				SchemaVersion: 101,
+				TableInfo: &model.TableInfo{
+					ID:   tableID,
+					Name: pmodel.NewCIStr("t"),
+				},
+				FinishedTS: 201,
+			},
+		}
+		pStorage.handleDDLJob(job)
+	}
+
+	// alter the normal table to a partition table
+	tableID2 := tableID + 100
+	partitionID1 := tableID2 + 100
+	partitionID2 := tableID2 + 200
+	{
+		job := &model.Job{
+			Type:     model.ActionAlterTablePartitioning,
+			SchemaID: schemaID,
+			TableID:  tableID,
+			BinlogInfo: &model.HistoryInfo{
+				SchemaVersion: 103,
+				TableInfo: &model.TableInfo{
+					ID:   tableID2,
+					Name: pmodel.NewCIStr("t"),
+					Partition: &model.PartitionInfo{
+						Definitions: []model.PartitionDefinition{
+							{
+								ID: partitionID1,
+							},
+							{
+								ID: partitionID2,
+							},
+						},
+					},
+				},
+				FinishedTS: 203,
+			},
+		}
+		pStorage.handleDDLJob(job)
+
+		require.Equal(t, 1, len(pStorage.databaseMap[schemaID].Tables))
+		require.Equal(t, 1, len(pStorage.tableMap))
+		require.Equal(t, "t", pStorage.tableMap[tableID2].Name)
+		require.Equal(t, 1, len(pStorage.partitionMap))
+		require.Equal(t, 2, len(pStorage.partitionMap[tableID2]))
+		require.Equal(t, 2, len(pStorage.tablesDDLHistory[tableID]))
+		require.Equal(t, 1, len(pStorage.tablesDDLHistory[partitionID1]))
+		require.Equal(t, 1, len(pStorage.tablesDDLHistory[partitionID2]))
+	}
+
+	// remove partitioning of the partition table
+	tableID3 := tableID2 + 300
+	{
+		job := &model.Job{
+			Type:     model.ActionRemovePartitioning,
+			SchemaID: schemaID,
+			TableID:  tableID2,
+			BinlogInfo: &model.HistoryInfo{
+				SchemaVersion: 105,
+				TableInfo: &model.TableInfo{
+					ID:   tableID3,
+					Name: pmodel.NewCIStr("t"),
+				},
+				FinishedTS: 205,
+			},
+		}
+		pStorage.handleDDLJob(job)
+
+		require.Equal(t, 1, len(pStorage.databaseMap[schemaID].Tables))
+		require.Equal(t, 1, len(pStorage.tableMap))
+		require.Equal(t, "t", pStorage.tableMap[tableID3].Name)
+		require.Equal(t, 0, len(pStorage.partitionMap))
+		require.Equal(t, 2, len(pStorage.tablesDDLHistory[partitionID1]))
+		require.Equal(t, 2, len(pStorage.tablesDDLHistory[partitionID2]))
+		require.Equal(t, 1, len(pStorage.tablesDDLHistory[tableID3]))
+	}
+
+	{
+		store := newEmptyVersionedTableInfoStore(tableID)
+		pStorage.buildVersionedTableInfoStore(store)
+		require.Equal(t, 1, len(store.infos))
+		require.Equal(t, uint64(203), store.deleteVersion)
+	}
+
+	{
+		store := newEmptyVersionedTableInfoStore(partitionID1)
+		pStorage.buildVersionedTableInfoStore(store)
+		require.Equal(t, 1, len(store.infos))
+		require.Equal(t, uint64(203), store.infos[0].version)
+		require.Equal(t, uint64(205), store.deleteVersion)
+	}
+
+	{
+		store := newEmptyVersionedTableInfoStore(tableID3)
+		pStorage.buildVersionedTableInfoStore(store)
+		require.Equal(t, 1, len(store.infos))
+		require.Equal(t, uint64(205), store.infos[0].version)
+		require.Equal(t, "t", store.infos[0].info.Name.O)
+	}
+
+	{
+		ddlEvents, err := pStorage.fetchTableDDLEvents(tableID, nil, 201, 300)
+		require.Nil(t, err)
+		require.Equal(t, 1, len(ddlEvents))
+		// alter table partitioning event
+		require.Equal(t, uint64(203), ddlEvents[0].FinishedTs)
+		verifyTableIsBlocked(t, ddlEvents[0], tableID)
+		verifyTableIsBlocked(t, ddlEvents[0], heartbeatpb.DDLSpan.TableID)
+		verifyTableIsDropped(t, ddlEvents[0], tableID)
+		verifyTableIsAdded(t, ddlEvents[0], partitionID1, schemaID)
+		verifyTableIsAdded(t, ddlEvents[0], partitionID2, schemaID)
+	}
+
+	{
+		ddlEvents, err := pStorage.fetchTableDDLEvents(partitionID1, nil, 203, 300)
+		require.Nil(t, err)
+		require.Equal(t, 1, len(ddlEvents))
+		// remove partitioning event
+		require.Equal(t, uint64(205), ddlEvents[0].FinishedTs)
+		verifyTableIsBlocked(t, ddlEvents[0], partitionID1)
+		verifyTableIsBlocked(t, ddlEvents[0], partitionID2)
+		verifyTableIsBlocked(t, ddlEvents[0], heartbeatpb.DDLSpan.TableID)
+		verifyTableIsDropped(t, ddlEvents[0], partitionID1)
+		verifyTableIsDropped(t, ddlEvents[0], partitionID2)
+		verifyTableIsAdded(t, ddlEvents[0], tableID3, schemaID)
+	}
 
+	// the table is filtered out
+	{
+		filterConfig := &config.FilterConfig{
+			Rules: []string{"test.t2"},
+		}
+		tableFilter, err := filter.NewFilter(filterConfig, "", false)
+		require.Nil(t, err)
+		triggerDDLEvents, err := pStorage.fetchTableTriggerDDLEvents(tableFilter, 201, 10)
+		require.Nil(t, err)
+		require.Equal(t, 0, len(triggerDDLEvents))
+	}
 }
 
 func verifyTableIsBlocked(t *testing.T, event commonEvent.DDLEvent, tableID int64) {
@@ -1255,11 +1416,11 @@ func TestHandleRenameTable(t *testing.T) {
 	databaseInfo := make(map[int64]*model.DBInfo)
 	databaseInfo[schemaID1] = &model.DBInfo{
 		ID:   schemaID1,
-		Name: model.NewCIStr("test"),
+		Name: pmodel.NewCIStr("test"),
 	}
 	databaseInfo[schemaID2] = &model.DBInfo{
 		ID:   schemaID2,
-		Name: model.NewCIStr("test2"),
+		Name: pmodel.NewCIStr("test2"),
 	}
 	pStorage := newPersistentStorageForTest(dbPath, gcTs, databaseInfo)
 
@@ -1274,7 +1435,7 @@ func TestHandleRenameTable(t *testing.T) {
 				SchemaVersion: 501,
 				TableInfo: &model.TableInfo{
 					ID:   tableID,
-					Name: model.NewCIStr("t1"),
+					Name: pmodel.NewCIStr("t1"),
 				},
 				FinishedTS: 601,
 			},
@@ -1297,7 +1458,7 @@ func TestHandleRenameTable(t *testing.T) {
 				SchemaVersion: 505,
 				TableInfo: &model.TableInfo{
 					ID:   tableID,
-					Name: model.NewCIStr("t2"),
+					Name: pmodel.NewCIStr("t2"),
 				},
 				FinishedTS: 605,
 			},
@@ -1392,11 +1553,11 @@ func TestHandleRenamePartitionTable(t *testing.T) {
 	databaseInfo := make(map[int64]*model.DBInfo)
 	databaseInfo[schemaID1] = &model.DBInfo{
 		ID:   schemaID1,
-		Name: model.NewCIStr("test"),
+		Name: pmodel.NewCIStr("test"),
 	}
 	databaseInfo[schemaID2] = &model.DBInfo{
 		ID:   schemaID2,
-		Name: model.NewCIStr("test2"),
+		Name: pmodel.NewCIStr("test2"),
 	}
 	pStorage := newPersistentStorageForTest(dbPath, gcTs, databaseInfo)
 
@@ -1414,7 +1575,7 @@ func TestHandleRenamePartitionTable(t *testing.T) {
 				SchemaVersion: 501,
 				TableInfo: &model.TableInfo{
 					ID:   tableID,
-					Name: model.NewCIStr("t1"),
+					Name: pmodel.NewCIStr("t1"),
 					Partition: &model.PartitionInfo{
 						Definitions: []model.PartitionDefinition{
 							{
@@ -1445,7 +1606,7 @@ func TestHandleRenamePartitionTable(t *testing.T) {
 				SchemaVersion: 505,
 				TableInfo: &model.TableInfo{
 					ID:   tableID,
-					Name: model.NewCIStr("t2"),
+					Name: pmodel.NewCIStr("t2"),
 					Partition: &model.PartitionInfo{
 						Definitions: []model.PartitionDefinition{
 							{
@@ -1540,7 +1701,7 @@ func TestCreateTables(t *testing.T) {
 	databaseInfo := make(map[int64]*model.DBInfo)
 	databaseInfo[schemaID] = &model.DBInfo{
 		ID:   schemaID,
-		Name: model.NewCIStr("test"),
+		Name: pmodel.NewCIStr("test"),
 	}
 	pStorage := newPersistentStorageForTest(dbPath, gcTs, databaseInfo)
 
@@ -1558,15 +1719,15 @@ func TestCreateTables(t *testing.T) {
 				MultipleTableInfos: []*model.TableInfo{
 					{
 						ID:   tableID1,
-						Name: model.NewCIStr("t1"),
+						Name: pmodel.NewCIStr("t1"),
 					},
 					{
 						ID:   tableID2,
-						Name: model.NewCIStr("t2"),
+						Name: pmodel.NewCIStr("t2"),
 					},
 					{
 						ID:   tableID3,
-						Name: model.NewCIStr("t3"),
+						Name: pmodel.NewCIStr("t3"),
 					},
 				},
 				FinishedTS: 601,
@@ -1650,7 +1811,7 @@ func TestCreateTablesForPartitionTable(t *testing.T) {
 	databaseInfo := make(map[int64]*model.DBInfo)
 	databaseInfo[schemaID] = &model.DBInfo{
 		ID:   schemaID,
-		Name: model.NewCIStr("test"),
+		Name: pmodel.NewCIStr("test"),
 	}
 	pStorage := newPersistentStorageForTest(dbPath, gcTs, databaseInfo)
 
@@ -1674,7 +1835,7 @@ func TestCreateTablesForPartitionTable(t *testing.T) {
 				MultipleTableInfos: []*model.TableInfo{
 					{
 						ID:   tableID1,
-						Name: model.NewCIStr("t1"),
+						Name: pmodel.NewCIStr("t1"),
 						Partition: &model.PartitionInfo{
 							Definitions: []model.PartitionDefinition{
 								{
@@ -1688,7 +1849,7 @@ func TestCreateTablesForPartitionTable(t *testing.T) {
 					},
 					{
 						ID:   tableID2,
-						Name: model.NewCIStr("t2"),
+						Name: pmodel.NewCIStr("t2"),
 						Partition: &model.PartitionInfo{
 							Definitions: []model.PartitionDefinition{
 								{
@@ -1702,7 +1863,7 @@ func TestCreateTablesForPartitionTable(t *testing.T) {
 					},
 					{
 						ID:   tableID3,
-						Name: model.NewCIStr("t3"),
+						Name: pmodel.NewCIStr("t3"),
 						Partition: &model.PartitionInfo{
 							Definitions: []model.PartitionDefinition{
 								{
@@ -1828,7 +1989,7 @@ func TestFetchDDLEventsBasic(t *testing.T) {
 				SchemaVersion: 100,
 				DBInfo: &model.DBInfo{
 					ID:   schemaID,
-					Name: model.NewCIStr(schemaName),
+					Name: pmodel.NewCIStr(schemaName),
 				},
 				TableInfo:  nil,
 				FinishedTS: 200,
@@ -1848,7 +2009,7 @@ func TestFetchDDLEventsBasic(t *testing.T) {
 				SchemaVersion: 501,
 				TableInfo: &model.TableInfo{
 					ID:   tableID,
-					Name: model.NewCIStr("t1"),
+					Name: pmodel.NewCIStr("t1"),
 				},
 				FinishedTS: 601,
 			},
@@ -1866,7 +2027,7 @@ func TestFetchDDLEventsBasic(t *testing.T) {
 				SchemaVersion: 505,
 				TableInfo: &model.TableInfo{
 					ID:   tableID,
-					Name: model.NewCIStr("t2"),
+					Name: pmodel.NewCIStr("t2"),
 				},
 				FinishedTS: 605,
 			},
@@ -1885,7 +2046,7 @@ func TestFetchDDLEventsBasic(t *testing.T) {
 				SchemaVersion: 507,
 				TableInfo: &model.TableInfo{
 					ID:   tableID2,
-					Name: model.NewCIStr("t2"),
+					Name: pmodel.NewCIStr("t2"),
 				},
 				FinishedTS: 607,
 			},
@@ -1904,7 +2065,7 @@ func TestFetchDDLEventsBasic(t *testing.T) {
 				SchemaVersion: 509,
 				TableInfo: &model.TableInfo{
 					ID:   tableID,
-					Name: model.NewCIStr("t3"),
+					Name: pmodel.NewCIStr("t3"),
 				},
 				FinishedTS: 609,
 			},
@@ -1922,7 +2083,7 @@ func TestFetchDDLEventsBasic(t *testing.T) {
 				SchemaVersion: 511,
 				TableInfo: &model.TableInfo{
 					ID:   tableID,
-					Name: model.NewCIStr("t3"),
+					Name: pmodel.NewCIStr("t3"),
 				},
 				FinishedTS: 611,
 			},
@@ -1939,7 +2100,7 @@ func TestFetchDDLEventsBasic(t *testing.T) {
 				SchemaVersion: 600,
 				DBInfo: &model.DBInfo{
 					ID:   schemaID,
-					Name: model.NewCIStr(schemaName),
+					Name: pmodel.NewCIStr(schemaName),
 				},
 				TableInfo:  nil,
 				FinishedTS: 700,
@@ -2046,16 +2207,16 @@ func TestGCPersistStorage(t *testing.T) {
 	databaseInfo := make(map[int64]*model.DBInfo)
 	databaseInfo[schemaID] = &model.DBInfo{
 		ID:   schemaID,
-		Name: model.NewCIStr("test"),
-		Tables: []*model.TableInfo{
-			{
-				ID:   tableID1,
-				Name: model.NewCIStr("t1"),
-			},
-			{
-				ID:   tableID2,
-				Name: model.NewCIStr("t2"),
-			},
+		Name: pmodel.NewCIStr("test"),
+	}
+	databaseInfo[schemaID].Deprecated.Tables = []*model.TableInfo{
+		{
+			ID:   tableID1,
+			Name: pmodel.NewCIStr("t1"),
+		},
+		{
+			ID:   tableID2,
+			Name: pmodel.NewCIStr("t2"),
 		},
 	}
 	pStorage := newPersistentStorageForTest(dbPath, gcTs, databaseInfo)
@@ -2071,7 +2232,7 @@ func TestGCPersistStorage(t *testing.T) {
 				SchemaVersion: 501,
 				TableInfo: &model.TableInfo{
 					ID:   tableID3,
-					Name: model.NewCIStr("t3"),
+					Name: pmodel.NewCIStr("t3"),
 				},
 				FinishedTS: 602,
 			},
@@ -2104,7 +2265,7 @@ func TestGCPersistStorage(t *testing.T) {
 				SchemaVersion: 505,
 				TableInfo: &model.TableInfo{
 					ID:   tableID1,
-					Name: model.NewCIStr("t1_r"),
+					Name: pmodel.NewCIStr("t1_r"),
 				},
 				FinishedTS: 605,
 			},
@@ -2128,16 +2289,16 @@ func TestGCPersistStorage(t *testing.T) {
 		databaseInfo := make(map[int64]*model.DBInfo)
 		databaseInfo[schemaID] = &model.DBInfo{
 			ID:   schemaID,
-			Name: model.NewCIStr("test"),
-			Tables: []*model.TableInfo{
-				{
-					ID:   tableID1,
-					Name: model.NewCIStr("t1"),
-				},
-				{
-					ID:   tableID2,
-					Name: model.NewCIStr("t2"),
-				},
+			Name: pmodel.NewCIStr("test"),
+		}
+		databaseInfo[schemaID].Deprecated.Tables = []*model.TableInfo{
+			{
+				ID:   tableID1,
+				Name: pmodel.NewCIStr("t1"),
+			},
+			{
+				ID:   tableID2,
+				Name: pmodel.NewCIStr("t2"),
 			},
 		}
 		mockWriteKVSnapOnDisk(pStorage.db, newGcTs1, databaseInfo)
@@ -2156,16 +2317,16 @@ func TestGCPersistStorage(t *testing.T) {
 		databaseInfo := make(map[int64]*model.DBInfo)
 		databaseInfo[schemaID] = &model.DBInfo{
 			ID:   schemaID,
-			Name: model.NewCIStr("test"),
-			Tables: []*model.TableInfo{
-				{
-					ID:   tableID1,
-					Name: model.NewCIStr("t1"),
-				},
-				{
-					ID:   tableID3,
-					Name: model.NewCIStr("t3"),
-				},
+			Name: pmodel.NewCIStr("test"),
+		}
+		databaseInfo[schemaID].Deprecated.Tables = []*model.TableInfo{
+			{
+				ID:   tableID1,
+				Name: pmodel.NewCIStr("t1"),
+			},
+			{
+				ID:   tableID3,
+				Name: pmodel.NewCIStr("t3"),
 			},
 		}
 		mockWriteKVSnapOnDisk(pStorage.db, newGcTs2, databaseInfo)
@@ -2211,16 +2372,16 @@ func TestGetAllPhysicalTables(t *testing.T) {
 	databaseInfo := make(map[int64]*model.DBInfo)
 	databaseInfo[schemaID] = &model.DBInfo{
 		ID:   schemaID,
-		Name: model.NewCIStr("test"),
-		Tables: []*model.TableInfo{
-			{
-				ID:   tableID1,
-				Name: model.NewCIStr("t1"),
-			},
-			{
-				ID:   tableID2,
-				Name: model.NewCIStr("t2"),
-			},
+		Name: pmodel.NewCIStr("test"),
+	}
+	databaseInfo[schemaID].Deprecated.Tables = []*model.TableInfo{
+		{
+			ID:   tableID1,
+			Name: pmodel.NewCIStr("t1"),
+		},
+		{
+			ID:   tableID2,
+			Name: pmodel.NewCIStr("t2"),
 		},
 	}
 	pStorage := newPersistentStorageForTest(dbPath, gcTs, databaseInfo)
@@ -2236,7 +2397,7 @@ func TestGetAllPhysicalTables(t *testing.T) {
 				SchemaVersion: 501,
 				TableInfo: &model.TableInfo{
 					ID:   tableID3,
-					Name: model.NewCIStr("t3"),
+					Name: pmodel.NewCIStr("t3"),
 				},
 
 				FinishedTS: 601,
@@ -2274,7 +2435,7 @@ func TestGetAllPhysicalTables(t *testing.T) {
 				SchemaVersion: 503,
 				TableInfo: &model.TableInfo{
 					ID:   tableID4,
-					Name: model.NewCIStr("t4"),
+					Name: pmodel.NewCIStr("t4"),
 					Partition: &model.PartitionInfo{
 						Definitions: []model.PartitionDefinition{
 							{
@@ -2305,7 +2466,7 @@ func TestGetAllPhysicalTables(t *testing.T) {
 				SchemaVersion: 505,
 				TableInfo: &model.TableInfo{
 					ID:   tableID4,
-					Name: model.NewCIStr("t4"),
+					Name: pmodel.NewCIStr("t4"),
 					Partition: &model.PartitionInfo{
 						Definitions: []model.PartitionDefinition{
 							{
@@ -2362,3 +2523,614 @@ func TestGetAllPhysicalTables(t *testing.T) {
 		require.Equal(t, 2, len(allPhysicalTables))
 	}
 }
+
+func TestHandleRenameTables(t *testing.T) {
+	dbPath := fmt.Sprintf("/tmp/testdb-%s", t.Name())
+	err := os.RemoveAll(dbPath)
+	require.Nil(t, err)
+
+	gcTs := uint64(500)
+	schemaID1 := int64(300)
+	schemaID2 := int64(305)
+
+	databaseInfo := make(map[int64]*model.DBInfo)
+	databaseInfo[schemaID1] = &model.DBInfo{
+		ID:   schemaID1,
+		Name: pmodel.NewCIStr("test"),
+	}
+	databaseInfo[schemaID2] = &model.DBInfo{
+		ID:   schemaID2,
+		Name: pmodel.NewCIStr("test2"),
+	}
+	pStorage := newPersistentStorageForTest(dbPath, gcTs, databaseInfo)
+
+	// create two tables
+	tableID1 := int64(100)
+	tableID2 := int64(200)
+	{
+		job := &model.Job{
+			Type:     model.ActionCreateTables,
+			SchemaID: schemaID1,
+			Query:    "sql1;sql2",
+			BinlogInfo: &model.HistoryInfo{
+				SchemaVersion: 501,
+				MultipleTableInfos: []*model.TableInfo{
+					{
+						ID:   tableID1,
+						Name: pmodel.NewCIStr("t1"),
+					},
+					{
+						ID:   tableID2,
+						Name: pmodel.NewCIStr("t2"),
+					},
+				},
+				FinishedTS: 601,
+			},
+		}
+		pStorage.handleDDLJob(job)
+	}
+
+	// rename t1 to a different db and rename t2 in the same db
+	{
+		job := &model.Job{
+			Type:    model.ActionRenameTables,
+			Version: model.JobVersion2,
+			Query:   "RENAME TABLE test.t1 TO test2.t3, test.t2 TO test.t4",
+			BinlogInfo: &model.HistoryInfo{
+				SchemaVersion: 505,
+				MultipleTableInfos: []*model.TableInfo{
+					{
+						ID:   tableID1,
+						Name: pmodel.NewCIStr("t3"),
+					},
+					{
+						ID:   tableID2,
+						Name: pmodel.NewCIStr("t4"),
+					},
+				},
+				FinishedTS: 605,
+			},
+		}
+		job.FillArgs(&model.RenameTablesArgs{
+			RenameTableInfos: []*model.RenameTableArgs{
+				{
+					OldSchemaID:   schemaID1,
+					OldSchemaName: pmodel.NewCIStr("test"),
+					OldTableName:  pmodel.NewCIStr("t1"),
+					NewSchemaID:   schemaID2,
+					NewTableName:  pmodel.NewCIStr("t3"),
+					TableID:       tableID1,
+				},
+				{
+					OldSchemaID:   schemaID1,
+					OldSchemaName: pmodel.NewCIStr("test"),
+					OldTableName:  pmodel.NewCIStr("t2"),
+					NewSchemaID:   schemaID1,
+					NewTableName:  pmodel.NewCIStr("t4"),
+					TableID:       tableID2,
+				},
+			},
+		})
+		pStorage.handleDDLJob(job)
+		require.Equal(t, 1, len(pStorage.databaseMap[schemaID1].Tables))
+		require.Equal(t, 1, len(pStorage.databaseMap[schemaID2].Tables))
+		require.Equal(t, schemaID2, pStorage.tableMap[tableID1].SchemaID)
+		require.Equal(t, "t3", pStorage.tableMap[tableID1].Name)
+		require.Equal(t, schemaID1, pStorage.tableMap[tableID2].SchemaID)
+		require.Equal(t, "t4", pStorage.tableMap[tableID2].Name)
+		require.Equal(t, 2, len(pStorage.tablesDDLHistory[tableID1]))
+		require.Equal(t, 2, len(pStorage.tablesDDLHistory[tableID2]))
+	}
+
+	{
+		store := newEmptyVersionedTableInfoStore(tableID1)
+		pStorage.buildVersionedTableInfoStore(store)
+		require.Equal(t, 2, len(store.infos))
+		require.Equal(t, "t3", store.infos[1].info.Name.O)
+		require.Equal(t, "test2", store.infos[1].info.TableName.Schema)
+	}
+
+	{
+		ddlEvents, err := pStorage.fetchTableDDLEvents(tableID1, nil, 601, 700)
+		require.Nil(t, err)
+		require.Equal(t, 1, len(ddlEvents))
+		// rename tables event
+		require.Equal(t, uint64(605), ddlEvents[0].FinishedTs)
+		verifyTableIsBlocked(t, ddlEvents[0], tableID1)
+		verifyTableIsBlocked(t, ddlEvents[0], tableID2)
+		verifyTableIsBlocked(t, ddlEvents[0], heartbeatpb.DDLSpan.TableID)
+
+		require.Equal(t, 1, len(ddlEvents[0].UpdatedSchemas))
+		require.Equal(t, tableID1, ddlEvents[0].UpdatedSchemas[0].TableID)
+		require.Equal(t, schemaID1, ddlEvents[0].UpdatedSchemas[0].OldSchemaID)
+		require.Equal(t, schemaID2, ddlEvents[0].UpdatedSchemas[0].NewSchemaID)
+		require.Equal(t, "RENAME TABLE test.t1 TO test2.t3, test.t2 TO test.t4", ddlEvents[0].Query)
+	}
+
+	// test filter: t1 is filtered out after rename
+	{
+		filterConfig := &config.FilterConfig{
+			Rules: []string{"test.*"},
+		}
+		tableFilter, err := filter.NewFilter(filterConfig, "", false)
+		require.Nil(t, err)
+		ddlEvents, err := pStorage.fetchTableDDLEvents(tableID1, tableFilter, 601, 700)
+		require.Nil(t, err)
+		require.Equal(t, 1, len(ddlEvents))
+		verifyTableIsBlocked(t, ddlEvents[0], tableID1)
+		verifyTableIsBlocked(t, ddlEvents[0], tableID2)
+		verifyTableIsDropped(t, ddlEvents[0], tableID1)
+		require.Nil(t, ddlEvents[0].NeedAddedTables)
+		require.Equal(t, 0, len(ddlEvents[0].UpdatedSchemas))
+
+		require.Equal(t, 1, len(ddlEvents[0].TableNameChange.DropName))
+		require.Equal(t, "test", ddlEvents[0].TableNameChange.DropName[0].SchemaName)
+		require.Equal(t, "t1", ddlEvents[0].TableNameChange.DropName[0].TableName)
+	}
+
+	// test filter: t1 is filtered out before rename and t2 is always filtered out
+	{
+		filterConfig := &config.FilterConfig{
+			Rules: []string{"test2.*"},
+		}
+		tableFilter, err := filter.NewFilter(filterConfig, "", false)
+		require.Nil(t, err)
+		triggerDDLEvents, err := pStorage.fetchTableTriggerDDLEvents(tableFilter, 601, 10)
+		require.Nil(t, err)
+		require.Equal(t, 1, len(triggerDDLEvents))
+		require.Equal(t, 1, len(triggerDDLEvents[0].BlockedTables.TableIDs))
+		verifyTableIsBlocked(t, triggerDDLEvents[0], heartbeatpb.DDLSpan.TableID)
+		require.Nil(t, triggerDDLEvents[0].NeedDroppedTables)
+
+		require.Equal(t, 1, len(triggerDDLEvents[0].NeedAddedTables))
+		verifyTableIsAdded(t, triggerDDLEvents[0], tableID1, schemaID2)
+		require.Equal(t, "test2", triggerDDLEvents[0].TableNameChange.AddName[0].SchemaName)
+		require.Equal(t, "t3", triggerDDLEvents[0].TableNameChange.AddName[0].TableName)
+		require.Equal(t, "RENAME TABLE `test`.`t1` TO `test2`.`t3`", triggerDDLEvents[0].Query)
+	}
+
+	// test filter: all tables are always filtered out
+	{
+		filterConfig := &config.FilterConfig{
+			Rules: []string{"test3.*"},
+		}
+		tableFilter, err := filter.NewFilter(filterConfig, "", false)
+		require.Nil(t, err)
+		triggerDDLEvents, err := pStorage.fetchTableTriggerDDLEvents(tableFilter, 601, 10)
+		require.Nil(t, err)
+		require.Equal(t, 0, len(triggerDDLEvents))
+	}
+}
+
+func TestRecoverTable(t *testing.T) {
+	dbPath := fmt.Sprintf("/tmp/testdb-%s", t.Name())
+	err := os.RemoveAll(dbPath)
+	require.Nil(t, err)
+
+	gcTs := uint64(500)
+	schemaID := int64(300)
+	databaseInfo := make(map[int64]*model.DBInfo)
+	databaseInfo[schemaID] = &model.DBInfo{
+		ID:   schemaID,
+		Name: pmodel.NewCIStr("test"),
+	}
+	pStorage := newPersistentStorageForTest(dbPath, gcTs, databaseInfo)
+
+	tableID := int64(100)
+	tableInfo := &model.TableInfo{
+		ID:   tableID,
+		Name: pmodel.NewCIStr("t"),
+	}
+	// create a table
+	{
+		job := &model.Job{
+			Type:     model.ActionCreateTable,
+			SchemaID: schemaID,
+			TableID:  tableID,
+			BinlogInfo: &model.HistoryInfo{
+				SchemaVersion: 501,
+				TableInfo:     tableInfo,
+				FinishedTS:    601,
+			},
+		}
+		pStorage.handleDDLJob(job)
+	}
+
+	// drop the table
+	{
+		job := &model.Job{
+			Type:     model.ActionDropTable,
+			SchemaID: schemaID,
+			TableID:  tableID,
+			BinlogInfo: &model.HistoryInfo{
+				SchemaVersion: 503,
+				TableInfo:     tableInfo,
+				FinishedTS:    603,
+			},
+		}
+		pStorage.handleDDLJob(job)
+		require.Equal(t, 0, len(pStorage.databaseMap[schemaID].Tables))
+		require.Equal(t, 0, len(pStorage.tableMap))
+	}
+
+	// recover the table
+	{
+		job := &model.Job{
+			Type:     model.ActionRecoverTable,
+			SchemaID: schemaID,
+			TableID:  tableID,
+			BinlogInfo: &model.HistoryInfo{
+				SchemaVersion: 505,
+				TableInfo:     tableInfo,
+				FinishedTS:    605,
+			},
+		}
+		pStorage.handleDDLJob(job)
+		require.Equal(t, 1, len(pStorage.databaseMap[schemaID].Tables))
+		require.Equal(t, "t", pStorage.tableMap[tableID].Name)
+		require.Equal(t, 3, len(pStorage.tablesDDLHistory[tableID]))
+		require.Equal(t, 3, len(pStorage.tableTriggerDDLHistory))
+	}
+
+	{
+		store := newEmptyVersionedTableInfoStore(tableID)
+		pStorage.buildVersionedTableInfoStore(store)
+		require.Equal(t, 2, len(store.infos))
+		require.Equal(t, uint64(math.MaxUint64), store.deleteVersion)
+		info, err := store.getTableInfo(605)
+		require.Nil(t, err)
+		require.Equal(t, "t", info.Name.O)
+	}
+
+	{
+		triggerDDLEvents, err := pStorage.fetchTableTriggerDDLEvents(nil, 603, 10)
+		require.Nil(t, err)
+		require.Equal(t, 1, len(triggerDDLEvents))
+		require.Equal(t, uint64(605), triggerDDLEvents[0].FinishedTs)
+		verifyTableIsBlocked(t, triggerDDLEvents[0], heartbeatpb.DDLSpan.TableID)
+		verifyTableIsAdded(t, triggerDDLEvents[0], tableID, schemaID)
+	}
+
+	// the table is filtered out
+	{
+		filterConfig := &config.FilterConfig{
+			Rules: []string{"test.t2"},
+		}
+		tableFilter, err := filter.NewFilter(filterConfig, "", false)
+		require.Nil(t, err)
+		triggerDDLEvents, err := pStorage.fetchTableTriggerDDLEvents(tableFilter, 603, 10)
+		require.Nil(t, err)
+		require.Equal(t, 0, len(triggerDDLEvents))
+	}
+}
+
+func TestRecoverSchema(t *testing.T) {
+	dbPath := fmt.Sprintf("/tmp/testdb-%s", t.Name())
+	err := os.RemoveAll(dbPath)
+	require.Nil(t, err)
+
+	gcTs := uint64(500)
+	schemaID := int64(300)
+	dbInfo := &model.DBInfo{
+		ID:   schemaID,
+		Name: pmodel.NewCIStr("test"),
+	}
+	databaseInfo := make(map[int64]*model.DBInfo)
+	databaseInfo[schemaID] = dbInfo
+	pStorage := newPersistentStorageForTest(dbPath, gcTs, databaseInfo)
+
+	tableID1 := int64(100)
+	tableID2 := int64(200)
+	partitionID1 := tableID2 + 100
+	partitionID2 := tableID2 + 200
+	tableInfo1 := &model.TableInfo{
+		ID:   tableID1,
+		Name: pmodel.NewCIStr("t1"),
+	}
+	tableInfo2 := &model.TableInfo{
+		ID:   tableID2,
+		Name: pmodel.NewCIStr("t2"),
+		Partition: &model.PartitionInfo{
+			Definitions: []model.PartitionDefinition{
+				{
+					ID: partitionID1,
+				},
+				{
+					ID: partitionID2,
+				},
+			},
+		},
+	}
+	// create two tables
+	{
+		job := &model.Job{
+			Type:     model.ActionCreateTables,
+			SchemaID: schemaID,
+			Query:    "sql1;sql2",
+			BinlogInfo: &model.HistoryInfo{
+				SchemaVersion:      501,
+				MultipleTableInfos: []*model.TableInfo{tableInfo1, tableInfo2},
+				FinishedTS:         601,
+			},
+		}
+		pStorage.handleDDLJob(job)
+	}
+
+	// drop the schema
+	{
+		job := &model.Job{
+			Type:     model.ActionDropSchema,
+			SchemaID: schemaID,
+			BinlogInfo: &model.HistoryInfo{
+				SchemaVersion: 503,
+				DBInfo:        dbInfo,
+				FinishedTS:    603,
+			},
+		}
+		pStorage.handleDDLJob(job)
+		require.Equal(t, 0, len(pStorage.databaseMap))
+		require.Equal(t, 0, len(pStorage.tableMap))
+		require.Equal(t, 0, len(pStorage.partitionMap))
+	}
+
+	// recover the schema
+	{
+		job := &model.Job{
+			Type:     model.ActionRecoverSchema,
+			Version:  model.JobVersion2,
+			SchemaID: schemaID,
+			Query:    "RECOVER SCHEMA test",
+			BinlogInfo: &model.HistoryInfo{
+				SchemaVersion: 505,
+				DBInfo:        dbInfo,
+				FinishedTS:    605,
+			},
+		}
+		job.FillArgs(&model.RecoverArgs{
+			RecoverInfo: &model.RecoverSchemaInfo{
+				DBInfo: dbInfo,
+				RecoverTableInfos: []*model.RecoverTableInfo{
+					{
+						SchemaID:  schemaID,
+						TableInfo: tableInfo1,
+					},
+					{
+						SchemaID:  schemaID,
+						TableInfo: tableInfo2,
+					},
+				},
+			},
+		})
+		pStorage.handleDDLJob(job)
+		require.Equal(t, 1, len(pStorage.databaseMap))
+		require.Equal(t, 2, len(pStorage.databaseMap[schemaID].Tables))
+		require.Equal(t, 2, len(pStorage.tableMap))
+		require.Equal(t, 2, len(pStorage.partitionMap[tableID2]))
+		require.Equal(t, 3, len(pStorage.tablesDDLHistory[tableID1]))
+		require.Equal(t, 2, len(pStorage.tablesDDLHistory[partitionID1]))
+		require.Equal(t, 2, len(pStorage.tablesDDLHistory[partitionID2]))
+	}
+
+	{
+		store := newEmptyVersionedTableInfoStore(tableID1)
+		pStorage.buildVersionedTableInfoStore(store)
+		require.Equal(t, 2, len(store.infos))
+		require.Equal(t, uint64(math.MaxUint64), store.deleteVersion)
+		info, err := store.getTableInfo(605)
+		require.Nil(t, err)
+		require.Equal(t, "t1", info.Name.O)
+	}
+
+	{
+		triggerDDLEvents, err := pStorage.fetchTableTriggerDDLEvents(nil, 603, 10)
+		require.Nil(t, err)
+		require.Equal(t, 1, len(triggerDDLEvents))
+		require.Equal(t, uint64(605), triggerDDLEvents[0].FinishedTs)
+		verifyTableIsBlocked(t, triggerDDLEvents[0], heartbeatpb.DDLSpan.TableID)
+		require.Equal(t, 3, len(triggerDDLEvents[0].NeedAddedTables))
+		verifyTableIsAdded(t, triggerDDLEvents[0], tableID1, schemaID)
+		verifyTableIsAdded(t, triggerDDLEvents[0], partitionID1, schemaID)
+		verifyTableIsAdded(t, triggerDDLEvents[0], partitionID2, schemaID)
+		require.Equal(t, 2, len(triggerDDLEvents[0].TableNameChange.AddName))
+	}
+
+	// filter t2
+	{
+		filterConfig := &config.FilterConfig{
+			Rules: []string{"test.t1"},
+		}
+		tableFilter, err := filter.NewFilter(filterConfig, "", false)
+		require.Nil(t, err)
+		triggerDDLEvents, err := pStorage.fetchTableTriggerDDLEvents(tableFilter, 603, 10)
+		require.Nil(t, err)
+		require.Equal(t, 1, len(triggerDDLEvents))
+		require.Equal(t, 1, len(triggerDDLEvents[0].NeedAddedTables))
+		verifyTableIsAdded(t, triggerDDLEvents[0], tableID1, schemaID)
+		require.Equal(t, "t1", triggerDDLEvents[0].TableNameChange.AddName[0].TableName)
+	}
+
+	// the schema is filtered out
+	{
+		filterConfig := &config.FilterConfig{
+			Rules: []string{"test2.*"},
+		}
+		tableFilter, err := filter.NewFilter(filterConfig, "", false)
+		require.Nil(t, err)
+		triggerDDLEvents, err := pStorage.fetchTableTriggerDDLEvents(tableFilter, 603, 10)
+		require.Nil(t, err)
+		require.Equal(t, 0, len(triggerDDLEvents))
+	}
+}
+
+func TestSchemaAndViewDDL(t *testing.T) {
+	dbPath := fmt.Sprintf("/tmp/testdb-%s", t.Name())
+	err := os.RemoveAll(dbPath)
+	require.Nil(t, err)
+
+	gcTs := uint64(500)
+	schemaID := int64(300)
+	databaseInfo := make(map[int64]*model.DBInfo)
+	databaseInfo[schemaID] = &model.DBInfo{
+		ID:   schemaID,
+		Name: pmodel.NewCIStr("test"),
+	}
+	pStorage := newPersistentStorageForTest(dbPath, gcTs, databaseInfo)
+
+	// create a partition table
+	tableID := int64(100)
+	partitionID1 := tableID + 100
+	partitionID2 := tableID + 200
+	{
+		job := &model.Job{
+			Type:     model.ActionCreateTable,
+			SchemaID: schemaID,
+			TableID:  tableID,
+			BinlogInfo: &model.HistoryInfo{
+				SchemaVersion: 501,
+				TableInfo: &model.TableInfo{
+					ID:   tableID,
+					Name: pmodel.NewCIStr("t"),
+					Partition: &model.PartitionInfo{
+						Definitions: []model.PartitionDefinition{
+							{
+								ID: partitionID1,
+							},
+							{
+								ID: partitionID2,
+							},
+						},
+					},
+				},
+				FinishedTS: 601,
+			},
+		}
+		pStorage.handleDDLJob(job)
+	}
+
+	// modify schema charset
+	{
+		job := &model.Job{
+			Type:     model.ActionModifySchemaCharsetAndCollate,
+			SchemaID: schemaID,
+			Query:    "ALTER DATABASE test CHARACTER SET utf8mb4",
+			BinlogInfo: &model.HistoryInfo{
+				SchemaVersion: 503,
+				DBInfo: &model.DBInfo{
+					ID:   schemaID,
+					Name: pmodel.NewCIStr("test"),
+				},
+				FinishedTS: 603,
+			},
+		}
+		pStorage.handleDDLJob(job)
+	}
+
+	// multi schema change on the partition table
+	{
+		job := &model.Job{
+			Type:     model.ActionMultiSchemaChange,
+			SchemaID: schemaID,
+			TableID:  tableID,
+			Query:    "ALTER TABLE t ADD COLUMN a INT, ADD COLUMN b INT",
+			BinlogInfo: &model.HistoryInfo{
+				SchemaVersion: 505,
+				TableInfo: &model.TableInfo{
+					ID:   tableID,
+					Name: pmodel.NewCIStr("t"),
+					Partition: &model.PartitionInfo{
+						Definitions: []model.PartitionDefinition{
+							{
+								ID: partitionID1,
+							},
+							{
+								ID: partitionID2,
+							},
+						},
+					},
+				},
+				FinishedTS: 605,
+			},
+		}
+		pStorage.handleDDLJob(job)
+		require.Equal(t, 2, len(pStorage.tablesDDLHistory[partitionID1]))
+		require.Equal(t, 2, len(pStorage.tablesDDLHistory[partitionID2]))
+	}
+
+	// drop a view
+	{
+		job := &model.Job{
+			Type:     model.ActionDropView,
+			SchemaID: schemaID,
+			TableID:  tableID + 1000,
+			Query:    "DROP VIEW v",
+			BinlogInfo: &model.HistoryInfo{
+				SchemaVersion: 507,
+				TableInfo: &model.TableInfo{
+					ID:   tableID + 1000,
+					Name: pmodel.NewCIStr("v"),
+				},
+				FinishedTS: 607,
+			},
+		}
+		pStorage.handleDDLJob(job)
+	}
+
+	// flashback cluster
+	{
+		job := &model.Job{
+			Type:  model.ActionFlashbackCluster,
+			Query: "FLASHBACK CLUSTER TO TIMESTAMP '2024-01-01 00:00:00'",
+			BinlogInfo: &model.HistoryInfo{
+				SchemaVersion: 509,
+				FinishedTS:    609,
+			},
+		}
+		pStorage.handleDDLJob(job)
+		require.Equal(t, 1, len(pStorage.tableMap))
+		require.Equal(t, 4, len(pStorage.tableTriggerDDLHistory))
+	}
+
+	{
+		store := newEmptyVersionedTableInfoStore(partitionID1)
+		pStorage.buildVersionedTableInfoStore(store)
+		require.Equal(t, 2, len(store.infos))
+	}
+
+	{
+		ddlEvents, err := pStorage.fetchTableDDLEvents(partitionID1, nil, 601, 700)
+		require.Nil(t, err)
+		require.Equal(t, 1, len(ddlEvents))
+		// multi schema change event
+		require.Equal(t, uint64(605), ddlEvents[0].FinishedTs)
+		verifyTableIsBlocked(t, ddlEvents[0], partitionID1)
+		verifyTableIsBlocked(t, ddlEvents[0], partitionID2)
+	}
+
+	{
+		triggerDDLEvents, err := pStorage.fetchTableTriggerDDLEvents(nil, 601, 10)
+		require.Nil(t, err)
+		require.Equal(t, 3, len(triggerDDLEvents))
+		// modify schema charset event
+		require.Equal(t, uint64(603), triggerDDLEvents[0].FinishedTs)
+		verifyTableIsBlocked(t, triggerDDLEvents[0], heartbeatpb.DDLSpan.TableID)
+		require.Equal(t, "test", triggerDDLEvents[0].SchemaName)
+		// drop view event
+		require.Equal(t, uint64(607), triggerDDLEvents[1].FinishedTs)
+		verifyTableIsBlocked(t, triggerDDLEvents[1], heartbeatpb.DDLSpan.TableID)
+		require.Equal(t, "v", triggerDDLEvents[1].TableName)
+		// flashback cluster event
+		require.Equal(t, uint64(609), triggerDDLEvents[2].FinishedTs)
+	}
+
+	// flashback cluster is not sent downstream with a filter
+	{
+		filterConfig := &config.FilterConfig{
+			Rules: []string{"test.*"},
+		}
+		tableFilter, err := filter.NewFilter(filterConfig, "", false)
+		require.Nil(t, err)
+		triggerDDLEvents, err := pStorage.fetchTableTriggerDDLEvents(tableFilter, 601, 10)
+		require.Nil(t, err)
+		require.Equal(t, 2, len(triggerDDLEvents))
+	}
+}
@@ -27,6 +27,14 @@ type PersistedDDLEvent struct {
 	// The following fields are only set when the ddl job involves a partition table
 	PrevPartitions []int64 `msg:"prev_partitions"`
 
+	// The following fields are only set for rename tables,
+	// the i-th element is the info of the table in MultipleTableInfos[i]
+	PrevSchemaIDs      []int64  `msg:"prev_schema_ids"`
+	PrevSchemaNames    []string `msg:"prev_schema_names"`
+	PrevTableNames     []string `msg:"prev_table_names"`
+	CurrentSchemaIDs   []int64  `msg:"current_schema_ids"`
+	CurrentSchemaNames []string `msg:"current_schema_names"`
+
 	Query         string           `msg:"query"`
 	SchemaVersion int64            `msg:"schema_version"`
 	DBInfo        *model.DBInfo    `msg:"-"`
@@ -35,6 +43,8 @@ type PersistedDDLEvent struct {
 	TableInfoValue []byte `msg:"table_info_value"`
 	FinishedTs     uint64 `msg:"finished_ts"`
 
+	// for create tables and rename tables, it is the info of all tables in the ddl
+	// for recover schema, it is the info of all recovered tables
 	MultipleTableInfos      []*model.TableInfo `msg:"-"`
 	MultipleTableInfosValue [][]byte           `msg:"multi_table_info_value"`
 
@@ -103,6 +103,101 @@ func (z *PersistedDDLEvent) DecodeMsg(dc *msgp.Reader) (err error) {
 					return
 				}
 			}
+		case "prev_schema_ids":
+			var zb0003 uint32
+			zb0003, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "PrevSchemaIDs")
+				return
+			}
+			if cap(z.PrevSchemaIDs) >= int(zb0003) {
+				z.PrevSchemaIDs = (z.PrevSchemaIDs)[:zb0003]
+			} else {
+				z.PrevSchemaIDs = make([]int64, zb0003)
+			}
+			for za0002 := range z.PrevSchemaIDs {
+				z.PrevSchemaIDs[za0002], err = dc.ReadInt64()
+				if err != nil {
+					err = msgp.WrapError(err, "PrevSchemaIDs", za0002)
+					return
+				}
+			}
+		case "prev_schema_names":
+			var zb0004 uint32
+			zb0004, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "PrevSchemaNames")
+				return
+			}
+			if cap(z.PrevSchemaNames) >= int(zb0004) {
+				z.PrevSchemaNames = (z.PrevSchemaNames)[:zb0004]
+			} else {
+				z.PrevSchemaNames = make([]string, zb0004)
+			}
+			for za0003 := range z.PrevSchemaNames {
+				z.PrevSchemaNames[za0003], err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "PrevSchemaNames", za0003)
+					return
+				}
+			}
+		case "prev_table_names":
+			var zb0005 uint32
+			zb0005, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "PrevTableNames")
+				return
+			}
+			if cap(z.PrevTableNames) >= int(zb0005) {
+				z.PrevTableNames = (z.PrevTableNames)[:zb0005]
+			} else {
+				z.PrevTableNames = make([]string, zb0005)
+			}
+			for za0004 := range z.PrevTableNames {
+				z.PrevTableNames[za0004], err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "PrevTableNames", za0004)
+					return
+				}
+			}
+		case "current_schema_ids":
+			var zb0006 uint32
+			zb0006, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "CurrentSchemaIDs")
+				return
+			}
+			if cap(z.CurrentSchemaIDs) >= int(zb0006) {
+				z.CurrentSchemaIDs = (z.CurrentSchemaIDs)[:zb0006]
+			} else {
+				z.CurrentSchemaIDs = make([]int64, zb0006)
+			}
+			for za0005 := range z.CurrentSchemaIDs {
+				z.CurrentSchemaIDs[za0005], err = dc.ReadInt64()
+				if err != nil {
+					err = msgp.WrapError(err, "CurrentSchemaIDs", za0005)
+					return
+				}
+			}
+		case "current_schema_names":
+			var zb0007 uint32
+			zb0007, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "CurrentSchemaNames")
+				return
+			}
+			if cap(z.CurrentSchemaNames) >= int(zb0007) {
+				z.CurrentSchemaNames = (z.CurrentSchemaNames)[:zb0007]
+			} else {
+				z.CurrentSchemaNames = make([]string, zb0007)
+			}
+			for za0006 := range z.CurrentSchemaNames {
+				z.CurrentSchemaNames[za0006], err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "CurrentSchemaNames", za0006)
+					return
+				}
+			}
 		case "query":
 			z.Query, err = dc.ReadString()
 			if err != nil {
@@ -128,21 +223,21 @@ func (z *PersistedDDLEvent) DecodeMsg(dc *msgp.Reader) (err error) {
 				return
 			}
 		case "multi_table_info_value":
-			var zb0003 uint32
-			zb0003, err = dc.ReadArrayHeader()
+			var zb0008 uint32
+			zb0008, err = dc.ReadArrayHeader()
 			if err != nil {
 				err = msgp.WrapError(err, "MultipleTableInfosValue")
 				return
 			}
-			if cap(z.MultipleTableInfosValue) >= int(zb0003) {
-				z.MultipleTableInfosValue = (z.MultipleTableInfosValue)[:zb0003]
+			if cap(z.MultipleTableInfosValue) >= int(zb0008) {
+				z.MultipleTableInfosValue = (z.MultipleTableInfosValue)[:zb0008]
 			} else {
-				z.MultipleTableInfosValue = make([][]byte, zb0003)
+				z.MultipleTableInfosValue = make([][]byte, zb0008)
 			}
-			for za0002 := range z.MultipleTableInfosValue {
-				z.MultipleTableInfosValue[za0002], err = dc.ReadBytes(z.MultipleTableInfosValue[za0002])
+			for za0007 := range z.MultipleTableInfosValue {
+				z.MultipleTableInfosValue[za0007], err = dc.ReadBytes(z.MultipleTableInfosValue[za0007])
 				if err != nil {
-					err = msgp.WrapError(err, "MultipleTableInfosValue", za0002)
+					err = msgp.WrapError(err, "MultipleTableInfosValue", za0007)
 					return
 				}
 			}
@@ -171,9 +266,9 @@ func (z *PersistedDDLEvent) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *PersistedDDLEvent) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 18
+	// map header, size 23
 	// write "id"
-	err = en.Append(0xde, 0x0, 0x12, 0xa2, 0x69, 0x64)
+	err = en.Append(0xde, 0x0, 0x17, 0xa2, 0x69, 0x64)
 	if err != nil {
 		return
 	}
@@ -289,6 +384,91 @@ func (z *PersistedDDLEvent) EncodeMsg(en *msgp.Writer) (err error) {
 			return
 		}
 	}
+	// write "prev_schema_ids"
+	err = en.Append(0xaf, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x69, 0x64, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.PrevSchemaIDs)))
+	if err != nil {
+		err = msgp.WrapError(err, "PrevSchemaIDs")
+		return
+	}
+	for za0002 := range z.PrevSchemaIDs {
+		err = en.WriteInt64(z.PrevSchemaIDs[za0002])
+		if err != nil {
+			err = msgp.WrapError(err, "PrevSchemaIDs", za0002)
+			return
+		}
+	}
+	// write "prev_schema_names"
+	err = en.Append(0xb1, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.PrevSchemaNames)))
+	if err != nil {
+		err = msgp.WrapError(err, "PrevSchemaNames")
+		return
+	}
+	for za0003 := range z.PrevSchemaNames {
+		err = en.WriteString(z.PrevSchemaNames[za0003])
+		if err != nil {
+			err = msgp.WrapError(err, "PrevSchemaNames", za0003)
+			return
+		}
+	}
+	// write "prev_table_names"
+	err = en.Append(0xb0, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.PrevTableNames)))
+	if err != nil {
+		err = msgp.WrapError(err, "PrevTableNames")
+		return
+	}
+	for za0004 := range z.PrevTableNames {
+		err = en.WriteString(z.PrevTableNames[za0004])
+		if err != nil {
+			err = msgp.WrapError(err, "PrevTableNames", za0004)
+			return
+		}
+	}
+	// write "current_schema_ids"
+	err = en.Append(0xb2, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x69, 0x64, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.CurrentSchemaIDs)))
+	if err != nil {
+		err = msgp.WrapError(err, "CurrentSchemaIDs")
+		return
+	}
+	for za0005 := range z.CurrentSchemaIDs {
+		err = en.WriteInt64(z.CurrentSchemaIDs[za0005])
+		if err != nil {
+			err = msgp.WrapError(err, "CurrentSchemaIDs", za0005)
+			return
+		}
+	}
+	// write "current_schema_names"
+	err = en.Append(0xb4, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.CurrentSchemaNames)))
+	if err != nil {
+		err = msgp.WrapError(err, "CurrentSchemaNames")
+		return
+	}
+	for za0006 := range z.CurrentSchemaNames {
+		err = en.WriteString(z.CurrentSchemaNames[za0006])
+		if err != nil {
+			err = msgp.WrapError(err, "CurrentSchemaNames", za0006)
+			return
+		}
+	}
 	// write "query"
 	err = en.Append(0xa5, 0x71, 0x75, 0x65, 0x72, 0x79)
 	if err != nil {
@@ -339,10 +519,10 @@ func (z *PersistedDDLEvent) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "MultipleTableInfosValue")
 		return
 	}
-	for za0002 := range z.MultipleTableInfosValue {
-		err = en.WriteBytes(z.MultipleTableInfosValue[za0002])
+	for za0007 := range z.MultipleTableInfosValue {
+		err = en.WriteBytes(z.MultipleTableInfosValue[za0007])
 		if err != nil {
-			err = msgp.WrapError(err, "MultipleTableInfosValue", za0002)
+			err = msgp.WrapError(err, "MultipleTableInfosValue", za0007)
 			return
 		}
 	}
@@ -372,9 +552,9 @@ func (z *PersistedDDLEvent) EncodeMsg(en *msgp.Writer) (err error) {
 // MarshalMsg implements msgp.Marshaler
 func (z *PersistedDDLEvent) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 18
+	// map header, size 23
 	// string "id"
-	o = append(o, 0xde, 0x0, 0x12, 0xa2, 0x69, 0x64)
+	o = append(o, 0xde, 0x0, 0x17, 0xa2, 0x69, 0x64)
 	o = msgp.AppendInt64(o, z.ID)
 	// string "type"
 	o = append(o, 0xa4, 0x74, 0x79, 0x70, 0x65)
@@ -409,6 +589,36 @@ func (z *PersistedDDLEvent) MarshalMsg(b []byte) (o []byte, err error) {
 	for za0001 := range z.PrevPartitions {
 		o = msgp.AppendInt64(o, z.PrevPartitions[za0001])
 	}
+	// string "prev_schema_ids"
+	o = append(o, 0xaf, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x69, 0x64, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.PrevSchemaIDs)))
+	for za0002 := range z.PrevSchemaIDs {
+		o = msgp.AppendInt64(o, z.PrevSchemaIDs[za0002])
+	}
+	// string "prev_schema_names"
+	o = append(o, 0xb1, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.PrevSchemaNames)))
+	for za0003 := range z.PrevSchemaNames {
+		o = msgp.AppendString(o, z.PrevSchemaNames[za0003])
+	}
+	// string "prev_table_names"
+	o = append(o, 0xb0, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.PrevTableNames)))
+	for za0004 := range z.PrevTableNames {
+		o = msgp.AppendString(o, z.PrevTableNames[za0004])
+	}
+	// string "current_schema_ids"
+	o = append(o, 0xb2, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x69, 0x64, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.CurrentSchemaIDs)))
+	for za0005 := range z.CurrentSchemaIDs {
+		o = msgp.AppendInt64(o, z.CurrentSchemaIDs[za0005])
+	}
+	// string "current_schema_names"
+	o = append(o, 0xb4, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.CurrentSchemaNames)))
+	for za0006 := range z.CurrentSchemaNames {
+		o = msgp.AppendString(o, z.CurrentSchemaNames[za0006])
+	}
 	// string "query"
 	o = append(o, 0xa5, 0x71, 0x75, 0x65, 0x72, 0x79)
 	o = msgp.AppendString(o, z.Query)
@@ -424,8 +634,8 @@ func (z *PersistedDDLEvent) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "multi_table_info_value"
 	o = append(o, 0xb6, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65)
 	o = msgp.AppendArrayHeader(o, uint32(len(z.MultipleTableInfosValue)))
-	for za0002 := range z.MultipleTableInfosValue {
-		o = msgp.AppendBytes(o, z.MultipleTableInfosValue[za0002])
+	for za0007 := range z.MultipleTableInfosValue {
+		o = msgp.AppendBytes(o, z.MultipleTableInfosValue[za0007])
 	}
 	// string "bdr_role"
 	o = append(o, 0xa8, 0x62, 0x64, 0x72, 0x5f, 0x72, 0x6f, 0x6c, 0x65)
@@ -533,6 +743,101 @@ func (z *PersistedDDLEvent) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					return
 				}
 			}
+		case "prev_schema_ids":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PrevSchemaIDs")
+				return
+			}
+			if cap(z.PrevSchemaIDs) >= int(zb0003) {
+				z.PrevSchemaIDs = (z.PrevSchemaIDs)[:zb0003]
+			} else {
+				z.PrevSchemaIDs = make([]int64, zb0003)
+			}
+			for za0002 := range z.PrevSchemaIDs {
+				z.PrevSchemaIDs[za0002], bts, err = msgp.ReadInt64Bytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "PrevSchemaIDs", za0002)
+					return
+				}
+			}
+		case "prev_schema_names":
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PrevSchemaNames")
+				return
+			}
+			if cap(z.PrevSchemaNames) >= int(zb0004) {
+				z.PrevSchemaNames = (z.PrevSchemaNames)[:zb0004]
+			} else {
+				z.PrevSchemaNames = make([]string, zb0004)
+			}
+			for za0003 := range z.PrevSchemaNames {
+				z.PrevSchemaNames[za0003], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "PrevSchemaNames", za0003)
+					return
+				}
+			}
+		case "prev_table_names":
+			var zb0005 uint32
+			zb0005, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PrevTableNames")
+				return
+			}
+			if cap(z.PrevTableNames) >= int(zb0005) {
+				z.PrevTableNames = (z.PrevTableNames)[:zb0005]
+			} else {
+				z.PrevTableNames = make([]string, zb0005)
+			}
+			for za0004 := range z.PrevTableNames {
+				z.PrevTableNames[za0004], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "PrevTableNames", za0004)
+					return
+				}
+			}
+		case "current_schema_ids":
+			var zb0006 uint32
+			zb0006, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "CurrentSchemaIDs")
+				return
+			}
+			if cap(z.CurrentSchemaIDs) >= int(zb0006) {
+				z.CurrentSchemaIDs = (z.CurrentSchemaIDs)[:zb0006]
+			} else {
+				z.CurrentSchemaIDs = make([]int64, zb0006)
+			}
+			for za0005 := range z.CurrentSchemaIDs {
+				z.CurrentSchemaIDs[za0005], bts, err = msgp.ReadInt64Bytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "CurrentSchemaIDs", za0005)
+					return
+				}
+			}
+		case "current_schema_names":
+			var zb0007 uint32
+			zb0007, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "CurrentSchemaNames")
+				return
+			}
+			if cap(z.CurrentSchemaNames) >= int(zb0007) {
+				z.CurrentSchemaNames = (z.CurrentSchemaNames)[:zb0007]
+			} else {
+				z.CurrentSchemaNames = make([]string, zb0007)
+			}
+			for za0006 := range z.CurrentSchemaNames {
+				z.CurrentSchemaNames[za0006], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "CurrentSchemaNames", za0006)
+					return
+				}
+			}
 		case "query":
 			z.Query, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
@@ -558,21 +863,21 @@ func (z *PersistedDDLEvent) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				return
 			}
 		case "multi_table_info_value":
-			var zb0003 uint32
-			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			var zb0008 uint32
+			zb0008, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "MultipleTableInfosValue")
 				return
 			}
-			if cap(z.MultipleTableInfosValue) >= int(zb0003) {
-				z.MultipleTableInfosValue = (z.MultipleTableInfosValue)[:zb0003]
+			if cap(z.MultipleTableInfosValue) >= int(zb0008) {
+				z.MultipleTableInfosValue = (z.MultipleTableInfosValue)[:zb0008]
 			} else {
-				z.MultipleTableInfosValue = make([][]byte, zb0003)
+				z.MultipleTableInfosValue = make([][]byte, zb0008)
 			}
-			for za0002 := range z.MultipleTableInfosValue {
-				z.MultipleTableInfosValue[za0002], bts, err = msgp.ReadBytesBytes(bts, z.MultipleTableInfosValue[za0002])
+			for za0007 := range z.MultipleTableInfosValue {
+				z.MultipleTableInfosValue[za0007], bts, err = msgp.ReadBytesBytes(bts, z.MultipleTableInfosValue[za0007])
 				if err != nil {
-					err = msgp.WrapError(err, "MultipleTableInfosValue", za0002)
+					err = msgp.WrapError(err, "MultipleTableInfosValue", za0007)
 					return
 				}
 			}
@@ -602,9 +907,21 @@ func (z *PersistedDDLEvent) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *PersistedDDLEvent) Msgsize() (s int) {
-	s = 3 + 3 + msgp.Int64Size + 5 + msgp.ByteSize + 18 + msgp.Int64Size + 17 + msgp.Int64Size + 20 + msgp.StringPrefixSize + len(z.CurrentSchemaName) + 19 + msgp.StringPrefixSize + len(z.CurrentTableName) + 15 + msgp.Int64Size + 14 + msgp.Int64Size + 17 + msgp.StringPrefixSize + len(z.PrevSchemaName) + 16 + msgp.StringPrefixSize + len(z.PrevTableName) + 16 + msgp.ArrayHeaderSize + (len(z.PrevPartitions) * (msgp.Int64Size)) + 6 + msgp.StringPrefixSize + len(z.Query) + 15 + msgp.Int64Size + 17 + msgp.BytesPrefixSize + len(z.TableInfoValue) + 12 + msgp.Uint64Size + 23 + msgp.ArrayHeaderSize
-	for za0002 := range z.MultipleTableInfosValue {
-		s += msgp.BytesPrefixSize + len(z.MultipleTableInfosValue[za0002])
+	s = 3 + 3 + msgp.Int64Size + 5 + msgp.ByteSize + 18 + msgp.Int64Size + 17 + msgp.Int64Size + 20 + msgp.StringPrefixSize + len(z.CurrentSchemaName) + 19 + msgp.StringPrefixSize + len(z.CurrentTableName) + 15 + msgp.Int64Size + 14 + msgp.Int64Size + 17 + msgp.StringPrefixSize + len(z.PrevSchemaName) + 16 + msgp.StringPrefixSize + len(z.PrevTableName) + 16 + msgp.ArrayHeaderSize + (len(z.PrevPartitions) * (msgp.Int64Size)) + 16 + msgp.ArrayHeaderSize + (len(z.PrevSchemaIDs) * (msgp.Int64Size)) + 18 + msgp.ArrayHeaderSize
+	for za0003 := range z.PrevSchemaNames {
+		s += msgp.StringPrefixSize + len(z.PrevSchemaNames[za0003])
+	}
+	s += 17 + msgp.ArrayHeaderSize
+	for za0004 := range z.PrevTableNames {
+		s += msgp.StringPrefixSize + len(z.PrevTableNames[za0004])
+	}
+	s += 19 + msgp.ArrayHeaderSize + (len(z.CurrentSchemaIDs) * (msgp.Int64Size)) + 21 + msgp.ArrayHeaderSize
+	for za0006 := range z.CurrentSchemaNames {
+		s += msgp.StringPrefixSize + len(z.CurrentSchemaNames[za0006])
+	}
+	s += 6 + msgp.StringPrefixSize + len(z.Query) + 15 + msgp.Int64Size + 17 + msgp.BytesPrefixSize + len(z.TableInfoValue) + 12 + msgp.Uint64Size + 23 + msgp.ArrayHeaderSize
+	for za0007 := range z.MultipleTableInfosValue {
+		s += msgp.BytesPrefixSize + len(z.MultipleTableInfosValue[za0007])
 	}
 	s += 9 + msgp.StringPrefixSize + len(z.BDRRole) + 17 + msgp.Uint64Size
 	return
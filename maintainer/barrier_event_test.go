@@ -27,12 +27,13 @@ import (
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/server/watcher"
+	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/stretchr/testify/require"
 )
 
 func TestScheduleEvent(t *testing.T) {
 	setNodeManagerAndMessageCenter()
-	controller := NewController("test", 1, nil, nil, nil, nil, 1000, 0)
+	controller := NewController("test", 1, nil, nil, nil, nil, newTestDDLSpan(), 1000, 0)
 	controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: 1}, 1)
 	event := NewBlockEvent("test", controller, &heartbeatpb.State{
 		IsBlocked:         true,
@@ -75,7 +76,7 @@ func TestResendAction(t *testing.T) {
 	nodeManager := setNodeManagerAndMessageCenter()
 	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
 
-	controller := NewController("test", 1, nil, nil, nil, nil, 1000, 0)
+	controller := NewController("test", 1, nil, nil, nil, nil, newTestDDLSpan(), 1000, 0)
 	controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: 1}, 1)
 	controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: 2}, 1)
 	var dispatcherIDs []common.DispatcherID
@@ -170,7 +171,7 @@ func TestResendAction(t *testing.T) {
 
 func TestUpdateSchemaID(t *testing.T) {
 	setNodeManagerAndMessageCenter()
-	controller := NewController("test", 1, nil, nil, nil, nil, 1000, 0)
+	controller := NewController("test", 1, nil, nil, nil, nil, newTestDDLSpan(), 1000, 0)
 	controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: 1}, 1)
 	require.Equal(t, 1, controller.replicationDB.GetAbsentSize())
 	require.Len(t, controller.GetTasksBySchemaID(1), 1)
@@ -204,3 +205,13 @@ func setNodeManagerAndMessageCenter() *watcher.NodeManager {
 	appcontext.SetService(watcher.NodeManagerName, nodeManager)
 	return nodeManager
 }
+
+func newTestDDLSpan() *replica.SpanReplication {
+	id := common.NewDispatcherID()
+	return replica.NewWorkingReplicaSet(model.DefaultChangeFeedID("test"), id, heartbeatpb.DDLSpanSchemaID,
+		heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ID:              id.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+}
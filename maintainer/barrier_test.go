@@ -30,7 +30,7 @@ import (
 
 func TestOneBlockEvent(t *testing.T) {
 	setNodeManagerAndMessageCenter()
-	controller := NewController("test", 1, nil, nil, nil, nil, 1000, 0)
+	controller := NewController("test", 1, nil, nil, nil, nil, newTestDDLSpan(), 1000, 0)
 	controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: 1}, 0)
 	stm := controller.GetTasksByTableIDs(1)[0]
 	controller.replicationDB.BindSpanToNode("", "node1", stm)
@@ -97,7 +97,7 @@ func TestOneBlockEvent(t *testing.T) {
 				State: &heartbeatpb.State{
 					BlockTs:     10,
 					IsBlocked:   true,
-					EventDone:   true,
+					Stage:       heartbeatpb.BlockStage_DONE,
 					IsSyncPoint: true,
 				},
 			},
@@ -106,7 +106,7 @@ func TestOneBlockEvent(t *testing.T) {
 				State: &heartbeatpb.State{
 					BlockTs:     10,
 					IsBlocked:   true,
-					EventDone:   true,
+					Stage:       heartbeatpb.BlockStage_DONE,
 					IsSyncPoint: true,
 				},
 			},
@@ -126,7 +126,7 @@ func TestOneBlockEvent(t *testing.T) {
 				State: &heartbeatpb.State{
 					BlockTs:     10,
 					IsBlocked:   true,
-					EventDone:   true,
+					Stage:       heartbeatpb.BlockStage_DONE,
 					IsSyncPoint: true,
 				},
 			},
@@ -135,7 +135,7 @@ func TestOneBlockEvent(t *testing.T) {
 				State: &heartbeatpb.State{
 					BlockTs:     10,
 					IsBlocked:   true,
-					EventDone:   true,
+					Stage:       heartbeatpb.BlockStage_DONE,
 					IsSyncPoint: true,
 				},
 			},
@@ -149,7 +149,7 @@ func TestOneBlockEvent(t *testing.T) {
 
 func TestNormalBlock(t *testing.T) {
 	setNodeManagerAndMessageCenter()
-	controller := NewController("test", 1, nil, nil, nil, nil, 1000, 0)
+	controller := NewController("test", 1, nil, nil, nil, nil, newTestDDLSpan(), 1000, 0)
 	var blockedDispatcherIDS []*heartbeatpb.DispatcherID
 	for id := 1; id < 4; id++ {
 		controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: int64(id)}, 0)
@@ -295,7 +295,7 @@ func TestNormalBlock(t *testing.T) {
 				State: &heartbeatpb.State{
 					IsBlocked: true,
 					BlockTs:   10,
-					EventDone: true,
+					Stage:     heartbeatpb.BlockStage_DONE,
 				},
 			},
 		},
@@ -309,7 +309,7 @@ func TestNormalBlock(t *testing.T) {
 				State: &heartbeatpb.State{
 					IsBlocked: true,
 					BlockTs:   10,
-					EventDone: true,
+					Stage:     heartbeatpb.BlockStage_DONE,
 				},
 			},
 			{
@@ -317,7 +317,7 @@ func TestNormalBlock(t *testing.T) {
 				State: &heartbeatpb.State{
 					IsBlocked: true,
 					BlockTs:   10,
-					EventDone: true,
+					Stage:     heartbeatpb.BlockStage_DONE,
 				},
 			},
 		},
@@ -333,7 +333,7 @@ func TestSchemaBlock(t *testing.T) {
 	}
 	nmap["node1"] = &node.Info{ID: "node1"}
 	nmap["node2"] = &node.Info{ID: "node2"}
-	controller := NewController("test", 1, nil, nil, nil, nil, 1000, 0)
+	controller := NewController("test", 1, nil, nil, nil, nil, newTestDDLSpan(), 1000, 0)
 	// add the ddl dispatcher
 	setDllDispatcher(controller, "node1")
 
@@ -471,7 +471,7 @@ func TestSchemaBlock(t *testing.T) {
 				State: &heartbeatpb.State{
 					IsBlocked: true,
 					BlockTs:   10,
-					EventDone: true,
+					Stage:     heartbeatpb.BlockStage_DONE,
 				},
 			},
 		},
@@ -493,7 +493,7 @@ func TestSchemaBlock(t *testing.T) {
 				State: &heartbeatpb.State{
 					IsBlocked: true,
 					BlockTs:   10,
-					EventDone: true,
+					Stage:     heartbeatpb.BlockStage_DONE,
 				},
 			},
 			{
@@ -501,7 +501,7 @@ func TestSchemaBlock(t *testing.T) {
 				State: &heartbeatpb.State{
 					IsBlocked: true,
 					BlockTs:   10,
-					EventDone: true,
+					Stage:     heartbeatpb.BlockStage_DONE,
 				},
 			},
 		},
@@ -529,7 +529,7 @@ func TestSyncPointBlock(t *testing.T) {
 	}
 	nmap["node1"] = &node.Info{ID: "node1"}
 	nmap["node2"] = &node.Info{ID: "node2"}
-	controller := NewController("test", 1, nil, nil, nil, nil, 1000, 0)
+	controller := NewController("test", 1, nil, nil, nil, nil, newTestDDLSpan(), 1000, 0)
 	setDllDispatcher(controller, "node1")
 	controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: 1}, 1)
 	controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: 2}, 1)
@@ -655,7 +655,7 @@ func TestSyncPointBlock(t *testing.T) {
 				State: &heartbeatpb.State{
 					IsBlocked:   true,
 					BlockTs:     10,
-					EventDone:   true,
+					Stage:       heartbeatpb.BlockStage_DONE,
 					IsSyncPoint: true,
 				},
 			},
@@ -674,7 +674,7 @@ func TestSyncPointBlock(t *testing.T) {
 				State: &heartbeatpb.State{
 					IsBlocked:   true,
 					BlockTs:     10,
-					EventDone:   true,
+					Stage:       heartbeatpb.BlockStage_DONE,
 					IsSyncPoint: true,
 				},
 			},
@@ -683,7 +683,7 @@ func TestSyncPointBlock(t *testing.T) {
 				State: &heartbeatpb.State{
 					IsBlocked:   true,
 					BlockTs:     10,
-					EventDone:   true,
+					Stage:       heartbeatpb.BlockStage_DONE,
 					IsSyncPoint: true,
 				},
 			},
@@ -692,7 +692,7 @@ func TestSyncPointBlock(t *testing.T) {
 				State: &heartbeatpb.State{
 					IsBlocked:   true,
 					BlockTs:     10,
-					EventDone:   true,
+					Stage:       heartbeatpb.BlockStage_DONE,
 					IsSyncPoint: true,
 				},
 			},
@@ -703,7 +703,7 @@ func TestSyncPointBlock(t *testing.T) {
 
 func TestNonBlocked(t *testing.T) {
 	setNodeManagerAndMessageCenter()
-	controller := NewController("test", 1, nil, nil, nil, nil, 1000, 0)
+	controller := NewController("test", 1, nil, nil, nil, nil, newTestDDLSpan(), 1000, 0)
 	barrier := NewBarrier(controller, false)
 
 	var blockedDispatcherIDS []*heartbeatpb.DispatcherID
@@ -742,7 +742,7 @@ func TestNonBlocked(t *testing.T) {
 
 func TestSyncPointBlockPerf(t *testing.T) {
 	setNodeManagerAndMessageCenter()
-	controller := NewController("test", 1, nil, nil, nil, nil, 1000, 0)
+	controller := NewController("test", 1, nil, nil, nil, nil, newTestDDLSpan(), 1000, 0)
 	barrier := NewBarrier(controller, true)
 	for id := 1; id < 1000; id++ {
 		controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: int64(id)}, 1)
@@ -791,7 +791,7 @@ func TestSyncPointBlockPerf(t *testing.T) {
 				IsBlocked:   true,
 				BlockTs:     10,
 				IsSyncPoint: true,
-				EventDone:   true,
+				Stage:       heartbeatpb.BlockStage_DONE,
 			},
 		})
 	}
@@ -14,17 +14,44 @@
 package checker
 
 import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/maintainer/operator"
 	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/server/watcher"
+	"go.uber.org/zap"
 )
 
+// rowLoadBytes is the extra load of each row written to the sink,
+// the per row overhead of the sink is not negligible when the rows are small.
+const rowLoadBytes = 64
+
 // BalanceChecker is used to check the balance status of all spans among all nodes
+// by the throughput reported by the dispatchers, and moves the spans from the busiest
+// node to the idlest node to even out the load.
 type BalanceChecker struct {
 	changefeedID       string
 	operatorController *operator.Controller
 	replicationDB      *replica.ReplicationDB
 	nodeManager        *watcher.NodeManager
+
+	checkInterval time.Duration
+	lastCheckTime time.Time
+	// maxMovesPerRound is the max number of spans moved in a round of check.
+	maxMovesPerRound int
+	// toleranceRatio is the ratio of the load exceeding the average load which is tolerated,
+	// it avoids moving the spans back and forth when the load fluctuates.
+	toleranceRatio float64
+	// minLoadDiff is the min load diff between the busiest node and the idlest node to move a span.
+	minLoadDiff uint64
+	// maxSinkLag is the max sink lag of the destination node, the node which can't catch up
+	// with its current load is not chosen as the destination.
+	maxSinkLag time.Duration
 }
 
 func NewBalanceChecker(
@@ -37,13 +64,194 @@ func NewBalanceChecker(
 		operatorController: oc,
 		replicationDB:      db,
 		nodeManager:        nodeManager,
+
+		checkInterval:    time.Second * 120,
+		lastCheckTime:    time.Now(),
+		maxMovesPerRound: 8,
+		toleranceRatio:   0.2,
+		minLoadDiff:      1024 * 1024,
+		maxSinkLag:       time.Second * 30,
 	}
 }
 
 func (b *BalanceChecker) Check() {
+	if b.operatorController == nil {
+		return
+	}
+	if time.Since(b.lastCheckTime) < b.checkInterval {
+		return
+	}
+	if b.operatorController.OperatorSize() > 0 {
+		// not in stable schedule state, skip balance
+		return
+	}
+	b.lastCheckTime = time.Now()
+
+	nodes := b.collectNodeLoads()
+	if len(nodes) < 2 {
+		return
+	}
+	var totalLoad uint64
+	totalSpans := 0
+	for _, n := range nodes {
+		totalLoad += n.load
+		totalSpans += len(n.spans)
+	}
+	avgLoad := float64(totalLoad) / float64(len(nodes))
+	// keep the span count of each node under the upper limit of the scheduler,
+	// so the spans are not moved back by the span count balance.
+	upperLimit := int(math.Ceil(float64(totalSpans) / float64(len(nodes))))
 
+	moved := 0
+	for moved < b.maxMovesPerRound {
+		origin, dest := busiestAndIdlest(nodes)
+		if origin == nil || dest == nil {
+			break
+		}
+		if float64(origin.load) <= avgLoad*(1+b.toleranceRatio) ||
+			origin.load-dest.load < b.minLoadDiff {
+			break
+		}
+		// move the span which makes the load of the two nodes closest,
+		// the span whose load is not less than the load diff is not moved,
+		// since it makes the destination node busier than the origin node.
+		idx := origin.pickSpan(func(load uint64) bool { return load > 0 && load < origin.load-dest.load },
+			func(load uint64) uint64 { return absDiff(origin.load-dest.load, 2*load) })
+		if idx < 0 {
+			break
+		}
+		span := origin.spans[idx]
+		origin.removeSpan(idx)
+		if !b.moveSpan(span, origin, dest) {
+			continue
+		}
+		moved++
+
+		if len(dest.spans) > upperLimit && moved < b.maxMovesPerRound {
+			// swap the coldest span of the destination node back to the origin node
+			idx = dest.pickSpan(func(load uint64) bool { return load < span.load },
+				func(load uint64) uint64 { return load })
+			if idx >= 0 {
+				swapped := dest.spans[idx]
+				dest.removeSpan(idx)
+				if b.moveSpan(swapped, dest, origin) {
+					moved++
+				}
+			}
+		}
+	}
 }
 
 func (b *BalanceChecker) Name() string {
 	return "balance-checker"
 }
+
+// collectNodeLoads returns the load and the replicating spans of all schedulable nodes,
+// the spans on the unschedulable nodes are moved away by the scheduler.
+func (b *BalanceChecker) collectNodeLoads() []*nodeLoad {
+	aliveNodes := b.nodeManager.GetSchedulableNodes()
+	loads := make(map[node.ID]*nodeLoad, len(aliveNodes))
+	nodes := make([]*nodeLoad, 0, len(aliveNodes))
+	for id := range aliveNodes {
+		n := &nodeLoad{id: id}
+		loads[id] = n
+		nodes = append(nodes, n)
+	}
+	for _, span := range b.replicationDB.GetReplicating() {
+		n, ok := loads[span.GetNodeID()]
+		if !ok {
+			continue
+		}
+		status := span.GetStatus()
+		load := getSpanLoad(status)
+		n.spans = append(n.spans, &spanLoad{span: span, load: load})
+		n.load += load
+		if time.Duration(status.GetSinkLagMs())*time.Millisecond > b.maxSinkLag {
+			n.lagging = true
+		}
+	}
+	// sort the nodes to make the result stable
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].id < nodes[j].id })
+	return nodes
+}
+
+func (b *BalanceChecker) moveSpan(span *spanLoad, origin, dest *nodeLoad) bool {
+	op := operator.NewMoveDispatcherOperator(b.replicationDB, span.span, origin.id, dest.id)
+	if !b.operatorController.AddOperator(op) {
+		return false
+	}
+	log.Info("move span to balance the load",
+		zap.String("changefeed", b.changefeedID),
+		zap.String("span", span.span.ID.String()),
+		zap.Stringer("origin", origin.id),
+		zap.Stringer("dest", dest.id),
+		zap.Uint64("spanLoad", span.load),
+		zap.Uint64("originLoad", origin.load),
+		zap.Uint64("destLoad", dest.load))
+	dest.spans = append(dest.spans, span)
+	dest.load += span.load
+	return true
+}
+
+type spanLoad struct {
+	span *replica.SpanReplication
+	load uint64
+}
+
+type nodeLoad struct {
+	id    node.ID
+	load  uint64
+	spans []*spanLoad
+	// lagging is true if the sink lag of any span on the node exceeds the limit
+	lagging bool
+}
+
+// pickSpan returns the index of the span which satisfies the filter and has the min cost,
+// -1 is returned if no span satisfies the filter.
+func (n *nodeLoad) pickSpan(filter func(load uint64) bool, cost func(load uint64) uint64) int {
+	idx := -1
+	var minCost uint64
+	for i, span := range n.spans {
+		if !filter(span.load) {
+			continue
+		}
+		if c := cost(span.load); idx < 0 || c < minCost {
+			idx, minCost = i, c
+		}
+	}
+	return idx
+}
+
+func (n *nodeLoad) removeSpan(idx int) {
+	n.load -= n.spans[idx].load
+	n.spans = append(n.spans[:idx], n.spans[idx+1:]...)
+}
+
+// busiestAndIdlest returns the node with the max load and the node with the min load
+// among the nodes which are not lagging.
+func busiestAndIdlest(nodes []*nodeLoad) (busiest, idlest *nodeLoad) {
+	for _, n := range nodes {
+		if busiest == nil || n.load > busiest.load {
+			busiest = n
+		}
+		if !n.lagging && (idlest == nil || n.load < idlest.load) {
+			idlest = n
+		}
+	}
+	if busiest == idlest {
+		return nil, nil
+	}
+	return busiest, idlest
+}
+
+// getSpanLoad returns the load of the span by the throughput reported by its dispatcher.
+func getSpanLoad(status *heartbeatpb.TableSpanStatus) uint64 {
+	return status.GetEventBytesPerSecond() + status.GetEventRowsPerSecond()*rowLoadBytes
+}
+
+func absDiff(a, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -0,0 +1,140 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/operator"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/server/watcher"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBalanceChecker(nodes ...node.ID) *BalanceChecker {
+	ddlSpan := replica.NewReplicaSet(model.ChangeFeedID{}, common.NewDispatcherID(), 1, heartbeatpb.DDLSpan, 1)
+	db := replica.NewReplicaSetDB("test", ddlSpan)
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	for _, id := range nodes {
+		nodeManager.GetAliveNodes()[id] = &node.Info{ID: id}
+	}
+	oc := operator.NewOperatorController("test", nil, db, 1000)
+	b := NewBalanceChecker("test", oc, db, nodeManager)
+	b.lastCheckTime = time.Time{}
+	return b
+}
+
+func addSpan(b *BalanceChecker, tableID int64, nodeID node.ID, bytesPerSecond uint64, sinkLagMs uint64) *replica.SpanReplication {
+	id := common.NewDispatcherID()
+	span := replica.NewWorkingReplicaSet(model.ChangeFeedID{}, id, 1,
+		&heartbeatpb.TableSpan{TableID: tableID},
+		&heartbeatpb.TableSpanStatus{
+			ID:                  id.ToPB(),
+			ComponentStatus:     heartbeatpb.ComponentState_Working,
+			CheckpointTs:        1,
+			EventBytesPerSecond: bytesPerSecond,
+			SinkLagMs:           sinkLagMs,
+		}, nodeID)
+	b.replicationDB.AddReplicatingSpan(span)
+	return span
+}
+
+func getMoveDest(t *testing.T, b *BalanceChecker, span *replica.SpanReplication) node.ID {
+	op := b.operatorController.GetOperator(span.ID)
+	if op == nil {
+		return ""
+	}
+	msg := op.Schedule()
+	require.Equal(t, heartbeatpb.ScheduleAction_Remove, msg.Message[0].(*heartbeatpb.ScheduleDispatcherRequest).ScheduleAction)
+	require.Equal(t, span.GetNodeID(), msg.To)
+	op.Check(span.GetNodeID(), &heartbeatpb.TableSpanStatus{ID: span.ID.ToPB(), ComponentStatus: heartbeatpb.ComponentState_Stopped})
+	msg = op.Schedule()
+	require.Equal(t, heartbeatpb.ScheduleAction_Create, msg.Message[0].(*heartbeatpb.ScheduleDispatcherRequest).ScheduleAction)
+	return msg.To
+}
+
+func TestBalanceCheckerMoveHotSpan(t *testing.T) {
+	b := newTestBalanceChecker("node1", "node2")
+	hot1 := addSpan(b, 1, "node1", 100<<20, 0)
+	hot2 := addSpan(b, 2, "node1", 100<<20, 0)
+	cold1 := addSpan(b, 3, "node2", 1<<10, 0)
+	cold2 := addSpan(b, 4, "node2", 2<<10, 0)
+
+	b.Check()
+	// one hot span is moved to node2, and the coldest span of node2 is swapped
+	// back to node1 to keep the span count balanced.
+	require.Equal(t, 2, b.operatorController.OperatorSize())
+	dest1, dest2 := getMoveDest(t, b, hot1), getMoveDest(t, b, hot2)
+	require.ElementsMatch(t, []node.ID{"node2", ""}, []node.ID{dest1, dest2})
+	require.Equal(t, node.ID("node1"), getMoveDest(t, b, cold1))
+	require.Equal(t, node.ID(""), getMoveDest(t, b, cold2))
+
+	// the checker is not executed until the next round
+	b.Check()
+	require.Equal(t, 2, b.operatorController.OperatorSize())
+}
+
+func TestBalanceCheckerTolerance(t *testing.T) {
+	b := newTestBalanceChecker("node1", "node2")
+	addSpan(b, 1, "node1", 11<<20, 0)
+	addSpan(b, 2, "node1", 1<<20, 0)
+	addSpan(b, 3, "node2", 10<<20, 0)
+	addSpan(b, 4, "node2", 1<<20, 0)
+
+	// the load of node1 doesn't exceed the average load by the tolerance ratio
+	b.Check()
+	require.Equal(t, 0, b.operatorController.OperatorSize())
+
+	// the load diff is too small to move a span
+	b = newTestBalanceChecker("node1", "node2")
+	addSpan(b, 1, "node1", 512<<10, 0)
+	addSpan(b, 2, "node1", 256<<10, 0)
+	addSpan(b, 3, "node2", 0, 0)
+	b.Check()
+	require.Equal(t, 0, b.operatorController.OperatorSize())
+}
+
+func TestBalanceCheckerLaggingNode(t *testing.T) {
+	b := newTestBalanceChecker("node1", "node2", "node3")
+	hot1 := addSpan(b, 1, "node1", 100<<20, 0)
+	hot2 := addSpan(b, 2, "node1", 100<<20, 0)
+	hot3 := addSpan(b, 3, "node1", 100<<20, 0)
+	// node2 can't catch up with its current load
+	addSpan(b, 4, "node2", 0, uint64(time.Minute.Milliseconds()))
+
+	b.Check()
+	require.Equal(t, 1, b.operatorController.OperatorSize())
+	var dests []node.ID
+	for _, span := range []*replica.SpanReplication{hot1, hot2, hot3} {
+		if dest := getMoveDest(t, b, span); dest != "" {
+			dests = append(dests, dest)
+		}
+	}
+	require.Equal(t, []node.ID{"node3"}, dests)
+}
+
+func TestBalanceCheckerMaxMovesPerRound(t *testing.T) {
+	b := newTestBalanceChecker("node1", "node2")
+	b.maxMovesPerRound = 3
+	for i := 0; i < 20; i++ {
+		addSpan(b, int64(i), "node1", 10<<20, 0)
+	}
+	b.Check()
+	require.Equal(t, 3, b.operatorController.OperatorSize())
+}
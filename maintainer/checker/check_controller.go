@@ -19,6 +19,7 @@ import (
 	"github.com/pingcap/ticdc/maintainer/operator"
 	"github.com/pingcap/ticdc/maintainer/replica"
 	"github.com/pingcap/ticdc/maintainer/split"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/server/watcher"
 )
 
@@ -39,7 +40,8 @@ func NewController(changefeedID string,
 	splitter *split.Splitter,
 	oc *operator.Controller,
 	db *replica.ReplicationDB,
-	nodeManager *watcher.NodeManager) *Controller {
+	nodeManager *watcher.NodeManager,
+	cfg *config.ChangefeedSchedulerConfig) *Controller {
 	c := &Controller{
 		changefeedID:       changefeedID,
 		operatorController: oc,
@@ -47,9 +49,16 @@ func NewController(changefeedID string,
 		nodeManager:        nodeManager,
 		maxTimePerRound:    time.Second * 5,
 	}
+	// the split checker and merge checker share the cooldown of tables
+	var cooldown time.Duration
+	if cfg != nil {
+		cooldown = cfg.SplitCooldown
+	}
+	tableCooldown := newTableCooldown(cooldown)
 	c.checkers = []Checker{
-		NewSplitChecker(changefeedID, splitter, oc, db, nodeManager),
+		NewSplitChecker(changefeedID, splitter, oc, db, nodeManager, cfg, tableCooldown),
 		NewBalanceChecker(changefeedID, oc, db, nodeManager),
+		NewMergeChecker(changefeedID, splitter, oc, db, nodeManager, cfg, tableCooldown),
 	}
 	return c
 }
@@ -21,8 +21,8 @@ import (
 )
 
 func TestControllerExecute(t *testing.T) {
-	ctl := NewController("test", nil, nil, nil, nil)
-	require.Equal(t, 2, len(ctl.checkers))
+	ctl := NewController("test", nil, nil, nil, nil, nil)
+	require.Equal(t, 3, len(ctl.checkers))
 	ctl.maxTimePerRound = time.Hour
 	ctl.Execute()
 	require.Equal(t, 0, ctl.checkedIndex)
@@ -31,5 +31,7 @@ func TestControllerExecute(t *testing.T) {
 	ctl.Execute()
 	require.Equal(t, 1, ctl.checkedIndex)
 	ctl.Execute()
+	require.Equal(t, 2, ctl.checkedIndex)
+	ctl.Execute()
 	require.Equal(t, 0, ctl.checkedIndex)
 }
@@ -0,0 +1,55 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"time"
+)
+
+// tableCooldown records the last time the spans of a table are split or merged,
+// the split checker and merge checker share it to avoid splitting and merging
+// the spans of a table back and forth.
+// It's only accessed by the checkers, which are executed one by one, so it's not thread-safe.
+type tableCooldown struct {
+	duration time.Duration
+	lastTime map[int64]time.Time
+}
+
+func newTableCooldown(duration time.Duration) *tableCooldown {
+	return &tableCooldown{
+		duration: duration,
+		lastTime: make(map[int64]time.Time),
+	}
+}
+
+// inCooldown returns true if the spans of the table are split or merged recently.
+func (c *tableCooldown) inCooldown(tableID int64) bool {
+	last, ok := c.lastTime[tableID]
+	if !ok {
+		return false
+	}
+	if time.Since(last) >= c.duration {
+		delete(c.lastTime, tableID)
+		return false
+	}
+	return true
+}
+
+// record records that the spans of the table are split or merged now.
+func (c *tableCooldown) record(tableID int64) {
+	if c.duration <= 0 {
+		return
+	}
+	c.lastTime[tableID] = time.Now()
+}
@@ -0,0 +1,222 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/operator"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/maintainer/split"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/server/watcher"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+// MergeChecker merges the adjacent spans of a table on low traffic,
+// so a table doesn't keep too many dispatchers after its traffic drops.
+type MergeChecker struct {
+	changefeedID string
+	splitter     *split.Splitter
+	opController *operator.Controller
+	db           *replica.ReplicationDB
+	nodeManager  *watcher.NodeManager
+	cooldown     *tableCooldown
+
+	checkInterval time.Duration
+	lastCheckTime time.Time
+	// maxMergedLoad is the max load of a merged span.
+	maxMergedLoad uint64
+	// maxMergedRows and maxMergedBytes are the max throughput of a merged span,
+	// they are half of the thresholds of splitting a hot span, so the merged span
+	// is not split again soon. 0 means no limit.
+	maxMergedRows  uint64
+	maxMergedBytes uint64
+	// maxMergeSpans is the max number of spans merged by an operator.
+	maxMergeSpans int
+	// maxMergesPerRound is the max number of merge operators added in a round of check.
+	maxMergesPerRound int
+	// holdLead is the duration between now and the ts the merged spans are held at.
+	holdLead time.Duration
+}
+
+func NewMergeChecker(
+	changefeedID string,
+	splitter *split.Splitter,
+	opController *operator.Controller,
+	db *replica.ReplicationDB,
+	nodeManager *watcher.NodeManager,
+	cfg *config.ChangefeedSchedulerConfig,
+	cooldown *tableCooldown) *MergeChecker {
+	m := &MergeChecker{
+		changefeedID: changefeedID,
+		splitter:     splitter,
+		opController: opController,
+		db:           db,
+		nodeManager:  nodeManager,
+		cooldown:     cooldown,
+
+		checkInterval:     time.Second * 120,
+		lastCheckTime:     time.Now(),
+		maxMergedLoad:     1024 * 1024,
+		maxMergeSpans:     32,
+		maxMergesPerRound: 16,
+		holdLead:          5 * time.Second,
+	}
+	if cfg != nil {
+		m.maxMergedRows = uint64(cfg.EventRowsThreshold) / 2
+		m.maxMergedBytes = uint64(cfg.EventBytesThreshold) / 2
+	}
+	return m
+}
+
+func (m *MergeChecker) Name() string {
+	return "merge-checker"
+}
+
+func (m *MergeChecker) Check() {
+	if m.opController == nil {
+		return
+	}
+	if time.Since(m.lastCheckTime) < m.checkInterval {
+		return
+	}
+	m.lastCheckTime = time.Now()
+
+	tables := make(map[int64][]*replica.SpanReplication)
+	for _, span := range m.db.GetReplicating() {
+		tables[span.Span.TableID] = append(tables[span.Span.TableID], span)
+	}
+	tableIDs := make([]int64, 0, len(tables))
+	for tableID, spans := range tables {
+		if len(spans) > 1 && !m.cooldown.inCooldown(tableID) && !isBlocked(spans) {
+			tableIDs = append(tableIDs, tableID)
+		}
+	}
+	sort.Slice(tableIDs, func(i, j int) bool { return tableIDs[i] < tableIDs[j] })
+
+	holdTs := m.newHoldTs()
+	merges := 0
+	for _, tableID := range tableIDs {
+		spans := tables[tableID]
+		sort.Slice(spans, func(i, j int) bool {
+			return bytes.Compare(spans[i].Span.StartKey, spans[j].Span.StartKey) < 0
+		})
+		for _, group := range m.findMergeGroups(spans) {
+			if merges >= m.maxMergesPerRound {
+				return
+			}
+			if m.splitter != nil {
+				// the merged span must not be split again by the split checker
+				mergedSpan := &heartbeatpb.TableSpan{
+					TableID:  tableID,
+					StartKey: group[0].Span.StartKey,
+					EndKey:   group[len(group)-1].Span.EndKey,
+				}
+				if len(m.splitter.SplitSpans(context.Background(), mergedSpan, len(m.nodeManager.GetAliveNodes()))) > 1 {
+					continue
+				}
+			}
+			op := operator.NewMergeDispatcherOperator(m.db, group, max(holdTs, maxCheckpointTs(group)+1))
+			if m.opController.AddMergeOperator(op) {
+				log.Info("merge spans",
+					zap.String("changefeed", m.changefeedID),
+					zap.Int64("table", tableID),
+					zap.Int("spanSize", len(group)))
+				m.cooldown.record(tableID)
+				merges++
+			}
+		}
+	}
+}
+
+// newHoldTs returns the ts to hold the merged spans at, it's a little later than now,
+// so the dispatchers receive the hold request before they receive the events after it.
+func (m *MergeChecker) newHoldTs() uint64 {
+	return oracle.GoTimeToTS(time.Now().Add(m.holdLead))
+}
+
+// isBlocked returns true if any span of the table is waiting for a block event or held,
+// the table is not merged until the block event is done.
+func isBlocked(spans []*replica.SpanReplication) bool {
+	for _, span := range spans {
+		status := span.GetStatus()
+		if status.GetBlockTs() != 0 || status.GetHoldTs() != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func maxCheckpointTs(spans []*replica.SpanReplication) uint64 {
+	var ts uint64
+	for _, span := range spans {
+		ts = max(ts, span.GetStatus().GetCheckpointTs())
+	}
+	return ts
+}
+
+// findMergeGroups groups the adjacent spans sorted by the start key,
+// the total load and throughput of each group don't exceed the max merged ones.
+// Only the groups with more than one span are returned.
+func (m *MergeChecker) findMergeGroups(spans []*replica.SpanReplication) [][]*replica.SpanReplication {
+	var (
+		groups    [][]*replica.SpanReplication
+		group     []*replica.SpanReplication
+		groupLoad uint64
+		rows      uint64
+		bytesSize uint64
+	)
+	flush := func() {
+		if len(group) > 1 {
+			groups = append(groups, group)
+		}
+		group, groupLoad, rows, bytesSize = nil, 0, 0, 0
+	}
+	for _, span := range spans {
+		status := span.GetStatus()
+		load := getSpanLoad(status)
+		if len(group) > 0 && (!bytes.Equal(group[len(group)-1].Span.EndKey, span.Span.StartKey) ||
+			!m.canMerge(groupLoad+load, rows+status.GetEventRowsPerSecond(), bytesSize+status.GetEventBytesPerSecond()) ||
+			len(group) >= m.maxMergeSpans) {
+			flush()
+		}
+		if !m.canMerge(load, status.GetEventRowsPerSecond(), status.GetEventBytesPerSecond()) {
+			continue
+		}
+		group = append(group, span)
+		groupLoad += load
+		rows += status.GetEventRowsPerSecond()
+		bytesSize += status.GetEventBytesPerSecond()
+	}
+	flush()
+	return groups
+}
+
+// canMerge returns true if a merged span with the load and throughput is not too busy.
+func (m *MergeChecker) canMerge(load, rows, bytesSize uint64) bool {
+	if load > m.maxMergedLoad {
+		return false
+	}
+	if m.maxMergedRows > 0 && rows > m.maxMergedRows {
+		return false
+	}
+	return m.maxMergedBytes == 0 || bytesSize <= m.maxMergedBytes
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/operator"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/server/watcher"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeChecker(t *testing.T) {
+	ddlSpan := replica.NewReplicaSet(model.ChangeFeedID{}, common.NewDispatcherID(), 1, heartbeatpb.DDLSpan, 1)
+	db := replica.NewReplicaSetDB("test", ddlSpan)
+	oc := operator.NewOperatorController("test", nil, db, 1000)
+	m := NewMergeChecker("test", nil, oc, db, watcher.NewNodeManager(nil, nil), nil, newTableCooldown(0))
+	m.maxMergeSpans = 3
+
+	addSpan := func(tableID int64, start, end string, bytesPerSecond uint64, blockTs ...uint64) *replica.SpanReplication {
+		id := common.NewDispatcherID()
+		span := replica.NewWorkingReplicaSet(model.ChangeFeedID{}, id, 1,
+			&heartbeatpb.TableSpan{TableID: tableID, StartKey: []byte(start), EndKey: []byte(end)},
+			&heartbeatpb.TableSpanStatus{
+				ID:                  id.ToPB(),
+				ComponentStatus:     heartbeatpb.ComponentState_Working,
+				CheckpointTs:        1,
+				EventBytesPerSecond: bytesPerSecond,
+			}, "node1")
+		if len(blockTs) > 0 {
+			span.GetStatus().BlockTs = blockTs[0]
+		}
+		db.AddReplicatingSpan(span)
+		return span
+	}
+	// table 1: [a,b) [b,c) are merged, [c,d) is hot, [d,e) [e,f) [f,g) are merged,
+	// [g,h) is not merged since an operator merges 3 spans at most
+	t1 := []*replica.SpanReplication{
+		addSpan(1, "a", "b", 1024),
+		addSpan(1, "b", "c", 1024),
+		addSpan(1, "c", "d", 10<<20),
+		addSpan(1, "d", "e", 0),
+		addSpan(1, "e", "f", 0),
+		addSpan(1, "f", "g", 0),
+		addSpan(1, "g", "h", 0),
+	}
+	// table 2: the spans are not adjacent
+	t2 := []*replica.SpanReplication{
+		addSpan(2, "a", "b", 0),
+		addSpan(2, "c", "d", 0),
+	}
+	// table 3: the merged span would be too busy
+	t3 := []*replica.SpanReplication{
+		addSpan(3, "a", "b", 600<<10),
+		addSpan(3, "b", "c", 600<<10),
+	}
+
+	// table 4: a span is waiting for a ddl
+	t4 := []*replica.SpanReplication{
+		addSpan(4, "a", "b", 0),
+		addSpan(4, "b", "c", 0, 10),
+	}
+
+	// not checked until the check interval elapses
+	m.Check()
+	require.Equal(t, 0, oc.OperatorSize())
+
+	m.lastCheckTime = time.Time{}
+	m.Check()
+	require.Equal(t, 5, oc.OperatorSize())
+	require.Same(t, oc.GetOperator(t1[0].ID), oc.GetOperator(t1[1].ID))
+	require.Nil(t, oc.GetOperator(t1[2].ID))
+	require.Same(t, oc.GetOperator(t1[3].ID), oc.GetOperator(t1[4].ID))
+	require.Same(t, oc.GetOperator(t1[3].ID), oc.GetOperator(t1[5].ID))
+	require.NotSame(t, oc.GetOperator(t1[0].ID), oc.GetOperator(t1[3].ID))
+	require.Nil(t, oc.GetOperator(t1[6].ID))
+	for _, span := range append(append(t2, t3...), t4...) {
+		require.Nil(t, oc.GetOperator(span.ID))
+	}
+}
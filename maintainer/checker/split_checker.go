@@ -18,20 +18,25 @@ import (
 	"time"
 
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/maintainer/operator"
 	"github.com/pingcap/ticdc/maintainer/replica"
 	"github.com/pingcap/ticdc/maintainer/split"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/server/watcher"
 	"go.uber.org/zap"
 )
 
-// SplitChecker is used to check the split status of all spans
+// SplitChecker is used to check the split status of all spans.
+// Besides splitting the spans by the region count and written keys periodically,
+// it splits the hot spans quickly by the throughput and sink lag reported by the dispatchers.
 type SplitChecker struct {
 	changefeedID string
 	splitter     *split.Splitter
 	opController *operator.Controller
 	db           *replica.ReplicationDB
 	nodeManager  *watcher.NodeManager
+	cooldown     *tableCooldown
 
 	maxCheckTime  time.Duration
 	checkInterval time.Duration
@@ -39,6 +44,15 @@ type SplitChecker struct {
 
 	checkedIndex int
 	cachedSpans  []*replica.SpanReplication
+
+	// the thresholds of splitting a hot span, 0 means no threshold
+	eventRowsThreshold  uint64
+	eventBytesThreshold uint64
+	sinkLagThreshold    time.Duration
+
+	hotCheckInterval  time.Duration
+	lastHotCheckTime  time.Time
+	maxSplitsPerRound int
 }
 
 func NewSplitChecker(
@@ -46,17 +60,29 @@ func NewSplitChecker(
 	splitter *split.Splitter,
 	opController *operator.Controller,
 	db *replica.ReplicationDB,
-	nodeManager *watcher.NodeManager) *SplitChecker {
-	return &SplitChecker{
+	nodeManager *watcher.NodeManager,
+	cfg *config.ChangefeedSchedulerConfig,
+	cooldown *tableCooldown) *SplitChecker {
+	s := &SplitChecker{
 		changefeedID: changefeedID,
 		splitter:     splitter,
 		opController: opController,
 		db:           db,
 		nodeManager:  nodeManager,
+		cooldown:     cooldown,
 
 		maxCheckTime:  time.Second * 5,
 		checkInterval: time.Second * 120,
+
+		hotCheckInterval:  time.Second * 10,
+		maxSplitsPerRound: 8,
 	}
+	if cfg != nil {
+		s.eventRowsThreshold = uint64(cfg.EventRowsThreshold)
+		s.eventBytesThreshold = uint64(cfg.EventBytesThreshold)
+		s.sinkLagThreshold = cfg.SinkLagThreshold
+	}
+	return s
 }
 
 func (s *SplitChecker) Name() string {
@@ -67,6 +93,7 @@ func (s *SplitChecker) Check() {
 	if s.splitter == nil {
 		return
 	}
+	s.checkHotSpans()
 	if time.Since(s.lastCheckTime) < s.checkInterval {
 		return
 	}
@@ -77,7 +104,7 @@ func (s *SplitChecker) Check() {
 	start := time.Now()
 	for ; s.checkedIndex < len(s.cachedSpans); s.checkedIndex++ {
 		span := s.cachedSpans[s.checkedIndex]
-		if s.db.GetTaskByID(span.ID) == nil {
+		if s.db.GetTaskByID(span.ID) == nil || s.cooldown.inCooldown(span.Span.TableID) {
 			continue
 		}
 		spans := s.splitter.SplitSpans(context.Background(), span.Span, len(s.nodeManager.GetAliveNodes()))
@@ -86,7 +113,9 @@ func (s *SplitChecker) Check() {
 				zap.String("changefeed", s.changefeedID),
 				zap.String("span", span.ID.String()),
 				zap.Int("span szie", len(spans)))
-			s.opController.AddOperator(operator.NewSplitDispatcherOperator(s.db, span, span.GetNodeID(), spans))
+			if s.opController.AddOperator(operator.NewSplitDispatcherOperator(s.db, span, span.GetNodeID(), spans)) {
+				s.cooldown.record(span.Span.TableID)
+			}
 		}
 		if time.Since(start) > s.maxCheckTime {
 			break
@@ -98,3 +127,75 @@ func (s *SplitChecker) Check() {
 		s.lastCheckTime = time.Now()
 	}
 }
+
+// checkHotSpans splits the spans whose throughput or sink lag exceeds the thresholds,
+// a hot span is split into the number of spans which makes the throughput of
+// each span below the thresholds.
+func (s *SplitChecker) checkHotSpans() {
+	if s.eventRowsThreshold == 0 && s.eventBytesThreshold == 0 && s.sinkLagThreshold == 0 {
+		return
+	}
+	if time.Since(s.lastHotCheckTime) < s.hotCheckInterval {
+		return
+	}
+	s.lastHotCheckTime = time.Now()
+
+	splits := 0
+	splitTables := make(map[int64]struct{})
+	for _, span := range s.db.GetReplicating() {
+		if splits >= s.maxSplitsPerRound {
+			break
+		}
+		tableID := span.Span.TableID
+		if _, ok := splitTables[tableID]; !ok && s.cooldown.inCooldown(tableID) {
+			continue
+		}
+		if s.opController.GetOperator(span.ID) != nil {
+			continue
+		}
+		spansNum := s.getHotSpansNumber(span.GetStatus())
+		if spansNum <= 1 {
+			continue
+		}
+		spans := s.splitter.SplitSpansEvenly(context.Background(), span.Span, spansNum)
+		if len(spans) <= 1 {
+			continue
+		}
+		if s.opController.AddOperator(operator.NewSplitDispatcherOperator(s.db, span, span.GetNodeID(), spans)) {
+			log.Info("split hot span",
+				zap.String("changefeed", s.changefeedID),
+				zap.String("span", span.ID.String()),
+				zap.Uint64("eventRowsPerSecond", span.GetStatus().GetEventRowsPerSecond()),
+				zap.Uint64("eventBytesPerSecond", span.GetStatus().GetEventBytesPerSecond()),
+				zap.Uint64("sinkLagMs", span.GetStatus().GetSinkLagMs()),
+				zap.Int("spanSize", len(spans)))
+			splitTables[tableID] = struct{}{}
+			splits++
+		}
+	}
+	// record the cooldown after the round, so all the hot spans of a table can be split in a round
+	for tableID := range splitTables {
+		s.cooldown.record(tableID)
+	}
+}
+
+// getHotSpansNumber returns the number of spans the span should be split into.
+func (s *SplitChecker) getHotSpansNumber(status *heartbeatpb.TableSpanStatus) int {
+	spansNum := 1
+	if s.eventRowsThreshold > 0 {
+		spansNum = max(spansNum, int(ceilDiv(status.GetEventRowsPerSecond(), s.eventRowsThreshold)))
+	}
+	if s.eventBytesThreshold > 0 {
+		spansNum = max(spansNum, int(ceilDiv(status.GetEventBytesPerSecond(), s.eventBytesThreshold)))
+	}
+	// the sink can't catch up with the span, split it to write the events concurrently
+	if spansNum == 1 && s.sinkLagThreshold > 0 && status.GetEventRowsPerSecond() > 0 &&
+		time.Duration(status.GetSinkLagMs())*time.Millisecond > s.sinkLagThreshold {
+		spansNum = 2
+	}
+	return spansNum
+}
+
+func ceilDiv(a, b uint64) uint64 {
+	return (a + b - 1) / b
+}
@@ -0,0 +1,194 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/operator"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/maintainer/split"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/server/watcher"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/tikv"
+)
+
+// mockRegionCache splits the key range [t, u) into 10 regions.
+type mockRegionCache struct{}
+
+func (m *mockRegionCache) regions() []*tikv.KeyLocation {
+	locs := make([]*tikv.KeyLocation, 0, 10)
+	for i := 0; i < 10; i++ {
+		loc := &tikv.KeyLocation{StartKey: []byte(fmt.Sprintf("t%d", i)), EndKey: []byte(fmt.Sprintf("t%d", i+1))}
+		if i == 0 {
+			loc.StartKey = []byte("t")
+		}
+		if i == 9 {
+			loc.EndKey = []byte("u")
+		}
+		locs = append(locs, loc)
+	}
+	return locs
+}
+
+func (m *mockRegionCache) ListRegionIDsInKeyRange(
+	_ *tikv.Backoffer, startKey, endKey []byte,
+) (regionIDs []uint64, err error) {
+	for i, loc := range m.regions() {
+		if bytes.Compare(loc.StartKey, endKey) < 0 && bytes.Compare(loc.EndKey, startKey) > 0 {
+			regionIDs = append(regionIDs, uint64(i+1))
+		}
+	}
+	return regionIDs, nil
+}
+
+func (m *mockRegionCache) LocateRegionByID(_ *tikv.Backoffer, regionID uint64) (*tikv.KeyLocation, error) {
+	return m.regions()[regionID-1], nil
+}
+
+func TestSplitCheckerSplitHotSpans(t *testing.T) {
+	ddlSpan := replica.NewReplicaSet(model.ChangeFeedID{}, common.NewDispatcherID(), 1, heartbeatpb.DDLSpan, 1)
+	db := replica.NewReplicaSetDB("test", ddlSpan)
+	oc := operator.NewOperatorController("test", nil, db, 1000)
+	cfg := &config.ChangefeedSchedulerConfig{
+		EnableTableAcrossNodes: true,
+		RegionThreshold:        100,
+		EventRowsThreshold:     1000,
+		EventBytesThreshold:    1 << 20,
+		SinkLagThreshold:       time.Minute,
+		SplitCooldown:          time.Hour,
+	}
+	cooldown := newTableCooldown(cfg.SplitCooldown)
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	s := NewSplitChecker("test", split.NewSplitter("test", nil, &mockRegionCache{}, cfg), oc, db, nodeManager, cfg, cooldown)
+	// skip the check by the region count
+	s.lastCheckTime = time.Now()
+
+	addSpanWithKeys := func(tableID int64, start, end string, rows, bytesSize, sinkLagMs uint64) *replica.SpanReplication {
+		id := common.NewDispatcherID()
+		span := replica.NewWorkingReplicaSet(model.ChangeFeedID{}, id, 1,
+			&heartbeatpb.TableSpan{TableID: tableID, StartKey: []byte(start), EndKey: []byte(end)},
+			&heartbeatpb.TableSpanStatus{
+				ID:                  id.ToPB(),
+				ComponentStatus:     heartbeatpb.ComponentState_Working,
+				CheckpointTs:        1,
+				EventRowsPerSecond:  rows,
+				EventBytesPerSecond: bytesSize,
+				SinkLagMs:           sinkLagMs,
+			}, "node1")
+		db.AddReplicatingSpan(span)
+		return span
+	}
+	addSpan := func(tableID int64, rows, bytesSize, sinkLagMs uint64) *replica.SpanReplication {
+		return addSpanWithKeys(tableID, "t", "u", rows, bytesSize, sinkLagMs)
+	}
+	getSplitSpans := func(span *replica.SpanReplication) int {
+		op := oc.GetOperator(span.ID)
+		if op == nil {
+			return 0
+		}
+		return len(op.(*operator.SplitDispatcherOperator).SplitSpans())
+	}
+	// split by rows
+	t1 := addSpan(1, 2500, 0, 0)
+	// split by bytes
+	t2 := addSpan(2, 0, 4<<20, 0)
+	// split by sink lag
+	t3 := addSpan(3, 10, 0, uint64(2*time.Minute.Milliseconds()))
+	// the sink lags but no events are written
+	t4 := addSpan(4, 0, 0, uint64(2*time.Minute.Milliseconds()))
+	// not hot
+	t5 := addSpan(5, 100, 1024, 0)
+
+	s.Check()
+	require.Equal(t, 3, getSplitSpans(t1))
+	require.Equal(t, 4, getSplitSpans(t2))
+	require.Equal(t, 2, getSplitSpans(t3))
+	require.Equal(t, 0, getSplitSpans(t4))
+	require.Equal(t, 0, getSplitSpans(t5))
+	require.True(t, cooldown.inCooldown(1))
+	require.False(t, cooldown.inCooldown(5))
+
+	// not checked until the hot check interval elapses
+	t6 := addSpan(6, 2500, 0, 0)
+	s.Check()
+	require.Equal(t, 0, getSplitSpans(t6))
+	s.lastHotCheckTime = time.Time{}
+	s.Check()
+	require.Equal(t, 3, getSplitSpans(t6))
+
+	// the table in cooldown is not split again
+	t7 := addSpan(1, 2500, 0, 0)
+	s.lastHotCheckTime = time.Time{}
+	s.Check()
+	require.Equal(t, 0, getSplitSpans(t7))
+
+	// the idle spans of the table in cooldown are not merged either
+	idle1, idle2 := addSpanWithKeys(1, "a", "b", 0, 0, 0), addSpanWithKeys(1, "b", "c", 0, 0, 0)
+	m := NewMergeChecker("test", nil, oc, db, nodeManager, cfg, cooldown)
+	m.lastCheckTime = time.Time{}
+	m.Check()
+	require.Nil(t, oc.GetOperator(idle1.ID))
+	require.Nil(t, oc.GetOperator(idle2.ID))
+	// they are merged after the cooldown
+	cooldown.duration = 0
+	m.lastCheckTime = time.Time{}
+	m.Check()
+	require.NotNil(t, oc.GetOperator(idle1.ID))
+}
+
+func TestMergeCheckerHysteresis(t *testing.T) {
+	ddlSpan := replica.NewReplicaSet(model.ChangeFeedID{}, common.NewDispatcherID(), 1, heartbeatpb.DDLSpan, 1)
+	db := replica.NewReplicaSetDB("test", ddlSpan)
+	oc := operator.NewOperatorController("test", nil, db, 1000)
+	cfg := &config.ChangefeedSchedulerConfig{EventRowsThreshold: 1000}
+	m := NewMergeChecker("test", nil, oc, db, watcher.NewNodeManager(nil, nil), cfg, newTableCooldown(0))
+
+	addSpan := func(tableID int64, start, end string, rows uint64) *replica.SpanReplication {
+		id := common.NewDispatcherID()
+		span := replica.NewWorkingReplicaSet(model.ChangeFeedID{}, id, 1,
+			&heartbeatpb.TableSpan{TableID: tableID, StartKey: []byte(start), EndKey: []byte(end)},
+			&heartbeatpb.TableSpanStatus{
+				ID:                 id.ToPB(),
+				ComponentStatus:    heartbeatpb.ComponentState_Working,
+				CheckpointTs:       1,
+				EventRowsPerSecond: rows,
+			}, "node1")
+		db.AddReplicatingSpan(span)
+		return span
+	}
+	// table 1: the merged span would exceed half of the split threshold
+	t1 := []*replica.SpanReplication{
+		addSpan(1, "a", "b", 300),
+		addSpan(1, "b", "c", 300),
+	}
+	// table 2: the merged span is far below the split threshold
+	t2 := []*replica.SpanReplication{
+		addSpan(2, "a", "b", 200),
+		addSpan(2, "b", "c", 200),
+	}
+	m.lastCheckTime = time.Time{}
+	m.Check()
+	require.Nil(t, oc.GetOperator(t1[0].ID))
+	require.Nil(t, oc.GetOperator(t1[1].ID))
+	require.NotNil(t, oc.GetOperator(t2[0].ID))
+	require.Same(t, oc.GetOperator(t2[0].ID), oc.GetOperator(t2[1].ID))
+}
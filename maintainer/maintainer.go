@@ -14,7 +14,9 @@
 package maintainer
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"math"
 	"sync"
 	"time"
@@ -38,6 +40,7 @@ import (
 	"github.com/pingcap/ticdc/utils/dynstream"
 	"github.com/pingcap/ticdc/utils/threadpool"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/redo"
 	"github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/pdutil"
 	"github.com/prometheus/client_golang/prometheus"
@@ -104,6 +107,11 @@ type Maintainer struct {
 	lastPrintStatusTime  time.Time
 	lastCheckpointTsTime time.Time
 
+	// redoMetaManager persists the checkpointTs and resolvedTs of the changefeed
+	// to the redo storage, it's disabled if the redo log is not enabled.
+	redoMetaManager redo.MetaManager
+	redoMetaCancel  context.CancelFunc
+
 	errLock         sync.Mutex
 	runningErrors   map[node.ID]*heartbeatpb.RunningError
 	runningWarnings map[node.ID]*heartbeatpb.RunningError
@@ -156,6 +164,7 @@ func NewMaintainer(cfID model.ChangeFeedID,
 		nodeChanged:     atomic.NewBool(false),
 		cascadeRemoving: false,
 		config:          cfg,
+		redoMetaManager: redo.NewMetaManager(cfID, cfg.Config.Consistent.ToTiflowConfig(), checkpointTs),
 
 		tableTriggerEventDispatcherID: tableTriggerEventDispatcherID,
 
@@ -225,6 +234,7 @@ func (m *Maintainer) HandleEvent(event *Event) bool {
 func (m *Maintainer) Close() {
 	m.cleanupMetrics()
 	m.controller.Stop()
+	m.closeRedoMeta()
 	log.Info("changefeed maintainer closed",
 		zap.String("id", m.id.String()),
 		zap.Bool("removed", m.removed.Load()),
@@ -252,13 +262,31 @@ func (m *Maintainer) GetMaintainerStatus() *heartbeatpb.MaintainerStatus {
 		clear(m.runningWarnings)
 	}
 
+	taskSize := m.controller.GetTaskSizePerNode()
+	nodeSpanCounts := make([]*heartbeatpb.NodeSpanCount, 0, len(taskSize))
+	for id, size := range taskSize {
+		nodeSpanCounts = append(nodeSpanCounts, &heartbeatpb.NodeSpanCount{
+			NodeId:    id.String(),
+			SpanCount: uint32(size),
+		})
+	}
+
+	var checkpointLagMs uint64
+	if lag := oracle.GetPhysical(time.Now()) - oracle.ExtractPhysical(m.watermark.CheckpointTs); lag > 0 {
+		checkpointLagMs = uint64(lag)
+	}
+
 	status := &heartbeatpb.MaintainerStatus{
-		ChangefeedID: m.id.ID,
-		FeedState:    string(m.changefeedSate),
-		State:        m.state,
-		CheckpointTs: m.watermark.CheckpointTs,
-		Warning:      runningWarnings,
-		Err:          runningErrors,
+		ChangefeedID:    m.id.ID,
+		FeedState:       string(m.changefeedSate),
+		State:           m.state,
+		CheckpointTs:    m.watermark.CheckpointTs,
+		Warning:         runningWarnings,
+		Err:             runningErrors,
+		NodeSpanCounts:  nodeSpanCounts,
+		TableCount:      uint32(m.controller.GetTableSize()),
+		SpanCount:       uint32(m.controller.TaskSize()),
+		CheckpointLagMs: checkpointLagMs,
 	}
 	return status
 }
@@ -280,6 +308,8 @@ func (m *Maintainer) initialize() error {
 	m.state = heartbeatpb.ComponentState_Working
 	m.statusChanged.Store(true)
 
+	m.runRedoMeta()
+
 	// detect the capture changes
 	m.nodeManager.RegisterNodeChangeHandler(node.ID("maintainer-"+m.id.ID), func(allNodes map[node.ID]*node.Info) {
 		m.nodeChanged.Store(true)
@@ -343,6 +373,8 @@ func (m *Maintainer) onMessage(msg *messaging.TargetMessage) {
 		m.onRemoveMaintainer(req.Cascade, req.Removed)
 	case messaging.TypeCheckpointTsMessage:
 		m.onCheckpointTsPersisted(msg.Message[0].(*heartbeatpb.CheckpointTsMessage))
+	case messaging.TypeMoveTableRequest:
+		m.onMoveTableRequest(msg)
 	default:
 		log.Panic("unexpected message type",
 			zap.String("changefeed", m.id.ID),
@@ -369,6 +401,31 @@ func (m *Maintainer) onCheckpointTsPersisted(msg *heartbeatpb.CheckpointTsMessag
 	})
 }
 
+// onMoveTableRequest moves the table to the target node if the target node is set,
+// and responds the move status of the table to the coordinator.
+func (m *Maintainer) onMoveTableRequest(msg *messaging.TargetMessage) {
+	req := msg.Message[0].(*heartbeatpb.MoveTableRequest)
+	response := &heartbeatpb.MoveTableResponse{
+		ChangefeedID: req.ChangefeedID,
+		RequestId:    req.RequestId,
+		TableId:      req.TableId,
+	}
+	if req.TargetNode != "" {
+		if _, err := m.controller.MoveTable(req.TableId, node.ID(req.TargetNode)); err != nil {
+			response.Error = err.Error()
+		}
+	}
+	if response.Error == "" {
+		response.Spans = m.controller.GetTableMoveStatus(req.TableId)
+		if len(response.Spans) == 0 {
+			response.Error = fmt.Sprintf("table %d is not found in changefeed %s", req.TableId, m.id.ID)
+		}
+	}
+	m.sendMessages([]*messaging.TargetMessage{
+		messaging.NewSingleTargetMessage(msg.From, messaging.CoordinatorTopic, response),
+	})
+}
+
 func (m *Maintainer) onNodeChanged() {
 	currentNodes := m.bootstrapper.GetAllNodes()
 
@@ -430,6 +487,53 @@ func (m *Maintainer) calCheckpointTs() {
 	if newWatermark.ResolvedTs != math.MaxUint64 {
 		m.watermark.ResolvedTs = newWatermark.ResolvedTs
 	}
+	m.updateRedoMeta()
+}
+
+// runRedoMeta starts the redo meta manager in background if the redo log is enabled.
+func (m *Maintainer) runRedoMeta() {
+	if !m.redoMetaManager.Enabled() {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.redoMetaCancel = cancel
+	go func() {
+		err := m.redoMetaManager.Run(ctx)
+		if err != nil && errors.Cause(err) != context.Canceled {
+			m.handleError(err)
+		}
+	}()
+}
+
+// updateRedoMeta updates the redo meta with the watermark of the changefeed.
+// The resolvedTs reported by the dispatchers is the ts that all events before it
+// have been written to the redo log, and the events are always written to the
+// redo log before they are written to the downstream, so the resolvedTs of the
+// redo meta is at least the checkpointTs.
+func (m *Maintainer) updateRedoMeta() {
+	if !m.redoMetaManager.Enabled() || !m.redoMetaManager.Running() {
+		return
+	}
+	m.redoMetaManager.UpdateMeta(m.watermark.CheckpointTs,
+		max(m.watermark.ResolvedTs, m.watermark.CheckpointTs))
+}
+
+// closeRedoMeta stops the redo meta manager,
+// and removes all the redo logs if the changefeed is removed.
+func (m *Maintainer) closeRedoMeta() {
+	if !m.redoMetaManager.Enabled() {
+		return
+	}
+	if m.redoMetaCancel != nil {
+		m.redoMetaCancel()
+	}
+	if m.changefeedRemoved {
+		if err := m.redoMetaManager.Cleanup(context.Background()); err != nil {
+			log.Warn("cleanup redo logs failed",
+				zap.String("changefeed", m.id.ID),
+				zap.Error(err))
+		}
+	}
 }
 
 func (m *Maintainer) updateMetrics() {
@@ -471,9 +575,14 @@ func (m *Maintainer) onHeartBeatRequest(msg *messaging.TargetMessage) {
 		m.errLock.Unlock()
 	}
 	if req.Err != nil {
-		m.errLock.Unlock()
+		log.Warn("dispatcher manager reports error",
+			zap.String("changefeed", m.id.ID),
+			zap.Any("server", msg.From),
+			zap.String("error", req.Err.Message))
+		m.errLock.Lock()
 		m.runningErrors[msg.From] = req.Err
 		m.errLock.Unlock()
+		m.statusChanged.Store(true)
 	}
 }
 
@@ -633,6 +742,9 @@ func (m *Maintainer) getNewBootstrapFn() bootstrap.NewBootstrapMessageFn {
 		EnableSyncPoint:    *cfg.Config.EnableSyncPoint,
 		SyncPointInterval:  cfg.Config.SyncPointInterval,
 		SyncPointRetention: cfg.Config.SyncPointRetention,
+		Consistent:         cfg.Config.Consistent,
+		ScanLimit:          cfg.Config.ScanLimit,
+		TimeZone:           cfg.GetTimezone(),
 		// other fields are not necessary for maintainer
 	}
 	// cfgBytes only holds necessary fields to initialize a changefeed dispatcher.
@@ -15,6 +15,7 @@ package maintainer
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/pingcap/log"
@@ -96,7 +97,7 @@ func NewController(changefeedID string,
 		s.splitter = split.NewSplitter(changefeedID, pdapi, regionCache, config)
 		s.spanReplicationEnabled = true
 	}
-	s.checkController = checker.NewController(changefeedID, s.splitter, oc, replicaSetDB, nodeManager)
+	s.checkController = checker.NewController(changefeedID, s.splitter, oc, replicaSetDB, nodeManager, config)
 	return s
 }
 
@@ -130,6 +131,18 @@ func (c *Controller) HandleStatus(from node.ID, statusList []*heartbeatpb.TableS
 			continue
 		}
 		stm.UpdateStatus(status)
+		if status.HoldTs != 0 && status.ComponentStatus == heartbeatpb.ComponentState_Working {
+			// the merge operator holding the dispatcher is gone, e.g. the maintainer is restarted,
+			// release the dispatcher, otherwise it will never advance.
+			if _, ok := c.operatorController.GetOperator(dispatcherID).(*operator.MergeDispatcherOperator); !ok {
+				log.Info("release the held dispatcher",
+					zap.String("changefeed", c.changefeedID),
+					zap.String("from", from.String()),
+					zap.Uint64("holdTs", status.HoldTs),
+					zap.String("span", dispatcherID.String()))
+				_ = c.messageCenter.SendCommand(stm.NewHoldDispatcherMessage(from, 0))
+			}
+		}
 	}
 }
 
@@ -269,6 +282,60 @@ func (c *Controller) GetAllTasks() []*replica.SpanReplication {
 	return c.replicationDB.GetAllTasks()
 }
 
+// MoveTable moves all spans of the table to the target node,
+// the spans which are on the target node already or being scheduled are skipped.
+// It returns the number of spans to be moved.
+func (c *Controller) MoveTable(tableID int64, target node.ID) (int, error) {
+	if !c.bootstrapped {
+		return 0, fmt.Errorf("changefeed %s is not bootstrapped", c.changefeedID)
+	}
+	if tableID == heartbeatpb.DDLSpan.TableID {
+		return 0, fmt.Errorf("the table trigger event dispatcher can't be moved")
+	}
+	if _, ok := c.nodeManager.GetSchedulableNodes()[target]; !ok {
+		return 0, fmt.Errorf("node %s is not alive or is being drained", target)
+	}
+	spans := c.replicationDB.GetTasksByTableIDs(tableID)
+	if len(spans) == 0 {
+		return 0, fmt.Errorf("table %d is not found in changefeed %s", tableID, c.changefeedID)
+	}
+	moved := 0
+	for _, span := range spans {
+		origin := span.GetNodeID()
+		if origin == "" || origin == target {
+			continue
+		}
+		if c.operatorController.AddOperator(operator.NewMoveDispatcherOperator(c.replicationDB, span, origin, target)) {
+			moved++
+		}
+	}
+	log.Info("move table",
+		zap.String("changefeed", c.changefeedID),
+		zap.Int64("table", tableID),
+		zap.String("target", target.String()),
+		zap.Int("spans", len(spans)),
+		zap.Int("moved", moved))
+	return moved, nil
+}
+
+// GetTableMoveStatus returns the node and the operator of all spans of the table.
+func (c *Controller) GetTableMoveStatus(tableID int64) []*heartbeatpb.TableSpanMoveStatus {
+	spans := c.replicationDB.GetTasksByTableIDs(tableID)
+	statuses := make([]*heartbeatpb.TableSpanMoveStatus, 0, len(spans))
+	for _, span := range spans {
+		status := &heartbeatpb.TableSpanMoveStatus{
+			Id:   span.ID.ToPB(),
+			Span: span.Span,
+			Node: span.GetNodeID().String(),
+		}
+		if op := c.operatorController.GetOperator(span.ID); op != nil {
+			status.Operator = op.String()
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
 // UpdateSchemaID will update the schema id of the table, and move the task to the new schema map
 // it called when rename a table to another schema
 func (c *Controller) UpdateSchemaID(tableID, newSchemaID int64) {
@@ -289,6 +356,11 @@ func (c *Controller) TaskSize() int {
 	return c.replicationDB.TaskSize()
 }
 
+// GetTableSize returns the number of tables of the changefeed
+func (c *Controller) GetTableSize() int {
+	return c.replicationDB.GetTableSize()
+}
+
 func (c *Controller) GetSchedulingSize() int {
 	return c.replicationDB.GetSchedulingSize()
 }
@@ -297,6 +369,11 @@ func (c *Controller) GetTaskSizeByNodeID(id node.ID) int {
 	return c.replicationDB.GetTaskSizeByNodeID(id)
 }
 
+// GetTaskSizePerNode returns the number of spans scheduled to each node
+func (c *Controller) GetTaskSizePerNode() map[node.ID]int {
+	return c.replicationDB.GetTaskSizePerNode()
+}
+
 func (c *Controller) addWorkingSpans(tableMap utils.Map[*heartbeatpb.TableSpan, *replica.SpanReplication]) {
 	tableMap.Ascend(func(span *heartbeatpb.TableSpan, stm *replica.SpanReplication) bool {
 		c.replicationDB.AddReplicatingSpan(stm)
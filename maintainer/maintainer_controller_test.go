@@ -26,13 +26,13 @@ import (
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/ticdc/utils"
 	"github.com/pingcap/ticdc/utils/threadpool"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/processor/tablepb"
-	config2 "github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/pdutil"
 	"github.com/pingcap/tiflow/pkg/spanz"
 	"github.com/stretchr/testify/require"
@@ -45,7 +45,7 @@ func TestSchedule(t *testing.T) {
 	nodeManager.GetAliveNodes()["node2"] = &node.Info{ID: "node2"}
 	nodeManager.GetAliveNodes()["node3"] = &node.Info{ID: "node3"}
 
-	controller := NewController("test", 1, nil, nil, nil, nil, 9, time.Minute)
+	controller := NewController("test", 1, nil, nil, nil, nil, newTestDDLSpan(), 9, time.Minute)
 	for i := 0; i < 1000; i++ {
 		controller.AddNewTable(commonEvent.Table{
 			SchemaID: 1,
@@ -67,7 +67,7 @@ func TestSchedule(t *testing.T) {
 
 func TestRemoveAbsentTask(t *testing.T) {
 	setNodeManagerAndMessageCenter()
-	controller := NewController("test", 1, nil, nil, nil, nil, 9, time.Minute)
+	controller := NewController("test", 1, nil, nil, nil, nil, newTestDDLSpan(), 9, time.Minute)
 	controller.AddNewTable(commonEvent.Table{
 		SchemaID: 1,
 		TableID:  int64(1),
@@ -81,7 +81,7 @@ func TestBalance(t *testing.T) {
 	nodeManager := setNodeManagerAndMessageCenter()
 	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
 
-	s := NewController("test", 1, nil, nil, nil, nil, 1000, 0)
+	s := NewController("test", 1, nil, nil, nil, nil, newTestDDLSpan(), 1000, 0)
 	for i := 0; i < 100; i++ {
 		span := &heartbeatpb.TableSpan{TableID: int64(i)}
 		dispatcherID := common.NewDispatcherID()
@@ -138,7 +138,7 @@ func TestBalance(t *testing.T) {
 func TestStoppedWhenMoving(t *testing.T) {
 	nodeManager := setNodeManagerAndMessageCenter()
 	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
-	s := NewController("test", 1, nil, nil, nil, nil, 1000, 0)
+	s := NewController("test", 1, nil, nil, nil, nil, newTestDDLSpan(), 1000, 0)
 	for i := 0; i < 2; i++ {
 		span := &heartbeatpb.TableSpan{TableID: int64(i)}
 		dispatcherID := common.NewDispatcherID()
@@ -169,7 +169,7 @@ func TestStoppedWhenMoving(t *testing.T) {
 func TestFinishBootstrap(t *testing.T) {
 	nodeManager := setNodeManagerAndMessageCenter()
 	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
-	s := NewController("test", 1, nil, nil, &mockThreadPool{}, nil, 1000, 0)
+	s := NewController("test", 1, nil, nil, &mockThreadPool{}, nil, newTestDDLSpan(), 1000, 0)
 	span := &heartbeatpb.TableSpan{TableID: int64(1)}
 	s.SetInitialTables([]commonEvent.Table{{TableID: 1, SchemaID: 1}})
 
@@ -217,7 +217,7 @@ func TestBalanceUnEvenTask(t *testing.T) {
 	nodeManager := setNodeManagerAndMessageCenter()
 	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
 	nodeManager.GetAliveNodes()["node2"] = &node.Info{ID: "node2"}
-	s := NewController("test", 1, nil, nil, nil, nil, 1000, 0)
+	s := NewController("test", 1, nil, nil, nil, nil, newTestDDLSpan(), 1000, 0)
 
 	for i := 0; i < 4; i++ {
 		span := &heartbeatpb.TableSpan{TableID: int64(i)}
@@ -267,11 +267,11 @@ func TestSplitTableWhenBootstrapFinished(t *testing.T) {
 	nodeManager.GetAliveNodes()["node2"] = &node.Info{ID: "node2"}
 	s := NewController("test", 1,
 		pdAPI,
-		nil, nil, &config2.ChangefeedSchedulerConfig{
+		nil, nil, &config.ChangefeedSchedulerConfig{
 			EnableTableAcrossNodes: true,
 			RegionThreshold:        0,
 			WriteKeyThreshold:      1,
-		}, 1000, 0)
+		}, newTestDDLSpan(), 1000, 0)
 	s.taskScheduler = &mockThreadPool{}
 
 	// 1 is already split, and 2 will be split
@@ -343,11 +343,11 @@ func TestDynamicSplitTableBasic(t *testing.T) {
 	nodeManager.GetAliveNodes()["node2"] = &node.Info{ID: "node2"}
 	s := NewController("test", 1,
 		pdAPI,
-		nil, nil, &config2.ChangefeedSchedulerConfig{
+		nil, nil, &config.ChangefeedSchedulerConfig{
 			EnableTableAcrossNodes: true,
 			RegionThreshold:        0,
 			WriteKeyThreshold:      1,
-		}, 1000, 0)
+		}, newTestDDLSpan(), 1000, 0)
 	s.taskScheduler = &mockThreadPool{}
 
 	totalSpan := spanz.TableIDToComparableSpan(1)
@@ -412,3 +412,63 @@ type mockThreadPool struct {
 func (m *mockThreadPool) Submit(_ threadpool.Task, _ time.Time) *threadpool.TaskHandle {
 	return nil
 }
+
+func TestMoveTable(t *testing.T) {
+	nodeManager := setNodeManagerAndMessageCenter()
+	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
+	nodeManager.GetAliveNodes()["node2"] = &node.Info{ID: "node2"}
+	nodeManager.GetAliveNodes()["node3"] = &node.Info{ID: "node3"}
+	require.True(t, nodeManager.SetNodeUnschedulable("node3"))
+	s := NewController("test", 1, nil, nil, nil, nil, newTestDDLSpan(), 1000, 0)
+	_, err := s.MoveTable(1, "node2")
+	require.Error(t, err)
+	s.bootstrapped = true
+
+	// table 1 has a span on node1 and a span on node2
+	addSpan := func(tableID int64, startKey, endKey string, nodeID node.ID) *replica.SpanReplication {
+		dispatcherID := common.NewDispatcherID()
+		span := &heartbeatpb.TableSpan{TableID: tableID, StartKey: []byte(startKey), EndKey: []byte(endKey)}
+		stm := replica.NewWorkingReplicaSet(model.ChangeFeedID{}, dispatcherID, 1, span, &heartbeatpb.TableSpanStatus{
+			ID:              dispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    10,
+		}, nodeID)
+		s.replicationDB.AddReplicatingSpan(stm)
+		return stm
+	}
+	span1 := addSpan(1, "a", "b", "node1")
+	span2 := addSpan(1, "b", "c", "node2")
+
+	// the target must be alive and schedulable, and the table must exist
+	_, err = s.MoveTable(1, "node4")
+	require.Error(t, err)
+	_, err = s.MoveTable(1, "node3")
+	require.Error(t, err)
+	_, err = s.MoveTable(2, "node2")
+	require.Error(t, err)
+	_, err = s.MoveTable(heartbeatpb.DDLSpan.TableID, "node2")
+	require.Error(t, err)
+	require.Equal(t, 0, s.operatorController.OperatorSize())
+
+	// the span on the target node is skipped
+	moved, err := s.MoveTable(1, "node2")
+	require.NoError(t, err)
+	require.Equal(t, 1, moved)
+	require.NotNil(t, s.operatorController.GetOperator(span1.ID))
+	require.Nil(t, s.operatorController.GetOperator(span2.ID))
+	// the span already under an operator is skipped
+	moved, err = s.MoveTable(1, "node1")
+	require.NoError(t, err)
+	require.Equal(t, 1, moved)
+	require.NotNil(t, s.operatorController.GetOperator(span2.ID))
+	moved, err = s.MoveTable(1, "node1")
+	require.NoError(t, err)
+	require.Equal(t, 0, moved)
+
+	statuses := s.GetTableMoveStatus(1)
+	require.Len(t, statuses, 2)
+	for _, status := range statuses {
+		require.NotEmpty(t, status.Operator)
+	}
+	require.Empty(t, s.GetTableMoveStatus(2))
+}
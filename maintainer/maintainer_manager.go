@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/ticdc/utils/dynstream"
 	"github.com/pingcap/ticdc/utils/threadpool"
 	"github.com/pingcap/tiflow/cdc/model"
@@ -116,6 +117,25 @@ func (m *Manager) recvMessages(ctx context.Context, msg *messaging.TargetMessage
 	case messaging.TypeCheckpointTsMessage:
 		req := msg.Message[0].(*heartbeatpb.CheckpointTsMessage)
 		return m.dispatcherMaintainerMessage(ctx, req.ChangefeedID, msg)
+	// receive move table request from the coordinator
+	case messaging.TypeMoveTableRequest:
+		req := msg.Message[0].(*heartbeatpb.MoveTableRequest)
+		if _, ok := m.maintainers.Load(model.DefaultChangeFeedID(req.ChangefeedID)); !ok {
+			m.sendMoveTableError(msg.From, req, "maintainer is not found")
+			return nil
+		}
+		return m.dispatcherMaintainerMessage(ctx, req.ChangefeedID, msg)
+	// receive drain node request from the coordinator,
+	// the schedulers of the maintainers will move the dispatchers away from the unschedulable nodes
+	case messaging.TypeDrainNodeRequest:
+		req := msg.Message[0].(*heartbeatpb.DrainNodeRequest)
+		nodes := make([]node.ID, 0, len(req.NodeIds))
+		for _, id := range req.NodeIds {
+			nodes = append(nodes, node.ID(id))
+		}
+		nodeManager := appcontext.GetService[*watcher.NodeManager](watcher.NodeManagerName)
+		nodeManager.SetUnschedulableNodes(nodes)
+		return nil
 	default:
 		log.Panic("unknown message type", zap.Any("message", msg.Message))
 	}
@@ -312,6 +332,19 @@ func (m *Manager) handleMessage(msg *messaging.TargetMessage) {
 	}
 }
 
+func (m *Manager) sendMoveTableError(to node.ID, req *heartbeatpb.MoveTableRequest, errMsg string) {
+	response := &heartbeatpb.MoveTableResponse{
+		ChangefeedID: req.ChangefeedID,
+		RequestId:    req.RequestId,
+		TableId:      req.TableId,
+		Error:        errMsg,
+	}
+	err := m.mc.SendCommand(messaging.NewSingleTargetMessage(to, messaging.CoordinatorTopic, response))
+	if err != nil {
+		log.Warn("send command failed", zap.Error(err))
+	}
+}
+
 func (m *Manager) dispatcherMaintainerMessage(
 	ctx context.Context, changefeed string, msg *messaging.TargetMessage,
 ) error {
@@ -335,3 +335,37 @@ func startDispatcherNode(ctx context.Context,
 		dispatcherManager: dispManager,
 	}
 }
+
+type recordMessageCenter struct {
+	messaging.MessageCenter
+	msgs []*messaging.TargetMessage
+}
+
+func (m *recordMessageCenter) SendCommand(msg *messaging.TargetMessage) error {
+	m.msgs = append(m.msgs, msg)
+	return nil
+}
+
+func TestMoveTableRequestWithoutMaintainer(t *testing.T) {
+	mc := &recordMessageCenter{}
+	manager := &Manager{mc: mc}
+	req := &heartbeatpb.MoveTableRequest{
+		ChangefeedID: "test",
+		RequestId:    10,
+		TableId:      1,
+		TargetNode:   "node2",
+	}
+	err := manager.recvMessages(context.Background(),
+		messaging.NewSingleTargetMessage("node1", messaging.MaintainerManagerTopic, req))
+	require.NoError(t, err)
+
+	// the error is replied to the coordinator with the same request id
+	require.Len(t, mc.msgs, 1)
+	require.Equal(t, messaging.CoordinatorTopic, mc.msgs[0].Topic)
+	resp := mc.msgs[0].Message[0].(*heartbeatpb.MoveTableResponse)
+	require.Equal(t, uint64(10), resp.RequestId)
+	require.Equal(t, "test", resp.ChangefeedID)
+	require.Equal(t, int64(1), resp.TableId)
+	require.NotEmpty(t, resp.Error)
+	require.Empty(t, resp.Spans)
+}
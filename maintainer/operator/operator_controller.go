@@ -141,6 +141,35 @@ func (oc *Controller) AddOperator(op operator.Operator[common.DispatcherID, *hea
 	return true
 }
 
+// AddMergeOperator adds a merge operator to the controller, the operator is bound to all spans it merges.
+// If any of the spans has an operator already or is not found, return false.
+func (oc *Controller) AddMergeOperator(op *MergeDispatcherOperator) bool {
+	oc.lock.Lock()
+	defer oc.lock.Unlock()
+
+	for _, id := range op.SpanIDs() {
+		if _, ok := oc.operators[id]; ok {
+			log.Info("add operator failed, operator already exists",
+				zap.String("changefeed", oc.changefeedID),
+				zap.String("span", id.String()),
+				zap.String("operator", op.String()))
+			return false
+		}
+		if oc.replicationDB.GetTaskByID(id) == nil {
+			log.Warn("add operator failed, span not found",
+				zap.String("changefeed", oc.changefeedID),
+				zap.String("span", id.String()),
+				zap.String("operator", op.String()))
+			return false
+		}
+	}
+	for _, id := range op.SpanIDs() {
+		oc.operators[id] = op
+	}
+	oc.pushOperator(op)
+	return true
+}
+
 func (oc *Controller) UpdateOperatorStatus(id common.DispatcherID, from node.ID, status *heartbeatpb.TableSpanStatus) {
 	oc.lock.RLock()
 	defer oc.lock.RUnlock()
@@ -198,7 +227,7 @@ func (oc *Controller) pollQueueingOperator() (operator.Operator[common.Dispatche
 	// always call the PostFinish method to ensure the operator is cleaned up by itself.
 	if op.IsFinished() {
 		op.PostFinish()
-		delete(oc.operators, opID)
+		oc.removeOperatorUnLock(op)
 		metrics.FinishedOperatorCount.WithLabelValues(model.DefaultNamespace, oc.changefeedID, op.Type()).Inc()
 		metrics.OperatorDuration.WithLabelValues(model.DefaultNamespace, oc.changefeedID, op.Type()).Observe(time.Since(item.EnqueueTime).Seconds())
 		log.Info("operator finished",
@@ -232,6 +261,20 @@ func (oc *Controller) removeReplicaSet(op *RemoveDispatcherOperator) {
 	oc.pushOperator(op)
 }
 
+// removeOperatorUnLock removes the finished operator from the controller,
+// the span may be bound to a new operator if the old one is replaced.
+func (oc *Controller) removeOperatorUnLock(op operator.Operator[common.DispatcherID, *heartbeatpb.TableSpanStatus]) {
+	ids := []common.DispatcherID{op.ID()}
+	if merge, ok := op.(*MergeDispatcherOperator); ok {
+		ids = merge.SpanIDs()
+	}
+	for _, id := range ids {
+		if oc.operators[id] == op {
+			delete(oc.operators, id)
+		}
+	}
+}
+
 // pushOperator add an operator to the controller queue.
 func (oc *Controller) pushOperator(op operator.Operator[common.DispatcherID, *heartbeatpb.TableSpanStatus]) {
 	log.Info("add operator to running queue",
@@ -0,0 +1,309 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/messaging"
+	"github.com/pingcap/ticdc/pkg/node"
+	"go.uber.org/zap"
+)
+
+// MergeDispatcherOperator is an operator to remove some adjacent table spans of a table
+// from their dispatchers and then add a merged span to the replication db.
+//
+// The spans are quiesced to a common checkpoint ts before they are removed:
+//  1. All dispatchers are held at holdTs, a held dispatcher drops the events after holdTs,
+//     so its checkpoint ts stops at holdTs.
+//  2. After all dispatchers report that their checkpoint ts reach holdTs, they are removed.
+//  3. The merged span starts from holdTs, so no event is replicated twice or lost.
+//
+// If a dispatcher has received the events after holdTs, it can't be held, the operator is aborted
+// and all dispatchers are released. If the node of a span is removed, the merged span starts
+// from the min checkpoint ts of the spans, which is the same as rescheduling the span.
+type MergeDispatcherOperator struct {
+	db          *replica.ReplicationDB
+	replicaSets []*replica.SpanReplication
+	// the origin node of each replica set
+	originNodes []node.ID
+	// holdTs is the common checkpoint ts of the replica sets, the merged span starts from it
+	holdTs uint64
+	// the checkpoint ts of each replica set, it's updated when the dispatcher is held or stopped
+	checkpointTs []uint64
+	// held is true if the dispatcher is held at holdTs and its checkpoint ts reaches holdTs
+	held    []bool
+	stopped []bool
+	// released is true if the release message is sent to the dispatcher after the operator is aborted
+	released   []bool
+	mergedSpan *heartbeatpb.TableSpan
+	// scheduleIndex is the index of the replica set to send the message to in the next schedule
+	scheduleIndex int
+
+	aborted  bool
+	removed  bool
+	finished atomic.Bool
+
+	lck sync.Mutex
+}
+
+// NewMergeDispatcherOperator creates a new MergeDispatcherOperator, the replica sets must be
+// adjacent spans of the same table and sorted by the start key. holdTs must be larger than
+// the checkpoint ts of all replica sets, and it should be later than the ts the dispatchers
+// have received, or the operator is aborted.
+func NewMergeDispatcherOperator(db *replica.ReplicationDB,
+	replicaSets []*replica.SpanReplication, holdTs uint64) *MergeDispatcherOperator {
+	op := &MergeDispatcherOperator{
+		db:           db,
+		replicaSets:  replicaSets,
+		originNodes:  make([]node.ID, len(replicaSets)),
+		holdTs:       holdTs,
+		checkpointTs: make([]uint64, len(replicaSets)),
+		held:         make([]bool, len(replicaSets)),
+		stopped:      make([]bool, len(replicaSets)),
+		released:     make([]bool, len(replicaSets)),
+		mergedSpan: &heartbeatpb.TableSpan{
+			TableID:  replicaSets[0].Span.TableID,
+			StartKey: replicaSets[0].Span.StartKey,
+			EndKey:   replicaSets[len(replicaSets)-1].Span.EndKey,
+		},
+	}
+	for i, replicaSet := range replicaSets {
+		op.originNodes[i] = replicaSet.GetNodeID()
+		op.checkpointTs[i] = replicaSet.GetStatus().GetCheckpointTs()
+	}
+	return op
+}
+
+func (m *MergeDispatcherOperator) Start() {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+
+	for _, replicaSet := range m.replicaSets {
+		m.db.MarkSpanScheduling(replicaSet)
+	}
+}
+
+func (m *MergeDispatcherOperator) OnNodeRemove(n node.ID) {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+
+	for i, origin := range m.originNodes {
+		if origin == n && !m.stopped[i] {
+			// the events after the last reported checkpoint ts are replicated by the merged span
+			log.Info("origin node is removed",
+				zap.String("replicaSet", m.replicaSets[i].ID.String()),
+				zap.Uint64("checkpointTs", m.checkpointTs[i]))
+			m.stopped[i] = true
+		}
+	}
+	m.checkFinished()
+}
+
+// ID returns the id of the first replica set, the operator is bound to all the replica sets.
+func (m *MergeDispatcherOperator) ID() common.DispatcherID {
+	return m.replicaSets[0].ID
+}
+
+// SpanIDs returns the ids of all the replica sets merged by the operator.
+func (m *MergeDispatcherOperator) SpanIDs() []common.DispatcherID {
+	ids := make([]common.DispatcherID, 0, len(m.replicaSets))
+	for _, replicaSet := range m.replicaSets {
+		ids = append(ids, replicaSet.ID)
+	}
+	return ids
+}
+
+func (m *MergeDispatcherOperator) IsFinished() bool {
+	return m.finished.Load()
+}
+
+func (m *MergeDispatcherOperator) Check(from node.ID, status *heartbeatpb.TableSpanStatus) {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+
+	id := common.NewDispatcherIDFromPB(status.ID)
+	for i, replicaSet := range m.replicaSets {
+		if replicaSet.ID != id {
+			continue
+		}
+		if from != m.originNodes[i] || m.stopped[i] {
+			return
+		}
+		if status.ComponentStatus != heartbeatpb.ComponentState_Working {
+			if status.CheckpointTs > m.checkpointTs[i] {
+				m.checkpointTs[i] = status.CheckpointTs
+			}
+			log.Info("replica set removed from origin node",
+				zap.Uint64("checkpointTs", m.checkpointTs[i]),
+				zap.String("replicaSet", replicaSet.ID.String()))
+			m.stopped[i] = true
+			m.checkFinished()
+			return
+		}
+		if m.aborted || m.held[i] {
+			return
+		}
+		if status.CheckpointTs > m.holdTs {
+			// the dispatcher has written the events after holdTs, the spans can't be merged at holdTs
+			log.Info("replica set can't be held at the hold ts, abort merging",
+				zap.String("replicaSet", replicaSet.ID.String()),
+				zap.Uint64("holdTs", m.holdTs),
+				zap.Uint64("checkpointTs", status.CheckpointTs))
+			m.aborted = true
+			m.checkFinished()
+			return
+		}
+		if status.HoldTs == m.holdTs && status.CheckpointTs == m.holdTs {
+			log.Info("replica set is held at the hold ts",
+				zap.String("replicaSet", replicaSet.ID.String()),
+				zap.Uint64("holdTs", m.holdTs))
+			m.checkpointTs[i] = status.CheckpointTs
+			m.held[i] = true
+		}
+		return
+	}
+}
+
+// Schedule sends the messages to the replica sets which are not stopped in turn.
+// The hold messages are sent until all dispatchers are held, and then the remove messages are sent.
+// If the operator is aborted, a release message is sent to each dispatcher.
+func (m *MergeDispatcherOperator) Schedule() *messaging.TargetMessage {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+
+	allHeld := m.allHeld()
+	for range m.replicaSets {
+		i := m.scheduleIndex
+		m.scheduleIndex = (m.scheduleIndex + 1) % len(m.replicaSets)
+		if m.stopped[i] {
+			continue
+		}
+		switch {
+		case m.aborted:
+			if !m.released[i] {
+				m.released[i] = true
+				m.checkFinished()
+				return m.replicaSets[i].NewHoldDispatcherMessage(m.originNodes[i], 0)
+			}
+		case !allHeld:
+			if !m.held[i] {
+				return m.replicaSets[i].NewHoldDispatcherMessage(m.originNodes[i], m.holdTs)
+			}
+		default:
+			return m.replicaSets[i].NewRemoveDispatcherMessage(m.originNodes[i])
+		}
+	}
+	return nil
+}
+
+// OnTaskRemoved is called when the task is removed by ddl
+func (m *MergeDispatcherOperator) OnTaskRemoved() {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+
+	log.Info("task removed", zap.String("replicaSet", m.ID().String()))
+	m.removed = true
+	m.finished.Store(true)
+}
+
+func (m *MergeDispatcherOperator) PostFinish() {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+
+	if m.removed {
+		return
+	}
+	if m.aborted {
+		// the released dispatchers continue to replicate the spans
+		for i, replicaSet := range m.replicaSets {
+			if m.stopped[i] {
+				m.db.MarkSpanAbsent(replicaSet)
+			} else {
+				m.db.MarkSpanReplicating(replicaSet)
+			}
+		}
+		log.Info("merge dispatcher operator aborted",
+			zap.String("id", m.ID().String()),
+			zap.Uint64("holdTs", m.holdTs))
+		return
+	}
+	checkpointTs := m.minCheckpointTs()
+	if checkpointTs != m.holdTs {
+		// some spans are stopped before they are held, e.g. their node is removed
+		log.Warn("merged span doesn't start from the hold ts",
+			zap.String("id", m.ID().String()),
+			zap.Uint64("holdTs", m.holdTs),
+			zap.Uint64("checkpointTs", checkpointTs))
+	}
+	m.db.ReplaceReplicaSet(m.replicaSets, []*heartbeatpb.TableSpan{m.mergedSpan}, checkpointTs)
+	log.Info("merge dispatcher operator finished",
+		zap.String("id", m.ID().String()),
+		zap.Uint64("checkpointTs", checkpointTs))
+}
+
+func (m *MergeDispatcherOperator) String() string {
+	ids := make([]string, 0, len(m.replicaSets))
+	for _, replicaSet := range m.replicaSets {
+		ids = append(ids, replicaSet.ID.String())
+	}
+	return fmt.Sprintf("merge dispatcher operator: [%s], mergedSpan:[%s,%s], holdTs:%d",
+		strings.Join(ids, ","),
+		hex.EncodeToString(m.mergedSpan.StartKey), hex.EncodeToString(m.mergedSpan.EndKey), m.holdTs)
+}
+
+func (m *MergeDispatcherOperator) Type() string {
+	return "merge"
+}
+
+// checkFinished marks the operator finished if all replica sets are stopped,
+// or all replica sets are stopped or released after the operator is aborted.
+func (m *MergeDispatcherOperator) checkFinished() {
+	for i, stopped := range m.stopped {
+		if !stopped && (!m.aborted || !m.released[i]) {
+			return
+		}
+	}
+	m.finished.Store(true)
+}
+
+// allHeld returns true if all replica sets which are not stopped are held at the hold ts.
+func (m *MergeDispatcherOperator) allHeld() bool {
+	for i, held := range m.held {
+		if !held && !m.stopped[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// minCheckpointTs returns the min checkpoint ts of the replica sets, which is the start ts
+// of the merged span. It equals to the hold ts if all replica sets are held before stopped.
+func (m *MergeDispatcherOperator) minCheckpointTs() uint64 {
+	minTs := m.checkpointTs[0]
+	for _, ts := range m.checkpointTs[1:] {
+		if ts < minTs {
+			minTs = ts
+		}
+	}
+	return minTs
+}
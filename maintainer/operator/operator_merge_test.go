@@ -0,0 +1,195 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+const testHoldTs = 100
+
+func newTestMergeSpans(t *testing.T, checkpointTs []uint64, nodes []node.ID) (*Controller, []*replica.SpanReplication) {
+	ddlSpan := replica.NewReplicaSet(model.ChangeFeedID{}, common.NewDispatcherID(), 1, heartbeatpb.DDLSpan, 1)
+	db := replica.NewReplicaSetDB("test", ddlSpan)
+	oc := NewOperatorController("test", nil, db, 1000)
+	keys := []string{"a", "b", "c", "d", "e", "f"}
+	var spans []*replica.SpanReplication
+	for i, ts := range checkpointTs {
+		id := common.NewDispatcherID()
+		span := replica.NewWorkingReplicaSet(model.ChangeFeedID{}, id, 1,
+			&heartbeatpb.TableSpan{TableID: 1, StartKey: []byte(keys[i]), EndKey: []byte(keys[i+1])},
+			&heartbeatpb.TableSpanStatus{
+				ID:              id.ToPB(),
+				ComponentStatus: heartbeatpb.ComponentState_Working,
+				CheckpointTs:    ts,
+			}, nodes[i])
+		db.AddReplicatingSpan(span)
+		spans = append(spans, span)
+	}
+	require.True(t, oc.AddMergeOperator(NewMergeDispatcherOperator(db, spans, testHoldTs)))
+	require.Equal(t, len(spans), db.GetSchedulingSize())
+	for _, span := range spans {
+		require.NotNil(t, oc.GetOperator(span.ID))
+	}
+	return oc, spans
+}
+
+func reportStatus(oc *Controller, from node.ID, span *replica.SpanReplication,
+	state heartbeatpb.ComponentState, checkpointTs uint64, holdTs uint64) {
+	oc.UpdateOperatorStatus(span.ID, from, &heartbeatpb.TableSpanStatus{
+		ID:              span.ID.ToPB(),
+		ComponentStatus: state,
+		CheckpointTs:    checkpointTs,
+		HoldTs:          holdTs,
+	})
+}
+
+func requireScheduleRequest(t *testing.T, op *MergeDispatcherOperator, span *replica.SpanReplication,
+	action heartbeatpb.ScheduleAction, holdTs uint64) {
+	msg := op.Schedule()
+	require.NotNil(t, msg)
+	require.Equal(t, span.GetNodeID(), msg.To)
+	req := msg.Message[0].(*heartbeatpb.ScheduleDispatcherRequest)
+	require.Equal(t, action, req.ScheduleAction)
+	require.Equal(t, span.ID.ToPB(), req.Config.DispatcherID)
+	require.Equal(t, holdTs, req.HoldTs)
+}
+
+func TestMergeDispatcherOperator(t *testing.T) {
+	oc, spans := newTestMergeSpans(t, []uint64{10, 20, 15}, []node.ID{"node1", "node2", "node1"})
+	op := oc.GetOperator(spans[0].ID).(*MergeDispatcherOperator)
+	// the span can't be merged by another operator
+	require.False(t, oc.AddMergeOperator(NewMergeDispatcherOperator(oc.replicationDB, spans[1:], testHoldTs)))
+
+	// the hold messages are sent to all spans in turn
+	for i := 0; i < 2*len(spans); i++ {
+		requireScheduleRequest(t, op, spans[i%len(spans)], heartbeatpb.ScheduleAction_Hold, testHoldTs)
+	}
+
+	// the dispatcher is held, but the events before the hold ts are still flushing
+	reportStatus(oc, "node1", spans[0], heartbeatpb.ComponentState_Working, 50, testHoldTs)
+	// the status from another node is ignored
+	reportStatus(oc, "node1", spans[1], heartbeatpb.ComponentState_Working, testHoldTs, testHoldTs)
+	// the checkpoint ts of the dispatchers reach the hold ts
+	reportStatus(oc, "node1", spans[0], heartbeatpb.ComponentState_Working, testHoldTs, testHoldTs)
+	reportStatus(oc, "node1", spans[2], heartbeatpb.ComponentState_Working, testHoldTs, testHoldTs)
+	// the hold message is only sent to the span which is not held,
+	// no span is removed before all spans are held
+	requireScheduleRequest(t, op, spans[1], heartbeatpb.ScheduleAction_Hold, testHoldTs)
+	requireScheduleRequest(t, op, spans[1], heartbeatpb.ScheduleAction_Hold, testHoldTs)
+	reportStatus(oc, "node2", spans[1], heartbeatpb.ComponentState_Working, testHoldTs, testHoldTs)
+
+	// the remove messages are sent to all spans in turn after all spans are held
+	for i := 0; i < len(spans); i++ {
+		msg := op.Schedule()
+		req := msg.Message[0].(*heartbeatpb.ScheduleDispatcherRequest)
+		require.Equal(t, heartbeatpb.ScheduleAction_Remove, req.ScheduleAction)
+	}
+	for i, span := range spans {
+		reportStatus(oc, span.GetNodeID(), span, heartbeatpb.ComponentState_Stopped, testHoldTs, testHoldTs)
+		require.Equal(t, i == len(spans)-1, op.IsFinished())
+	}
+
+	_, next := oc.pollQueueingOperator()
+	require.True(t, next)
+	require.Equal(t, 0, oc.OperatorSize())
+	for _, span := range spans {
+		require.Nil(t, oc.replicationDB.GetTaskByID(span.ID))
+	}
+	// all spans have flushed the events up to the hold ts and dropped the events after it,
+	// the merged span starts from the hold ts, so no event is replicated again.
+	absent := oc.replicationDB.GetAbsent(nil, 10)
+	require.Len(t, absent, 1)
+	require.Equal(t, &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("d")}, absent[0].Span)
+	req := absent[0].NewAddDispatcherMessage("node1").Message[0].(*heartbeatpb.ScheduleDispatcherRequest)
+	require.Equal(t, uint64(testHoldTs), req.Config.StartTs)
+}
+
+func TestMergeDispatcherOperatorAborted(t *testing.T) {
+	oc, spans := newTestMergeSpans(t, []uint64{10, 20, 15}, []node.ID{"node1", "node2", "node1"})
+	op := oc.GetOperator(spans[0].ID).(*MergeDispatcherOperator)
+
+	reportStatus(oc, "node1", spans[0], heartbeatpb.ComponentState_Working, testHoldTs, testHoldTs)
+	// the dispatcher has written the events after the hold ts, it can't be held
+	reportStatus(oc, "node2", spans[1], heartbeatpb.ComponentState_Working, testHoldTs+10, 0)
+	require.False(t, op.IsFinished())
+
+	// all dispatchers are released
+	for _, span := range spans {
+		require.False(t, op.IsFinished())
+		requireScheduleRequest(t, op, span, heartbeatpb.ScheduleAction_Hold, 0)
+	}
+	require.True(t, op.IsFinished())
+	require.Nil(t, op.Schedule())
+
+	// the spans are not merged and continue to be replicated by the origin dispatchers
+	_, next := oc.pollQueueingOperator()
+	require.True(t, next)
+	require.Equal(t, 0, oc.OperatorSize())
+	require.Equal(t, 0, oc.replicationDB.GetAbsentSize())
+	require.Equal(t, len(spans), oc.replicationDB.GetReplicatingSize())
+	for _, span := range spans {
+		require.NotNil(t, oc.replicationDB.GetTaskByID(span.ID))
+	}
+}
+
+func TestMergeDispatcherOperatorNodeRemoved(t *testing.T) {
+	oc, spans := newTestMergeSpans(t, []uint64{10, 20}, []node.ID{"node1", "node2"})
+	op := oc.GetOperator(spans[0].ID)
+
+	reportStatus(oc, "node1", spans[0], heartbeatpb.ComponentState_Working, testHoldTs, testHoldTs)
+	// the span on the removed node starts from its last reported checkpoint ts
+	oc.OnNodeRemoved("node2")
+	require.False(t, op.IsFinished())
+	msg := op.Schedule()
+	require.Equal(t, heartbeatpb.ScheduleAction_Remove, msg.Message[0].(*heartbeatpb.ScheduleDispatcherRequest).ScheduleAction)
+	reportStatus(oc, "node1", spans[0], heartbeatpb.ComponentState_Stopped, testHoldTs, testHoldTs)
+	require.True(t, op.IsFinished())
+	_, next := oc.pollQueueingOperator()
+	require.True(t, next)
+	require.Equal(t, 0, oc.OperatorSize())
+
+	absent := oc.replicationDB.GetAbsent(nil, 10)
+	require.Len(t, absent, 1)
+	req := absent[0].NewAddDispatcherMessage("node1").Message[0].(*heartbeatpb.ScheduleDispatcherRequest)
+	require.Equal(t, uint64(20), req.Config.StartTs)
+	require.Equal(t, &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("c")}, req.Config.Span)
+}
+
+func TestMergeDispatcherOperatorTaskRemoved(t *testing.T) {
+	oc, spans := newTestMergeSpans(t, []uint64{10, 20, 15}, []node.ID{"node1", "node2", "node1"})
+	op := oc.GetOperator(spans[0].ID)
+
+	reportStatus(oc, "node1", spans[0], heartbeatpb.ComponentState_Working, testHoldTs, testHoldTs)
+	// the table is dropped while merging
+	oc.RemoveTasksByTableIDs(1)
+	require.True(t, op.IsFinished())
+	for _, span := range spans {
+		_, ok := oc.GetOperator(span.ID).(*RemoveDispatcherOperator)
+		require.True(t, ok)
+	}
+
+	// the merge operator doesn't add the merged span, and doesn't remove the new operators
+	_, next := oc.pollQueueingOperator()
+	require.True(t, next)
+	require.Equal(t, 0, oc.replicationDB.GetAbsentSize())
+	require.Equal(t, len(spans), oc.OperatorSize())
+}
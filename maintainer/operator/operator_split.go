@@ -121,7 +121,7 @@ func (m *SplitDispatcherOperator) PostFinish() {
 	m.lck.Lock()
 	defer m.lck.Unlock()
 
-	m.db.ReplaceReplicaSet(m.replicaSet, m.splitSpans, m.checkpointTs)
+	m.db.ReplaceReplicaSet([]*replica.SpanReplication{m.replicaSet}, m.splitSpans, m.checkpointTs)
 	log.Info("split dispatcher operator finished", zap.String("id", m.replicaSet.ID.String()))
 }
 
@@ -133,3 +133,8 @@ func (m *SplitDispatcherOperator) String() string {
 func (m *SplitDispatcherOperator) Type() string {
 	return "split"
 }
+
+// SplitSpans returns the spans the replica set is split into.
+func (m *SplitDispatcherOperator) SplitSpans() []*heartbeatpb.TableSpan {
+	return m.splitSpans
+}
@@ -72,6 +72,14 @@ func (db *ReplicationDB) TaskSize() int {
 	return len(db.allTasks) - 1
 }
 
+// GetTableSize returns the number of tables in the db, the ddl span is not included
+func (db *ReplicationDB) GetTableSize() int {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	return len(db.tableTasks)
+}
+
 // TryRemoveAll removes non-scheduled tasks from the db and return the scheduled tasks
 func (db *ReplicationDB) TryRemoveAll() []*SpanReplication {
 	db.lock.Lock()
@@ -292,31 +300,33 @@ func (db *ReplicationDB) AddAbsentReplicaSet(tasks ...*SpanReplication) {
 	db.addAbsentReplicaSetUnLock(tasks...)
 }
 
-// ReplaceReplicaSet replaces the old replica set with the new spans
-func (db *ReplicationDB) ReplaceReplicaSet(old *SpanReplication, newSpans []*heartbeatpb.TableSpan, checkpointTs uint64) bool {
+// ReplaceReplicaSet replaces the old replica sets with the new spans
+func (db *ReplicationDB) ReplaceReplicaSet(olds []*SpanReplication, newSpans []*heartbeatpb.TableSpan, checkpointTs uint64) bool {
 	db.lock.Lock()
 	defer db.lock.Unlock()
 
-	// first check  the old replica set exists, if not, return false
-	if _, ok := db.allTasks[old.ID]; !ok {
-		log.Warn("old replica set not found, skip",
-			zap.String("changefeed", db.changefeedID),
-			zap.String("span", old.ID.String()))
-		return false
+	// first check  the old replica sets exist, if not, return false
+	for _, old := range olds {
+		if _, ok := db.allTasks[old.ID]; !ok {
+			log.Warn("old replica set not found, skip",
+				zap.String("changefeed", db.changefeedID),
+				zap.String("span", old.ID.String()))
+			return false
+		}
 	}
 
 	var news []*SpanReplication
 	for _, span := range newSpans {
 		news = append(news,
 			NewReplicaSet(
-				old.ChangefeedID,
+				olds[0].ChangefeedID,
 				common.NewDispatcherID(),
-				old.GetSchemaID(),
+				olds[0].GetSchemaID(),
 				span, checkpointTs))
 	}
 
 	// remove and insert the new replica set
-	db.removeSpanUnLock(old)
+	db.removeSpanUnLock(olds...)
 	db.addAbsentReplicaSetUnLock(news...)
 	return true
 }
@@ -100,6 +100,11 @@ func (r *SpanReplication) UpdateStatus(newStatus *heartbeatpb.TableSpanStatus) {
 	}
 }
 
+// GetStatus returns the latest status reported by the dispatcher of the span.
+func (r *SpanReplication) GetStatus() *heartbeatpb.TableSpanStatus {
+	return r.status
+}
+
 func (r *SpanReplication) GetSchemaID() int64 {
 	return r.schemaID
 }
@@ -134,6 +139,20 @@ func (r *SpanReplication) NewRemoveDispatcherMessage(server node.ID) *messaging.
 	return NewRemoveDispatcherMessage(server, r.ChangefeedID.ID, r.ID.ToPB())
 }
 
+// NewHoldDispatcherMessage creates a message to hold the dispatcher at holdTs, 0 means releasing it.
+func (r *SpanReplication) NewHoldDispatcherMessage(server node.ID, holdTs uint64) *messaging.TargetMessage {
+	return messaging.NewSingleTargetMessage(server,
+		messaging.HeartbeatCollectorTopic,
+		&heartbeatpb.ScheduleDispatcherRequest{
+			ChangefeedID: r.ChangefeedID.ID,
+			Config: &heartbeatpb.DispatcherConfig{
+				DispatcherID: r.ID.ToPB(),
+			},
+			ScheduleAction: heartbeatpb.ScheduleAction_Hold,
+			HoldTs:         holdTs,
+		})
+}
+
 func NewRemoveDispatcherMessage(server node.ID, cfID string, dispatcherID *heartbeatpb.DispatcherID) *messaging.TargetMessage {
 	return messaging.NewSingleTargetMessage(server,
 		messaging.HeartbeatCollectorTopic,
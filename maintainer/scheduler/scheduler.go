@@ -73,23 +73,59 @@ func (s *Scheduler) Execute() time.Time {
 		}
 		absent := s.replicationDB.GetAbsent(s.absent, availableSize)
 		nodeSize := s.replicationDB.GetTaskSizePerNode()
-		// add the absent node to the node size map
-		// todo: use the bootstrap nodes
-		for id, _ := range s.nodeManager.GetAliveNodes() {
-			if _, ok := nodeSize[id]; !ok {
-				nodeSize[id] = 0
-			}
-		}
+		s.fillSchedulableNodes(nodeSize)
 		scheduler.BasicSchedule(availableSize, absent, nodeSize, func(replication *replica.SpanReplication, id node.ID) bool {
 			return s.operatorController.AddOperator(operator.NewAddDispatcherOperator(s.replicationDB, replication, id))
 		})
 		s.absent = absent[:0]
-	} else {
+	} else if !s.drain() {
 		s.balance()
 	}
 	return time.Now().Add(time.Millisecond * 500)
 }
 
+// drain moves the replicating spans away from the unschedulable nodes,
+// returns true if there are spans on the unschedulable nodes
+func (s *Scheduler) drain() bool {
+	if len(s.nodeManager.GetUnschedulableNodes()) == 0 {
+		return false
+	}
+	var victims []*replica.SpanReplication
+	for _, span := range s.replicationDB.GetReplicating() {
+		if s.nodeManager.IsNodeUnschedulable(span.GetNodeID()) {
+			victims = append(victims, span)
+		}
+	}
+	if len(victims) == 0 {
+		return false
+	}
+	availableSize := s.batchSize - s.operatorController.OperatorSize()
+	if availableSize <= 0 {
+		return true
+	}
+	nodeSize := s.replicationDB.GetTaskSizePerNode()
+	s.fillSchedulableNodes(nodeSize)
+	scheduler.BasicSchedule(availableSize, victims, nodeSize, func(replication *replica.SpanReplication, id node.ID) bool {
+		return s.operatorController.AddOperator(operator.NewMoveDispatcherOperator(s.replicationDB, replication, replication.GetNodeID(), id))
+	})
+	return true
+}
+
+// fillSchedulableNodes removes the unschedulable nodes from the node size map,
+// and adds the schedulable nodes that have no spans to it
+func (s *Scheduler) fillSchedulableNodes(nodeSize map[node.ID]int) {
+	for _, id := range s.nodeManager.GetUnschedulableNodes() {
+		delete(nodeSize, id)
+	}
+	// add the absent node to the node size map
+	// todo: use the bootstrap nodes
+	for id, _ := range s.nodeManager.GetSchedulableNodes() {
+		if _, ok := nodeSize[id]; !ok {
+			nodeSize[id] = 0
+		}
+	}
+}
+
 // balance balances the spans by size
 func (s *Scheduler) balance() {
 	if time.Since(s.lastRebalanceTime) < s.checkBalanceInterval {
@@ -106,12 +142,14 @@ func (s *Scheduler) balance() {
 	}
 
 	// check the balance status
-	moveSize := scheduler.CheckBalanceStatus(s.replicationDB.GetTaskSizePerNode(), s.nodeManager.GetAliveNodes())
+	nodeSize := s.replicationDB.GetTaskSizePerNode()
+	s.fillSchedulableNodes(nodeSize)
+	moveSize := scheduler.CheckBalanceStatus(nodeSize, s.nodeManager.GetSchedulableNodes())
 	if moveSize <= 0 {
 		// fast check the balance status, no need to do the balance,skip
 		return
 	}
-	scheduler.Balance(s.batchSize, s.random, s.nodeManager.GetAliveNodes(), s.replicationDB.GetReplicating(), func(replication *replica.SpanReplication, id node.ID) bool {
+	scheduler.Balance(s.batchSize, s.random, s.nodeManager.GetSchedulableNodes(), s.replicationDB.GetReplicating(), func(replication *replica.SpanReplication, id node.ID) bool {
 		return s.operatorController.AddOperator(operator.NewMoveDispatcherOperator(s.replicationDB, replication, replication.GetNodeID(), id))
 	})
 	s.lastRebalanceTime = now
@@ -0,0 +1,125 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/operator"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/server/watcher"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulerDrainUnschedulableNode(t *testing.T) {
+	ddlSpan := replica.NewReplicaSet(model.ChangeFeedID{}, common.NewDispatcherID(), 1, heartbeatpb.DDLSpan, 1)
+	db := replica.NewReplicaSetDB("test", ddlSpan)
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	for _, id := range []node.ID{"node1", "node2", "node3"} {
+		nodeManager.GetAliveNodes()[id] = &node.Info{ID: id}
+	}
+	oc := operator.NewOperatorController("test", nil, db, 1000)
+	s := NewScheduler("test", 1000, oc, db, nodeManager, time.Hour)
+
+	addSpan := func(tableID int64, nodeID node.ID) *replica.SpanReplication {
+		id := common.NewDispatcherID()
+		span := replica.NewWorkingReplicaSet(model.ChangeFeedID{}, id, 1,
+			&heartbeatpb.TableSpan{TableID: tableID},
+			&heartbeatpb.TableSpanStatus{
+				ID:              id.ToPB(),
+				ComponentStatus: heartbeatpb.ComponentState_Working,
+				CheckpointTs:    1,
+			}, nodeID)
+		db.AddReplicatingSpan(span)
+		return span
+	}
+	var drained []*replica.SpanReplication
+	for i := 0; i < 4; i++ {
+		drained = append(drained, addSpan(int64(i+1), "node1"))
+	}
+	other := addSpan(5, "node2")
+
+	require.False(t, nodeManager.SetNodeUnschedulable("node4"))
+	require.True(t, nodeManager.SetNodeUnschedulable("node1"))
+	require.True(t, nodeManager.IsNodeUnschedulable("node1"))
+	require.Len(t, nodeManager.GetSchedulableNodes(), 2)
+
+	s.Execute()
+	dests := make(map[node.ID]int)
+	for _, span := range drained {
+		op := oc.GetOperator(span.ID)
+		require.NotNil(t, op)
+		require.Equal(t, "move", op.Type())
+		msg := op.Schedule()
+		require.Equal(t, node.ID("node1"), msg.To)
+		op.Check("node1", &heartbeatpb.TableSpanStatus{ID: span.ID.ToPB(), ComponentStatus: heartbeatpb.ComponentState_Stopped})
+		msg = op.Schedule()
+		require.Equal(t, heartbeatpb.ScheduleAction_Create, msg.Message[0].(*heartbeatpb.ScheduleDispatcherRequest).ScheduleAction)
+		dests[msg.To]++
+	}
+	// the spans are moved to the least loaded schedulable nodes
+	require.Len(t, dests, 2)
+	require.Equal(t, 4, dests["node2"]+dests["node3"])
+	require.LessOrEqual(t, 1+dests["node2"]-dests["node3"], 1)
+	require.GreaterOrEqual(t, 1+dests["node2"]-dests["node3"], -1)
+	require.Nil(t, oc.GetOperator(other.ID))
+
+	// the absent spans are not scheduled to the unschedulable node
+	absent := replica.NewReplicaSet(model.ChangeFeedID{}, common.NewDispatcherID(), 1,
+		&heartbeatpb.TableSpan{TableID: 6}, 1)
+	db.AddAbsentReplicaSet(absent)
+	s.Execute()
+	op := oc.GetOperator(absent.ID)
+	require.NotNil(t, op)
+	require.NotEqual(t, node.ID("node1"), op.Schedule().To)
+}
+
+func TestSchedulerUndrainNode(t *testing.T) {
+	ddlSpan := replica.NewReplicaSet(model.ChangeFeedID{}, common.NewDispatcherID(), 1, heartbeatpb.DDLSpan, 1)
+	db := replica.NewReplicaSetDB("test", ddlSpan)
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	for _, id := range []node.ID{"node1", "node2"} {
+		nodeManager.GetAliveNodes()[id] = &node.Info{ID: id}
+	}
+	oc := operator.NewOperatorController("test", nil, db, 1000)
+	s := NewScheduler("test", 1000, oc, db, nodeManager, 0)
+	for i := 0; i < 4; i++ {
+		id := common.NewDispatcherID()
+		db.AddReplicatingSpan(replica.NewWorkingReplicaSet(model.ChangeFeedID{}, id, 1,
+			&heartbeatpb.TableSpan{TableID: int64(i + 1)},
+			&heartbeatpb.TableSpanStatus{
+				ID:              id.ToPB(),
+				ComponentStatus: heartbeatpb.ComponentState_Working,
+				CheckpointTs:    1,
+			}, "node2"))
+	}
+
+	// the unschedulable nodes are synced from the coordinator, the spans are not balanced to them
+	nodeManager.SetUnschedulableNodes([]node.ID{"node1"})
+	require.True(t, nodeManager.IsNodeUnschedulable("node1"))
+	s.Execute()
+	require.Equal(t, 0, oc.OperatorSize())
+
+	// the spans are balanced to the node after it's undrained
+	nodeManager.SetUnschedulableNodes(nil)
+	require.False(t, nodeManager.IsNodeUnschedulable("node1"))
+	s.Execute()
+	require.Equal(t, 2, oc.OperatorSize())
+	require.False(t, nodeManager.SetNodeSchedulable("node1"))
+}
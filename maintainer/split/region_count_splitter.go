@@ -63,9 +63,48 @@ func (m *regionCountSplitter) split(
 		return []*heartbeatpb.TableSpan{span}
 	}
 
-	stepper := newEvenlySplitStepper(
-		getSpansNumber(len(regions), captureNum),
-		len(regions))
+	spans := m.splitByRegions(bo, span, regions, getSpansNumber(len(regions), captureNum))
+	if len(spans) > 1 {
+		log.Info("split span by region count",
+			zap.String("changefeed", m.changefeedID.ID),
+			zap.String("span", span.String()),
+			zap.Int("spans", len(spans)),
+			zap.Int("totalCaptures", captureNum),
+			zap.Int("regionCount", len(regions)),
+			zap.Int("regionThreshold", m.regionThreshold),
+			zap.Int("spanRegionLimit", spanRegionLimit))
+	}
+	return spans
+}
+
+// splitEvenly splits the span into spansNum spans with the same region count,
+// the span is not split if it has only one region.
+func (m *regionCountSplitter) splitEvenly(
+	ctx context.Context, span *heartbeatpb.TableSpan, spansNum int,
+) []*heartbeatpb.TableSpan {
+	bo := tikv.NewBackoffer(ctx, 500)
+	regions, err := m.regionCache.ListRegionIDsInKeyRange(bo, span.StartKey, span.EndKey)
+	if err != nil {
+		log.Warn("list regions failed, skip split span",
+			zap.String("changefeed", m.changefeedID.ID),
+			zap.String("span", span.String()),
+			zap.Error(err))
+		return []*heartbeatpb.TableSpan{span}
+	}
+	if len(regions) <= 1 || spansNum <= 1 {
+		return []*heartbeatpb.TableSpan{span}
+	}
+	if spansNum > maxSpanNumber {
+		spansNum = maxSpanNumber
+	}
+	return m.splitByRegions(bo, span, regions, spansNum)
+}
+
+// splitByRegions splits the span into spansNum spans by the regions of the span.
+func (m *regionCountSplitter) splitByRegions(
+	bo *tikv.Backoffer, span *heartbeatpb.TableSpan, regions []uint64, spansNum int,
+) []*heartbeatpb.TableSpan {
+	stepper := newEvenlySplitStepper(spansNum, len(regions))
 
 	spans := make([]*heartbeatpb.TableSpan, 0, stepper.SpanCount())
 	start, end := 0, stepper.Step()
@@ -119,14 +158,6 @@ func (m *regionCountSplitter) split(
 	// Make sure spans does not exceed [startKey, endKey).
 	spans[0].StartKey = span.StartKey
 	spans[len(spans)-1].EndKey = span.EndKey
-	log.Info("split span by region count",
-		zap.String("changefeed", m.changefeedID.ID),
-		zap.String("span", span.String()),
-		zap.Int("spans", len(spans)),
-		zap.Int("totalCaptures", captureNum),
-		zap.Int("regionCount", len(regions)),
-		zap.Int("regionThreshold", m.regionThreshold),
-		zap.Int("spanRegionLimit", spanRegionLimit))
 	return spans
 }
 
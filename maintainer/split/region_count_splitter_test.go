@@ -242,6 +242,31 @@ func TestSplitSpanRegionOutOfOrder(t *testing.T) {
 		t, []*heartbeatpb.TableSpan{&heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("t1"), EndKey: []byte("t2")}}, spans)
 }
 
+func TestRegionCountSplitSpanEvenly(t *testing.T) {
+	t.Parallel()
+
+	cache := NewMockRegionCache(nil)
+	cache.regions.ReplaceOrInsert(tablepb.Span{StartKey: []byte("t1_0"), EndKey: []byte("t1_1")}, 1)
+	cache.regions.ReplaceOrInsert(tablepb.Span{StartKey: []byte("t1_1"), EndKey: []byte("t1_2")}, 2)
+	cache.regions.ReplaceOrInsert(tablepb.Span{StartKey: []byte("t1_2"), EndKey: []byte("t1_3")}, 3)
+	cache.regions.ReplaceOrInsert(tablepb.Span{StartKey: []byte("t1_3"), EndKey: []byte("t2")}, 4)
+
+	// the region threshold doesn't affect splitting evenly
+	splitter := newRegionCountSplitter(model.ChangeFeedID{}, cache, 100)
+	span := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("t1"), EndKey: []byte("t2")}
+	require.Equal(t, []*heartbeatpb.TableSpan{span}, splitter.splitEvenly(context.Background(), span, 1))
+	require.Equal(t, []*heartbeatpb.TableSpan{
+		{TableID: 1, StartKey: []byte("t1"), EndKey: []byte("t1_2")},
+		{TableID: 1, StartKey: []byte("t1_2"), EndKey: []byte("t2")},
+	}, splitter.splitEvenly(context.Background(), span, 2))
+	// a span can't be split into more spans than its regions
+	require.Len(t, splitter.splitEvenly(context.Background(), span, 10), 4)
+
+	// a span with only one region is not split
+	span = &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("t1_1"), EndKey: []byte("t1_2")}
+	require.Equal(t, []*heartbeatpb.TableSpan{span}, splitter.splitEvenly(context.Background(), span, 2))
+}
+
 // mockCache mocks tikv.RegionCache.
 type mockCache struct {
 	regions *spanz.BtreeMap[uint64]
@@ -56,6 +56,8 @@ type splitter interface {
 type Splitter struct {
 	splitters    []splitter
 	changefeedID model.ChangeFeedID
+	// regionCounter is used to split the hot spans by the throughput
+	regionCounter *regionCountSplitter
 }
 
 // NewSplitter returns a Splitter.
@@ -66,13 +68,15 @@ func NewSplitter(
 	config *config.ChangefeedSchedulerConfig,
 ) *Splitter {
 	changefeedID := model.DefaultChangeFeedID(cf)
+	regionCounter := newRegionCountSplitter(changefeedID, regionCache, config.RegionThreshold)
 	return &Splitter{
 		changefeedID: changefeedID,
 		splitters: []splitter{
 			// write splitter has the highest priority.
 			newWriteSplitter(changefeedID, pdapi, config.WriteKeyThreshold),
-			newRegionCountSplitter(changefeedID, regionCache, config.RegionThreshold),
+			regionCounter,
 		},
+		regionCounter: regionCounter,
 	}
 }
 
@@ -89,6 +93,14 @@ func (s *Splitter) SplitSpans(ctx context.Context,
 	return spans
 }
 
+// SplitSpansEvenly splits the span into spansNum spans with the same region count,
+// it's used to split the hot span whose throughput exceeds the threshold.
+func (s *Splitter) SplitSpansEvenly(ctx context.Context,
+	span *heartbeatpb.TableSpan,
+	spansNum int) []*heartbeatpb.TableSpan {
+	return s.regionCounter.splitEvenly(ctx, span, spansNum)
+}
+
 // FindHoles returns an array of Span that are not covered in the range
 func FindHoles(currentSpan utils.Map[*heartbeatpb.TableSpan, *replica.SpanReplication], totalSpan *heartbeatpb.TableSpan) []*heartbeatpb.TableSpan {
 	lastSpan := &heartbeatpb.TableSpan{
@@ -47,6 +47,11 @@ type ChangefeedInterface interface {
 	Get(ctx context.Context, namespace string, name string) (*v2.ChangeFeedInfo, error)
 	// List lists all changefeeds
 	List(ctx context.Context, namespace string, state string) ([]v2.ChangefeedCommonInfo, error)
+	// MoveTable moves a table of a changefeed to the target node
+	MoveTable(ctx context.Context, namespace string, name string,
+		tableID int64, targetNodeID string) (*v2.TableMoveStatus, error)
+	// GetTableMoveStatus gets the move status of a table of a changefeed
+	GetTableMoveStatus(ctx context.Context, namespace string, name string, tableID int64) (*v2.TableMoveStatus, error)
 }
 
 // changefeeds implements ChangefeedInterface
@@ -157,3 +162,30 @@ func (c *changefeeds) List(ctx context.Context,
 		Into(result)
 	return result.Items, err
 }
+
+// MoveTable moves a table of a changefeed to the target node
+func (c *changefeeds) MoveTable(ctx context.Context,
+	namespace string, name string, tableID int64, targetNodeID string,
+) (*v2.TableMoveStatus, error) {
+	result := &v2.TableMoveStatus{}
+	u := fmt.Sprintf("changefeeds/%s/tables/%d/move?namespace=%s", name, tableID, namespace)
+	err := c.client.Post().
+		WithURI(u).
+		WithBody(&v2.MoveTableConfig{TargetNodeID: targetNodeID}).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+// GetTableMoveStatus gets the move status of a table of a changefeed
+func (c *changefeeds) GetTableMoveStatus(ctx context.Context,
+	namespace string, name string, tableID int64,
+) (*v2.TableMoveStatus, error) {
+	result := &v2.TableMoveStatus{}
+	u := fmt.Sprintf("changefeeds/%s/tables/%d/move?namespace=%s", name, tableID, namespace)
+	err := c.client.Get().
+		WithURI(u).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
@@ -50,6 +50,9 @@ type DDLEvent struct {
 	TableNameChange *TableNameChange `json:"table_name_change"`
 
 	TiDBOnly bool `json:"tidb_only"`
+	// IsBootstrap is only used by the simple protocol, it indicates that the event
+	// is a bootstrap event which carries the table schema only.
+	IsBootstrap bool `json:"-"`
 	// 用于在event flush 后执行，后续兼容不同下游的时候要看是不是要拆下去
 	PostTxnFlushed []func() `json:"-"`
 }
@@ -76,10 +76,14 @@ func NewDMLEvent(
 	}
 }
 
+// AppendRow decodes the raw KV entry and appends the decoded row(s) to the DMLEvent.
+// If filter is not nil and it returns true for the decoded row, the row is dropped
+// and the DMLEvent is left unchanged.
 func (t *DMLEvent) AppendRow(raw *common.RawKVEntry,
 	decode func(
 		rawKv *common.RawKVEntry,
 		tableInfo *common.TableInfo, chk *chunk.Chunk) (int, error),
+	filter func(rowType RowType, preRow, row chunk.Row) (bool, error),
 ) error {
 	RowType := RowTypeInsert
 	if raw.OpType == common.OpTypeDelete {
@@ -88,10 +92,28 @@ func (t *DMLEvent) AppendRow(raw *common.RawKVEntry,
 	if len(raw.Value) != 0 && len(raw.OldValue) != 0 {
 		RowType = RowTypeUpdate
 	}
+	numRows := t.Rows.NumRows()
 	count, err := decode(raw, t.TableInfo, t.Rows)
 	if err != nil {
 		return err
 	}
+	if count > 0 && filter != nil {
+		var preRow, row chunk.Row
+		switch RowType {
+		case RowTypeInsert:
+			row = t.Rows.GetRow(numRows)
+		case RowTypeDelete:
+			preRow = t.Rows.GetRow(numRows)
+		case RowTypeUpdate:
+			preRow = t.Rows.GetRow(numRows)
+			row = t.Rows.GetRow(numRows + 1)
+		}
+		ignore, err := filter(RowType, preRow, row)
+		if err != nil || ignore {
+			t.Rows.TruncateTo(numRows)
+			return err
+		}
+	}
 	if count == 1 {
 		t.RowTypes = append(t.RowTypes, RowType)
 	} else if count == 2 {
@@ -170,6 +192,11 @@ func (t *DMLEvent) GetNextRow() (RowChange, bool) {
 	return RowChange{}, false
 }
 
+// Rewind resets the row iterator, so the rows can be iterated again by GetNextRow.
+func (t *DMLEvent) Rewind() {
+	t.offset = 0
+}
+
 // Len returns the number of row change events in the transaction.
 // Note: An update event is counted as 1 row.
 func (t *DMLEvent) Len() int32 {
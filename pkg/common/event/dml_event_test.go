@@ -3,6 +3,9 @@ package event
 import (
 	"testing"
 
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/stretchr/testify/require"
 )
 
@@ -70,3 +73,48 @@ func TestEncodeAndDecodeV0(t *testing.T) {
 	reverseEvent.Rows = nil
 	require.Equal(t, dmlEvent, reverseEvent)
 }
+
+// TestAppendRowWithFilter test the filtered rows are not appended to the DMLEvent.
+func TestAppendRowWithFilter(t *testing.T) {
+	helper := NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.tk.MustExec("use test")
+	ddlJob := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, ddlJob)
+
+	rawKvs := helper.DML2RawKv("test", "t",
+		"insert into t values (1, 'a');",
+		"insert into t values (2, 'b');",
+		"insert into t values (3, 'c');")
+	tableInfo := helper.GetTableInfo(ddlJob)
+	dmlEvent := NewDMLEvent(common.NewDispatcherID(), tableInfo.TableName.TableID, 1, 2, tableInfo)
+
+	// ignore the row with id = 2
+	filter := func(rowType RowType, preRow, row chunk.Row) (bool, error) {
+		require.Equal(t, RowTypeInsert, rowType)
+		require.True(t, preRow.IsEmpty())
+		return row.GetInt64(0) == 2, nil
+	}
+	for _, rawKv := range rawKvs {
+		require.NoError(t, dmlEvent.AppendRow(rawKv, helper.mounter.DecodeToChunk, filter))
+	}
+	require.Equal(t, int32(2), dmlEvent.Len())
+	require.Equal(t, 2, dmlEvent.Rows.NumRows())
+	require.Equal(t, []RowType{RowTypeInsert, RowTypeInsert}, dmlEvent.RowTypes)
+	for _, id := range []int64{1, 3} {
+		row, ok := dmlEvent.GetNextRow()
+		require.True(t, ok)
+		require.Equal(t, id, row.Row.GetInt64(0))
+	}
+	_, ok := dmlEvent.GetNextRow()
+	require.False(t, ok)
+
+	// the error of the filter is returned, and the row is not appended.
+	err := dmlEvent.AppendRow(rawKvs[0], helper.mounter.DecodeToChunk, func(RowType, chunk.Row, chunk.Row) (bool, error) {
+		return false, errors.New("filter failed")
+	})
+	require.Error(t, err)
+	require.Equal(t, int32(2), dmlEvent.Len())
+	require.Equal(t, 2, dmlEvent.Rows.NumRows())
+}
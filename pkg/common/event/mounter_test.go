@@ -556,6 +556,61 @@ func TestTimezoneDefaultValue(t *testing.T) {
 	require.Equal(t, "2023-02-09 13:00:00", row.Row.GetTime(1).String())
 }
 
+// TestTimezoneAcrossDST tests the TIMESTAMP values are converted to the
+// local time of the mounter's timezone across the DST boundaries.
+func TestTimezoneAcrossDST(t *testing.T) {
+	helper := NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	helper.Tk().MustExec("set @@time_zone = 'UTC'")
+	job := helper.DDL2Job(`create table test.t(a int primary key, b timestamp)`)
+	tableInfo := helper.GetTableInfo(job)
+	rawKvs := helper.DML2RawKv("test", "t",
+		// America/New_York springs forward at 2024-03-10 07:00:00 UTC
+		`insert into test.t values (1, '2024-03-10 06:59:59')`,
+		`insert into test.t values (2, '2024-03-10 07:00:00')`,
+		// America/New_York falls back at 2024-11-03 06:00:00 UTC
+		`insert into test.t values (3, '2024-11-03 05:30:00')`,
+		`insert into test.t values (4, '2024-11-03 06:30:00')`,
+	)
+
+	cases := []struct {
+		timezone string
+		expected []string
+	}{
+		{
+			timezone: "UTC",
+			expected: []string{"2024-03-10 06:59:59", "2024-03-10 07:00:00", "2024-11-03 05:30:00", "2024-11-03 06:30:00"},
+		},
+		{
+			timezone: "America/New_York",
+			expected: []string{"2024-03-10 01:59:59", "2024-03-10 03:00:00", "2024-11-03 01:30:00", "2024-11-03 01:30:00"},
+		},
+		{
+			timezone: "Asia/Shanghai",
+			expected: []string{"2024-03-10 14:59:59", "2024-03-10 15:00:00", "2024-11-03 13:30:00", "2024-11-03 14:30:00"},
+		},
+	}
+	for _, c := range cases {
+		tz, err := time.LoadLocation(c.timezone)
+		require.NoError(t, err)
+		mounter := NewMounter(tz)
+		dmlEvent := NewDMLEvent(common.NewDispatcherID(), tableInfo.TableName.TableID, 1, 2, tableInfo)
+		for _, rawKv := range rawKvs {
+			require.NoError(t, dmlEvent.AppendRow(rawKv, mounter.DecodeToChunk, nil))
+		}
+		for i, expected := range c.expected {
+			row, ok := dmlEvent.GetNextRow()
+			require.True(t, ok)
+			require.Equal(t, int64(i+1), row.Row.GetInt64(0))
+			require.Equal(t, expected, row.Row.GetTime(1).String(), c.timezone)
+		}
+		_, ok := dmlEvent.GetNextRow()
+		require.False(t, ok)
+	}
+}
+
 func TestAllTypes(t *testing.T) {
 	helper := NewEventTestHelper(t)
 	defer helper.Close()
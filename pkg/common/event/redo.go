@@ -0,0 +1,133 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/pkg/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+// RedoEvent is an event which has been converted to the redo log format.
+// It implements the writer.RedoEvent interface of the redo log writer.
+// The redo log format is the same as the one used by tiflow,
+// so the logs can be replayed by the redo applier directly.
+type RedoEvent struct {
+	log *model.RedoLog
+}
+
+// ToRedoLog implements writer.RedoEvent.
+func (r *RedoEvent) ToRedoLog() *model.RedoLog {
+	return r.log
+}
+
+// ToRedoEvents converts all the rows in the DMLEvent to redo events.
+// The row iterator of the event is rewound after the conversion,
+// so the event can still be consumed by the sink.
+func (t *DMLEvent) ToRedoEvents() ([]*RedoEvent, error) {
+	defer t.Rewind()
+	tableName := &model.TableName{
+		Schema:      t.TableInfo.GetSchemaName(),
+		Table:       t.TableInfo.GetTableName(),
+		TableID:     t.PhysicalTableID,
+		IsPartition: t.TableInfo.IsPartitionTable(),
+	}
+	events := make([]*RedoEvent, 0, t.Len())
+	for {
+		row, ok := t.GetNextRow()
+		if !ok {
+			break
+		}
+		redoRow := &model.RowChangedEventInRedoLog{
+			StartTs:      t.StartTs,
+			CommitTs:     t.CommitTs,
+			Table:        tableName,
+			IndexColumns: t.TableInfo.IndexColumnsOffset,
+		}
+		var err error
+		if row.RowType != RowTypeDelete {
+			redoRow.Columns, err = chunkRowToRedoColumns(&row.Row, t.TableInfo)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if row.RowType != RowTypeInsert {
+			redoRow.PreColumns, err = chunkRowToRedoColumns(&row.PreRow, t.TableInfo)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		events = append(events, &RedoEvent{
+			log: &model.RedoLog{
+				RedoRow: model.RedoRowChangedEvent{Row: redoRow},
+				Type:    model.RedoLogTypeRow,
+			},
+		})
+	}
+	return events, nil
+}
+
+// ToRedoEvent converts the DDLEvent to a redo event.
+func (d *DDLEvent) ToRedoEvent() *RedoEvent {
+	ddl := &model.DDLEvent{
+		StartTs:  uint64(d.GetStartTs()),
+		CommitTs: d.FinishedTs,
+		Query:    d.Query,
+		Type:     timodel.ActionType(d.Type),
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{
+				Schema:  d.SchemaName,
+				Table:   d.TableName,
+				TableID: d.TableID,
+			},
+		},
+	}
+	if d.TableInfo != nil {
+		ddl.Charset = d.TableInfo.Charset
+		ddl.Collate = d.TableInfo.Collate
+	}
+	return &RedoEvent{
+		log: &model.RedoLog{
+			RedoDDL: model.RedoDDLEvent{DDL: ddl},
+			Type:    model.RedoLogTypeDDL,
+		},
+	}
+}
+
+// chunkRowToRedoColumns converts a chunk row to the columns stored in redo log.
+// Virtual generated columns are skipped, so the offsets of the result columns
+// are the same as the ones in TableInfo.IndexColumnsOffset.
+func chunkRowToRedoColumns(row *chunk.Row, tableInfo *common.TableInfo) ([]*model.Column, error) {
+	columns := make([]*model.Column, 0, len(tableInfo.Columns))
+	for idx, col := range tableInfo.Columns {
+		if col == nil || !common.IsColCDCVisible(col) {
+			continue
+		}
+		value, err := common.FormatColVal(row, col, idx)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		columns = append(columns, &model.Column{
+			Name:      col.Name.O,
+			Type:      col.GetType(),
+			Charset:   col.GetCharset(),
+			Collation: col.GetCollate(),
+			Flag:      model.ColumnFlagType(*tableInfo.ForceGetColumnFlagType(col.ID)),
+			Value:     value,
+		})
+	}
+	return columns, nil
+}
@@ -0,0 +1,68 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDMLEventToRedoEvents(t *testing.T) {
+	helper := NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.tk.MustExec("use test")
+	ddlJob := helper.DDL2Job(createTableSQL)
+	require.NotNil(t, ddlJob)
+
+	dmlEvent := helper.DML2Event("test", "t", insertDataSQL)
+	require.NotNil(t, dmlEvent)
+
+	events, err := dmlEvent.ToRedoEvents()
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	redoLog := events[0].ToRedoLog()
+	require.Equal(t, model.RedoLogTypeRow, redoLog.Type)
+	row := redoLog.RedoRow.Row
+	require.Equal(t, dmlEvent.CommitTs, row.CommitTs)
+	require.Equal(t, "test", row.Table.Schema)
+	require.Equal(t, "t", row.Table.Table)
+	require.Equal(t, dmlEvent.PhysicalTableID, row.Table.TableID)
+	require.Len(t, row.PreColumns, 0)
+	require.Equal(t, "id", row.Columns[0].Name)
+	require.Equal(t, int64(2), row.Columns[0].Value)
+	require.True(t, row.Columns[0].Flag.IsPrimaryKey())
+	require.Equal(t, dmlEvent.TableInfo.IndexColumnsOffset, row.IndexColumns)
+
+	// the rows can still be consumed after the conversion
+	rowChange, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, RowTypeInsert, rowChange.RowType)
+}
+
+func TestDDLEventToRedoEvent(t *testing.T) {
+	helper := NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.tk.MustExec("use test")
+	ddlJob := helper.DDL2Job(createTableSQL)
+	require.NotNil(t, ddlJob)
+
+	ddlEvent := &DDLEvent{
+		Type:       byte(ddlJob.Type),
+		SchemaName: ddlJob.SchemaName,
+		TableName:  ddlJob.TableName,
+		TableID:    ddlJob.TableID,
+		Query:      ddlJob.Query,
+		FinishedTs: ddlJob.BinlogInfo.FinishedTS,
+	}
+	redoLog := ddlEvent.ToRedoEvent().ToRedoLog()
+	require.Equal(t, model.RedoLogTypeDDL, redoLog.Type)
+	ddl := redoLog.RedoDDL.DDL
+	require.Equal(t, ddlJob.Query, ddl.Query)
+	require.Equal(t, ddlJob.BinlogInfo.FinishedTS, ddl.CommitTs)
+	require.Equal(t, ddlJob.Type, ddl.Type)
+	require.Equal(t, ddlJob.SchemaName, ddl.TableInfo.TableName.Schema)
+	require.Equal(t, ddlJob.TableName, ddl.TableInfo.TableName.Table)
+}
@@ -217,11 +217,14 @@ type MQRowEvent struct {
 }
 
 type RowEvent struct {
-	TableInfo      *common.TableInfo
-	CommitTs       uint64
-	Event          RowChange
-	ColumnSelector common.Selector
-	Callback       func()
+	// PhysicalTableID is the partition ID of the row if the table is partitioned,
+	// otherwise it's the same as the table ID.
+	PhysicalTableID int64
+	TableInfo       *common.TableInfo
+	CommitTs        uint64
+	Event           RowChange
+	ColumnSelector  common.Selector
+	Callback        func()
 }
 
 func (e *RowEvent) IsDelete() bool {
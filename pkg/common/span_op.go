@@ -119,6 +119,22 @@ func GetIntersectSpan(lhs heartbeatpb.TableSpan, rhs heartbeatpb.TableSpan) hear
 	}
 }
 
+// IsSubSpan returns true if the sub span is parent span's sub span.
+// It returns false if spans belong to different tables.
+func IsSubSpan(sub heartbeatpb.TableSpan, parent heartbeatpb.TableSpan) bool {
+	if sub.TableID != parent.TableID {
+		return false
+	}
+	return StartCompare(sub.StartKey, parent.StartKey) >= 0 &&
+		EndCompare(sub.EndKey, parent.EndKey) <= 0
+}
+
+// KeyInSpan returns true if the memcomparable key is in the span [StartKey, EndKey).
+func KeyInSpan(key []byte, span heartbeatpb.TableSpan) bool {
+	return StartCompare(key, span.StartKey) >= 0 &&
+		EndCompare(key, span.EndKey) < 0
+}
+
 // IsEmptySpan returns true if the span is empty.
 // TODO: check whether need span.StartKey >= span.EndKey
 func IsEmptySpan(span heartbeatpb.TableSpan) bool {
@@ -106,3 +106,20 @@ func TestTableSpanEqual(t *testing.T) {
 	require.True(t, span1.Equal(span2))
 	require.False(t, span1.Equal(span3))
 }
+
+func TestIsSubSpan(t *testing.T) {
+	parent := heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("b"), EndKey: []byte("y")}
+	require.True(t, IsSubSpan(parent, parent))
+	require.True(t, IsSubSpan(heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("c"), EndKey: []byte("d")}, parent))
+	require.False(t, IsSubSpan(heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("d")}, parent))
+	require.False(t, IsSubSpan(heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("c"), EndKey: []byte("z")}, parent))
+	require.False(t, IsSubSpan(heartbeatpb.TableSpan{TableID: 2, StartKey: []byte("c"), EndKey: []byte("d")}, parent))
+	// empty end key means positive infinity.
+	require.False(t, IsSubSpan(heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("c")}, parent))
+	require.True(t, IsSubSpan(parent, heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a")}))
+
+	require.True(t, KeyInSpan([]byte("b"), parent))
+	require.True(t, KeyInSpan([]byte("x"), parent))
+	require.False(t, KeyInSpan([]byte("y"), parent))
+	require.False(t, KeyInSpan([]byte("a"), parent))
+}
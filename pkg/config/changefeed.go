@@ -36,6 +36,11 @@ type ChangefeedConfig struct {
 	SyncPointRetention *time.Duration `json:"sync_point_retention" default:"24h"`
 
 	SinkConfig *SinkConfig `json:"sink_config"`
+	// Consistent is the redo log config, redo log is disabled when it is nil
+	// or its level is not `eventual`.
+	Consistent *ConsistentConfig `json:"consistent"`
+	// ScanLimit is the limits of scanning events for a dispatcher, there is no limit when it is nil.
+	ScanLimit *ScanLimitConfig `json:"scan_limit"`
 }
 
 // ChangeFeedInfo describes the detail of a ChangeFeed
@@ -142,6 +147,17 @@ func (info *ChangeFeedInfo) GetTargetTs() uint64 {
 	return uint64(math.MaxUint64)
 }
 
+// GetTimezone returns the timezone of the changefeed, which is specified by the
+// `time-zone` parameter of the sink uri, or the timezone of the server if it's not specified.
+func (info *ChangeFeedInfo) GetTimezone() string {
+	if uri, err := url.Parse(info.SinkURI); err == nil {
+		if tz := uri.Query().Get("time-zone"); tz != "" {
+			return tz
+		}
+	}
+	return GetGlobalServerConfig().TZ
+}
+
 // Marshal returns the json marshal format of a ChangeFeedInfo
 func (info *ChangeFeedInfo) Marshal() (string, error) {
 	data, err := json.Marshal(info)
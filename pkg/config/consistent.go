@@ -18,6 +18,7 @@ import (
 
 	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tiflow/pkg/compression"
+	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/redo"
 	"github.com/pingcap/tiflow/pkg/util"
@@ -123,3 +124,29 @@ func (c *ConsistentConfig) ValidateAndAdjust() error {
 func (c *ConsistentConfig) MaskSensitiveData() {
 	c.Storage = util.MaskSensitiveDataInURI(c.Storage)
 }
+
+// ToTiflowConfig converts the consistent config to the tiflow one,
+// which is used by the redo log writers and the redo meta manager.
+func (c *ConsistentConfig) ToTiflowConfig() *config.ConsistentConfig {
+	if c == nil {
+		return nil
+	}
+	cfg := &config.ConsistentConfig{
+		Level:                 c.Level,
+		MaxLogSize:            c.MaxLogSize,
+		FlushIntervalInMs:     c.FlushIntervalInMs,
+		MetaFlushIntervalInMs: c.MetaFlushIntervalInMs,
+		EncodingWorkerNum:     c.EncodingWorkerNum,
+		FlushWorkerNum:        c.FlushWorkerNum,
+		Storage:               c.Storage,
+		UseFileBackend:        c.UseFileBackend,
+		Compression:           c.Compression,
+		FlushConcurrency:      c.FlushConcurrency,
+	}
+	if c.MemoryUsage != nil {
+		cfg.MemoryUsage = &config.ConsistentMemoryUsage{
+			MemoryQuotaPercentage: c.MemoryUsage.MemoryQuotaPercentage,
+		}
+	}
+	return cfg
+}
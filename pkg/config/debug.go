@@ -30,6 +30,12 @@ type DebugConfig struct {
 
 	// Puller is the configuration of the puller.
 	Puller *PullerConfig `toml:"puller" json:"puller"`
+
+	// EventStore is the configuration of the event store.
+	EventStore *EventStoreConfig `toml:"event-store" json:"event-store"`
+
+	// EventCollector is the configuration of the event collector.
+	EventCollector *EventCollectorConfig `toml:"event-collector" json:"event-collector"`
 }
 
 // ValidateAndAdjust validates and adjusts the debug configuration
@@ -43,6 +49,18 @@ func (c *DebugConfig) ValidateAndAdjust() error {
 	if err := c.Scheduler.ValidateAndAdjust(); err != nil {
 		return errors.Trace(err)
 	}
+	if c.EventStore == nil {
+		c.EventStore = NewDefaultEventStoreConfig()
+	}
+	if err := c.EventStore.ValidateAndAdjust(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.EventCollector == nil {
+		c.EventCollector = NewDefaultEventCollectorConfig()
+	}
+	if err := c.EventCollector.ValidateAndAdjust(); err != nil {
+		return errors.Trace(err)
+	}
 
 	return nil
 }
@@ -0,0 +1,52 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"time"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// EventCollectorConfig represents config for the event collector.
+type EventCollectorConfig struct {
+	// EnableRemoteEventService determines whether a dispatcher can read events from
+	// the event service of another node, which already has the data of the table span,
+	// while the local event store is catching up.
+	//
+	// The default value is true.
+	EnableRemoteEventService bool `toml:"enable-remote-event-service" json:"enable-remote-event-service"`
+	// RemoteEventServiceCheckInterval is the interval to check whether a dispatcher
+	// reading from a remote event service can switch back to the local event service.
+	//
+	// The default value is 10s.
+	RemoteEventServiceCheckInterval TomlDuration `toml:"remote-event-service-check-interval" json:"remote-event-service-check-interval"`
+}
+
+// NewDefaultEventCollectorConfig return the default event collector configuration
+func NewDefaultEventCollectorConfig() *EventCollectorConfig {
+	return &EventCollectorConfig{
+		EnableRemoteEventService:        true,
+		RemoteEventServiceCheckInterval: TomlDuration(10 * time.Second),
+	}
+}
+
+// ValidateAndAdjust validates and adjusts the event collector configuration
+func (c *EventCollectorConfig) ValidateAndAdjust() error {
+	if c.RemoteEventServiceCheckInterval <= 0 {
+		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
+			"debug.event-collector.remote-event-service-check-interval must be larger than 0")
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// EventStoreConfig represents config for the storage engine of event store.
+type EventStoreConfig struct {
+	// DBCount is the number of pebble instances used by event store.
+	// The data persisted by the previous process is reusable only in the dbs
+	// whose index is less than the new count, the other dbs are removed at startup
+	// if the count is lowered.
+	//
+	// The default value is 32.
+	DBCount int `toml:"db-count" json:"db-count"`
+	// MemTableSize is the size of memory table of each pebble instance.
+	//
+	// The default value is 8388608, 8MB.
+	MemTableSize int `toml:"memtable-size" json:"memtable-size"`
+	// BlockSize is the target uncompressed size of data blocks in sst files.
+	//
+	// The default value is 4096, 4KB.
+	BlockSize int `toml:"block-size" json:"block-size"`
+	// Compression is the block compression algorithm of sst files.
+	// Valid values are "none", "snappy" or "zstd".
+	//
+	// The default value is "snappy".
+	Compression string `toml:"compression" json:"compression"`
+	// EnableValueCompression determines whether to compress each event value
+	// with zstd before writing it to pebble.
+	//
+	// The default value is true.
+	EnableValueCompression bool `toml:"enable-value-compression" json:"enable-value-compression"`
+	// DiskQuota is the max bytes of disk space used by event store.
+	// Event store stops pulling events from upstream when the quota is reached,
+	// and resumes after the space is reclaimed. 0 means no limit.
+	//
+	// The default value is 0.
+	DiskQuota uint64 `toml:"disk-quota" json:"disk-quota"`
+}
+
+// NewDefaultEventStoreConfig return the default event store configuration
+func NewDefaultEventStoreConfig() *EventStoreConfig {
+	return &EventStoreConfig{
+		DBCount:                32,
+		MemTableSize:           8 << 20,
+		BlockSize:              4 << 10,
+		Compression:            "snappy",
+		EnableValueCompression: true,
+		DiskQuota:              0,
+	}
+}
+
+// ValidateAndAdjust validates and adjusts the event store configuration
+func (c *EventStoreConfig) ValidateAndAdjust() error {
+	if c.DBCount <= 0 {
+		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
+			"debug.event-store.db-count must be larger than 0")
+	}
+	// pebble requires the memtable size to be less than 4GB.
+	if c.MemTableSize <= 0 || c.MemTableSize >= 4<<30 {
+		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
+			"debug.event-store.memtable-size must be in (0, 4GB)")
+	}
+	if c.BlockSize <= 0 {
+		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
+			"debug.event-store.block-size must be larger than 0")
+	}
+	switch c.Compression {
+	case "none", "snappy", "zstd":
+	default:
+		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
+			"debug.event-store.compression must be \"none\", \"snappy\" or \"zstd\"")
+	}
+	return nil
+}
@@ -101,6 +101,10 @@ var defaultReplicaConfig = &ReplicaConfig{
 		EnableTableAcrossNodes: false,
 		RegionThreshold:        100_000,
 		WriteKeyThreshold:      0,
+		EventRowsThreshold:     0,
+		EventBytesThreshold:    0,
+		SinkLagThreshold:       0,
+		SplitCooldown:          5 * time.Minute,
 	},
 	Integrity: &integrity.Config{
 		IntegrityCheckLevel:   integrity.CheckLevelNone,
@@ -108,6 +112,11 @@ var defaultReplicaConfig = &ReplicaConfig{
 	},
 	ChangefeedErrorStuckDuration: util.AddressOf(time.Minute * 30),
 	SyncedStatus:                 &SyncedStatusConfig{SyncedCheckInterval: 5 * 60, CheckpointInterval: 15},
+	ScanLimit: &ScanLimitConfig{
+		MaxBytes:        64 * 1024 * 1024,
+		MaxRows:         0,
+		MaxDurationInMs: 1000,
+	},
 }
 
 // GetDefaultReplicaConfig returns the default replica config.
@@ -161,6 +170,8 @@ type replicaConfig struct {
 	Integrity                    *integrity.Config   `toml:"integrity" json:"integrity"`
 	ChangefeedErrorStuckDuration *time.Duration      `toml:"changefeed-error-stuck-duration" json:"changefeed-error-stuck-duration,omitempty"`
 	SyncedStatus                 *SyncedStatusConfig `toml:"synced-status" json:"synced-status,omitempty"`
+	// ScanLimit is the limits of scanning events for a dispatcher in the event service.
+	ScanLimit *ScanLimitConfig `toml:"scan-limit" json:"scan-limit,omitempty"`
 
 	// Deprecated: we don't use this field since v8.0.0.
 	SQLMode string `toml:"sql-mode" json:"sql-mode"`
@@ -0,0 +1,26 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// ScanLimitConfig represents the limits of a single scan of a dispatcher in the event service.
+// A scan stops at a transaction boundary once any limit is reached, and continues later.
+// 0 means no limit.
+type ScanLimitConfig struct {
+	// MaxBytes is the max bytes of events read by a single scan.
+	MaxBytes uint64 `toml:"max-bytes" json:"max-bytes"`
+	// MaxRows is the max rows read by a single scan.
+	MaxRows uint64 `toml:"max-rows" json:"max-rows"`
+	// MaxDurationInMs is the max time of a single scan in milliseconds.
+	MaxDurationInMs uint64 `toml:"max-duration-in-ms" json:"max-duration-in-ms"`
+}
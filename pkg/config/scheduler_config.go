@@ -29,6 +29,18 @@ type ChangefeedSchedulerConfig struct {
 	RegionThreshold int `toml:"region-threshold" json:"region-threshold"`
 	// WriteKeyThreshold is the written keys threshold of splitting a table.
 	WriteKeyThreshold int `toml:"write-key-threshold" json:"write-key-threshold"`
+	// EventRowsThreshold is the threshold of the rows written to the sink per second by a span,
+	// the span is split if its event rate exceeds the threshold, 0 means no threshold.
+	EventRowsThreshold int `toml:"event-rows-threshold" json:"event-rows-threshold"`
+	// EventBytesThreshold is the threshold of the size of the events written to the sink per second by a span,
+	// the span is split if its event rate exceeds the threshold, 0 means no threshold.
+	EventBytesThreshold int `toml:"event-bytes-threshold" json:"event-bytes-threshold"`
+	// SinkLagThreshold is the threshold of the sink lag of a span, the span which is still
+	// receiving events is split if its sink lag exceeds the threshold, 0 means no threshold.
+	SinkLagThreshold time.Duration `toml:"sink-lag-threshold" json:"sink-lag-threshold"`
+	// SplitCooldown is the min interval between two splits or merges of the spans of a table,
+	// it avoids splitting and merging the spans of a table back and forth.
+	SplitCooldown time.Duration `toml:"split-cooldown" json:"split-cooldown"`
 }
 
 // Validate validates the config.
@@ -42,6 +54,18 @@ func (c *ChangefeedSchedulerConfig) Validate() error {
 	if c.WriteKeyThreshold < 0 {
 		return errors.New("write-key-threshold must be larger than 0")
 	}
+	if c.EventRowsThreshold < 0 {
+		return errors.New("event-rows-threshold must be larger than 0")
+	}
+	if c.EventBytesThreshold < 0 {
+		return errors.New("event-bytes-threshold must be larger than 0")
+	}
+	if c.SinkLagThreshold < 0 {
+		return errors.New("sink-lag-threshold must be larger than 0")
+	}
+	if c.SplitCooldown < 0 {
+		return errors.New("split-cooldown must be larger than 0")
+	}
 	return nil
 }
 
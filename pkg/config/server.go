@@ -119,6 +119,9 @@ var defaultServerConfig = &ServerConfig{
 
 		Scheduler: NewDefaultSchedulerConfig(),
 		Puller:    NewDefaultPullerConfig(),
+
+		EventStore:     NewDefaultEventStoreConfig(),
+		EventCollector: NewDefaultEventCollectorConfig(),
 	},
 	ClusterID:              "default",
 	GcTunerMemoryThreshold: DisableMemoryLimit,
@@ -12,6 +12,7 @@ import (
 	"github.com/pingcap/ticdc/utils/dynstream"
 
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/eventpb"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/logservice/eventstore"
 	"github.com/pingcap/ticdc/logservice/schemastore"
@@ -19,6 +20,8 @@ import (
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/client-go/v2/oracle"
@@ -35,6 +38,7 @@ var metricEventServiceSendEventDuration = metrics.EventServiceSendEventDuration.
 var metricEventBrokerDropTaskCount = metrics.EventServiceDropScanTaskCount
 var metricEventBrokerDropResolvedTsCount = metrics.EventServiceDropResolvedTsCount
 var metricScanTaskQueueDuration = metrics.EventServiceScanTaskQueueDuration
+var metricEventBrokerInterruptedScanCount = metrics.EventServiceInterruptedScanCount
 
 // eventBroker get event from the eventStore, and send the event to the dispatchers.
 // Every TiDB cluster has a eventBroker.
@@ -45,8 +49,13 @@ type eventBroker struct {
 	// eventStore is the source of the events, eventBroker get the events from the eventStore.
 	eventStore  eventstore.EventStore
 	schemaStore schemastore.SchemaStore
+	// mounter decodes the row values with the timezone of the event service,
+	// it's used by the dispatchers which don't specify a timezone.
 	// todo: only one mounter, this may become the bottleneck affect the throughput performance
 	mounter pevent.Mounter
+	// mounters caches the mounters of the timezones specified by the dispatchers,
+	// timezone name -> pevent.Mounter.
+	mounters sync.Map
 	// msgSender is used to send the events to the dispatchers.
 	msgSender messaging.MessageSender
 
@@ -363,17 +372,37 @@ func (c *eventBroker) doScan(ctx context.Context, task scanTask) {
 		log.Panic("get ddl events failed", zap.Error(err))
 	}
 
+	// watermark is the ts that all events before it are sent to the dispatcher after the scan.
+	// It is less than dataRange.EndTs if the scan is interrupted by the scan limits.
+	watermark := dataRange.EndTs
+	interrupted := false
 	// After all the events are sent, we need to
 	// drain the ddlEvents and wake up the dispatcher.
 	defer func() {
 		for _, e := range ddlEvents {
+			// The remaining ddl events will be fetched again by the next scan.
+			if e.FinishedTs > watermark {
+				break
+			}
 			c.sendDDL(ctx, remoteID, e, task.dispatcherStat)
 		}
 		// After all the events are sent, we send the watermark to the dispatcher.
 		c.sendWatermark(remoteID,
 			task.dispatcherStat,
-			dataRange.EndTs,
+			watermark,
 			task.dispatcherStat.metricEventServiceSendResolvedTsCount)
+		if interrupted {
+			task.dispatcherStat.watermark.Store(watermark)
+			metricEventBrokerInterruptedScanCount.Inc()
+			// Re-queue the task to scan the rest events after the tasks of other dispatchers.
+			// Only the task of the running dispatcher is re-queued, the paused one is scanned after it resumes.
+			if task.dispatcherStat.isRunning.Load() {
+				select {
+				case <-ctx.Done():
+				case c.ds.In() <- newScanTask(task.dispatcherStat):
+				}
+			}
+		}
 	}()
 
 	//2. Get event iterator from eventStore.
@@ -389,7 +418,8 @@ func (c *eventBroker) doScan(ctx context.Context, task scanTask) {
 	}()
 
 	sendDML := func(dml *pevent.DMLEvent) {
-		if dml == nil {
+		// All rows of the transaction may be filtered out.
+		if dml == nil || dml.Len() == 0 {
 			return
 		}
 
@@ -406,6 +436,16 @@ func (c *eventBroker) doScan(ctx context.Context, task scanTask) {
 
 	// 3. Send the events to the dispatcher.
 	var dml *pevent.DMLEvent
+	// filterRow applies the event filter rules of the dispatcher to every row before it is appended to the dml,
+	// so the filtered rows are never sent to the dispatcher.
+	filterRow := func(rowType pevent.RowType, preRow, row chunk.Row) (bool, error) {
+		ignore, err := task.dispatcherStat.filter.ShouldIgnoreDML(rowType, preRow, row, dml.TableInfo, dml.StartTs)
+		if ignore {
+			task.dispatcherStat.metricEventServiceFilteredRowCount.Inc()
+		}
+		return ignore, err
+	}
+	scannedBytes, scannedRows := uint64(0), uint64(0)
 	for {
 		//Node: The first event of the txn must return isNewTxn as true.
 		e, isNewTxn, err := iter.Next()
@@ -424,6 +464,21 @@ func (c *eventBroker) doScan(ctx context.Context, task scanTask) {
 			log.Panic("should never Happen", zap.Uint64("commitTs", e.CRTs), zap.Uint64("watermark", task.dispatcherStat.watermark.Load()))
 		}
 		if isNewTxn {
+			// Only stop at the boundary of commit ts, so all events before the watermark are sent.
+			if dml != nil && e.CRTs > dml.CommitTs &&
+				task.dispatcherStat.scanLimit.isReached(scannedBytes, scannedRows, time.Since(start)) {
+				sendDML(dml)
+				watermark = dml.CommitTs
+				interrupted = true
+				c.metricScanEventDuration.Observe(time.Since(start).Seconds())
+				log.Debug("scan is interrupted by the scan limit",
+					zap.Stringer("dispatcher", dispatcherID),
+					zap.Uint64("watermark", watermark),
+					zap.Uint64("endTs", dataRange.EndTs),
+					zap.Uint64("bytes", scannedBytes),
+					zap.Uint64("rows", scannedRows))
+				return
+			}
 			sendDML(dml)
 			tableID := task.dispatcherStat.info.GetTableSpan().TableID
 			tableInfo, err := c.schemaStore.GetTableInfo(tableID, e.CRTs-1)
@@ -433,8 +488,42 @@ func (c *eventBroker) doScan(ctx context.Context, task scanTask) {
 			}
 			dml = pevent.NewDMLEvent(dispatcherID, tableID, e.StartTs, e.CRTs, tableInfo)
 		}
-		dml.AppendRow(e, c.mounter.DecodeToChunk)
+		if err = dml.AppendRow(e, task.dispatcherStat.mounter.DecodeToChunk, filterRow); err != nil {
+			// The dml is not sent, so the watermark is the commitTs of the last sent dml.
+			// The dispatcher is not scanned anymore, and its changefeed will be failed by the error.
+			watermark = task.dispatcherStat.watermark.Load()
+			task.dispatcherStat.isRunning.Store(false)
+			c.sendDispatcherError(ctx, remoteID, task.dispatcherStat.info, err)
+			c.metricScanEventDuration.Observe(time.Since(start).Seconds())
+			return
+		}
+		scannedBytes += uint64(e.ApproximateDataSize())
+		scannedRows++
+	}
+}
+
+// sendDispatcherError reports the error met by registering the dispatcher or scanning its events
+// to the event collector, which fails the changefeed of the dispatcher.
+func (c *eventBroker) sendDispatcherError(ctx context.Context, remoteID node.ID, info DispatcherInfo, err error) {
+	namespace, id := info.GetChangefeedID()
+	log.Error("the dispatcher meets error",
+		zap.String("namespace", namespace),
+		zap.String("changefeed", id),
+		zap.Stringer("dispatcher", info.GetID()),
+		zap.Error(err))
+	code, ok := cerror.RFCCode(err)
+	if !ok {
+		code = cerror.ErrProcessorUnknown.RFCCode()
 	}
+	msg := messaging.NewSingleTargetMessage(remoteID, messaging.EventCollectorTopic, &eventpb.DispatcherError{
+		DispatcherId: info.GetID().ToPB(),
+		Err: &heartbeatpb.RunningError{
+			Time:    time.Now().String(),
+			Code:    string(code),
+			Message: err.Error(),
+		},
+	})
+	c.sendMsg(ctx, msg, nil)
 }
 
 func (c *eventBroker) runSendMessageWorker(ctx context.Context) {
@@ -606,11 +695,36 @@ func (c *eventBroker) onNotify(d *dispatcherStat, resolvedTs uint64) {
 	}
 }
 
-func (c *eventBroker) addDispatcher(info DispatcherInfo) {
+// getMounter returns the mounter which decodes the row values with the timezone.
+// The mounter of the event service is returned if the timezone is empty.
+func (c *eventBroker) getMounter(timezone string) (pevent.Mounter, error) {
+	if timezone == "" {
+		return c.mounter, nil
+	}
+	if mounter, ok := c.mounters.Load(timezone); ok {
+		return mounter.(pevent.Mounter), nil
+	}
+	tz, err := util.GetTimezone(timezone)
+	if err != nil {
+		return nil, err
+	}
+	mounter, _ := c.mounters.LoadOrStore(timezone, pevent.NewMounter(tz))
+	return mounter.(pevent.Mounter), nil
+}
+
+// addDispatcher registers the dispatcher, an error is returned if its filter or timezone is invalid.
+func (c *eventBroker) addDispatcher(info DispatcherInfo) error {
+	// The timezone is checked before the filter, which needs a valid timezone.
+	mounter, err := c.getMounter(info.GetTimezone())
+	if err != nil {
+		log.Warn("create mounter failed", zap.Error(err), zap.String("timezone", info.GetTimezone()))
+		return err
+	}
 	filterConfig := info.GetFilterConfig()
-	filter, err := filter.NewFilter(filterConfig, "", false)
+	filter, err := filter.NewFilter(filterConfig, info.GetTimezone(), false)
 	if err != nil {
-		log.Panic("create filter failed", zap.Error(err), zap.Any("filterConfig", filterConfig))
+		log.Warn("create filter failed", zap.Error(err), zap.Any("filterConfig", filterConfig))
+		return err
 	}
 
 	defer c.metricDispatcherCount.Inc()
@@ -618,13 +732,13 @@ func (c *eventBroker) addDispatcher(info DispatcherInfo) {
 	id := info.GetID()
 	span := info.GetTableSpan()
 	startTs := info.GetStartTs()
-	dispatcher := newDispatcherStat(startTs, info, filter)
+	dispatcher := newDispatcherStat(startTs, info, filter, mounter)
 	if span.Equal(heartbeatpb.DDLSpan) {
 		c.tableTriggerDispatchers.Store(id, dispatcher)
 		log.Info("table trigger dispatcher register acceptor", zap.Uint64("clusterID", c.tidbClusterID),
 			zap.Any("acceptorID", id), zap.Int64("tableID", span.TableID),
 			zap.Uint64("startTs", startTs), zap.Duration("brokerRegisterDuration", time.Since(start)))
-		return
+		return nil
 	}
 
 	c.dispatchers.Store(id, dispatcher)
@@ -658,6 +772,7 @@ func (c *eventBroker) addDispatcher(info DispatcherInfo) {
 		zap.Any("acceptorID", id), zap.Int64("tableID", span.TableID),
 		zap.Uint64("startTs", startTs), zap.Duration("brokerRegisterDuration", brokerRegisterDuration),
 		zap.Duration("eventStoreRegisterDuration", eventStoreRegisterDuration))
+	return nil
 }
 
 func (c *eventBroker) removeDispatcher(dispatcherInfo DispatcherInfo) {
@@ -670,6 +785,8 @@ func (c *eventBroker) removeDispatcher(dispatcherInfo DispatcherInfo) {
 	c.eventStore.UnregisterDispatcher(id)
 	c.schemaStore.UnregisterTable(dispatcherInfo.GetTableSpan().TableID)
 	c.dispatchers.Delete(id)
+	namespace, changefeed := dispatcherInfo.GetChangefeedID()
+	metrics.EventServiceFilteredRowCount.DeleteLabelValues(namespace, changefeed, id.String())
 
 	log.Info("deregister acceptor", zap.Uint64("clusterID", c.tidbClusterID), zap.Any("acceptorID", id))
 }
@@ -699,15 +816,17 @@ func (c *eventBroker) resetDispatcher(dispatcherInfo DispatcherInfo) {
 		return
 	}
 	dispStat := stat.(*dispatcherStat)
-	tableInfo, err := c.schemaStore.GetTableInfo(dispStat.info.GetTableSpan().TableID, dispStat.info.GetStartTs())
+	// The dispatcher restarts from the start ts in the reset request.
+	startTs := dispatcherInfo.GetStartTs()
+	tableInfo, err := c.schemaStore.GetTableInfo(dispStat.info.GetTableSpan().TableID, startTs)
 	if err != nil {
-		log.Panic("get table info from schemaStore failed", zap.Error(err), zap.Int64("tableID", dispStat.info.GetTableSpan().TableID), zap.Uint64("startTs", dispStat.info.GetStartTs()))
+		log.Panic("get table info from schemaStore failed", zap.Error(err), zap.Int64("tableID", dispStat.info.GetTableSpan().TableID), zap.Uint64("startTs", startTs))
 	}
 	dispStat.updateTableInfo(tableInfo)
-	dispStat.watermark.Store(dispStat.info.GetStartTs())
+	dispStat.watermark.Store(startTs)
 	// Reset the seq to 0, so that the next event will be sent with seq 1.
 	dispStat.seq.Store(0)
-	dispStat.startTs.Store(dispStat.info.GetStartTs())
+	dispStat.startTs.Store(startTs)
 	dispStat.isRunning.Store(true)
 	dispStat.isInitialized.Store(false)
 }
@@ -719,6 +838,8 @@ type dispatcherStat struct {
 	// startTableInfo is the table info of the dispatcher when it is registered or reset.
 	startTableInfo atomic.Pointer[common.TableInfo]
 	filter         filter.Filter
+	// mounter decodes the row values with the timezone of the dispatcher.
+	mounter pevent.Mounter
 	// The start ts of the dispatcher
 	startTs atomic.Uint64
 	// The max resolved ts recevied from event store.
@@ -744,25 +865,39 @@ type dispatcherStat struct {
 	nextSyncPoint     uint64
 	syncPointInterval time.Duration
 
+	// scanLimit is the limits of a single scan of the dispatcher.
+	scanLimit scanLimit
+
 	metricSorterOutputEventCountKV        prometheus.Counter
 	metricEventServiceSendKvCount         prometheus.Counter
 	metricEventServiceSendDDLCount        prometheus.Counter
 	metricEventServiceSendResolvedTsCount prometheus.Counter
+	metricEventServiceFilteredRowCount    prometheus.Counter
 }
 
 func newDispatcherStat(
 	startTs uint64,
 	info DispatcherInfo,
 	filter filter.Filter,
+	mounter pevent.Mounter,
 ) *dispatcherStat {
 	namespace, id := info.GetChangefeedID()
 	dispStat := &dispatcherStat{
 		info:                                  info,
 		filter:                                filter,
+		mounter:                               mounter,
 		metricSorterOutputEventCountKV:        metrics.SorterOutputEventCount.WithLabelValues(namespace, id, "kv"),
 		metricEventServiceSendKvCount:         metrics.EventServiceSendEventCount.WithLabelValues(namespace, id, "kv"),
 		metricEventServiceSendDDLCount:        metrics.EventServiceSendEventCount.WithLabelValues(namespace, id, "ddl"),
 		metricEventServiceSendResolvedTsCount: metrics.EventServiceSendEventCount.WithLabelValues(namespace, id, "resolved_ts"),
+		metricEventServiceFilteredRowCount:    metrics.EventServiceFilteredRowCount.WithLabelValues(namespace, id, info.GetID().String()),
+	}
+	if limit := info.GetScanLimit(); limit != nil {
+		dispStat.scanLimit = scanLimit{
+			maxBytes:    limit.MaxBytes,
+			maxRows:     limit.MaxRows,
+			maxDuration: time.Duration(limit.MaxDurationInMs) * time.Millisecond,
+		}
 	}
 	if info.SyncPointEnabled() {
 		dispStat.enableSyncPoint = true
@@ -801,6 +936,20 @@ func (a *dispatcherStat) getDataRange() (common.DataRange, bool) {
 	return r, true
 }
 
+// scanLimit is the limits of a single scan, 0 means no limit.
+type scanLimit struct {
+	maxBytes    uint64
+	maxRows     uint64
+	maxDuration time.Duration
+}
+
+// isReached returns true if the scan should stop.
+func (l scanLimit) isReached(bytes, rows uint64, duration time.Duration) bool {
+	return (l.maxBytes > 0 && bytes >= l.maxBytes) ||
+		(l.maxRows > 0 && rows >= l.maxRows) ||
+		(l.maxDuration > 0 && duration >= l.maxDuration)
+}
+
 type scanTask struct {
 	dispatcherStat *dispatcherStat
 	createTime     time.Time
@@ -12,6 +12,7 @@ import (
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	pevent "github.com/pingcap/ticdc/pkg/common/event"
+	tconfig "github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/stretchr/testify/require"
 )
@@ -25,12 +26,11 @@ func TestNewDispatcherStat(t *testing.T) {
 		startTs:   startTs,
 	}
 
-	spanSubscription := newSpanSubscription(info.GetTableSpan(), startTs)
-	stat := newDispatcherStat(startTs, info, spanSubscription, nil)
+	stat := newDispatcherStat(startTs, info, nil, nil)
 	require.Equal(t, info, stat.info)
 	require.Equal(t, startTs, stat.watermark.Load())
-	require.NotNil(t, stat.spanSubscription)
-	require.Equal(t, startTs, stat.spanSubscription.watermark.Load())
+	require.Equal(t, startTs, stat.resolvedTs.Load())
+	require.True(t, stat.isRunning.Load())
 	require.Nil(t, stat.filter)
 }
 
@@ -42,29 +42,22 @@ func TestDispatcherStatUpdateWatermark(t *testing.T) {
 		startTs:   startTs,
 	}
 
-	spanSubscription := newSpanSubscription(info.GetTableSpan(), startTs)
-	stat := newDispatcherStat(startTs, info, spanSubscription, nil)
+	stat := newDispatcherStat(startTs, info, nil, nil)
 
-	// Case 1: no new events, only watermark change
-	stat.spanSubscription.onSubscriptionWatermark(456)
-	require.Equal(t, uint64(456), stat.spanSubscription.watermark.Load())
+	// Case 1: the resolved ts increases
+	require.True(t, stat.onSubscriptionResolvedTs(456))
+	require.Equal(t, uint64(456), stat.resolvedTs.Load())
 	log.Info("pass TestDispatcherStatUpdateWatermark case 1")
 
-	// Case 2: new events, and watermark increase
-	stat.spanSubscription.onSubscriptionWatermark(789)
-	stat.spanSubscription.onNewCommitTs(360)
-	require.Equal(t, uint64(360), stat.spanSubscription.maxEventCommitTs.Load())
-	require.Equal(t, uint64(789), stat.spanSubscription.watermark.Load())
+	// Case 2: the resolved ts doesn't change
+	require.False(t, stat.onSubscriptionResolvedTs(456))
+	require.Equal(t, uint64(456), stat.resolvedTs.Load())
 	log.Info("pass TestDispatcherStatUpdateWatermark case 2")
 
-	// Case 3: new events, and watermark decrease
-	// watermark should not decrease
-	stat.spanSubscription.onSubscriptionWatermark(456)
-	stat.spanSubscription.onNewCommitTs(800)
-	require.Equal(t, uint64(789), stat.spanSubscription.watermark.Load())
-	require.Equal(t, uint64(800), stat.spanSubscription.maxEventCommitTs.Load())
+	// Case 3: the resolved ts should not decrease
+	require.Panics(t, func() { stat.onSubscriptionResolvedTs(345) })
+	require.Equal(t, uint64(456), stat.resolvedTs.Load())
 	log.Info("pass TestDispatcherStatUpdateWatermark case 3")
-
 }
 
 func newTableSpan(tableID int64, start, end string) *heartbeatpb.TableSpan {
@@ -153,6 +146,7 @@ func TestSendEvents(t *testing.T) {
 			t        int // type
 			commitTs common.Ts
 		}{
+			{t: pevent.TypeHandshakeEvent, commitTs: common.Ts(1)},
 			{t: pevent.TypeDDLEvent, commitTs: ddlEvent.FinishedTs},
 			{t: pevent.TypeDMLEvent, commitTs: kvEvents[0].CRTs},
 			{t: pevent.TypeDDLEvent, commitTs: ddlEvent1.FinishedTs},
@@ -188,8 +182,8 @@ func TestSendEvents(t *testing.T) {
 	// Register the dispatcher
 	tableID := ddlEvent.TableID
 	info := newMockDispatcherInfo(common.NewDispatcherID(), tableID, eventpb.ActionType_ACTION_TYPE_REGISTER)
-	s.addDispatcher(info)
-	_, ok := s.spans[tableID]
+	require.NoError(t, s.addDispatcher(info))
+	_, ok := s.dispatchers.Load(info.GetID())
 	require.True(t, ok)
 
 	schemaStore.AppendDDLEvent(tableID, ddlEvent, ddlEvent1, ddlEvent2)
@@ -201,3 +195,215 @@ func TestSendEvents(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestAddDispatcherWithTimezone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mc := &mockMessageCenter{messageCh: make(chan *messaging.TargetMessage, 16)}
+	s := newEventBroker(ctx, 1, newMockEventStore(100), newMockSchemaStore(), mc, time.UTC)
+	defer s.close()
+
+	// The timezone is carried by the register request sent by the event collector.
+	req := &messaging.RegisterDispatcherRequest{RegisterDispatcherRequest: &eventpb.RegisterDispatcherRequest{
+		DispatcherId: common.NewDispatcherID().ToPB(),
+		ServerId:     "server1",
+		TableSpan:    newTableSpan(1, "a", "z"),
+		StartTs:      1,
+		ActionType:   eventpb.ActionType_ACTION_TYPE_REGISTER,
+		FilterConfig: &eventpb.FilterConfig{Rules: []string{"*.*"}},
+		Timezone:     "Asia/Shanghai",
+	}}
+	data, err := req.Marshal()
+	require.NoError(t, err)
+	decoded := &messaging.RegisterDispatcherRequest{RegisterDispatcherRequest: &eventpb.RegisterDispatcherRequest{}}
+	require.NoError(t, decoded.Unmarshal(data))
+	infos := msgToDispatcherInfo(messaging.NewSingleTargetMessage("server2", messaging.EventServiceTopic, decoded))
+	require.Len(t, infos, 1)
+	require.Equal(t, "Asia/Shanghai", infos[0].GetTimezone())
+
+	// The rows of the dispatcher are decoded with its own timezone.
+	require.NoError(t, s.addDispatcher(infos[0]))
+	v, ok := s.dispatchers.Load(infos[0].GetID())
+	require.True(t, ok)
+	mounter, ok := s.mounters.Load("Asia/Shanghai")
+	require.True(t, ok)
+	require.True(t, v.(*dispatcherStat).mounter == mounter)
+	require.True(t, v.(*dispatcherStat).mounter != s.mounter)
+
+	// The dispatcher without a timezone uses the timezone of the event service.
+	info := newMockDispatcherInfo(common.NewDispatcherID(), 2, eventpb.ActionType_ACTION_TYPE_REGISTER)
+	require.NoError(t, s.addDispatcher(info))
+	v, ok = s.dispatchers.Load(info.GetID())
+	require.True(t, ok)
+	require.True(t, v.(*dispatcherStat).mounter == s.mounter)
+
+	// The dispatcher with an invalid timezone is not registered.
+	info = newMockDispatcherInfo(common.NewDispatcherID(), 3, eventpb.ActionType_ACTION_TYPE_REGISTER)
+	info.timezone = "Invalid/Timezone"
+	require.Error(t, s.addDispatcher(info))
+	_, ok = s.dispatchers.Load(info.GetID())
+	require.False(t, ok)
+}
+
+type sentEvent struct {
+	t        int
+	startTs  uint64
+	commitTs uint64
+}
+
+// collectSentEvents collects the events sent to the dispatcher until the resolved ts reaches endTs,
+// the handshake events and the resolved ts which doesn't advance are ignored.
+func collectSentEvents(t *testing.T, mc *mockMessageCenter, id common.DispatcherID, endTs uint64) []sentEvent {
+	var (
+		events     []sentEvent
+		resolvedTs uint64
+	)
+	timeout := time.After(10 * time.Second)
+	for resolvedTs < endTs {
+		select {
+		case <-timeout:
+			require.FailNow(t, "wait events timeout", "events: %+v", events)
+		case msg := <-mc.messageCh:
+			for _, m := range msg.Message {
+				switch e := m.(type) {
+				case *pevent.HandshakeEvent:
+				case *pevent.BatchResolvedEvent:
+					for _, r := range e.Events {
+						if r.DispatcherID == id && r.ResolvedTs > resolvedTs {
+							resolvedTs = r.ResolvedTs
+							events = append(events, sentEvent{t: pevent.TypeResolvedEvent, commitTs: r.ResolvedTs})
+						}
+					}
+				case *pevent.DMLEvent:
+					events = append(events, sentEvent{t: pevent.TypeDMLEvent, startTs: e.StartTs, commitTs: e.CommitTs})
+				case *pevent.DDLEvent:
+					events = append(events, sentEvent{t: pevent.TypeDDLEvent, commitTs: e.FinishedTs})
+				}
+			}
+		}
+	}
+	return events
+}
+
+// genScanLimitEvents generates three transactions after the ddl, the first one has two rows,
+// and the last two share the same commit ts.
+func genScanLimitEvents(t *testing.T) (pevent.DDLEvent, []*common.RawKVEntry) {
+	helper := pevent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	ddlEvent, kvEvents := genEvents(helper, t, `create table test.t(id int primary key, c char(50))`, []string{
+		`insert into test.t(id,c) values (0, "c0")`,
+		`insert into test.t(id,c) values (1, "c1")`,
+		`insert into test.t(id,c) values (2, "c2")`,
+		`insert into test.t(id,c) values (3, "c3")`,
+	}...)
+	require.Len(t, kvEvents, 4)
+	withTs := func(e *common.RawKVEntry, startTs, commitTs uint64) *common.RawKVEntry {
+		kv := *e
+		kv.StartTs = startTs
+		kv.CRTs = commitTs
+		return &kv
+	}
+	commitTs := kvEvents[0].CRTs
+	return ddlEvent, []*common.RawKVEntry{
+		withTs(kvEvents[0], commitTs-1, commitTs),
+		withTs(kvEvents[1], commitTs-1, commitTs),
+		withTs(kvEvents[2], commitTs+1, commitTs+10),
+		withTs(kvEvents[3], commitTs+2, commitTs+10),
+	}
+}
+
+func TestScanLimit(t *testing.T) {
+	ddlEvent, kvEvents := genScanLimitEvents(t)
+	commitTs := kvEvents[0].CRTs
+	endTs := commitTs + 20
+
+	cases := []struct {
+		name      string
+		limit     *tconfig.ScanLimitConfig
+		nextDelay time.Duration
+	}{
+		{name: "bytes", limit: &tconfig.ScanLimitConfig{MaxBytes: 1}},
+		{name: "rows", limit: &tconfig.ScanLimitConfig{MaxRows: 1}},
+		{name: "duration", limit: &tconfig.ScanLimitConfig{MaxDurationInMs: 1}, nextDelay: 2 * time.Millisecond},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			eventStore := newMockEventStore(100)
+			eventStore.nextDelay = tc.nextDelay
+			schemaStore := newMockSchemaStore()
+			mc := &mockMessageCenter{messageCh: make(chan *messaging.TargetMessage, 1024)}
+			s := newEventBroker(ctx, 1, eventStore, schemaStore, mc, time.Local)
+			defer s.close()
+
+			info := newMockDispatcherInfo(common.NewDispatcherID(), ddlEvent.TableID, eventpb.ActionType_ACTION_TYPE_REGISTER)
+			info.scanLimit = tc.limit
+			schemaStore.AppendDDLEvent(ddlEvent.TableID, ddlEvent)
+			require.NoError(t, s.addDispatcher(info))
+			v, ok := eventStore.spansMap.Load(ddlEvent.TableID)
+			require.True(t, ok)
+			v.(*mockSpanStats).update(endTs, kvEvents...)
+
+			// The first scan stops after the first transaction, and sends its commit ts as the watermark.
+			// The next scan resumes from the watermark, and doesn't stop between the transactions
+			// with the same commit ts.
+			require.Equal(t, []sentEvent{
+				{t: pevent.TypeDDLEvent, commitTs: ddlEvent.FinishedTs},
+				{t: pevent.TypeDMLEvent, startTs: commitTs - 1, commitTs: commitTs},
+				{t: pevent.TypeResolvedEvent, commitTs: commitTs},
+				{t: pevent.TypeDMLEvent, startTs: commitTs + 1, commitTs: commitTs + 10},
+				{t: pevent.TypeDMLEvent, startTs: commitTs + 2, commitTs: commitTs + 10},
+				{t: pevent.TypeResolvedEvent, commitTs: endTs},
+			}, collectSentEvents(t, mc, info.GetID(), endTs))
+		})
+	}
+}
+
+func TestInterruptedScanOfPausedDispatcher(t *testing.T) {
+	ddlEvent, kvEvents := genScanLimitEvents(t)
+	commitTs := kvEvents[0].CRTs
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventStore := newMockEventStore(100)
+	schemaStore := newMockSchemaStore()
+	mc := &mockMessageCenter{messageCh: make(chan *messaging.TargetMessage, 1024)}
+	s := newEventBroker(ctx, 1, eventStore, schemaStore, mc, time.Local)
+	defer s.close()
+
+	info := newMockDispatcherInfo(common.NewDispatcherID(), ddlEvent.TableID, eventpb.ActionType_ACTION_TYPE_REGISTER)
+	info.scanLimit = &tconfig.ScanLimitConfig{MaxRows: 1}
+	schemaStore.AppendDDLEvent(ddlEvent.TableID, ddlEvent)
+	require.NoError(t, s.addDispatcher(info))
+	v, ok := s.dispatchers.Load(info.GetID())
+	require.True(t, ok)
+	stat := v.(*dispatcherStat)
+	// The dispatcher is paused after its scan task is queued.
+	stat.isRunning.Store(false)
+	v, ok = eventStore.spansMap.Load(ddlEvent.TableID)
+	require.True(t, ok)
+	v.(*mockSpanStats).update(commitTs+20, kvEvents...)
+	s.ds.In() <- newScanTask(stat)
+
+	require.Equal(t, []sentEvent{
+		{t: pevent.TypeDDLEvent, commitTs: ddlEvent.FinishedTs},
+		{t: pevent.TypeDMLEvent, startTs: commitTs - 1, commitTs: commitTs},
+		{t: pevent.TypeResolvedEvent, commitTs: commitTs},
+	}, collectSentEvents(t, mc, info.GetID(), commitTs))
+	require.Equal(t, commitTs, stat.watermark.Load())
+	// The interrupted scan is not re-queued.
+	require.Never(t, func() bool {
+		select {
+		case msg := <-mc.messageCh:
+			for _, m := range msg.Message {
+				if _, ok := m.(*pevent.DMLEvent); ok {
+					return true
+				}
+			}
+		default:
+		}
+		return false
+	}, 500*time.Millisecond, 50*time.Millisecond)
+}
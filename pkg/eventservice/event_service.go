@@ -13,6 +13,8 @@ import (
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/messaging"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/zap"
 )
 
@@ -34,6 +36,11 @@ type DispatcherInfo interface {
 	GetActionType() eventpb.ActionType
 	GetChangefeedID() (namespace, id string)
 	GetFilterConfig() *config.FilterConfig
+	// GetScanLimit returns the limits of a single scan of the dispatcher.
+	GetScanLimit() *config.ScanLimitConfig
+	// GetTimezone returns the name of the timezone used to decode the row values of the dispatcher,
+	// the timezone of the event service is used if it is empty.
+	GetTimezone() string
 
 	// sync point related
 	SyncPointEnabled() bool
@@ -57,13 +64,17 @@ type eventService struct {
 
 func New(eventStore eventstore.EventStore, schemaStore schemastore.SchemaStore) common.SubModule {
 	mc := appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)
+	tz, err := util.GetTimezone(config.GetGlobalServerConfig().TZ)
+	if err != nil {
+		log.Panic("load timezone failed", zap.Error(err))
+	}
 	es := &eventService{
 		mc:             mc,
 		eventStore:     eventStore,
 		schemaStore:    schemaStore,
 		brokers:        make(map[uint64]*eventBroker),
 		dispatcherInfo: make(chan DispatcherInfo, defaultChannelSize*16),
-		tz:             time.Local, // FIXME use the timezone from the config
+		tz:             tz,
 	}
 	es.mc.RegisterHandler(messaging.EventServiceTopic, es.handleMessage)
 	return es
@@ -127,7 +138,9 @@ func (s *eventService) registerDispatcher(ctx context.Context, info DispatcherIn
 		c = newEventBroker(ctx, clusterID, s.eventStore, s.schemaStore, s.mc, s.tz)
 		s.brokers[clusterID] = c
 	}
-	c.addDispatcher(info)
+	if err := c.addDispatcher(info); err != nil {
+		c.sendDispatcherError(ctx, node.ID(info.GetServerID()), info, err)
+	}
 }
 
 func (s *eventService) deregisterDispatcher(dispatcherInfo DispatcherInfo) {
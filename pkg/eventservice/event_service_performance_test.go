@@ -22,6 +22,7 @@ import (
 //     -> dispatcherStat.onSubscriptionWatermark() -> dispatcherStat.onAsyncNotify() -> taskPool.pushTask(), merge task -> scanWorker new Msg -> messageCenter.SendMsg()
 //     It should be note that some task of the same dispatcher are merged into one task, so the messageCenter.SendMsg() is not called for each dispatcherStat.onSubscriptionWatermark().
 func TestEventServiceOneMillionTable(t *testing.T) {
+	t.Skip("Skipping TestEventServiceOneMillionTable because it is a performance test which takes minutes, run it manually")
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	wg := &sync.WaitGroup{}
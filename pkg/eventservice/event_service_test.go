@@ -22,9 +22,10 @@ import (
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	pevent "github.com/pingcap/ticdc/pkg/common/event"
+	tconfig "github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/messaging"
-	tconfig "github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
@@ -99,13 +100,19 @@ var _ eventstore.EventStore = &mockEventStore{}
 // mockEventStore is a mock implementation of the EventStore interface
 type mockEventStore struct {
 	resolvedTsUpdateInterval time.Duration
-	spansMap                 sync.Map
+	// spansMap is a map from tableID to *mockSpanStats.
+	spansMap sync.Map
+	// dispatcherMap is a map from dispatcherID to *mockSpanStats.
+	dispatcherMap sync.Map
+	// nextDelay is the time it takes to read an event from the iterator.
+	nextDelay time.Duration
 }
 
 func newMockEventStore(resolvedTsUpdateInterval int) *mockEventStore {
 	return &mockEventStore{
 		resolvedTsUpdateInterval: time.Millisecond * time.Duration(resolvedTsUpdateInterval),
 		spansMap:                 sync.Map{},
+		dispatcherMap:            sync.Map{},
 	}
 }
 
@@ -137,17 +144,29 @@ func (m *mockEventStore) Close(ctx context.Context) error {
 	return nil
 }
 
-func (m *mockEventStore) UpdateDispatcherSendTs(dispatcherID common.DispatcherID, span *heartbeatpb.TableSpan, gcTS uint64) error {
+func (m *mockEventStore) UpdateDispatcherSendTs(dispatcherID common.DispatcherID, sendTs uint64) error {
 	return nil
 }
 
-func (m *mockEventStore) UnregisterDispatcher(dispatcherID common.DispatcherID, span *heartbeatpb.TableSpan) error {
+func (m *mockEventStore) UnregisterDispatcher(dispatcherID common.DispatcherID) error {
+	m.dispatcherMap.Delete(dispatcherID)
 	return nil
 }
 
+func (m *mockEventStore) GetDispatcherDMLEventState(dispatcherID common.DispatcherID) eventstore.DMLEventState {
+	v, ok := m.dispatcherMap.Load(dispatcherID)
+	if !ok {
+		return eventstore.DMLEventState{}
+	}
+	return eventstore.DMLEventState{
+		MaxEventCommitTs: v.(*mockSpanStats).maxEventCommitTs.Load(),
+	}
+}
+
 func (m *mockEventStore) GetIterator(dispatcherID common.DispatcherID, dataRange common.DataRange) (eventstore.EventIterator, error) {
 	iter := &mockEventIterator{
 		events: make([]*common.RawKVEntry, 0),
+		delay:  m.nextDelay,
 	}
 	v, ok := m.spansMap.Load(dataRange.Span.TableID)
 	if !ok {
@@ -165,19 +184,18 @@ func (m *mockEventStore) GetIterator(dispatcherID common.DispatcherID, dataRange
 func (m *mockEventStore) RegisterDispatcher(
 	dispatcherID common.DispatcherID,
 	span *heartbeatpb.TableSpan,
-	startTS common.Ts,
-	observer eventstore.EventObserver,
-	notifier eventstore.WatermarkNotifier,
+	startTS uint64,
+	notifier eventstore.ResolvedTsNotifier,
 ) error {
-	log.Info("subscribe table span", zap.Any("span", span), zap.Uint64("startTs", uint64(startTS)))
+	log.Info("subscribe table span", zap.Any("span", span), zap.Uint64("startTs", startTS))
 	spanStats := &mockSpanStats{
-		startTs:           uint64(startTS),
+		startTs:           startTS,
 		watermarkNotifier: notifier,
-		eventObserver:     observer,
 		pendingEvents:     make([]*common.RawKVEntry, 0),
 	}
-	spanStats.watermark.Store(uint64(startTS))
+	spanStats.watermark.Store(startTS)
 	m.spansMap.Store(span.TableID, spanStats)
+	m.dispatcherMap.Store(dispatcherID, spanStats)
 	return nil
 }
 
@@ -186,9 +204,11 @@ type mockEventIterator struct {
 	prevStartTS  uint64
 	prevCommitTS uint64
 	rowCount     int
+	delay        time.Duration
 }
 
 func (iter *mockEventIterator) Next() (*common.RawKVEntry, bool, error) {
+	time.Sleep(iter.delay)
 	if len(iter.events) == 0 {
 		return nil, false, nil
 	}
@@ -306,8 +326,8 @@ func (m *mockSchemaStore) FetchTableTriggerDDLEvents(tableFilter filter.Filter,
 type mockSpanStats struct {
 	startTs           uint64
 	watermark         atomic.Uint64
+	maxEventCommitTs  atomic.Uint64
 	pendingEvents     []*common.RawKVEntry
-	eventObserver     func(watermark uint64)
 	watermarkNotifier func(watermark uint64)
 }
 
@@ -315,7 +335,9 @@ func (m *mockSpanStats) update(watermark uint64, events ...*common.RawKVEntry) {
 	m.pendingEvents = append(m.pendingEvents, events...)
 	m.watermark.Store(watermark)
 	for _, e := range events {
-		m.eventObserver(e.CRTs)
+		if e != nil && e.CRTs > m.maxEventCommitTs.Load() {
+			m.maxEventCommitTs.Store(e.CRTs)
+		}
 	}
 	m.watermarkNotifier(watermark)
 }
@@ -329,6 +351,8 @@ type mockDispatcherInfo struct {
 	span       *heartbeatpb.TableSpan
 	startTs    uint64
 	actionType eventpb.ActionType
+	timezone   string
+	scanLimit  *tconfig.ScanLimitConfig
 }
 
 func newMockDispatcherInfo(dispatcherID common.DispatcherID, tableID int64, actionType eventpb.ActionType) *mockDispatcherInfo {
@@ -379,6 +403,14 @@ func (m *mockDispatcherInfo) GetChangefeedID() (namespace, id string) {
 	return "default", "test"
 }
 
+func (m *mockDispatcherInfo) GetScanLimit() *tconfig.ScanLimitConfig {
+	return m.scanLimit
+}
+
+func (m *mockDispatcherInfo) GetTimezone() string {
+	return m.timezone
+}
+
 func (m *mockDispatcherInfo) GetFilterConfig() *tconfig.FilterConfig {
 	return &tconfig.FilterConfig{
 		Rules: []string{"*.*"},
@@ -522,15 +554,15 @@ func TestEventServiceBasic(t *testing.T) {
 			case *commonEvent.DMLEvent:
 				require.NotNil(t, msg)
 				require.Equal(t, "event-collector", msg.Topic)
-				require.Equal(t, len(kvEvents), e.Len())
+				require.Equal(t, int32(len(kvEvents)), e.Len())
 				require.Equal(t, kvEvents[0].CRTs, e.CommitTs)
-				require.Equal(t, uint64(2), e.Seq)
+				require.Equal(t, uint64(3), e.Seq)
 				log.Info("receive dml event", zap.Any("event", e))
 			case *commonEvent.DDLEvent:
 				require.NotNil(t, msg)
 				require.Equal(t, "event-collector", msg.Topic)
 				require.Equal(t, ddlEvent.FinishedTs, e.FinishedTs)
-				require.Equal(t, uint64(3), e.Seq)
+				require.Equal(t, uint64(2), e.Seq)
 				log.Info("receive ddl event", zap.Any("event", e))
 			case *commonEvent.BatchResolvedEvent:
 				require.NotNil(t, msg)
@@ -543,3 +575,28 @@ func TestEventServiceBasic(t *testing.T) {
 	}
 
 }
+
+func TestRegisterDispatcherWithInvalidTimezone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockStore := newMockEventStore(100)
+	mc := &mockMessageCenter{
+		messageCh: make(chan *messaging.TargetMessage, 100),
+	}
+	esImpl := initEventService(ctx, t, mc, mockStore)
+	defer esImpl.Close(ctx)
+
+	dispatcherInfo := newMockDispatcherInfo(common.NewDispatcherID(), 1, eventpb.ActionType_ACTION_TYPE_REGISTER)
+	dispatcherInfo.timezone = "Invalid/Timezone"
+	esImpl.dispatcherInfo <- dispatcherInfo
+
+	// the error is reported to the event collector instead of panicking.
+	msg := <-mc.messageCh
+	require.Equal(t, node.ID(dispatcherInfo.serverID), msg.To)
+	require.Equal(t, messaging.EventCollectorTopic, msg.Topic)
+	e, ok := msg.Message[0].(*eventpb.DispatcherError)
+	require.True(t, ok)
+	require.Equal(t, dispatcherInfo.id, common.NewDispatcherIDFromPB(e.DispatcherId))
+	require.Equal(t, string(cerror.ErrLoadTimezone.RFCCode()), e.Err.Code)
+}
@@ -19,8 +19,11 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/tidb/pkg/expression"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/types"
@@ -38,8 +41,8 @@ import (
 // but have slightly changed to fit the usage of cdc.
 type dmlExprFilterRule struct {
 	mu sync.Mutex
-	// Cache tableInfos to check if the table was changed.
-	tables map[string]*model.TableInfo
+	// Cache the versions of tableInfos to check if the table was changed.
+	tableVersions map[string]uint64
 
 	insertExprs    map[string]expression.Expression // tableName -> expr
 	updateOldExprs map[string]expression.Expression // tableName -> expr
@@ -63,7 +66,7 @@ func newExprFilterRule(
 	}
 
 	ret := &dmlExprFilterRule{
-		tables:         make(map[string]*model.TableInfo),
+		tableVersions:  make(map[string]uint64),
 		insertExprs:    make(map[string]expression.Expression),
 		updateOldExprs: make(map[string]expression.Expression),
 		updateNewExprs: make(map[string]expression.Expression),
@@ -113,28 +116,28 @@ func (r *dmlExprFilterRule) verify(tableInfos []*model.TableInfo) error {
 			continue
 		}
 		if r.config.IgnoreInsertValueExpr != "" {
-			e, err := r.getSimpleExprOfTable(r.config.IgnoreInsertValueExpr, ti)
+			e, err := r.getSimpleExprOfTable(r.config.IgnoreInsertValueExpr, tableName, ti.TableInfo)
 			if err != nil {
 				return err
 			}
 			r.insertExprs[tableName] = e
 		}
 		if r.config.IgnoreUpdateOldValueExpr != "" {
-			e, err := r.getSimpleExprOfTable(r.config.IgnoreUpdateOldValueExpr, ti)
+			e, err := r.getSimpleExprOfTable(r.config.IgnoreUpdateOldValueExpr, tableName, ti.TableInfo)
 			if err != nil {
 				return err
 			}
 			r.updateOldExprs[tableName] = e
 		}
 		if r.config.IgnoreUpdateNewValueExpr != "" {
-			e, err := r.getSimpleExprOfTable(r.config.IgnoreUpdateNewValueExpr, ti)
+			e, err := r.getSimpleExprOfTable(r.config.IgnoreUpdateNewValueExpr, tableName, ti.TableInfo)
 			if err != nil {
 				return err
 			}
 			r.updateNewExprs[tableName] = e
 		}
 		if r.config.IgnoreDeleteValueExpr != "" {
-			e, err := r.getSimpleExprOfTable(r.config.IgnoreDeleteValueExpr, ti)
+			e, err := r.getSimpleExprOfTable(r.config.IgnoreDeleteValueExpr, tableName, ti.TableInfo)
 			if err != nil {
 				return err
 			}
@@ -154,15 +157,14 @@ func (r *dmlExprFilterRule) resetExpr(tableName string) {
 
 // getInsertExprs returns the expression filter to filter INSERT events.
 // This function will lazy calculate expressions if not initialized.
-func (r *dmlExprFilterRule) getInsertExpr(ti *model.TableInfo) (
+func (r *dmlExprFilterRule) getInsertExpr(tableName string, ti *timodel.TableInfo) (
 	expression.Expression, error,
 ) {
-	tableName := ti.TableName.String()
 	if r.insertExprs[tableName] != nil {
 		return r.insertExprs[tableName], nil
 	}
 	if r.config.IgnoreInsertValueExpr != "" {
-		expr, err := r.getSimpleExprOfTable(r.config.IgnoreInsertValueExpr, ti)
+		expr, err := r.getSimpleExprOfTable(r.config.IgnoreInsertValueExpr, tableName, ti)
 		if err != nil {
 			return nil, err
 		}
@@ -171,16 +173,15 @@ func (r *dmlExprFilterRule) getInsertExpr(ti *model.TableInfo) (
 	return r.insertExprs[tableName], nil
 }
 
-func (r *dmlExprFilterRule) getUpdateOldExpr(ti *model.TableInfo) (
+func (r *dmlExprFilterRule) getUpdateOldExpr(tableName string, ti *timodel.TableInfo) (
 	expression.Expression, error,
 ) {
-	tableName := ti.TableName.String()
 	if r.updateOldExprs[tableName] != nil {
 		return r.updateOldExprs[tableName], nil
 	}
 
 	if r.config.IgnoreUpdateOldValueExpr != "" {
-		expr, err := r.getSimpleExprOfTable(r.config.IgnoreUpdateOldValueExpr, ti)
+		expr, err := r.getSimpleExprOfTable(r.config.IgnoreUpdateOldValueExpr, tableName, ti)
 		if err != nil {
 			return nil, err
 		}
@@ -189,16 +190,15 @@ func (r *dmlExprFilterRule) getUpdateOldExpr(ti *model.TableInfo) (
 	return r.updateOldExprs[tableName], nil
 }
 
-func (r *dmlExprFilterRule) getUpdateNewExpr(ti *model.TableInfo) (
+func (r *dmlExprFilterRule) getUpdateNewExpr(tableName string, ti *timodel.TableInfo) (
 	expression.Expression, error,
 ) {
-	tableName := ti.TableName.String()
 	if r.updateNewExprs[tableName] != nil {
 		return r.updateNewExprs[tableName], nil
 	}
 
 	if r.config.IgnoreUpdateNewValueExpr != "" {
-		expr, err := r.getSimpleExprOfTable(r.config.IgnoreUpdateNewValueExpr, ti)
+		expr, err := r.getSimpleExprOfTable(r.config.IgnoreUpdateNewValueExpr, tableName, ti)
 		if err != nil {
 			return nil, err
 		}
@@ -207,16 +207,15 @@ func (r *dmlExprFilterRule) getUpdateNewExpr(ti *model.TableInfo) (
 	return r.updateNewExprs[tableName], nil
 }
 
-func (r *dmlExprFilterRule) getDeleteExpr(ti *model.TableInfo) (
+func (r *dmlExprFilterRule) getDeleteExpr(tableName string, ti *timodel.TableInfo) (
 	expression.Expression, error,
 ) {
-	tableName := ti.TableName.String()
 	if r.deleteExprs[tableName] != nil {
 		return r.deleteExprs[tableName], nil
 	}
 
 	if r.config.IgnoreDeleteValueExpr != "" {
-		expr, err := r.getSimpleExprOfTable(r.config.IgnoreDeleteValueExpr, ti)
+		expr, err := r.getSimpleExprOfTable(r.config.IgnoreDeleteValueExpr, tableName, ti)
 		if err != nil {
 			return nil, err
 		}
@@ -227,9 +226,10 @@ func (r *dmlExprFilterRule) getDeleteExpr(ti *model.TableInfo) (
 
 func (r *dmlExprFilterRule) getSimpleExprOfTable(
 	expr string,
-	ti *model.TableInfo,
+	tableName string,
+	ti *timodel.TableInfo,
 ) (expression.Expression, error) {
-	e, err := expression.ParseSimpleExprWithTableInfo(r.sessCtx.GetExprCtx(), expr, ti.TableInfo)
+	e, err := expression.ParseSimpleExprWithTableInfo(r.sessCtx.GetExprCtx(), expr, ti)
 	if err != nil {
 		// If an expression contains an unknown column,
 		// we return an error and stop the changefeed.
@@ -238,7 +238,7 @@ func (r *dmlExprFilterRule) getSimpleExprOfTable(
 				zap.String("expression", expr),
 				zap.Error(err))
 			return nil, cerror.ErrExpressionColumnNotFound.
-				FastGenByArgs(getColumnFromError(err), ti.TableName.String(), expr)
+				FastGenByArgs(getColumnFromError(err), tableName, expr)
 		}
 		log.Error("failed to parse expression", zap.Error(err))
 		return nil, cerror.ErrExpressionParseFailed.FastGenByArgs(err, expr)
@@ -251,81 +251,92 @@ func (r *dmlExprFilterRule) shouldSkipDML(
 	rawRow model.RowChangedDatums,
 	ti *model.TableInfo,
 ) (bool, error) {
-	tableName := ti.TableName.String()
+	var rowType commonEvent.RowType
+	switch {
+	case row.IsInsert():
+		rowType = commonEvent.RowTypeInsert
+	case row.IsUpdate():
+		rowType = commonEvent.RowTypeUpdate
+	case row.IsDelete():
+		rowType = commonEvent.RowTypeDelete
+	default:
+		log.Warn("unknown row changed event type")
+		return false, nil
+	}
+	return r.shouldSkipRow(
+		ti.TableName.String(),
+		ti.Version,
+		ti.TableInfo,
+		rowType,
+		datumsToRow(rawRow.PreRowDatums),
+		datumsToRow(rawRow.RowDatums),
+	)
+}
 
+// shouldSkipRow checks whether the row should be skipped by the expressions of the rule.
+// The preRow is used by the update and delete rows, and the row is used by the insert and update rows.
+func (r *dmlExprFilterRule) shouldSkipRow(
+	tableName string,
+	version uint64,
+	ti *timodel.TableInfo,
+	rowType commonEvent.RowType,
+	preRow, row chunk.Row,
+) (bool, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if oldTi, ok := r.tables[tableName]; ok {
-		// If one table's tableInfo was updated, we need to reset this rule
-		// and update the tableInfo in the cache.
-		if ti.Version != oldTi.Version {
-			r.tables[tableName] = ti.Clone()
-			r.resetExpr(ti.TableName.String())
-		}
-	} else {
-		r.tables[tableName] = ti.Clone()
+	// If one table's tableInfo was updated, we need to reset this rule
+	// and update the version in the cache.
+	if oldVersion, ok := r.tableVersions[tableName]; !ok || oldVersion != version {
+		r.tableVersions[tableName] = version
+		r.resetExpr(tableName)
 	}
 
-	switch {
-	case row.IsInsert():
-		exprs, err := r.getInsertExpr(ti)
+	switch rowType {
+	case commonEvent.RowTypeInsert:
+		exprs, err := r.getInsertExpr(tableName, ti)
 		if err != nil {
 			return false, err
 		}
-		return r.skipDMLByExpression(
-			rawRow.RowDatums,
-			exprs,
-		)
-	case row.IsUpdate():
-		oldExprs, err := r.getUpdateOldExpr(ti)
+		return r.skipDMLByExpression(row, exprs)
+	case commonEvent.RowTypeUpdate:
+		oldExprs, err := r.getUpdateOldExpr(tableName, ti)
 		if err != nil {
 			return false, err
 		}
-		newExprs, err := r.getUpdateNewExpr(ti)
+		newExprs, err := r.getUpdateNewExpr(tableName, ti)
 		if err != nil {
 			return false, err
 		}
-		ignoreOld, err := r.skipDMLByExpression(
-			rawRow.PreRowDatums,
-			oldExprs,
-		)
+		ignoreOld, err := r.skipDMLByExpression(preRow, oldExprs)
 		if err != nil {
 			return false, err
 		}
-		ignoreNew, err := r.skipDMLByExpression(
-			rawRow.RowDatums,
-			newExprs,
-		)
+		ignoreNew, err := r.skipDMLByExpression(row, newExprs)
 		if err != nil {
 			return false, err
 		}
 		return ignoreOld || ignoreNew, nil
-	case row.IsDelete():
-		exprs, err := r.getDeleteExpr(ti)
+	case commonEvent.RowTypeDelete:
+		exprs, err := r.getDeleteExpr(tableName, ti)
 		if err != nil {
 			return false, err
 		}
-		return r.skipDMLByExpression(
-			rawRow.PreRowDatums,
-			exprs,
-		)
+		return r.skipDMLByExpression(preRow, exprs)
 	default:
-		log.Warn("unknown row changed event type")
+		log.Warn("unknown row changed event type", zap.String("rowType", commonEvent.RowTypeToString(rowType)))
 		return false, nil
 	}
 }
 
 func (r *dmlExprFilterRule) skipDMLByExpression(
-	rowData []types.Datum,
+	row chunk.Row,
 	expr expression.Expression,
 ) (bool, error) {
-	if len(rowData) == 0 || expr == nil {
+	if row.IsEmpty() || expr == nil {
 		return false, nil
 	}
 
-	row := chunk.MutRowFromDatums(rowData).ToRow()
-
 	d, err := expr.Eval(r.sessCtx.GetExprCtx().GetEvalCtx(), row)
 	if err != nil {
 		log.Error("failed to eval expression", zap.Error(err))
@@ -337,6 +348,14 @@ func (r *dmlExprFilterRule) skipDMLByExpression(
 	return false, nil
 }
 
+// datumsToRow converts the datums to a chunk row, an empty row is returned if there is no datum.
+func datumsToRow(datums []types.Datum) chunk.Row {
+	if len(datums) == 0 {
+		return chunk.Row{}
+	}
+	return chunk.MutRowFromDatums(datums).ToRow()
+}
+
 func getColumnFromError(err error) string {
 	if !plannererrors.ErrUnknownColumn.Equal(err) {
 		return err.Error()
@@ -431,3 +450,32 @@ func (f *dmlExprFilter) shouldSkipDML(
 	}
 	return false, nil
 }
+
+// shouldSkipRow skips the row of the new event model by sql expression.
+func (f *dmlExprFilter) shouldSkipRow(
+	rowType commonEvent.RowType,
+	preRow, row chunk.Row,
+	ti *common.TableInfo,
+) (bool, error) {
+	if len(f.rules) == 0 {
+		return false, nil
+	}
+	// for defense purpose, normally the ti should not be nil.
+	if ti == nil || (preRow.IsEmpty() && row.IsEmpty()) {
+		return false, nil
+	}
+	rules := f.getRules(ti.GetSchemaName(), ti.GetTableName())
+	for _, rule := range rules {
+		ignore, err := rule.shouldSkipRow(ti.TableName.String(), ti.GetVersion(), ti.TableInfo, rowType, preRow, row)
+		if err != nil {
+			if cerror.ShouldFailChangefeed(err) {
+				return false, err
+			}
+			return false, cerror.WrapError(cerror.ErrFailedToFilterDML, err, ti.TableName.String())
+		}
+		if ignore {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -18,9 +18,11 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/apperror"
+	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	tfilter "github.com/pingcap/tidb/pkg/util/table-filter"
 	"github.com/pingcap/tiflow/cdc/model"
 	"go.uber.org/zap"
@@ -43,6 +45,9 @@ const (
 type Filter interface {
 	// ShouldIgnoreDMLEvent returns true if the DML event should not be sent to downstream.
 	ShouldIgnoreDMLEvent(dml *model.RowChangedEvent, rawRow model.RowChangedDatums, tableInfo *model.TableInfo) (bool, error)
+	// ShouldIgnoreDML returns true if the row of a DMLEvent should not be sent to downstream.
+	// The preRow is set for update and delete rows, and the row is set for insert and update rows.
+	ShouldIgnoreDML(rowType commonEvent.RowType, preRow, row chunk.Row, tableInfo *common.TableInfo, startTs uint64) (bool, error)
 	// ShouldIgnoreDDLEvent returns true if the DDL event should not be sent to downstream.
 	ShouldIgnoreDDLEvent(ddl *model.DDLEvent) (bool, error)
 	// ShouldDiscardDDL returns true if this DDL should be discarded.
@@ -156,6 +161,35 @@ func (f *filter) ShouldIgnoreDMLEvent(
 	return f.dmlExprFilter.shouldSkipDML(dml, rawRow, ti)
 }
 
+// ShouldIgnoreDML checks if a row of a DMLEvent should be ignore by conditions below:
+// 0. By startTs.
+// 1. By table name.
+// 2. By type.
+// 3. By columns value.
+func (f *filter) ShouldIgnoreDML(
+	rowType commonEvent.RowType,
+	preRow, row chunk.Row,
+	ti *common.TableInfo,
+	startTs uint64,
+) (bool, error) {
+	if f.shouldIgnoreStartTs(startTs) {
+		return true, nil
+	}
+
+	if f.ShouldIgnoreTable(ti.GetSchemaName(), ti.GetTableName()) {
+		return true, nil
+	}
+
+	ignoreByEventType, err := f.sqlEventFilter.shouldSkipRow(rowType, ti)
+	if err != nil {
+		return false, err
+	}
+	if ignoreByEventType {
+		return true, nil
+	}
+	return f.dmlExprFilter.shouldSkipRow(rowType, preRow, row, ti)
+}
+
 // ShouldDiscardDDL checks if a DDL should be discarded by conditions below:
 // 0. By allow list.
 // 1. By schema name.
@@ -0,0 +1,120 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"testing"
+
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	bf "github.com/pingcap/tiflow/pkg/binlog-filter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldIgnoreDML(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+	dmlEvent := helper.DML2Event("test", "t",
+		"insert into t values (1, 'a');",
+		"insert into t values (2, 'b');")
+	first, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	second, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	tableInfo := dmlEvent.TableInfo
+
+	cfg := &config.FilterConfig{
+		Rules: []string{"test.*"},
+		EventFilters: []*config.EventFilterRule{
+			{
+				Matcher:                  []string{"test.t"},
+				IgnoreEvent:              []bf.EventType{bf.DeleteEvent},
+				IgnoreInsertValueExpr:    "id > 1",
+				IgnoreUpdateNewValueExpr: "name = 'b'",
+			},
+		},
+		IgnoreTxnStartTs: []uint64{100},
+	}
+	f, err := NewFilter(cfg, "", false)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name     string
+		rowType  commonEvent.RowType
+		preRow   chunk.Row
+		row      chunk.Row
+		startTs  uint64
+		expected bool
+	}{
+		{
+			name:    "insert is not matched by the expression",
+			rowType: commonEvent.RowTypeInsert,
+			row:     first.Row,
+			startTs: 1,
+		},
+		{
+			name:     "insert is matched by the expression",
+			rowType:  commonEvent.RowTypeInsert,
+			row:      second.Row,
+			startTs:  1,
+			expected: true,
+		},
+		{
+			name:     "delete is ignored by the event type",
+			rowType:  commonEvent.RowTypeDelete,
+			preRow:   first.Row,
+			startTs:  1,
+			expected: true,
+		},
+		{
+			name:    "update is not matched by the expression",
+			rowType: commonEvent.RowTypeUpdate,
+			preRow:  second.Row,
+			row:     first.Row,
+			startTs: 1,
+		},
+		{
+			name:     "update is matched by the expression",
+			rowType:  commonEvent.RowTypeUpdate,
+			preRow:   first.Row,
+			row:      second.Row,
+			startTs:  1,
+			expected: true,
+		},
+		{
+			name:     "ignored by the start ts",
+			rowType:  commonEvent.RowTypeInsert,
+			row:      first.Row,
+			startTs:  100,
+			expected: true,
+		},
+	}
+	for _, tc := range cases {
+		ignore, err := f.ShouldIgnoreDML(tc.rowType, tc.preRow, tc.row, tableInfo, tc.startTs)
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.expected, ignore, tc.name)
+	}
+
+	// all rows are ignored if the table is not matched by the rules.
+	f, err = NewFilter(&config.FilterConfig{Rules: []string{"other.*"}}, "", false)
+	require.NoError(t, err)
+	ignore, err := f.ShouldIgnoreDML(commonEvent.RowTypeInsert, chunk.Row{}, first.Row, tableInfo, 1)
+	require.NoError(t, err)
+	require.True(t, ignore)
+}
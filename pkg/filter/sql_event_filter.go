@@ -16,6 +16,8 @@ package filter
 import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	tfilter "github.com/pingcap/tidb/pkg/util/table-filter"
 	"github.com/pingcap/tiflow/cdc/model"
@@ -208,3 +210,35 @@ func (f *sqlEventFilter) shouldSkipDML(event *model.RowChangedEvent) (bool, erro
 	}
 	return false, nil
 }
+
+// shouldSkipRow skips the row of the new event model by its type.
+func (f *sqlEventFilter) shouldSkipRow(rowType commonEvent.RowType, ti *common.TableInfo) (bool, error) {
+	if len(f.rules) == 0 {
+		return false, nil
+	}
+
+	var et bf.EventType
+	switch rowType {
+	case commonEvent.RowTypeInsert:
+		et = bf.InsertEvent
+	case commonEvent.RowTypeUpdate:
+		et = bf.UpdateEvent
+	case commonEvent.RowTypeDelete:
+		et = bf.DeleteEvent
+	default:
+		// It should never happen.
+		log.Warn("unknown row changed event type", zap.String("rowType", commonEvent.RowTypeToString(rowType)))
+		return false, nil
+	}
+	rules := f.getRules(ti.GetSchemaName(), ti.GetTableName())
+	for _, rule := range rules {
+		action, err := rule.bf.Filter(binlogFilterSchemaPlaceholder, binlogFilterTablePlaceholder, et, dmlQuery)
+		if err != nil {
+			return false, cerror.WrapError(cerror.ErrFailedToFilterDML, err, ti.TableName.String())
+		}
+		if action == bf.Ignore {
+			return true, nil
+		}
+	}
+	return false, nil
+}
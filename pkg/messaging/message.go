@@ -10,6 +10,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/eventpb"
 	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/logservicepb"
 	"github.com/pingcap/ticdc/pkg/apperror"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
@@ -46,6 +47,16 @@ const (
 
 	TypeMessageError
 	TypeMessageHandShake
+
+	TypeDispatcherError
+	TypeMoveTableRequest
+	TypeMoveTableResponse
+	TypeDrainNodeRequest
+	TypeEventStoreHeartbeatRequest
+	TypeEventStoreState
+	TypeReusableEventServiceRequest
+	TypeReusableEventServiceResponse
+	TypeLogCoordinatorBroadcastRequest
 )
 
 func (t IOType) String() string {
@@ -94,6 +105,24 @@ func (t IOType) String() string {
 		return "MessageHandShake"
 	case TypeCheckpointTsMessage:
 		return "CheckpointTsMessage"
+	case TypeEventStoreHeartbeatRequest:
+		return "EventStoreHeartbeatRequest"
+	case TypeEventStoreState:
+		return "EventStoreState"
+	case TypeReusableEventServiceRequest:
+		return "ReusableEventServiceRequest"
+	case TypeReusableEventServiceResponse:
+		return "ReusableEventServiceResponse"
+	case TypeLogCoordinatorBroadcastRequest:
+		return "LogCoordinatorBroadcastRequest"
+	case TypeMoveTableRequest:
+		return "MoveTableRequest"
+	case TypeMoveTableResponse:
+		return "MoveTableResponse"
+	case TypeDrainNodeRequest:
+		return "DrainNodeRequest"
+	case TypeDispatcherError:
+		return "DispatcherError"
 	default:
 	}
 	return "Unknown"
@@ -166,6 +195,14 @@ func (r RegisterDispatcherRequest) GetFilterConfig() *config.FilterConfig {
 	return filterCfg
 }
 
+func (r RegisterDispatcherRequest) GetScanLimit() *config.ScanLimitConfig {
+	return &config.ScanLimitConfig{
+		MaxBytes:        r.ScanMaxBytes,
+		MaxRows:         r.ScanMaxRows,
+		MaxDurationInMs: r.ScanMaxDurationMs,
+	}
+}
+
 func (r RegisterDispatcherRequest) SyncPointEnabled() bool {
 	return r.EnableSyncPoint
 }
@@ -226,6 +263,24 @@ func decodeIOType(ioType IOType, value []byte) (IOTypeT, error) {
 		m = &heartbeatpb.MaintainerBootstrapRequest{}
 	case TypeCheckpointTsMessage:
 		m = &heartbeatpb.CheckpointTsMessage{}
+	case TypeEventStoreHeartbeatRequest:
+		m = &logservicepb.EventStoreHeartbeatRequest{}
+	case TypeEventStoreState:
+		m = &logservicepb.EventStoreState{}
+	case TypeReusableEventServiceRequest:
+		m = &logservicepb.ReusableEventServiceRequest{}
+	case TypeReusableEventServiceResponse:
+		m = &logservicepb.ReusableEventServiceResponse{}
+	case TypeLogCoordinatorBroadcastRequest:
+		m = &logservicepb.LogCoordinatorBroadcastRequest{}
+	case TypeMoveTableRequest:
+		m = &heartbeatpb.MoveTableRequest{}
+	case TypeMoveTableResponse:
+		m = &heartbeatpb.MoveTableResponse{}
+	case TypeDrainNodeRequest:
+		m = &heartbeatpb.DrainNodeRequest{}
+	case TypeDispatcherError:
+		m = &eventpb.DispatcherError{}
 	case TypeMessageError:
 		m = &MessageError{AppError: &apperror.AppError{}}
 	default:
@@ -294,6 +349,24 @@ func NewSingleTargetMessage(To node.ID, Topic string, Message IOTypeT) *TargetMe
 		ioType = TypeMaintainerCloseResponse
 	case *heartbeatpb.CheckpointTsMessage:
 		ioType = TypeCheckpointTsMessage
+	case *logservicepb.EventStoreHeartbeatRequest:
+		ioType = TypeEventStoreHeartbeatRequest
+	case *logservicepb.EventStoreState:
+		ioType = TypeEventStoreState
+	case *logservicepb.ReusableEventServiceRequest:
+		ioType = TypeReusableEventServiceRequest
+	case *logservicepb.ReusableEventServiceResponse:
+		ioType = TypeReusableEventServiceResponse
+	case *logservicepb.LogCoordinatorBroadcastRequest:
+		ioType = TypeLogCoordinatorBroadcastRequest
+	case *heartbeatpb.MoveTableRequest:
+		ioType = TypeMoveTableRequest
+	case *heartbeatpb.MoveTableResponse:
+		ioType = TypeMoveTableResponse
+	case *heartbeatpb.DrainNodeRequest:
+		ioType = TypeDrainNodeRequest
+	case *eventpb.DispatcherError:
+		ioType = TypeDispatcherError
 	default:
 		panic("unknown io type")
 	}
@@ -0,0 +1,100 @@
+package messaging
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveTableMessage(t *testing.T) {
+	req := &heartbeatpb.MoveTableRequest{
+		ChangefeedID: "test",
+		RequestId:    1,
+		TableId:      100,
+		TargetNode:   "node1",
+	}
+	msg := NewSingleTargetMessage(node.NewID(), MaintainerManagerTopic, req)
+	require.Equal(t, TypeMoveTableRequest, msg.Type)
+	data, err := req.Marshal()
+	require.NoError(t, err)
+	decoded, err := decodeIOType(msg.Type, data)
+	require.NoError(t, err)
+	require.Equal(t, req, decoded)
+
+	resp := &heartbeatpb.MoveTableResponse{
+		ChangefeedID: "test",
+		RequestId:    1,
+		TableId:      100,
+		Spans: []*heartbeatpb.TableSpanMoveStatus{
+			{
+				Id:       &heartbeatpb.DispatcherID{High: 1, Low: 2},
+				Span:     &heartbeatpb.TableSpan{TableID: 100, StartKey: []byte("a"), EndKey: []byte("b")},
+				Node:     "node1",
+				Operator: "move",
+			},
+		},
+	}
+	msg = NewSingleTargetMessage(node.NewID(), CoordinatorTopic, resp)
+	require.Equal(t, TypeMoveTableResponse, msg.Type)
+	data, err = resp.Marshal()
+	require.NoError(t, err)
+	decoded, err = decodeIOType(msg.Type, data)
+	require.NoError(t, err)
+	require.Equal(t, resp, decoded)
+}
+
+func TestDrainNodeMessage(t *testing.T) {
+	req := &heartbeatpb.DrainNodeRequest{NodeIds: []string{"node1", "node2"}}
+	msg := NewSingleTargetMessage(node.NewID(), MaintainerManagerTopic, req)
+	require.Equal(t, TypeDrainNodeRequest, msg.Type)
+	require.Equal(t, "DrainNodeRequest", msg.Type.String())
+	data, err := req.Marshal()
+	require.NoError(t, err)
+	decoded, err := decodeIOType(msg.Type, data)
+	require.NoError(t, err)
+	require.Equal(t, req, decoded)
+}
+
+// The values of the io types are encoded in the messages sent between nodes,
+// they must not be changed, or the nodes of different versions can't communicate.
+func TestIOTypeValues(t *testing.T) {
+	expected := map[IOType]int32{
+		TypeInvalid:                        0,
+		TypeDMLEvent:                       1,
+		TypeDDLEvent:                       2,
+		TypeBatchResolvedTs:                3,
+		TypeSyncPointEvent:                 4,
+		TypeHandshakeEvent:                 5,
+		TypeHeartBeatRequest:               6,
+		TypeHeartBeatResponse:              7,
+		TypeScheduleDispatcherRequest:      8,
+		TypeRegisterDispatcherRequest:      9,
+		TypeCheckpointTsMessage:            10,
+		TypeBlockStatusRequest:             11,
+		TypeCoordinatorBootstrapRequest:    12,
+		TypeCoordinatorBootstrapResponse:   13,
+		TypeAddMaintainerRequest:           14,
+		TypeRemoveMaintainerRequest:        15,
+		TypeMaintainerHeartbeatRequest:     16,
+		TypeMaintainerBootstrapRequest:     17,
+		TypeMaintainerBootstrapResponse:    18,
+		TypeMaintainerCloseRequest:         19,
+		TypeMaintainerCloseResponse:        20,
+		TypeMessageError:                   21,
+		TypeMessageHandShake:               22,
+		TypeDispatcherError:                23,
+		TypeMoveTableRequest:               24,
+		TypeMoveTableResponse:              25,
+		TypeDrainNodeRequest:               26,
+		TypeEventStoreHeartbeatRequest:     27,
+		TypeEventStoreState:                28,
+		TypeReusableEventServiceRequest:    29,
+		TypeReusableEventServiceResponse:   30,
+		TypeLogCoordinatorBroadcastRequest: 31,
+	}
+	for ioType, value := range expected {
+		require.Equal(t, value, int32(ioType), ioType.String())
+	}
+}
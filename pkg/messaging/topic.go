@@ -6,6 +6,8 @@ const (
 	EventServiceTopic = "EventServiceTopic"
 	// LogCoordinatorTopic is the topic of the log coordinator
 	LogCoordinatorTopic = "log-coordinator"
+	// EventStoreTopic is the topic of the event store.
+	EventStoreTopic = "event-store"
 	// EventCollectorTopic is the topic of the event collector.
 	EventCollectorTopic = "event-collector"
 	// CoordinatorTopic is the topic of the coordinator.
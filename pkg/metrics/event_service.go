@@ -34,6 +34,14 @@ var (
 		Help:      "The number of events sent by the event service",
 	}, []string{"namespace", "changefeed", "type"})
 
+	// EventServiceFilteredRowCount is the metric that counts rows filtered out by the event filter rules of dispatchers.
+	EventServiceFilteredRowCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ticdc",
+		Subsystem: "event_service",
+		Name:      "filtered_row_count",
+		Help:      "The number of rows filtered out by the event service",
+	}, []string{"namespace", "changefeed", "dispatcher"})
+
 	// EventServiceSendEventDuration is the metric that records the duration of sending events by the event service.
 	EventServiceSendEventDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 		Namespace: "ticdc",
@@ -78,6 +86,13 @@ var (
 			Name:      "drop_scan_task_count",
 			Help:      "The number of scan tasks dropped",
 		})
+	EventServiceInterruptedScanCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_service",
+			Name:      "interrupted_scan_count",
+			Help:      "The number of scans stopped by the scan limits of dispatchers",
+		})
 	EventServiceDropResolvedTsCount = prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Namespace: "ticdc",
@@ -100,11 +115,13 @@ func InitEventServiceMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(SorterOutputEventCount)
 	registry.MustRegister(EventServiceSendEventCount)
 	registry.MustRegister(EventServiceSendEventDuration)
+	registry.MustRegister(EventServiceFilteredRowCount)
 	registry.MustRegister(EventServiceResolvedTsGauge)
 	registry.MustRegister(EventServiceResolvedTsLagGauge)
 	registry.MustRegister(EventServiceScanDuration)
 	registry.MustRegister(EventServiceDispatcherGuage)
 	registry.MustRegister(EventServiceDropScanTaskCount)
+	registry.MustRegister(EventServiceInterruptedScanCount)
 	registry.MustRegister(EventServiceDropResolvedTsCount)
 	registry.MustRegister(EventServiceScanTaskQueueDuration)
 }
@@ -58,6 +58,38 @@ var (
 			Help:      "The number of delete range received by event store.",
 		})
 
+	EventStoreReclaimedBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "reclaimed_bytes",
+			Help:      "The estimated bytes reclaimed by deleting the data of removed subscriptions.",
+		}, []string{"reason"}) // reasons : unregister, orphan, expired.
+
+	EventStoreReclaimedSubscriptionCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "reclaimed_subscription_count",
+			Help:      "The number of removed subscriptions whose data is deleted by event store.",
+		}, []string{"reason"}) // reasons : unregister, orphan, expired.
+
+	EventStoreOnDiskDataSizeGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "on_disk_data_size",
+			Help:      "The amount of disk space used by event store.",
+		})
+
+	EventStorePausedGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "paused",
+			Help:      "Whether event store stops pulling events because the disk quota is reached.",
+		})
+
 	EventStoreDispatcherResolvedTsLagHist = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: "ticdc",
@@ -99,6 +131,10 @@ func InitEventStoreMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(EventStoreScanRequestsCount)
 	registry.MustRegister(EventStoreScanBytes)
 	registry.MustRegister(EventStoreDeleteRangeCount)
+	registry.MustRegister(EventStoreReclaimedBytes)
+	registry.MustRegister(EventStoreReclaimedSubscriptionCount)
+	registry.MustRegister(EventStoreOnDiskDataSizeGauge)
+	registry.MustRegister(EventStorePausedGauge)
 	registry.MustRegister(EventStoreDispatcherResolvedTsLagHist)
 	registry.MustRegister(EventStoreMaxResolvedTsLagGauge)
 	registry.MustRegister(EventStoreDispatcherWatermarkLagHist)
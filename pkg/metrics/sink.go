@@ -200,6 +200,52 @@ var (
 )
 
 // InitMetrics registers all metrics in this file.
+// ---------- Metrics for cloud storage sink. ---------- //
+var (
+	// CloudStorageWriteBytesGauge records the total number of bytes written to cloud storage.
+	CloudStorageWriteBytesGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "ticdc",
+		Subsystem: "sink",
+		Name:      "cloud_storage_write_bytes_total",
+		Help:      "Total number of bytes written to cloud storage",
+	}, []string{"namespace", "changefeed"})
+
+	// CloudStorageFileCountGauge records the number of files generated by cloud storage sink.
+	CloudStorageFileCountGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "ticdc",
+		Subsystem: "sink",
+		Name:      "cloud_storage_file_count",
+		Help:      "Total number of files managed by a cloud storage sink",
+	}, []string{"namespace", "changefeed"})
+
+	// CloudStorageWriteDurationHistogram records the latency of cloud storage writing.
+	CloudStorageWriteDurationHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "ticdc",
+		Subsystem: "sink",
+		Name:      "cloud_storage_write_duration_seconds",
+		Help:      "The latency of writing data to cloud storage",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2.0, 13),
+	}, []string{"namespace", "changefeed"})
+
+	// CloudStorageFlushDurationHistogram records the latency of flushing data to cloud storage.
+	CloudStorageFlushDurationHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "ticdc",
+		Subsystem: "sink",
+		Name:      "cloud_storage_flush_duration_seconds",
+		Help:      "The latency of flushing data to cloud storage",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2.0, 13),
+	}, []string{"namespace", "changefeed"})
+
+	// CloudStorageWorkerBusyRatio records the busy ratio of cloud storage sink dml worker.
+	CloudStorageWorkerBusyRatio = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "cloud_storage_worker_busy_ratio",
+			Help:      "Busy ratio (X ms in 1s) for cloud storage sink dml worker.",
+		}, []string{"namespace", "changefeed", "id"})
+)
+
 func InitSinkMetrics(registry *prometheus.Registry) {
 	// common sink metrics
 	registry.MustRegister(ExecBatchHistogram)
@@ -225,4 +271,11 @@ func InitSinkMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(WorkerBatchDuration)
 	registry.MustRegister(CheckpointTsMessageDuration)
 	registry.MustRegister(CheckpointTsMessageCount)
+
+	// cloud storage sink metrics
+	registry.MustRegister(CloudStorageWriteBytesGauge)
+	registry.MustRegister(CloudStorageFileCountGauge)
+	registry.MustRegister(CloudStorageWriteDurationHistogram)
+	registry.MustRegister(CloudStorageFlushDurationHistogram)
+	registry.MustRegister(CloudStorageWorkerBusyRatio)
 }
@@ -16,6 +16,7 @@ package node
 import (
 	"context"
 
+	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/tiflow/cdc/model"
 )
@@ -44,4 +45,13 @@ type Coordinator interface {
 	ResumeChangefeed(ctx context.Context, id model.ChangeFeedID, newCheckpointTs uint64) error
 	// UpdateChangefeed updates a changefeed
 	UpdateChangefeed(ctx context.Context, change *config.ChangeFeedInfo) error
+	// MoveTable moves all spans of a table of the changefeed to the target node
+	MoveTable(ctx context.Context, id model.ChangeFeedID, tableID int64, target ID) (*heartbeatpb.MoveTableResponse, error)
+	// GetTableMoveStatus returns the nodes and operators of all spans of a table of the changefeed
+	GetTableMoveStatus(ctx context.Context, id model.ChangeFeedID, tableID int64) (*heartbeatpb.MoveTableResponse, error)
+	// DrainNode marks the node unschedulable and moves its maintainers and dispatchers to other nodes,
+	// it returns the number of maintainers and dispatchers remaining on the node
+	DrainNode(ctx context.Context, target ID) (int, error)
+	// UndrainNode marks the drained node schedulable again
+	UndrainNode(ctx context.Context, target ID) error
 }
@@ -16,6 +16,7 @@ package scheduler
 import (
 	"math"
 	"math/rand"
+	"sort"
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/node"
@@ -181,3 +182,143 @@ func BasicSchedule[T any](
 		priorityQueue.AddOrUpdate(item)
 	}
 }
+
+// WeightedReplication is the replication task whose cost is weighed by its load
+type WeightedReplication interface {
+	Replication
+	// GetLoad returns the estimated load of the task, it must be positive
+	GetLoad() int
+}
+
+// LoadSchedule schedules the tasks to the least loaded nodes, the heavier tasks are scheduled first,
+// so they are spread across the nodes
+func LoadSchedule[T WeightedReplication](
+	availableSize int,
+	tasks []T,
+	nodeLoads map[node.ID]int,
+	schedule func(T, node.ID) bool) {
+	if len(nodeLoads) == 0 {
+		log.Warn("no node available, skip")
+		return
+	}
+	priorityQueue := heap.NewHeap[*Item]()
+	for key, load := range nodeLoads {
+		priorityQueue.AddOrUpdate(&Item{
+			Node: key,
+			Load: load,
+		})
+	}
+	sorted := make([]T, len(tasks))
+	copy(sorted, tasks)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].GetLoad() > sorted[j].GetLoad()
+	})
+
+	taskSize := 0
+	for _, task := range sorted {
+		if taskSize >= availableSize {
+			break
+		}
+		item, _ := priorityQueue.PeekTop()
+		// the operator is pushed successfully
+		if schedule(task, item.Node) {
+			// update the load priority queue
+			item.Load += task.GetLoad()
+			taskSize++
+			priorityQueue.AddOrUpdate(item)
+		}
+	}
+}
+
+// loadBalanceToleranceRatio is the ratio of the load exceeding the average load which is tolerated by LoadBalance,
+// it avoids moving the tasks back and forth when the load fluctuates.
+const loadBalanceToleranceRatio = 0.2
+
+// LoadBalance balances the running tasks by the load of each node, it moves a task from the most loaded node
+// to the least loaded node only if the load of the most loaded node exceeds the tolerance of the average load,
+// and the move reduces the load of the most loaded node.
+// It returns the number of the moved tasks.
+func LoadBalance[T WeightedReplication](batchSize int,
+	activeNodes map[node.ID]*node.Info,
+	replicating []T,
+	move func(T, node.ID) bool) int {
+	if len(activeNodes) < 2 {
+		return 0
+	}
+	nodeLoads := make(map[node.ID]int, len(activeNodes))
+	nodeTasks := make(map[node.ID][]T, len(activeNodes))
+	for id := range activeNodes {
+		nodeLoads[id] = 0
+	}
+	totalLoad := 0
+	for _, task := range replicating {
+		nodeID := task.GetNodeID()
+		if _, ok := nodeLoads[nodeID]; !ok {
+			continue
+		}
+		nodeLoads[nodeID] += task.GetLoad()
+		nodeTasks[nodeID] = append(nodeTasks[nodeID], task)
+		totalLoad += task.GetLoad()
+	}
+	maxTolerableLoad := float64(totalLoad) / float64(len(nodeLoads)) * (1 + loadBalanceToleranceRatio)
+	// sort the nodes to make the result stable
+	nodes := make([]node.ID, 0, len(nodeLoads))
+	for id := range nodeLoads {
+		nodes = append(nodes, id)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+
+	movedSize := 0
+	for movedSize < batchSize {
+		maxNode, minNode := nodes[0], nodes[0]
+		for _, id := range nodes {
+			if nodeLoads[id] > nodeLoads[maxNode] {
+				maxNode = id
+			}
+			if nodeLoads[id] < nodeLoads[minNode] {
+				minNode = id
+			}
+		}
+		if float64(nodeLoads[maxNode]) <= maxTolerableLoad {
+			break
+		}
+		diff := nodeLoads[maxNode] - nodeLoads[minNode]
+		// pick the task whose load is closest to the half of the difference,
+		// the load of the task must be less than the difference, otherwise the move makes no improvement
+		idx := -1
+		for i, task := range nodeTasks[maxNode] {
+			load := task.GetLoad()
+			if load >= diff {
+				continue
+			}
+			if idx < 0 || abs(2*load-diff) < abs(2*nodeTasks[maxNode][idx].GetLoad()-diff) {
+				idx = i
+			}
+		}
+		if idx < 0 {
+			break
+		}
+		task := nodeTasks[maxNode][idx]
+		// the task is either moved or can't be moved, don't pick it again
+		nodeTasks[maxNode] = append(nodeTasks[maxNode][:idx], nodeTasks[maxNode][idx+1:]...)
+		if !move(task, minNode) {
+			continue
+		}
+		nodeLoads[maxNode] -= task.GetLoad()
+		nodeLoads[minNode] += task.GetLoad()
+		movedSize++
+	}
+	if movedSize > 0 {
+		log.Info("load balance done",
+			zap.Int("movedSize", movedSize),
+			zap.Int("replicating", len(replicating)))
+	}
+	return movedSize
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
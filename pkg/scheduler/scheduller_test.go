@@ -39,3 +39,105 @@ func TestCheckBalanceStatus(t *testing.T) {
 		"node2": {ID: "node2"},
 	}))
 }
+
+type mockWeightedTask struct {
+	id     string
+	nodeID node.ID
+	load   int
+}
+
+func (m *mockWeightedTask) GetNodeID() node.ID {
+	return m.nodeID
+}
+
+func (m *mockWeightedTask) GetLoad() int {
+	return m.load
+}
+
+func TestLoadSchedule(t *testing.T) {
+	tasks := []*mockWeightedTask{
+		{id: "small1", load: 1},
+		{id: "heavy1", load: 100},
+		{id: "small2", load: 1},
+		{id: "heavy2", load: 100},
+	}
+	nodeLoads := map[node.ID]int{"node1": 0, "node2": 0}
+	LoadSchedule(10, tasks, nodeLoads, func(task *mockWeightedTask, id node.ID) bool {
+		task.nodeID = id
+		return true
+	})
+	// the heavy tasks are spread across the nodes
+	require.NotEqual(t, tasks[1].nodeID, tasks[3].nodeID)
+	require.NotEqual(t, tasks[0].nodeID, tasks[2].nodeID)
+
+	// the tasks are scheduled to the least loaded node, and the available size is respected
+	tasks = []*mockWeightedTask{{id: "t1", load: 1}, {id: "t2", load: 1}, {id: "t3", load: 1}}
+	scheduled := 0
+	LoadSchedule(2, tasks, map[node.ID]int{"node1": 100, "node2": 1}, func(task *mockWeightedTask, id node.ID) bool {
+		require.Equal(t, node.ID("node2"), id)
+		scheduled++
+		return true
+	})
+	require.Equal(t, 2, scheduled)
+}
+
+func TestLoadBalance(t *testing.T) {
+	nodes := map[node.ID]*node.Info{
+		"node1": {ID: "node1"},
+		"node2": {ID: "node2"},
+	}
+	move := func(task *mockWeightedTask, id node.ID) bool {
+		task.nodeID = id
+		return true
+	}
+	// two heavy tasks on the same node, one of them is moved
+	heavy1 := &mockWeightedTask{id: "heavy1", nodeID: "node1", load: 100}
+	heavy2 := &mockWeightedTask{id: "heavy2", nodeID: "node1", load: 100}
+	small := &mockWeightedTask{id: "small", nodeID: "node2", load: 1}
+	require.Equal(t, 1, LoadBalance(10, nodes, []*mockWeightedTask{heavy1, heavy2, small}, move))
+	require.NotEqual(t, heavy1.nodeID, heavy2.nodeID)
+	require.Equal(t, node.ID("node2"), small.nodeID)
+
+	// the node count is balanced, but a heavy task and some small tasks are on the same node,
+	// the small tasks are moved away from the heavy task
+	tasks := []*mockWeightedTask{
+		{id: "heavy", nodeID: "node1", load: 10},
+		{id: "s1", nodeID: "node1", load: 1},
+		{id: "s2", nodeID: "node2", load: 1},
+		{id: "s3", nodeID: "node2", load: 1},
+	}
+	require.Equal(t, 1, LoadBalance(10, nodes, tasks, move))
+	require.Equal(t, node.ID("node2"), tasks[1].nodeID)
+	require.Equal(t, node.ID("node1"), tasks[0].nodeID)
+
+	// moving the only heavy task makes no improvement
+	heavy := &mockWeightedTask{id: "heavy", nodeID: "node1", load: 100}
+	require.Equal(t, 0, LoadBalance(10, nodes, []*mockWeightedTask{heavy}, move))
+	require.Equal(t, node.ID("node1"), heavy.nodeID)
+
+	// the tasks are balanced by count if they have the same load
+	tasks = tasks[:0]
+	for i := 0; i < 6; i++ {
+		tasks = append(tasks, &mockWeightedTask{nodeID: "node1", load: 1})
+	}
+	require.Equal(t, 3, LoadBalance(10, nodes, tasks, move))
+	// the batch size is respected
+	for _, task := range tasks {
+		task.nodeID = "node1"
+	}
+	require.Equal(t, 2, LoadBalance(2, nodes, tasks, move))
+
+	// the load within the tolerance of the average load is not balanced,
+	// even if moving a task reduces the load of the most loaded node
+	tasks = []*mockWeightedTask{
+		{id: "t1", nodeID: "node1", load: 10},
+		{id: "t2", nodeID: "node1", load: 1},
+		{id: "t3", nodeID: "node1", load: 1},
+		{id: "t4", nodeID: "node2", load: 10},
+	}
+	require.Equal(t, 0, LoadBalance(10, nodes, tasks, move))
+	tasks[3].load = 6
+	require.Equal(t, 2, LoadBalance(10, nodes, tasks, move))
+	require.Equal(t, node.ID("node2"), tasks[1].nodeID)
+	require.Equal(t, node.ID("node2"), tasks[2].nodeID)
+}
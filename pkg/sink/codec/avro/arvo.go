@@ -28,13 +28,15 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
-	"github.com/pingcap/ticdc/pkg/config"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
 	"github.com/pingcap/ticdc/pkg/sink/codec/encoder"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/rowcodec"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	ticommon "github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"github.com/tikv/client-go/v2/oracle"
@@ -47,7 +49,7 @@ type BatchEncoder struct {
 	schemaM   SchemaManager
 	result    []*ticommon.Message
 
-	config *ticommon.Config
+	config *newcommon.Config
 }
 
 type avroEncodeInput struct {
@@ -68,6 +70,46 @@ func (r *avroEncodeInput) Swap(i, j int) {
 	r.columns[i], r.columns[j] = r.columns[j], r.columns[i]
 }
 
+// newAvroEncodeInput collects the columns of the given row which should be encoded.
+// If onlyHandleKey is true, only the handle key columns are collected, this is used to build the key.
+func newAvroEncodeInput(
+	e *commonEvent.RowEvent, row *chunk.Row, onlyHandleKey bool,
+) (*avroEncodeInput, error) {
+	tableInfo := e.TableInfo
+	input := &avroEncodeInput{
+		columns:  make([]*common.Column, 0, len(tableInfo.Columns)),
+		colInfos: make([]rowcodec.ColInfo, 0, len(tableInfo.Columns)),
+	}
+	for idx, col := range tableInfo.Columns {
+		if col == nil || !common.IsColCDCVisible(col) {
+			continue
+		}
+		flag := tableInfo.ForceGetColumnFlagType(col.ID)
+		if onlyHandleKey {
+			if !flag.IsHandleKey() {
+				continue
+			}
+		} else if e.ColumnSelector != nil && !e.ColumnSelector.Select(col) {
+			continue
+		}
+		value, err := common.FormatColVal(row, col, idx)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		input.columns = append(input.columns, &common.Column{
+			Name:      col.Name.O,
+			Type:      col.GetType(),
+			Charset:   col.GetCharset(),
+			Collation: col.GetCollate(),
+			Flag:      *flag,
+			Value:     value,
+			Default:   common.GetColumnDefaultValue(col),
+		})
+		input.colInfos = append(input.colInfos, tableInfo.RowColInfos[idx])
+	}
+	return input, nil
+}
+
 type avroEncodeResult struct {
 	data []byte
 	// header is the message header, it will be encoder into the head
@@ -76,19 +118,22 @@ type avroEncodeResult struct {
 	header []byte
 }
 
-func (a *BatchEncoder) encodeKey(ctx context.Context, topic string, e *commonEvent.RowChangedEvent) ([]byte, error) {
-	cols, colInfos := e.HandleKeyColInfos()
+func (a *BatchEncoder) encodeKey(ctx context.Context, topic string, e *commonEvent.RowEvent) ([]byte, error) {
+	row := e.GetRows()
+	if e.IsDelete() {
+		row = e.GetPreRows()
+	}
+	keyColumns, err := newAvroEncodeInput(e, row, true)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	// result may be nil if the event has no handle key columns, this may happen in the force replicate mode.
 	// todo: disallow force replicate mode if using the avro.
-	if len(cols) == 0 {
+	if len(keyColumns.columns) == 0 {
 		return nil, nil
 	}
 
-	keyColumns := &avroEncodeInput{
-		columns:  cols,
-		colInfos: colInfos,
-	}
-	avroCodec, header, err := a.getKeySchemaCodec(ctx, topic, &e.TableInfo.TableName, e.TableInfo.Version, keyColumns)
+	avroCodec, header, err := a.getKeySchemaCodec(ctx, topic, &e.TableInfo.TableName, e.TableInfo.GetVersion(), keyColumns)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -160,20 +205,20 @@ func (a *BatchEncoder) getKeySchemaCodec(
 	return avroCodec, header, nil
 }
 
-func (a *BatchEncoder) encodeValue(ctx context.Context, topic string, e *commonEvent.RowChangedEvent) ([]byte, error) {
+func (a *BatchEncoder) encodeValue(ctx context.Context, topic string, e *commonEvent.RowEvent) ([]byte, error) {
 	if e.IsDelete() {
 		return nil, nil
 	}
 
-	input := &avroEncodeInput{
-		columns:  e.GetColumns(),
-		colInfos: e.TableInfo.GetColInfosForRowChangedEvent(),
+	input, err := newAvroEncodeInput(e, e.GetRows(), false)
+	if err != nil {
+		return nil, errors.Trace(err)
 	}
 	if len(input.columns) == 0 {
 		return nil, nil
 	}
 
-	avroCodec, header, err := a.getValueSchemaCodec(ctx, topic, &e.TableInfo.TableName, e.TableInfo.Version, input)
+	avroCodec, header, err := a.getValueSchemaCodec(ctx, topic, &e.TableInfo.TableName, e.TableInfo.GetVersion(), input)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -209,8 +254,7 @@ func (a *BatchEncoder) encodeValue(ctx context.Context, topic string, e *commonE
 func (a *BatchEncoder) AppendRowChangedEvent(
 	ctx context.Context,
 	topic string,
-	e *commonEvent.RowChangedEvent,
-	callback func(),
+	e *commonEvent.RowEvent,
 ) error {
 	topic = sanitizeTopic(topic)
 
@@ -235,7 +279,7 @@ func (a *BatchEncoder) AppendRowChangedEvent(
 		e.TableInfo.GetSchemaNamePtr(),
 		e.TableInfo.GetTableNamePtr(),
 	)
-	message.Callback = callback
+	message.Callback = e.Callback
 	message.IncRowsCount()
 
 	if message.Length() > a.config.MaxMessageBytes {
@@ -316,7 +360,7 @@ const (
 	updateOperation = "u"
 )
 
-func getOperation(e *commonEvent.RowChangedEvent) string {
+func getOperation(e *commonEvent.RowEvent) string {
 	if e.IsInsert() {
 		return insertOperation
 	} else if e.IsUpdate() {
@@ -327,17 +371,13 @@ func getOperation(e *commonEvent.RowChangedEvent) string {
 
 func (a *BatchEncoder) nativeValueWithExtension(
 	native map[string]interface{},
-	e *commonEvent.RowChangedEvent,
+	e *commonEvent.RowEvent,
 ) map[string]interface{} {
 	native[tidbOp] = getOperation(e)
 	native[tidbCommitTs] = int64(e.CommitTs)
 	native[tidbPhysicalTime] = oracle.ExtractPhysical(e.CommitTs)
-
-	if a.config.EnableRowChecksum && e.Checksum != nil {
-		native[tidbRowLevelChecksum] = strconv.FormatUint(uint64(e.Checksum.Current), 10)
-		native[tidbCorrupted] = e.Checksum.Corrupted
-		native[tidbChecksumVersion] = e.Checksum.Version
-	}
+	// The row level checksum fields are not filled, since the row level checksum
+	// is rejected by the config validation of the avro protocol.
 	return native
 }
 
@@ -714,7 +754,7 @@ func (a *BatchEncoder) columnToAvroSchema(
 	case mysql.TypeLonglong: // BIGINT
 		t := "long"
 		if col.Flag.IsUnsigned() &&
-			a.config.AvroBigintUnsignedHandlingMode == ticommon.BigintUnsignedHandlingModeString {
+			a.config.AvroBigintUnsignedHandlingMode == newcommon.BigintUnsignedHandlingModeString {
 			t = "string"
 		}
 		return avroSchema{
@@ -744,7 +784,7 @@ func (a *BatchEncoder) columnToAvroSchema(
 			},
 		}, nil
 	case mysql.TypeNewDecimal:
-		if a.config.AvroDecimalHandlingMode == ticommon.DecimalHandlingModePrecise {
+		if a.config.AvroDecimalHandlingMode == newcommon.DecimalHandlingModePrecise {
 			defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.GetType())
 			displayFlen, displayDecimal := ft.GetFlen(), ft.GetDecimal()
 			// length not specified, set it to system type default
@@ -859,7 +899,7 @@ func (a *BatchEncoder) columnToAvroData(
 	case mysql.TypeLonglong:
 		if v, ok := col.Value.(string); ok {
 			if col.Flag.IsUnsigned() {
-				if a.config.AvroBigintUnsignedHandlingMode == ticommon.BigintUnsignedHandlingModeString {
+				if a.config.AvroBigintUnsignedHandlingMode == newcommon.BigintUnsignedHandlingModeString {
 					return v, "string", nil
 				}
 				n, err := strconv.ParseUint(v, 10, 64)
@@ -875,7 +915,7 @@ func (a *BatchEncoder) columnToAvroData(
 			return n, "long", nil
 		}
 		if col.Flag.IsUnsigned() {
-			if a.config.AvroBigintUnsignedHandlingMode == ticommon.BigintUnsignedHandlingModeLong {
+			if a.config.AvroBigintUnsignedHandlingMode == newcommon.BigintUnsignedHandlingModeLong {
 				return int64(col.Value.(uint64)), "long", nil
 			}
 			// bigintUnsignedHandlingMode == "string"
@@ -906,7 +946,7 @@ func (a *BatchEncoder) columnToAvroData(
 		}
 		return []byte(types.NewBinaryLiteralFromUint(col.Value.(uint64), -1)), "bytes", nil
 	case mysql.TypeNewDecimal:
-		if a.config.AvroDecimalHandlingMode == ticommon.DecimalHandlingModePrecise {
+		if a.config.AvroDecimalHandlingMode == newcommon.DecimalHandlingModePrecise {
 			v, succ := new(big.Rat).SetString(col.Value.(string))
 			if !succ {
 				return nil, "", cerror.ErrAvroEncodeFailed.GenWithStack(
@@ -1000,65 +1040,39 @@ func (r *avroEncodeResult) toEnvelope() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-type batchEncoderBuilder struct {
-	namespace string
-	config    *ticommon.Config
-	schemaM   SchemaManager
-}
-
 const (
 	keySchemaSuffix   = "-key"
 	valueSchemaSuffix = "-value"
 )
 
 // NewAvroEncoder return a avro encoder.
-func NewAvroEncoder(ctx context.Context, config *ticommon.Config) (encoder.EventEncoder, error) {
+func NewAvroEncoder(ctx context.Context, cfg *newcommon.Config) (encoder.EventEncoder, error) {
 	var schemaM SchemaManager
 	var err error
 
-	schemaRegistryType := config.SchemaRegistryType()
+	schemaRegistryType := cfg.SchemaRegistryType()
 	switch schemaRegistryType {
-	case ticommon.SchemaRegistryTypeConfluent:
-		schemaM, err = NewConfluentSchemaManager(ctx, config.AvroConfluentSchemaRegistry, nil)
+	case newcommon.SchemaRegistryTypeConfluent:
+		schemaM, err = NewConfluentSchemaManager(ctx, cfg.AvroConfluentSchemaRegistry, nil)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-	case ticommon.SchemaRegistryTypeGlue:
-		schemaM, err = NewGlueSchemaManager(ctx, config.AvroGlueSchemaRegistry)
+	case newcommon.SchemaRegistryTypeGlue:
+		schemaM, err = NewGlueSchemaManager(ctx, cfg.AvroGlueSchemaRegistry)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
 	default:
 		return nil, cerror.ErrAvroSchemaAPIError.GenWithStackByArgs(schemaRegistryType)
 	}
+	return newBatchEncoder(cfg.ChangefeedID.Namespace, schemaM, cfg), nil
+}
+
+func newBatchEncoder(namespace string, schemaM SchemaManager, cfg *newcommon.Config) *BatchEncoder {
 	return &BatchEncoder{
-		namespace: config.ChangefeedID.Namespace,
+		namespace: namespace,
 		schemaM:   schemaM,
 		result:    make([]*ticommon.Message, 0, 1),
-		config:    config,
-	}, nil
+		config:    cfg,
+	}
 }
-
-// // SetupEncoderAndSchemaRegistry4Testing start a local schema registry for testing.
-// func SetupEncoderAndSchemaRegistry4Testing(
-// 	ctx context.Context,
-// 	config *ticommon.Config,
-// ) (*BatchEncoder, error) {
-// 	startHTTPInterceptForTestingRegistry()
-// 	schemaM, err := NewConfluentSchemaManager(ctx, "http://127.0.0.1:8081", nil)
-// 	if err != nil {
-// 		return nil, errors.Trace(err)
-// 	}
-
-// 	return &BatchEncoder{
-// 		namespace: model.DefaultNamespace,
-// 		schemaM:   schemaM,
-// 		result:    make([]*ticommon.Message, 0, 1),
-// 		config:    config,
-// 	}, nil
-// }
-
-// // TeardownEncoderAndSchemaRegistry4Testing stop the local schema registry for testing.
-// func TeardownEncoderAndSchemaRegistry4Testing() {
-// 	stopHTTPInterceptForTestingRegistry()
-// }
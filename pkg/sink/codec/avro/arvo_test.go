@@ -0,0 +1,298 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConfluentRegistry is a minimal in-memory confluent schema registry for testing.
+type fakeConfluentRegistry struct {
+	mu       sync.Mutex
+	nextID   int
+	schemas  map[int]string
+	subjects map[string]int
+}
+
+func newFakeConfluentRegistry(t *testing.T) (*fakeConfluentRegistry, *httptest.Server) {
+	registry := &fakeConfluentRegistry{
+		nextID:   1,
+		schemas:  make(map[int]string),
+		subjects: make(map[string]int),
+	}
+	server := httptest.NewServer(http.HandlerFunc(registry.serveHTTP))
+	t.Cleanup(server.Close)
+	return registry, server
+}
+
+func (r *fakeConfluentRegistry) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	path := req.URL.EscapedPath()
+	switch {
+	case req.Method == http.MethodGet && path == "/":
+		_, _ = w.Write([]byte("{}"))
+	case req.Method == http.MethodPost &&
+		strings.HasPrefix(path, "/subjects/") && strings.HasSuffix(path, "/versions"):
+		subject, err := url.QueryUnescape(strings.TrimSuffix(strings.TrimPrefix(path, "/subjects/"), "/versions"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var reqBody registerRequest
+		if err := json.NewDecoder(req.Body).Decode(&reqBody); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		id := r.nextID
+		for existID, schema := range r.schemas {
+			if schema == reqBody.Schema {
+				id = existID
+			}
+		}
+		if id == r.nextID {
+			r.schemas[id] = reqBody.Schema
+			r.nextID++
+		}
+		r.subjects[subject] = id
+		_ = json.NewEncoder(w).Encode(registerResponse{SchemaID: id})
+	case req.Method == http.MethodGet && strings.HasPrefix(path, "/schemas/ids/"):
+		id, err := strconv.Atoi(strings.TrimPrefix(path, "/schemas/ids/"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		schema, ok := r.schemas[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(lookupResponse{SchemaID: id, Schema: schema})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (r *fakeConfluentRegistry) subjectSchema(subject string) (int, string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id, ok := r.subjects[subject]
+	if !ok {
+		return 0, "", false
+	}
+	return id, r.schemas[id], true
+}
+
+func newAvroTestConfig(registryURL string) *newcommon.Config {
+	cfg := newcommon.NewConfig(config.ProtocolAvro).
+		WithChangefeedID(model.DefaultChangeFeedID("avro-test"))
+	cfg.AvroConfluentSchemaRegistry = registryURL
+	cfg.EnableTiDBExtension = true
+	return cfg
+}
+
+func decodeConfluentMessage(
+	t *testing.T, registry *fakeConfluentRegistry, subject string, data []byte,
+) map[string]interface{} {
+	require.Equal(t, magicByte, data[0])
+	id, schema, ok := registry.subjectSchema(subject)
+	require.True(t, ok)
+	require.Equal(t, uint32(id), binary.BigEndian.Uint32(data[1:5]))
+
+	codec, err := goavro.NewCodec(schema)
+	require.NoError(t, err)
+	native, _, err := codec.NativeFromBinary(data[5:])
+	require.NoError(t, err)
+	return native.(map[string]interface{})
+}
+
+func TestAvroEncodeWithConfluentRegistry(t *testing.T) {
+	ctx := context.Background()
+	registry, server := newFakeConfluentRegistry(t)
+	cfg := newAvroTestConfig(server.URL)
+	e, err := NewAvroEncoder(ctx, cfg)
+	require.NoError(t, err)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(
+		a int primary key, b varchar(32), c bigint unsigned, d decimal(10, 2), e enum('x', 'y'), f datetime, g blob)`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t",
+		`insert into test.t values (1, 'hello', 18446744073709551615, 12.34, 'y', '2024-01-02 03:04:05', x'0102')`)
+	require.NotNil(t, dmlEvent)
+	insertRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+
+	count := 0
+	insertEvent := &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       417318403368288260,
+		Event:          insertRow,
+		ColumnSelector: common.NewDefaultColumnSelector(),
+		Callback:       func() { count++ },
+	}
+	err = e.AppendRowChangedEvent(ctx, "avro.topic", insertEvent)
+	require.NoError(t, err)
+
+	messages := e.Build()
+	require.Len(t, messages, 1)
+	message := messages[0]
+	require.Equal(t, 1, message.GetRowsCount())
+
+	key := decodeConfluentMessage(t, registry, "avro_topic-key", message.Key)
+	require.Equal(t, map[string]interface{}{"a": int32(1)}, key)
+
+	value := decodeConfluentMessage(t, registry, "avro_topic-value", message.Value)
+	require.Equal(t, int32(1), value["a"])
+	require.Equal(t, map[string]interface{}{"string": "hello"}, value["b"])
+	require.Equal(t, map[string]interface{}{"long": int64(-1)}, value["c"])
+	require.Equal(t, "y", value["e"].(map[string]interface{})["string"])
+	require.Equal(t, "2024-01-02 03:04:05", value["f"].(map[string]interface{})["string"])
+	require.Equal(t, []byte{0x01, 0x02}, value["g"].(map[string]interface{})["bytes"])
+	require.Equal(t, insertOperation, value[tidbOp])
+	require.Equal(t, int64(417318403368288260), value[tidbCommitTs])
+
+	message.Callback()
+	require.Equal(t, 1, count)
+
+	// the schema is cached, the delete event only carries the key.
+	deleteEvent := &commonEvent.RowEvent{
+		TableInfo: tableInfo,
+		CommitTs:  417318403368288261,
+		Event: commonEvent.RowChange{
+			PreRow:  insertRow.Row,
+			RowType: commonEvent.RowTypeDelete,
+		},
+		ColumnSelector: common.NewDefaultColumnSelector(),
+	}
+	err = e.AppendRowChangedEvent(ctx, "avro.topic", deleteEvent)
+	require.NoError(t, err)
+	messages = e.Build()
+	require.Len(t, messages, 1)
+	require.Nil(t, messages[0].Value)
+	key = decodeConfluentMessage(t, registry, "avro_topic-key", messages[0].Key)
+	require.Equal(t, map[string]interface{}{"a": int32(1)}, key)
+}
+
+func TestAvroEncodeMessageTooLarge(t *testing.T) {
+	ctx := context.Background()
+	_, server := newFakeConfluentRegistry(t)
+	cfg := newAvroTestConfig(server.URL).WithMaxMessageBytes(1)
+	e, err := NewAvroEncoder(ctx, cfg)
+	require.NoError(t, err)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(a int primary key, b int)`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1, 2)`)
+	insertRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+
+	err = e.AppendRowChangedEvent(ctx, "topic", &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       1,
+		Event:          insertRow,
+		ColumnSelector: common.NewDefaultColumnSelector(),
+	})
+	require.Error(t, err)
+}
+
+func TestNewAvroEncoderUnexpectedRegistry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("not a schema registry"))
+	}))
+	defer server.Close()
+
+	_, err := NewAvroEncoder(context.Background(), newAvroTestConfig(server.URL))
+	require.Error(t, err)
+}
+
+func TestAvroRejectRowChecksum(t *testing.T) {
+	cfg := newAvroTestConfig("http://127.0.0.1:8081")
+	require.NoError(t, cfg.Validate())
+
+	cfg.EnableRowChecksum = true
+	cfg.AvroDecimalHandlingMode = newcommon.DecimalHandlingModeString
+	cfg.AvroBigintUnsignedHandlingMode = newcommon.BigintUnsignedHandlingModeString
+	require.ErrorContains(t, cfg.Validate(), "row level checksum")
+}
+
+func TestAvroEncodeWithGlueRegistry(t *testing.T) {
+	ctx := context.Background()
+	cfg := newAvroTestConfig("")
+	schemaM := &glueSchemaManager{
+		registryName: "test-registry",
+		client:       newMockGlueClientImpl(),
+		cache:        make(map[string]*schemaCacheEntry),
+		registryType: newcommon.SchemaRegistryTypeGlue,
+	}
+	e := newBatchEncoder(cfg.ChangefeedID.Namespace, schemaM, cfg)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(a int primary key, b varchar(32))`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1, 'glue')`)
+	insertRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+
+	err := e.AppendRowChangedEvent(ctx, "topic", &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       1,
+		Event:          insertRow,
+		ColumnSelector: common.NewDefaultColumnSelector(),
+	})
+	require.NoError(t, err)
+
+	messages := e.Build()
+	require.Len(t, messages, 1)
+	value := messages[0].Value
+	require.Equal(t, headerVersionByte, value[0])
+	require.Equal(t, compressionDefaultByte, value[1])
+
+	glueSchemaID, err := getGlueSchemaIDFromHeader(value)
+	require.NoError(t, err)
+	codec, err := schemaM.Lookup(ctx, "topic-value", schemaID{glueSchemaID: glueSchemaID})
+	require.NoError(t, err)
+	native, _, err := codec.NativeFromBinary(value[18:])
+	require.NoError(t, err)
+	require.Equal(t, int32(1), native.(map[string]interface{})["a"])
+	require.Equal(t, map[string]interface{}{"string": "glue"}, native.(map[string]interface{})["b"])
+}
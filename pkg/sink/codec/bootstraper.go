@@ -109,9 +109,9 @@ func (b *bootstrapWorker) run(ctx context.Context) error {
 func (b *bootstrapWorker) addEvent(
 	ctx context.Context,
 	key model.TopicPartitionKey,
-	row *commonEvent.RowChangedEvent,
+	row *commonEvent.RowEvent,
 ) error {
-	table, ok := b.activeTables.Load(row.GetTableID())
+	table, ok := b.activeTables.Load(row.TableInfo.TableName.TableID)
 	if !ok {
 		tb := newTableStatistic(key, row)
 		b.activeTables.Store(tb.id, tb)
@@ -153,12 +153,14 @@ func (b *bootstrapWorker) sendBootstrapMsg(ctx context.Context, table *tableStat
 	return nil
 }
 
+// NewBootstrapDDLEvent returns a bootstrap DDL event which carries the table schema only.
 func NewBootstrapDDLEvent(tableInfo *common.TableInfo) *commonEvent.DDLEvent {
 	return &commonEvent.DDLEvent{
-		// StartTs:  0,
-		FinishedTs: 0,
-		// TableInfo:   tableInfo,
-		// IsBootstrap: true,
+		SchemaName:  tableInfo.TableName.Schema,
+		TableName:   tableInfo.TableName.Table,
+		TableInfo:   tableInfo,
+		FinishedTs:  0,
+		IsBootstrap: true,
 	}
 }
 
@@ -231,16 +233,16 @@ type tableStatistic struct {
 	tableInfo atomic.Value
 }
 
-func newTableStatistic(key model.TopicPartitionKey, row *commonEvent.RowChangedEvent) *tableStatistic {
+func newTableStatistic(key model.TopicPartitionKey, row *commonEvent.RowEvent) *tableStatistic {
 	res := &tableStatistic{
-		id:    row.GetTableID(),
+		id:    row.TableInfo.TableName.TableID,
 		topic: key.Topic,
 	}
 	res.totalPartition.Store(key.TotalPartition)
 	res.counter.Add(1)
 	res.lastMsgReceivedTime.Store(time.Now())
 	res.lastSendTime.Store(time.Unix(0, 0))
-	res.version.Store(row.TableInfo.UpdateTS)
+	res.version.Store(row.TableInfo.GetVersion())
 	res.tableInfo.Store(row.TableInfo)
 	return res
 }
@@ -254,15 +256,15 @@ func (t *tableStatistic) shouldSendBootstrapMsg(
 		t.counter.Load() >= sendBootstrapMsgCountInterval
 }
 
-func (t *tableStatistic) update(row *commonEvent.RowChangedEvent, totalPartition int32) {
+func (t *tableStatistic) update(row *commonEvent.RowEvent, totalPartition int32) {
 	t.counter.Add(1)
 	t.lastMsgReceivedTime.Store(time.Now())
 
 	// Note(dongmen): Rename Table DDL is a special case,
 	// the TableInfo.Name is changed but the TableInfo.UpdateTs is not changed.
-	if t.version.Load() != row.TableInfo.UpdateTS ||
-		t.tableInfo.Load().(*model.TableInfo).Name != row.TableInfo.Name {
-		t.version.Store(row.TableInfo.UpdateTS)
+	if t.version.Load() != row.TableInfo.GetVersion() ||
+		t.tableInfo.Load().(*common.TableInfo).Name != row.TableInfo.Name {
+		t.version.Store(row.TableInfo.GetVersion())
 		t.tableInfo.Store(row.TableInfo)
 	}
 	if t.totalPartition.Load() != totalPartition {
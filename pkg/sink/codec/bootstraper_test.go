@@ -0,0 +1,84 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBootstrapWorker(t *testing.T) {
+	ctx := context.Background()
+	changefeedID := model.DefaultChangeFeedID("bootstrap-test")
+	cfg := newcommon.NewConfig(config.ProtocolSimple).WithChangefeedID(changefeedID)
+	encoder, err := NewEventEncoder(ctx, cfg)
+	require.NoError(t, err)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(a int primary key, b int)`)
+	tableInfo := helper.GetTableInfo(job)
+	row := &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       1,
+		ColumnSelector: common.NewDefaultColumnSelector(),
+	}
+
+	outCh := make(chan *future, 16)
+	worker := newBootstrapWorker(changefeedID, outCh, encoder,
+		3600, 3, false, defaultMaxInactiveDuration)
+	key := model.TopicPartitionKey{Topic: "topic", Partition: 1, TotalPartition: 3}
+
+	// the bootstrap message is sent to the partition 0 immediately for a new table.
+	require.NoError(t, worker.addEvent(ctx, key, row))
+	require.Len(t, outCh, 1)
+	f := <-outCh
+	require.Equal(t, model.TopicPartitionKey{Topic: "topic", Partition: 0}, f.Key)
+	require.Len(t, f.Messages, 1)
+	require.Equal(t, model.MessageTypeDDL, f.Messages[0].Type)
+	require.Equal(t, "t", *f.Messages[0].Table)
+
+	// the bootstrap message is not sent until the message count is reached.
+	require.NoError(t, worker.addEvent(ctx, key, row))
+	table, ok := worker.activeTables.Load(tableInfo.TableName.TableID)
+	require.True(t, ok)
+	require.NoError(t, worker.sendBootstrapMsg(ctx, table.(*tableStatistic)))
+	require.Len(t, outCh, 0)
+
+	require.NoError(t, worker.addEvent(ctx, key, row))
+	require.NoError(t, worker.addEvent(ctx, key, row))
+	worker.sendBootstrapToAllPartition = true
+	require.NoError(t, worker.sendBootstrapMsg(ctx, table.(*tableStatistic)))
+	require.Len(t, outCh, 3)
+	for i := int32(0); i < 3; i++ {
+		f = <-outCh
+		require.Equal(t, i, f.Key.Partition)
+	}
+
+	// the inactive table is removed.
+	worker.maxInactiveDuration = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	worker.gcInactiveTables()
+	_, ok = worker.activeTables.Load(tableInfo.TableName.TableID)
+	require.False(t, ok)
+}
@@ -378,16 +378,11 @@ func (c *Config) Validate() error {
 			)
 		}
 
+		// The row level checksum is not carried by the events yet,
+		// so the avro encoder can't fill the checksum fields of the schema.
 		if c.EnableRowChecksum {
-			if !(c.EnableTiDBExtension && c.AvroDecimalHandlingMode == DecimalHandlingModeString &&
-				c.AvroBigintUnsignedHandlingMode == BigintUnsignedHandlingModeString) {
-				return cerror.ErrCodecInvalidConfig.GenWithStack(
-					`Avro protocol with row level checksum,
-					should set "%s" to "%s", and set "%s" to "%s" and "%s" to "%s"`,
-					codecOPTEnableTiDBExtension, "true",
-					codecOPTAvroDecimalHandlingMode, DecimalHandlingModeString,
-					codecOPTAvroBigintUnsignedHandlingMode, BigintUnsignedHandlingModeString)
-			}
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`Avro protocol doesn't support the row level checksum yet, please disable the integrity check`)
 		}
 	}
 
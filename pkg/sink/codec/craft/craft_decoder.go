@@ -16,6 +16,7 @@ package craft
 import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/sink/codec/decoder"
 	"github.com/pingcap/tiflow/cdc/model"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
@@ -53,7 +54,7 @@ func (b *batchDecoder) NextResolvedEvent() (uint64, error) {
 }
 
 // NextRowChangedEvent implements the RowEventDecoder interface
-func (b *batchDecoder) NextRowChangedEvent() (*common.RowChangedEvent, error) {
+func (b *batchDecoder) NextRowChangedEvent() (*commonEvent.RowChangedEvent, error) {
 	ty, hasNext, err := b.HasNext()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -65,7 +66,7 @@ func (b *batchDecoder) NextRowChangedEvent() (*common.RowChangedEvent, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	ev := &common.RowChangedEvent{}
+	ev := &commonEvent.RowChangedEvent{}
 	var cols, preCols []*common.Column
 	if oldValue != nil {
 		if preCols, err = oldValue.ToModel(); err != nil {
@@ -78,12 +79,12 @@ func (b *batchDecoder) NextRowChangedEvent() (*common.RowChangedEvent, error) {
 		}
 	}
 	ev.CommitTs = b.headers.GetTs(b.index)
-	if len(preCols) > 0 {
-		indexColumns := common.GetHandleAndUniqueIndexOffsets4Test(preCols)
-		ev.TableInfo = common.BuildTableInfo(b.headers.GetSchema(b.index), b.headers.GetTable(b.index), preCols, indexColumns)
-	} else {
-		indexColumns := common.GetHandleAndUniqueIndexOffsets4Test(cols)
+	if len(cols) > 0 {
+		indexColumns := getHandleAndUniqueIndexOffsets(cols)
 		ev.TableInfo = common.BuildTableInfo(b.headers.GetSchema(b.index), b.headers.GetTable(b.index), cols, indexColumns)
+	} else {
+		indexColumns := getHandleAndUniqueIndexOffsets(preCols)
+		ev.TableInfo = common.BuildTableInfo(b.headers.GetSchema(b.index), b.headers.GetTable(b.index), preCols, indexColumns)
 	}
 	if len(preCols) > 0 {
 		ev.PreColumns = preCols
@@ -100,8 +101,27 @@ func (b *batchDecoder) NextRowChangedEvent() (*common.RowChangedEvent, error) {
 	return ev, nil
 }
 
+// getHandleAndUniqueIndexOffsets returns the offsets of the handle key columns
+// and the unique key columns, the decoded columns do not carry the index info,
+// so each unique key column is treated as a single column unique index.
+func getHandleAndUniqueIndexOffsets(cols []*common.Column) [][]int {
+	result := make([][]int, 0)
+	handleColumns := make([]int, 0)
+	for i, col := range cols {
+		if col.Flag.IsHandleKey() {
+			handleColumns = append(handleColumns, i)
+		} else if col.Flag.IsUniqueKey() {
+			result = append(result, []int{i})
+		}
+	}
+	if len(handleColumns) != 0 {
+		result = append(result, handleColumns)
+	}
+	return result
+}
+
 // NextDDLEvent implements the RowEventDecoder interface
-func (b *batchDecoder) NextDDLEvent() (*model.DDLEvent, error) {
+func (b *batchDecoder) NextDDLEvent() (*commonEvent.DDLEvent, error) {
 	ty, hasNext, err := b.HasNext()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -113,27 +133,28 @@ func (b *batchDecoder) NextDDLEvent() (*model.DDLEvent, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	event := &model.DDLEvent{
-		CommitTs: b.headers.GetTs(b.index),
-		Query:    query,
-		Type:     ddlType,
-		TableInfo: &model.TableInfo{
-			TableName: model.TableName{
-				Schema: b.headers.GetSchema(b.index),
-				Table:  b.headers.GetTable(b.index),
-			},
-		},
+	event := &commonEvent.DDLEvent{
+		Type:       byte(ddlType),
+		SchemaName: b.headers.GetSchema(b.index),
+		TableName:  b.headers.GetTable(b.index),
+		Query:      query,
+		FinishedTs: b.headers.GetTs(b.index),
 	}
 	b.index++
 	return event, nil
 }
 
 func newBatchDecoder(_, value []byte) (decoder.RowEventDecoder, error) {
-	decoder := NewBatchDecoderWithAllocator(NewSliceAllocator(64))
+	decoder := NewBatchDecoder()
 	err := decoder.AddKeyValue(nil, value)
 	return decoder, err
 }
 
+// NewBatchDecoder creates a new batchDecoder.
+func NewBatchDecoder() decoder.RowEventDecoder {
+	return NewBatchDecoderWithAllocator(NewSliceAllocator(64))
+}
+
 // NewBatchDecoderWithAllocator creates a new batchDecoder with given allocator.
 func NewBatchDecoderWithAllocator(
 	allocator *SliceAllocator,
@@ -155,6 +176,7 @@ func (b *batchDecoder) AddKeyValue(_, value []byte) error {
 	}
 	b.decoder = decoder
 	b.headers = headers
+	b.index = 0
 
 	return nil
 }
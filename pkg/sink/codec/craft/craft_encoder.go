@@ -16,7 +16,9 @@ package craft
 import (
 	"context"
 
+	"github.com/pingcap/errors"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
 	"github.com/pingcap/ticdc/pkg/sink/codec/encoder"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
@@ -29,7 +31,7 @@ type BatchEncoder struct {
 	messageBuf       []*ticommon.Message
 	callbackBuf      []func()
 
-	config *ticommon.Config
+	config *newcommon.Config
 
 	allocator *SliceAllocator
 }
@@ -45,12 +47,14 @@ func (e *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*ticommon.Message, erro
 func (e *BatchEncoder) AppendRowChangedEvent(
 	_ context.Context,
 	_ string,
-	ev *commonEvent.RowChangedEvent,
-	callback func(),
+	ev *commonEvent.RowEvent,
 ) error {
-	rows, size := e.rowChangedBuffer.AppendRowChangedEvent(ev, e.config.DeleteOnlyHandleKeyColumns)
-	if callback != nil {
-		e.callbackBuf = append(e.callbackBuf, callback)
+	rows, size, err := e.rowChangedBuffer.AppendRowChangedEvent(ev, e.config.DeleteOnlyHandleKeyColumns)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if ev.Callback != nil {
+		e.callbackBuf = append(e.callbackBuf, ev.Callback)
 	}
 	if size > e.config.MaxMessageBytes || rows >= e.config.MaxBatchSize {
 		e.flush()
@@ -60,9 +64,9 @@ func (e *BatchEncoder) AppendRowChangedEvent(
 
 // EncodeDDLEvent implements the RowEventEncoder interface
 func (e *BatchEncoder) EncodeDDLEvent(ev *commonEvent.DDLEvent) (*ticommon.Message, error) {
-	// return ticommon.NewDDLMsg(config.ProtocolCraft,
-	// 	nil, NewDDLEventEncoder(e.allocator, ev).Encode(), ev), nil
-	return nil, nil
+	return ticommon.NewMsg(config.ProtocolCraft,
+		nil, NewDDLEventEncoder(e.allocator, ev).Encode(), ev.FinishedTs,
+		model.MessageTypeDDL, &ev.SchemaName, &ev.TableName), nil
 }
 
 // Build implements the RowEventEncoder interface
@@ -98,7 +102,7 @@ func (e *BatchEncoder) flush() {
 }
 
 // NewBatchEncoder creates a new BatchEncoder.
-func NewBatchEncoder(config *ticommon.Config) encoder.EventEncoder {
+func NewBatchEncoder(config *newcommon.Config) encoder.EventEncoder {
 	// 64 is a magic number that come up with these assumptions and manual benchmark.
 	// 1. Most table will not have more than 64 columns
 	// 2. It only worth allocating slices in batch for slices that's small enough
@@ -108,7 +112,7 @@ func NewBatchEncoder(config *ticommon.Config) encoder.EventEncoder {
 func (e *BatchEncoder) Clean() {}
 
 // NewBatchEncoderWithAllocator creates a new BatchEncoder with given allocator.
-func NewBatchEncoderWithAllocator(allocator *SliceAllocator, config *ticommon.Config) encoder.EventEncoder {
+func NewBatchEncoderWithAllocator(allocator *SliceAllocator, config *newcommon.Config) encoder.EventEncoder {
 	return &BatchEncoder{
 		allocator:        allocator,
 		messageBuf:       make([]*ticommon.Message, 0, 2),
@@ -0,0 +1,253 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package craft
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func columnValues(columns []*common.Column) map[string]interface{} {
+	result := make(map[string]interface{}, len(columns))
+	for _, col := range columns {
+		result[col.Name] = col.Value
+	}
+	return result
+}
+
+func TestCraftEncodeDecodeRowChangedEvent(t *testing.T) {
+	ctx := context.Background()
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(
+		a int primary key, b varchar(32), c bigint unsigned, d double, e float,
+		f enum('x', 'y'), g datetime, h blob, i bit(8), j decimal(10, 2), k year)`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t",
+		`insert into test.t values (1, 'hello', 18446744073709551615, 1.5, 2.5, 'y', '2024-01-02 03:04:05', x'0102', b'101', 12.34, 2024)`,
+		`insert into test.t values (2, 'world', 1, 3.5, 4.5, 'x', '2024-01-02 03:04:06', null, b'1', 56.78, 2023)`)
+	firstRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	secondRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+
+	cfg := newcommon.NewConfig(config.ProtocolCraft)
+	cfg.MaxBatchSize = 2
+	encoder := NewBatchEncoder(cfg)
+
+	count := 0
+	events := []commonEvent.RowChange{
+		firstRow,
+		secondRow,
+		{PreRow: firstRow.Row, RowType: commonEvent.RowTypeDelete},
+	}
+	for i, row := range events {
+		err := encoder.AppendRowChangedEvent(ctx, "", &commonEvent.RowEvent{
+			TableInfo:      tableInfo,
+			CommitTs:       uint64(417318403368288260 + i),
+			Event:          row,
+			ColumnSelector: common.NewDefaultColumnSelector(),
+			Callback:       func() { count++ },
+		})
+		require.NoError(t, err)
+	}
+
+	// the rows are batched by the max batch size.
+	messages := encoder.Build()
+	require.Len(t, messages, 2)
+	require.Equal(t, 2, messages[0].GetRowsCount())
+	require.Equal(t, 1, messages[1].GetRowsCount())
+	require.Equal(t, model.MessageTypeRow, messages[0].Type)
+	require.Equal(t, "test", *messages[0].Schema)
+	require.Equal(t, "t", *messages[0].Table)
+	for _, m := range messages {
+		m.Callback()
+	}
+	require.Equal(t, 3, count)
+
+	decoded := make([]*commonEvent.RowChangedEvent, 0, 3)
+	decoder := NewBatchDecoder()
+	for _, m := range messages {
+		require.NoError(t, decoder.AddKeyValue(m.Key, m.Value))
+		for {
+			tp, hasNext, err := decoder.HasNext()
+			require.NoError(t, err)
+			if !hasNext {
+				break
+			}
+			require.Equal(t, model.MessageTypeRow, tp)
+			row, err := decoder.NextRowChangedEvent()
+			require.NoError(t, err)
+			decoded = append(decoded, row)
+		}
+	}
+	require.Len(t, decoded, 3)
+
+	first := decoded[0]
+	require.True(t, first.IsInsert())
+	require.Equal(t, uint64(417318403368288260), first.CommitTs)
+	require.Equal(t, "test", first.TableInfo.GetSchemaName())
+	require.Equal(t, "t", first.TableInfo.GetTableName())
+	require.Equal(t, []string{"a"}, first.PrimaryKeyColumnNames())
+	values := columnValues(first.Columns)
+	require.Equal(t, int64(1), values["a"])
+	require.Equal(t, []byte("hello"), values["b"])
+	require.Equal(t, uint64(18446744073709551615), values["c"])
+	require.Equal(t, 1.5, values["d"])
+	require.Equal(t, 2.5, values["e"])
+	require.Equal(t, uint64(2), values["f"])
+	require.Equal(t, "2024-01-02 03:04:05", values["g"])
+	require.Equal(t, []byte{0x01, 0x02}, values["h"])
+	require.Equal(t, uint64(5), values["i"])
+	require.Equal(t, "12.34", values["j"])
+	require.Equal(t, int64(2024), values["k"])
+
+	second := decoded[1]
+	require.Equal(t, int64(2), columnValues(second.Columns)["a"])
+	require.Nil(t, columnValues(second.Columns)["h"])
+
+	deleted := decoded[2]
+	require.True(t, deleted.IsDelete())
+	require.Equal(t, int64(1), columnValues(deleted.PreColumns)["a"])
+	require.Len(t, deleted.PreColumns, len(tableInfo.Columns))
+}
+
+func TestCraftEncodeDeleteOnlyHandleKeyColumns(t *testing.T) {
+	ctx := context.Background()
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(a int primary key, b varchar(32))`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1, 'hello')`)
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+
+	cfg := newcommon.NewConfig(config.ProtocolCraft)
+	cfg.DeleteOnlyHandleKeyColumns = true
+	encoder := NewBatchEncoder(cfg)
+	err := encoder.AppendRowChangedEvent(ctx, "", &commonEvent.RowEvent{
+		TableInfo: tableInfo,
+		CommitTs:  1,
+		Event: commonEvent.RowChange{
+			PreRow:  row.Row,
+			RowType: commonEvent.RowTypeDelete,
+		},
+		ColumnSelector: common.NewDefaultColumnSelector(),
+	})
+	require.NoError(t, err)
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+
+	decoder := NewBatchDecoder()
+	require.NoError(t, decoder.AddKeyValue(messages[0].Key, messages[0].Value))
+	decoded, err := decoder.NextRowChangedEvent()
+	require.NoError(t, err)
+	require.True(t, decoded.IsDelete())
+	require.Len(t, decoded.PreColumns, 1)
+	require.Equal(t, "a", decoded.PreColumns[0].Name)
+}
+
+func TestCraftEncodePartitionTable(t *testing.T) {
+	ctx := context.Background()
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(a int primary key, b varchar(32))
+		partition by range (a) (partition p0 values less than (10), partition p1 values less than (20))`)
+	tableInfo := helper.GetTableInfo(job)
+	require.True(t, tableInfo.IsPartitionTable())
+	partitionID := tableInfo.Partition.Definitions[1].ID
+	require.NotEqual(t, tableInfo.ID, partitionID)
+
+	// The row is generated by a table with the same columns, since the test helper
+	// can't get the rows of a partitioned table.
+	helper.DDL2Job(`create table test.t1(a int primary key, b varchar(32))`)
+	dmlEvent := helper.DML2Event("test", "t1", `insert into test.t1 values (11, 'hello')`)
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+
+	encoder := NewBatchEncoder(newcommon.NewConfig(config.ProtocolCraft))
+	err := encoder.AppendRowChangedEvent(ctx, "", &commonEvent.RowEvent{
+		PhysicalTableID: partitionID,
+		TableInfo:       tableInfo,
+		CommitTs:        1,
+		Event:           row,
+		ColumnSelector:  common.NewDefaultColumnSelector(),
+	})
+	require.NoError(t, err)
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+
+	decoder := NewBatchDecoder()
+	require.NoError(t, decoder.AddKeyValue(messages[0].Key, messages[0].Value))
+	decoded, err := decoder.NextRowChangedEvent()
+	require.NoError(t, err)
+	require.Equal(t, partitionID, decoded.PhysicalTableID)
+	require.True(t, decoded.TableInfo.TableName.IsPartition)
+}
+
+func TestCraftEncodeDDLAndCheckpointEvent(t *testing.T) {
+	cfg := newcommon.NewConfig(config.ProtocolCraft)
+	encoder := NewBatchEncoder(cfg)
+
+	m, err := encoder.EncodeDDLEvent(&commonEvent.DDLEvent{
+		Type:       byte(timodel.ActionCreateTable),
+		SchemaName: "test",
+		TableName:  "t",
+		Query:      "create table test.t(a int primary key)",
+		FinishedTs: 417318403368288260,
+	})
+	require.NoError(t, err)
+	require.Equal(t, model.MessageTypeDDL, m.Type)
+	require.Equal(t, uint64(417318403368288260), m.Ts)
+
+	decoder := NewBatchDecoder()
+	require.NoError(t, decoder.AddKeyValue(m.Key, m.Value))
+	tp, hasNext, err := decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeDDL, tp)
+	ddl, err := decoder.NextDDLEvent()
+	require.NoError(t, err)
+	require.Equal(t, byte(timodel.ActionCreateTable), ddl.Type)
+	require.Equal(t, "test", ddl.SchemaName)
+	require.Equal(t, "t", ddl.TableName)
+	require.Equal(t, "create table test.t(a int primary key)", ddl.Query)
+	require.Equal(t, uint64(417318403368288260), ddl.FinishedTs)
+
+	m, err = encoder.EncodeCheckpointEvent(417318403368288261)
+	require.NoError(t, err)
+	require.Equal(t, model.MessageTypeResolved, m.Type)
+	require.NoError(t, decoder.AddKeyValue(m.Key, m.Value))
+	tp, hasNext, err = decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeResolved, tp)
+	ts, err := decoder.NextResolvedEvent()
+	require.NoError(t, err)
+	require.Equal(t, uint64(417318403368288261), ts)
+}
@@ -200,10 +200,13 @@ func EncodeTiDBType(allocator *SliceAllocator, ty byte, flag common.ColumnFlagTy
 		return encodeUvarint(allocator.byteSlice(binary.MaxVarintLen64)[:0], value.(uint64))
 	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
 		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
-		// value type for these mysql types are []byte
+		// value type for these mysql types are []byte, or string for the non-binary charset
+		if s, ok := value.(string); ok {
+			return unsafeStringToBytes(s)
+		}
 		return value.([]byte)
 	case mysql.TypeFloat:
-		return encodeFloat64(allocator.byteSlice(4)[:0], float64(value.(float32)))
+		return encodeFloat64(allocator.byteSlice(8)[:0], float64(value.(float32)))
 	case mysql.TypeDouble:
 		// value type for these mysql types are float64
 		return encodeFloat64(allocator.byteSlice(8)[:0], value.(float64))
@@ -314,22 +317,21 @@ func NewResolvedEventEncoder(allocator *SliceAllocator, ts uint64) *MessageEncod
 
 // NewDDLEventEncoder creates a new encoder with given allocator and timestamp
 func NewDDLEventEncoder(allocator *SliceAllocator, ev *commonEvent.DDLEvent) *MessageEncoder {
-	// ty := uint64(ev.Type)
-	// query := ev.Query
-	// var schema, table *string
-	// if len(ev.TableInfo.TableName.Schema) > 0 {
-	// 	schema = &ev.TableInfo.TableName.Schema
-	// }
-	// if len(ev.TableInfo.TableName.Table) > 0 {
-	// 	table = &ev.TableInfo.TableName.Table
-	// }
-	// return NewMessageEncoder(allocator).encodeHeaders(&Headers{
-	// 	ts:        allocator.oneUint64Slice(ev.CommitTs),
-	// 	ty:        allocator.oneUint64Slice(uint64(model.MessageTypeDDL)),
-	// 	partition: oneNullInt64Slice,
-	// 	schema:    allocator.oneNullableStringSlice(schema),
-	// 	table:     allocator.oneNullableStringSlice(table),
-	// 	count:     1,
-	// }).encodeUvarint(ty).encodeString(query).encodeBodySize()
-	return nil
+	ty := uint64(ev.Type)
+	query := ev.Query
+	var schema, table *string
+	if len(ev.SchemaName) > 0 {
+		schema = &ev.SchemaName
+	}
+	if len(ev.TableName) > 0 {
+		table = &ev.TableName
+	}
+	return NewMessageEncoder(allocator).encodeHeaders(&Headers{
+		ts:        allocator.oneUint64Slice(ev.FinishedTs),
+		ty:        allocator.oneUint64Slice(uint64(model.MessageTypeDDL)),
+		partition: oneNullInt64Slice,
+		schema:    allocator.oneNullableStringSlice(schema),
+		table:     allocator.oneNullableStringSlice(table),
+		count:     1,
+	}).encodeUvarint(ty).encodeString(query).encodeBodySize()
 }
@@ -16,6 +16,8 @@ package craft
 import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tiflow/cdc/model"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 )
@@ -211,7 +213,14 @@ func (h *Headers) appendHeader(allocator *SliceAllocator, ts, ty uint64, partiti
 	h.table[idx] = table
 	h.count++
 
-	return 32 + len(*schema) + len(*table) /* 4 64-bits integers and two bytes array */
+	size := 32 /* 4 64-bits integers */
+	if schema != nil {
+		size += len(*schema)
+	}
+	if table != nil {
+		size += len(*table)
+	}
+	return size
 }
 
 func (h *Headers) reset() {
@@ -367,10 +376,12 @@ func decodeColumnGroup(bits []byte, allocator *SliceAllocator, dict *termDiction
 	}, nil
 }
 
-func newColumnGroup(allocator *SliceAllocator, ty byte, columns []*common.Column, onlyHandleKeyColumns bool) (int, *columnGroup) {
-	l := len(columns)
+func newColumnGroup(
+	allocator *SliceAllocator, ty byte, row *chunk.Row, tableInfo *common.TableInfo, onlyHandleKeyColumns bool,
+) (int, *columnGroup, error) {
+	l := len(tableInfo.Columns)
 	if l == 0 {
-		return 0, nil
+		return 0, nil, nil
 	}
 	values := allocator.bytesSlice(l)
 	names := allocator.stringSlice(l)
@@ -378,19 +389,24 @@ func newColumnGroup(allocator *SliceAllocator, ty byte, columns []*common.Column
 	flags := allocator.uint64Slice(l)
 	estimatedSize := 0
 	idx := 0
-	for _, col := range columns {
-		if col == nil {
+	for i, col := range tableInfo.Columns {
+		if !common.IsColCDCVisible(col) {
 			continue
 		}
-		if onlyHandleKeyColumns && !col.Flag.IsHandleKey() {
+		flag := *tableInfo.ForceGetColumnFlagType(col.ID)
+		if onlyHandleKeyColumns && !flag.IsHandleKey() {
 			continue
 		}
-		names[idx] = col.Name
-		types[idx] = uint64(col.Type)
-		flags[idx] = uint64(col.Flag)
-		value := EncodeTiDBType(allocator, col.Type, col.Flag, col.Value)
+		v, err := common.FormatColVal(row, col, i)
+		if err != nil {
+			return 0, nil, cerror.WrapError(cerror.ErrCraftCodecInvalidData, err)
+		}
+		names[idx] = col.Name.O
+		types[idx] = uint64(col.GetType())
+		flags[idx] = uint64(flag)
+		value := EncodeTiDBType(allocator, col.GetType(), flag, v)
 		values[idx] = value
-		estimatedSize += len(col.Name) + len(value) + 16 /* two 64-bits integers */
+		estimatedSize += len(col.Name.O) + len(value) + 16 /* two 64-bits integers */
 		idx++
 	}
 	if idx > 0 {
@@ -400,44 +416,61 @@ func newColumnGroup(allocator *SliceAllocator, ty byte, columns []*common.Column
 			types:  types[:idx],
 			flags:  flags[:idx],
 			values: values[:idx],
-		}
+		}, nil
 	}
-	return estimatedSize, nil
+	return estimatedSize, nil, nil
 }
 
 // Row changed message is basically an array of column groups
 type rowChangedEvent = []*columnGroup
 
-func newRowChangedMessage(allocator *SliceAllocator, ev *common.RowChangedEvent, onlyHandleKeyColumns bool) (int, rowChangedEvent) {
+func newRowChangedMessage(
+	allocator *SliceAllocator, ev *commonEvent.RowEvent, onlyHandleKeyColumns bool,
+) (int, rowChangedEvent, error) {
 	numGroups := 0
-	if ev.PreColumns != nil {
+	if !ev.IsDelete() {
 		numGroups++
 	}
-	if ev.Columns != nil {
+	if !ev.IsInsert() {
 		numGroups++
 	}
 	groups := allocator.columnGroupSlice(numGroups)
 	estimatedSize := 0
 	idx := 0
-	if size, group := newColumnGroup(
-		allocator,
-		columnGroupTypeNew,
-		ev.GetColumns(),
-		false); group != nil {
-		groups[idx] = group
-		idx++
-		estimatedSize += size
+	if !ev.IsDelete() {
+		size, group, err := newColumnGroup(
+			allocator,
+			columnGroupTypeNew,
+			ev.GetRows(),
+			ev.TableInfo,
+			false)
+		if err != nil {
+			return 0, nil, errors.Trace(err)
+		}
+		if group != nil {
+			groups[idx] = group
+			idx++
+			estimatedSize += size
+		}
 	}
-	onlyHandleKeyColumns = onlyHandleKeyColumns && ev.IsDelete()
-	if size, group := newColumnGroup(
-		allocator,
-		columnGroupTypeOld,
-		ev.GetPreColumns(),
-		onlyHandleKeyColumns); group != nil {
-		groups[idx] = group
-		estimatedSize += size
+	if !ev.IsInsert() {
+		onlyHandleKeyColumns = onlyHandleKeyColumns && ev.IsDelete()
+		size, group, err := newColumnGroup(
+			allocator,
+			columnGroupTypeOld,
+			ev.GetPreRows(),
+			ev.TableInfo,
+			onlyHandleKeyColumns)
+		if err != nil {
+			return 0, nil, errors.Trace(err)
+		}
+		if group != nil {
+			groups[idx] = group
+			idx++
+			estimatedSize += size
+		}
 	}
-	return estimatedSize, groups
+	return estimatedSize, groups[:idx], nil
 }
 
 // RowChangedEventBuffer is a buffer to save row changed events in batch
@@ -467,10 +500,12 @@ func (b *RowChangedEventBuffer) Encode() []byte {
 }
 
 // AppendRowChangedEvent append a new event to buffer
-func (b *RowChangedEventBuffer) AppendRowChangedEvent(ev *common.RowChangedEvent, onlyHandleKeyColumns bool) (rows, size int) {
+func (b *RowChangedEventBuffer) AppendRowChangedEvent(
+	ev *commonEvent.RowEvent, onlyHandleKeyColumns bool,
+) (rows, size int, err error) {
 	var partition int64 = -1
 	if ev.TableInfo.IsPartitionTable() {
-		partition = ev.GetTableID()
+		partition = ev.PhysicalTableID
 	}
 
 	var schema, table *string
@@ -481,6 +516,10 @@ func (b *RowChangedEventBuffer) AppendRowChangedEvent(ev *common.RowChangedEvent
 		table = ev.TableInfo.GetTableNamePtr()
 	}
 
+	messageSize, message, err := newRowChangedMessage(b.allocator, ev, onlyHandleKeyColumns)
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
 	b.estimatedSize += b.headers.appendHeader(
 		b.allocator,
 		ev.CommitTs,
@@ -492,11 +531,10 @@ func (b *RowChangedEventBuffer) AppendRowChangedEvent(ev *common.RowChangedEvent
 	if b.eventsCount+1 > len(b.events) {
 		b.events = b.allocator.resizeRowChangedEventSlice(b.events, newBufferSize(b.eventsCount))
 	}
-	size, message := newRowChangedMessage(b.allocator, ev, onlyHandleKeyColumns)
 	b.events[b.eventsCount] = message
 	b.eventsCount++
-	b.estimatedSize += size
-	return b.eventsCount, b.estimatedSize
+	b.estimatedSize += messageSize
+	return b.eventsCount, b.estimatedSize, nil
 }
 
 // Reset buffer
@@ -0,0 +1,80 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csv
+
+import (
+	"bytes"
+
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/pkg/sink/codec/encoder"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	ticommon "github.com/pingcap/tiflow/pkg/sink/codec/common"
+)
+
+// BatchEncoder encodes the events into the byte of a batch into.
+type BatchEncoder struct {
+	valueBuf  *bytes.Buffer
+	callback  func()
+	batchSize int
+	config    *newcommon.Config
+}
+
+// NewTxnEventEncoder creates a new csv BatchEncoder.
+func NewTxnEventEncoder(config *newcommon.Config) encoder.TxnEventEncoder {
+	return &BatchEncoder{
+		config:   config,
+		valueBuf: &bytes.Buffer{},
+	}
+}
+
+// AppendTxnEvent implements the TxnEventEncoder interface
+func (b *BatchEncoder) AppendTxnEvent(event *commonEvent.DMLEvent, callback func()) error {
+	for {
+		row, ok := event.GetNextRow()
+		if !ok {
+			break
+		}
+		msg, err := rowEvent2CSVMsg(b.config, event.TableInfo, event.CommitTs, row)
+		if err != nil {
+			return err
+		}
+		b.valueBuf.Write(msg.encode())
+		b.batchSize++
+	}
+	b.callback = callback
+	return nil
+}
+
+// Build implements the TxnEventEncoder interface
+func (b *BatchEncoder) Build() (messages []*ticommon.Message) {
+	if b.batchSize == 0 {
+		return nil
+	}
+
+	ret := ticommon.NewMsg(config.ProtocolCsv, nil,
+		b.valueBuf.Bytes(), 0, model.MessageTypeRow, nil, nil)
+	ret.SetRowsCount(b.batchSize)
+	ret.Callback = b.callback
+	if b.valueBuf.Cap() > encoder.MemBufShrinkThreshold {
+		b.valueBuf = &bytes.Buffer{}
+	} else {
+		b.valueBuf.Reset()
+	}
+	b.callback = nil
+	b.batchSize = 0
+
+	return []*ticommon.Message{ret}
+}
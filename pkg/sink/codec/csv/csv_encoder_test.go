@@ -0,0 +1,79 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csv
+
+import (
+	"strings"
+	"testing"
+
+	pevent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newCSVConfigForTest() *newcommon.Config {
+	cfg := newcommon.NewConfig(config.ProtocolCsv)
+	cfg.Delimiter = ","
+	cfg.Quote = "\""
+	cfg.NullString = "\\N"
+	cfg.Terminator = "\n"
+	cfg.BinaryEncodingMethod = config.BinaryEncodingBase64
+	return cfg
+}
+
+func TestCSVBatchEncoder(t *testing.T) {
+	helper := pevent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(a tinyint primary key, b varchar(10), c varbinary(10))`)
+	require.NotNil(t, job)
+	dmlEvent := helper.DML2Event("test", "t",
+		`insert into test.t values (1, 'a"b', 0x0102)`, `insert into test.t values (2, null, null)`)
+	dmlEvent.CommitTs = 10
+
+	cfg := newCSVConfigForTest()
+	cfg.IncludeCommitTs = true
+	encoder := NewTxnEventEncoder(cfg)
+	require.Nil(t, encoder.Build())
+
+	called := false
+	err := encoder.AppendTxnEvent(dmlEvent, func() { called = true })
+	require.NoError(t, err)
+
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	require.Equal(t, 2, messages[0].GetRowsCount())
+	require.Equal(t, strings.Join([]string{
+		`"I","t","test",10,1,"a""b","AQI="`,
+		`"I","t","test",10,2,\N,\N`,
+		"",
+	}, "\n"), string(messages[0].Value))
+
+	messages[0].Callback()
+	require.True(t, called)
+	require.Nil(t, encoder.Build())
+}
+
+func TestCSVFormatWithEscapes(t *testing.T) {
+	cfg := newCSVConfigForTest()
+	cfg.Quote = ""
+	cfg.Delimiter = "|@|"
+	msg := &csvMessage{config: cfg, newRecord: true}
+
+	strBuilder := new(strings.Builder)
+	msg.formatValue("a|@|b\nc\\d", strBuilder)
+	require.Equal(t, `a\|\@\|b\nc\\d`, strBuilder.String())
+}
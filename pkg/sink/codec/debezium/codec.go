@@ -24,33 +24,78 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/charset"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/hack"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
-	ticommon "github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
 )
 
 type dbzCodec struct {
-	config    *ticommon.Config
+	config    *newcommon.Config
 	clusterID string
 	nowFunc   func() time.Time
 }
 
+// selectedColumns returns the offsets of the columns which should be encoded,
+// virtual generated columns and the columns filtered by the selector are skipped.
+func selectedColumns(tableInfo *common.TableInfo, selector common.Selector) []int {
+	offsets := make([]int, 0, len(tableInfo.Columns))
+	for idx, colInfo := range tableInfo.Columns {
+		if colInfo == nil || !common.IsColCDCVisible(colInfo) {
+			continue
+		}
+		if selector != nil && !selector.Select(colInfo) {
+			continue
+		}
+		offsets = append(offsets, idx)
+	}
+	return offsets
+}
+
+// newColumn builds the column at the given offset of the row,
+// the value is nil if the row is nil, which is used to build the schema.
+func newColumn(row *chunk.Row, tableInfo *common.TableInfo, idx int) (*common.Column, error) {
+	colInfo := tableInfo.Columns[idx]
+	col := &common.Column{
+		Name: colInfo.Name.O,
+		Type: colInfo.GetType(),
+		Flag: *tableInfo.ForceGetColumnFlagType(colInfo.ID),
+	}
+	if row == nil {
+		return col, nil
+	}
+	value, err := common.FormatColVal(row, colInfo, idx)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrDebeziumEncodeFailed, err)
+	}
+	col.Value = value
+	return col, nil
+}
+
 func (c *dbzCodec) writeDebeziumFieldValues(
 	writer *util.JSONWriter,
 	fieldName string,
-	cols []*common.Column,
+	row *chunk.Row,
 	tableInfo *common.TableInfo,
+	offsets []int,
 ) error {
 	var err error
-	colInfos := tableInfo.GetColInfosForRowChangedEvent()
 	writer.WriteObjectField(fieldName, func() {
-		for i, col := range cols {
-			err = c.writeDebeziumFieldValue(writer, col, colInfos[i].Ft)
+		for _, idx := range offsets {
+			var col *common.Column
+			col, err = newColumn(row, tableInfo, idx)
+			if err != nil {
+				break
+			}
+			err = c.writeDebeziumFieldValue(writer, col, &tableInfo.Columns[idx].FieldType)
 			if err != nil {
 				break
 			}
@@ -292,6 +337,11 @@ func (c *dbzCodec) writeDebeziumFieldValue(
 
 	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
 		mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		// the value of the non-binary string column is formatted as string
+		if v, ok := col.Value.(string); ok {
+			writer.WriteStringField(col.Name, v)
+			return nil
+		}
 		v, ok := col.Value.([]byte)
 		if !ok {
 			return cerror.ErrDebeziumEncodeFailed.GenWithStack(
@@ -516,42 +566,157 @@ func (c *dbzCodec) writeBinaryField(writer *util.JSONWriter, fieldName string, v
 	writer.WriteBase64StringField(fieldName, value)
 }
 
+func (c *dbzCodec) writeSourceField(
+	jWriter *util.JSONWriter,
+	commitTs uint64,
+	schema string,
+	table string,
+) {
+	commitTime := oracle.GetTimeFromTS(commitTs)
+	jWriter.WriteObjectField("source", func() {
+		jWriter.WriteStringField("version", "2.4.0.Final")
+		jWriter.WriteStringField("connector", "TiCDC")
+		jWriter.WriteStringField("name", c.clusterID)
+		// ts_ms: In the source object, ts_ms indicates the time that the change was made in the database.
+		// https://debezium.io/documentation/reference/stable/connectors/mysql.html#mysql-create-events
+		jWriter.WriteInt64Field("ts_ms", commitTime.UnixMilli())
+		// snapshot field is a string of true,last,false,incremental
+		jWriter.WriteStringField("snapshot", "false")
+		jWriter.WriteStringField("db", schema)
+		jWriter.WriteStringField("table", table)
+		jWriter.WriteInt64Field("server_id", 0)
+		jWriter.WriteNullField("gtid")
+		jWriter.WriteStringField("file", "")
+		jWriter.WriteInt64Field("pos", 0)
+		jWriter.WriteInt64Field("row", 0)
+		jWriter.WriteInt64Field("thread", 0)
+		jWriter.WriteNullField("query")
+
+		// The followings are TiDB extended fields
+		jWriter.WriteUint64Field("commit_ts", commitTs)
+		jWriter.WriteStringField("cluster_id", c.clusterID)
+	})
+}
+
+// writeSourceSchema writes the schema of the source field,
+// it's shared by the row change events and the schema change events.
+func (c *dbzCodec) writeSourceSchema(jWriter *util.JSONWriter) {
+	jWriter.WriteObjectElement(func() {
+		jWriter.WriteStringField("type", "struct")
+		jWriter.WriteArrayField("fields", func() {
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "string")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteStringField("field", "version")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "string")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteStringField("field", "connector")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "string")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteStringField("field", "name")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "int64")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteStringField("field", "ts_ms")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "string")
+				jWriter.WriteBoolField("optional", true)
+				jWriter.WriteStringField("name", "io.debezium.data.Enum")
+				jWriter.WriteIntField("version", 1)
+				jWriter.WriteObjectField("parameters", func() {
+					jWriter.WriteStringField("allowed", "true,last,false,incremental")
+				})
+				jWriter.WriteStringField("default", "false")
+				jWriter.WriteStringField("field", "snapshot")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "string")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteStringField("field", "db")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "string")
+				jWriter.WriteBoolField("optional", true)
+				jWriter.WriteStringField("field", "sequence")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "string")
+				jWriter.WriteBoolField("optional", true)
+				jWriter.WriteStringField("field", "table")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "int64")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteStringField("field", "server_id")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "string")
+				jWriter.WriteBoolField("optional", true)
+				jWriter.WriteStringField("field", "gtid")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "string")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteStringField("field", "file")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "int64")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteStringField("field", "pos")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "int32")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteStringField("field", "row")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "int64")
+				jWriter.WriteBoolField("optional", true)
+				jWriter.WriteStringField("field", "thread")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "string")
+				jWriter.WriteBoolField("optional", true)
+				jWriter.WriteStringField("field", "query")
+			})
+			// Below are extra TiDB fields
+			// jWriter.WriteObjectElement(func() {
+			// 	jWriter.WriteStringField("type", "int64")
+			// 	jWriter.WriteBoolField("optional", false)
+			// 	jWriter.WriteStringField("field", "commit_ts")
+			// })
+			// jWriter.WriteObjectElement(func() {
+			// 	jWriter.WriteStringField("type", "string")
+			// 	jWriter.WriteBoolField("optional", false)
+			// 	jWriter.WriteStringField("field", "cluster_id")
+			// })
+		})
+		jWriter.WriteBoolField("optional", false)
+		jWriter.WriteStringField("name", "io.debezium.connector.mysql.Source")
+		jWriter.WriteStringField("field", "source")
+	})
+}
+
 func (c *dbzCodec) EncodeRowChangedEvent(
-	e *common.RowChangedEvent,
+	e *commonEvent.RowEvent,
 	dest io.Writer,
 ) error {
 	jWriter := util.BorrowJSONWriter(dest)
 	defer util.ReturnJSONWriter(jWriter)
 
-	commitTime := oracle.GetTimeFromTS(e.CommitTs)
+	offsets := selectedColumns(e.TableInfo, e.ColumnSelector)
 
 	var err error
 
 	jWriter.WriteObject(func() {
 		jWriter.WriteObjectField("payload", func() {
-			jWriter.WriteObjectField("source", func() {
-				jWriter.WriteStringField("version", "2.4.0.Final")
-				jWriter.WriteStringField("connector", "TiCDC")
-				jWriter.WriteStringField("name", c.clusterID)
-				// ts_ms: In the source object, ts_ms indicates the time that the change was made in the database.
-				// https://debezium.io/documentation/reference/stable/connectors/mysql.html#mysql-create-events
-				jWriter.WriteInt64Field("ts_ms", commitTime.UnixMilli())
-				// snapshot field is a string of true,last,false,incremental
-				jWriter.WriteStringField("snapshot", "false")
-				jWriter.WriteStringField("db", e.TableInfo.GetSchemaName())
-				jWriter.WriteStringField("table", e.TableInfo.GetTableName())
-				jWriter.WriteInt64Field("server_id", 0)
-				jWriter.WriteNullField("gtid")
-				jWriter.WriteStringField("file", "")
-				jWriter.WriteInt64Field("pos", 0)
-				jWriter.WriteInt64Field("row", 0)
-				jWriter.WriteInt64Field("thread", 0)
-				jWriter.WriteNullField("query")
-
-				// The followings are TiDB extended fields
-				jWriter.WriteUint64Field("commit_ts", e.CommitTs)
-				jWriter.WriteStringField("cluster_id", c.clusterID)
-			})
+			c.writeSourceField(jWriter, e.CommitTs, e.TableInfo.GetSchemaName(), e.TableInfo.GetTableName())
 
 			// ts_ms: displays the time at which the connector processed the event
 			// https://debezium.io/documentation/reference/stable/connectors/mysql.html#mysql-create-events
@@ -577,18 +742,18 @@ func (c *dbzCodec) EncodeRowChangedEvent(
 				// after: An optional field that specifies the state of the row after the event occurred.
 				// Optional field that specifies the state of the row after the event occurred.
 				// In a delete event value, the after field is null, signifying that the row no longer exists.
-				err = c.writeDebeziumFieldValues(jWriter, "after", e.GetColumns(), e.TableInfo)
+				err = c.writeDebeziumFieldValues(jWriter, "after", e.GetRows(), e.TableInfo, offsets)
 			} else if e.IsDelete() {
 				jWriter.WriteStringField("op", "d")
 				jWriter.WriteNullField("after")
-				err = c.writeDebeziumFieldValues(jWriter, "before", e.GetPreColumns(), e.TableInfo)
+				err = c.writeDebeziumFieldValues(jWriter, "before", e.GetPreRows(), e.TableInfo, offsets)
 			} else if e.IsUpdate() {
 				jWriter.WriteStringField("op", "u")
 				if c.config.DebeziumOutputOldValue {
-					err = c.writeDebeziumFieldValues(jWriter, "before", e.GetPreColumns(), e.TableInfo)
+					err = c.writeDebeziumFieldValues(jWriter, "before", e.GetPreRows(), e.TableInfo, offsets)
 				}
 				if err == nil {
-					err = c.writeDebeziumFieldValues(jWriter, "after", e.GetColumns(), e.TableInfo)
+					err = c.writeDebeziumFieldValues(jWriter, "after", e.GetRows(), e.TableInfo, offsets)
 				}
 			}
 		})
@@ -609,17 +774,10 @@ func (c *dbzCodec) EncodeRowChangedEvent(
 					{
 						fieldsBuf := &bytes.Buffer{}
 						fieldsWriter := util.BorrowJSONWriter(fieldsBuf)
-						var validCols []*common.Column
-						if e.IsInsert() {
-							validCols = e.GetColumns()
-						} else if e.IsDelete() {
-							validCols = e.GetPreColumns()
-						} else if e.IsUpdate() {
-							validCols = e.GetColumns()
-						}
-						colInfos := e.TableInfo.GetColInfosForRowChangedEvent()
-						for i, col := range validCols {
-							c.writeDebeziumFieldSchema(fieldsWriter, col, colInfos[i].Ft)
+						for _, idx := range offsets {
+							// the schema only depends on the column info, so the row is not needed.
+							col, _ := newColumn(nil, e.TableInfo, idx)
+							c.writeDebeziumFieldSchema(fieldsWriter, col, &e.TableInfo.Columns[idx].FieldType)
 						}
 						util.ReturnJSONWriter(fieldsWriter)
 						fieldsJSON = fieldsBuf.String()
@@ -648,106 +806,7 @@ func (c *dbzCodec) EncodeRowChangedEvent(
 							jWriter.WriteRaw(fieldsJSON)
 						})
 					})
-					jWriter.WriteObjectElement(func() {
-						jWriter.WriteStringField("type", "struct")
-						jWriter.WriteArrayField("fields", func() {
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "string")
-								jWriter.WriteBoolField("optional", false)
-								jWriter.WriteStringField("field", "version")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "string")
-								jWriter.WriteBoolField("optional", false)
-								jWriter.WriteStringField("field", "connector")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "string")
-								jWriter.WriteBoolField("optional", false)
-								jWriter.WriteStringField("field", "name")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "int64")
-								jWriter.WriteBoolField("optional", false)
-								jWriter.WriteStringField("field", "ts_ms")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "string")
-								jWriter.WriteBoolField("optional", true)
-								jWriter.WriteStringField("name", "io.debezium.data.Enum")
-								jWriter.WriteIntField("version", 1)
-								jWriter.WriteObjectField("parameters", func() {
-									jWriter.WriteStringField("allowed", "true,last,false,incremental")
-								})
-								jWriter.WriteStringField("default", "false")
-								jWriter.WriteStringField("field", "snapshot")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "string")
-								jWriter.WriteBoolField("optional", false)
-								jWriter.WriteStringField("field", "db")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "string")
-								jWriter.WriteBoolField("optional", true)
-								jWriter.WriteStringField("field", "sequence")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "string")
-								jWriter.WriteBoolField("optional", true)
-								jWriter.WriteStringField("field", "table")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "int64")
-								jWriter.WriteBoolField("optional", false)
-								jWriter.WriteStringField("field", "server_id")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "string")
-								jWriter.WriteBoolField("optional", true)
-								jWriter.WriteStringField("field", "gtid")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "string")
-								jWriter.WriteBoolField("optional", false)
-								jWriter.WriteStringField("field", "file")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "int64")
-								jWriter.WriteBoolField("optional", false)
-								jWriter.WriteStringField("field", "pos")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "int32")
-								jWriter.WriteBoolField("optional", false)
-								jWriter.WriteStringField("field", "row")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "int64")
-								jWriter.WriteBoolField("optional", true)
-								jWriter.WriteStringField("field", "thread")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "string")
-								jWriter.WriteBoolField("optional", true)
-								jWriter.WriteStringField("field", "query")
-							})
-							// Below are extra TiDB fields
-							// jWriter.WriteObjectElement(func() {
-							// 	jWriter.WriteStringField("type", "int64")
-							// 	jWriter.WriteBoolField("optional", false)
-							// 	jWriter.WriteStringField("field", "commit_ts")
-							// })
-							// jWriter.WriteObjectElement(func() {
-							// 	jWriter.WriteStringField("type", "string")
-							// 	jWriter.WriteBoolField("optional", false)
-							// 	jWriter.WriteStringField("field", "cluster_id")
-							// })
-						})
-						jWriter.WriteBoolField("optional", false)
-						jWriter.WriteStringField("name", "io.debezium.connector.mysql.Source")
-						jWriter.WriteStringField("field", "source")
-					})
+					c.writeSourceSchema(jWriter)
 					jWriter.WriteObjectElement(func() {
 						jWriter.WriteStringField("type", "string")
 						jWriter.WriteBoolField("optional", false)
@@ -789,3 +848,315 @@ func (c *dbzCodec) EncodeRowChangedEvent(
 
 	return err
 }
+
+// EncodeDDLEvent encodes the DDL event into the Debezium schema change event.
+// See https://debezium.io/documentation/reference/stable/connectors/mysql.html#mysql-schema-change-topic
+func (c *dbzCodec) EncodeDDLEvent(
+	e *commonEvent.DDLEvent,
+	keyDest io.Writer,
+	dest io.Writer,
+) error {
+	keyWriter := util.BorrowJSONWriter(keyDest)
+	keyWriter.WriteObject(func() {
+		keyWriter.WriteObjectField("payload", func() {
+			keyWriter.WriteStringField("databaseName", e.SchemaName)
+		})
+		if !c.config.DebeziumDisableSchema {
+			keyWriter.WriteObjectField("schema", func() {
+				keyWriter.WriteStringField("type", "struct")
+				keyWriter.WriteStringField("name", "io.debezium.connector.mysql.SchemaChangeKey")
+				keyWriter.WriteBoolField("optional", false)
+				keyWriter.WriteIntField("version", 1)
+				keyWriter.WriteArrayField("fields", func() {
+					keyWriter.WriteObjectElement(func() {
+						keyWriter.WriteStringField("field", "databaseName")
+						keyWriter.WriteBoolField("optional", false)
+						keyWriter.WriteStringField("type", "string")
+					})
+				})
+			})
+		}
+	})
+	util.ReturnJSONWriter(keyWriter)
+
+	jWriter := util.BorrowJSONWriter(dest)
+	defer util.ReturnJSONWriter(jWriter)
+
+	jWriter.WriteObject(func() {
+		jWriter.WriteObjectField("payload", func() {
+			c.writeSourceField(jWriter, e.FinishedTs, e.SchemaName, e.TableName)
+			jWriter.WriteInt64Field("ts_ms", c.nowFunc().UnixMilli())
+			jWriter.WriteStringField("databaseName", e.SchemaName)
+			jWriter.WriteNullField("schemaName")
+			jWriter.WriteStringField("ddl", e.Query)
+			jWriter.WriteArrayField("tableChanges", func() {
+				// The schema level DDLs don't change any table.
+				if e.TableInfo == nil {
+					return
+				}
+				jWriter.WriteObjectElement(func() {
+					jWriter.WriteStringField("type", getTableChangeType(e.GetDDLType()))
+					jWriter.WriteStringField("id", fmt.Sprintf("\"%s\".\"%s\"",
+						e.TableInfo.GetSchemaName(), e.TableInfo.GetTableName()))
+					jWriter.WriteObjectField("table", func() {
+						c.writeTableChange(jWriter, e.TableInfo)
+					})
+				})
+			})
+		})
+
+		if !c.config.DebeziumDisableSchema {
+			jWriter.WriteObjectField("schema", func() {
+				jWriter.WriteStringField("type", "struct")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteStringField("name", "io.debezium.connector.mysql.SchemaChangeValue")
+				jWriter.WriteIntField("version", 1)
+				jWriter.WriteArrayField("fields", func() {
+					c.writeSourceSchema(jWriter)
+					jWriter.WriteObjectElement(func() {
+						jWriter.WriteStringField("type", "int64")
+						jWriter.WriteBoolField("optional", true)
+						jWriter.WriteStringField("field", "ts_ms")
+					})
+					jWriter.WriteObjectElement(func() {
+						jWriter.WriteStringField("type", "string")
+						jWriter.WriteBoolField("optional", true)
+						jWriter.WriteStringField("field", "databaseName")
+					})
+					jWriter.WriteObjectElement(func() {
+						jWriter.WriteStringField("type", "string")
+						jWriter.WriteBoolField("optional", true)
+						jWriter.WriteStringField("field", "schemaName")
+					})
+					jWriter.WriteObjectElement(func() {
+						jWriter.WriteStringField("type", "string")
+						jWriter.WriteBoolField("optional", true)
+						jWriter.WriteStringField("field", "ddl")
+					})
+					jWriter.WriteObjectElement(func() {
+						jWriter.WriteStringField("type", "array")
+						jWriter.WriteObjectField("items", func() {
+							c.writeTableChangeSchema(jWriter)
+						})
+						jWriter.WriteBoolField("optional", false)
+						jWriter.WriteStringField("field", "tableChanges")
+					})
+				})
+			})
+		}
+	})
+	return nil
+}
+
+// getTableChangeType returns the type of the table change in the schema change event.
+func getTableChangeType(tp timodel.ActionType) string {
+	switch tp {
+	case timodel.ActionCreateTable, timodel.ActionCreateTables,
+		timodel.ActionCreateView, timodel.ActionRecoverTable:
+		return "CREATE"
+	case timodel.ActionDropTable, timodel.ActionDropView:
+		return "DROP"
+	default:
+		return "ALTER"
+	}
+}
+
+// writeTableChange writes the table structure after the DDL,
+// the format is the same as the `table` field of the debezium table changes.
+func (c *dbzCodec) writeTableChange(jWriter *util.JSONWriter, tableInfo *common.TableInfo) {
+	jWriter.WriteStringField("defaultCharsetName", tableInfo.Charset)
+	jWriter.WriteArrayField("primaryKeyColumnNames", func() {
+		for _, name := range tableInfo.GetPrimaryKeyColumnNames() {
+			jWriter.WriteStringElement(name)
+		}
+	})
+	jWriter.WriteArrayField("columns", func() {
+		position := 0
+		for _, col := range tableInfo.Columns {
+			if col.Hidden {
+				continue
+			}
+			position++
+			ft := &col.FieldType
+			typeName := strings.ToUpper(types.TypeToStr(ft.GetType(), ft.GetCharset()))
+			if mysql.HasUnsignedFlag(ft.GetFlag()) {
+				typeName += " UNSIGNED"
+			}
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("name", col.Name.O)
+				jWriter.WriteIntField("jdbcType", getJdbcType(ft))
+				jWriter.WriteNullField("nativeType")
+				jWriter.WriteStringField("typeName", typeName)
+				jWriter.WriteStringField("typeExpression", typeName)
+				if ft.GetCharset() != "" && ft.GetCharset() != charset.CharsetBin {
+					jWriter.WriteStringField("charsetName", ft.GetCharset())
+				} else {
+					jWriter.WriteNullField("charsetName")
+				}
+				if ft.GetFlen() > 0 {
+					jWriter.WriteIntField("length", ft.GetFlen())
+				} else {
+					jWriter.WriteNullField("length")
+				}
+				if ft.GetType() == mysql.TypeNewDecimal && ft.GetDecimal() >= 0 {
+					jWriter.WriteIntField("scale", ft.GetDecimal())
+				} else {
+					jWriter.WriteNullField("scale")
+				}
+				jWriter.WriteIntField("position", position)
+				jWriter.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
+				jWriter.WriteBoolField("autoIncremented", mysql.HasAutoIncrementFlag(ft.GetFlag()))
+				jWriter.WriteBoolField("generated", col.IsGenerated())
+				if col.Comment != "" {
+					jWriter.WriteStringField("comment", col.Comment)
+				} else {
+					jWriter.WriteNullField("comment")
+				}
+				defaultValue := col.GetDefaultValue()
+				jWriter.WriteBoolField("hasDefaultValue", defaultValue != nil)
+				if defaultValue != nil {
+					jWriter.WriteStringField("defaultValueExpression", fmt.Sprintf("%v", defaultValue))
+				} else {
+					jWriter.WriteNullField("defaultValueExpression")
+				}
+				if len(ft.GetElems()) > 0 {
+					jWriter.WriteArrayField("enumValues", func() {
+						for _, elem := range ft.GetElems() {
+							jWriter.WriteStringElement(elem)
+						}
+					})
+				} else {
+					jWriter.WriteNullField("enumValues")
+				}
+			})
+		}
+	})
+	if tableInfo.Comment != "" {
+		jWriter.WriteStringField("comment", tableInfo.Comment)
+	} else {
+		jWriter.WriteNullField("comment")
+	}
+}
+
+// getJdbcType returns the java.sql.Types of the column, which is used by debezium
+// to describe the column type in the table changes.
+func getJdbcType(ft *types.FieldType) int {
+	binary := mysql.HasBinaryFlag(ft.GetFlag()) || ft.GetCharset() == charset.CharsetBin
+	switch ft.GetType() {
+	case mysql.TypeBit:
+		return -7 // BIT
+	case mysql.TypeTiny:
+		return -6 // TINYINT
+	case mysql.TypeShort:
+		return 5 // SMALLINT
+	case mysql.TypeInt24, mysql.TypeLong, mysql.TypeYear:
+		return 4 // INTEGER
+	case mysql.TypeLonglong:
+		return -5 // BIGINT
+	case mysql.TypeFloat:
+		return 6 // FLOAT
+	case mysql.TypeDouble:
+		return 8 // DOUBLE
+	case mysql.TypeNewDecimal:
+		return 3 // DECIMAL
+	case mysql.TypeDate:
+		return 91 // DATE
+	case mysql.TypeDuration:
+		return 92 // TIME
+	case mysql.TypeDatetime:
+		return 93 // TIMESTAMP
+	case mysql.TypeTimestamp:
+		return 2014 // TIMESTAMP_WITH_TIMEZONE
+	case mysql.TypeString:
+		if binary {
+			return -2 // BINARY
+		}
+		return 1 // CHAR
+	case mysql.TypeVarchar, mysql.TypeVarString:
+		if binary {
+			return -3 // VARBINARY
+		}
+		return 12 // VARCHAR
+	case mysql.TypeTinyBlob, mysql.TypeBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+		if binary {
+			return 2004 // BLOB
+		}
+		return 12 // VARCHAR
+	case mysql.TypeEnum, mysql.TypeSet:
+		return 1 // CHAR
+	default:
+		return 1111 // OTHER
+	}
+}
+
+// writeTableChangeSchema writes the schema of the elements in the table changes.
+func (c *dbzCodec) writeTableChangeSchema(jWriter *util.JSONWriter) {
+	writeField := func(tp string, optional bool, field string) {
+		jWriter.WriteObjectElement(func() {
+			jWriter.WriteStringField("type", tp)
+			jWriter.WriteBoolField("optional", optional)
+			jWriter.WriteStringField("field", field)
+		})
+	}
+	writeStringArrayField := func(optional bool, field string) {
+		jWriter.WriteObjectElement(func() {
+			jWriter.WriteStringField("type", "array")
+			jWriter.WriteObjectField("items", func() {
+				jWriter.WriteStringField("type", "string")
+				jWriter.WriteBoolField("optional", false)
+			})
+			jWriter.WriteBoolField("optional", optional)
+			jWriter.WriteStringField("field", field)
+		})
+	}
+
+	jWriter.WriteStringField("type", "struct")
+	jWriter.WriteArrayField("fields", func() {
+		writeField("string", false, "type")
+		writeField("string", false, "id")
+		jWriter.WriteObjectElement(func() {
+			jWriter.WriteStringField("type", "struct")
+			jWriter.WriteArrayField("fields", func() {
+				writeField("string", true, "defaultCharsetName")
+				writeStringArrayField(true, "primaryKeyColumnNames")
+				jWriter.WriteObjectElement(func() {
+					jWriter.WriteStringField("type", "array")
+					jWriter.WriteObjectField("items", func() {
+						jWriter.WriteStringField("type", "struct")
+						jWriter.WriteArrayField("fields", func() {
+							writeField("string", false, "name")
+							writeField("int32", false, "jdbcType")
+							writeField("int32", true, "nativeType")
+							writeField("string", false, "typeName")
+							writeField("string", true, "typeExpression")
+							writeField("string", true, "charsetName")
+							writeField("int32", true, "length")
+							writeField("int32", true, "scale")
+							writeField("int32", false, "position")
+							writeField("boolean", true, "optional")
+							writeField("boolean", true, "autoIncremented")
+							writeField("boolean", true, "generated")
+							writeField("string", true, "comment")
+							writeField("boolean", true, "hasDefaultValue")
+							writeField("string", true, "defaultValueExpression")
+							writeStringArrayField(true, "enumValues")
+						})
+						jWriter.WriteBoolField("optional", false)
+						jWriter.WriteStringField("name", "io.debezium.connector.schema.Column")
+						jWriter.WriteIntField("version", 1)
+					})
+					jWriter.WriteBoolField("optional", false)
+					jWriter.WriteStringField("field", "columns")
+				})
+				writeField("string", true, "comment")
+			})
+			jWriter.WriteBoolField("optional", false)
+			jWriter.WriteStringField("name", "io.debezium.connector.schema.Table")
+			jWriter.WriteIntField("version", 1)
+			jWriter.WriteStringField("field", "table")
+		})
+	})
+	jWriter.WriteBoolField("optional", false)
+	jWriter.WriteStringField("name", "io.debezium.connector.schema.Change")
+	jWriter.WriteIntField("version", 1)
+}
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
 	"github.com/pingcap/ticdc/pkg/sink/codec/encoder"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
@@ -30,7 +31,7 @@ import (
 type BatchEncoder struct {
 	messages []*ticommon.Message
 
-	config *ticommon.Config
+	config *newcommon.Config
 	codec  *dbzCodec
 }
 
@@ -44,8 +45,7 @@ func (d *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*ticommon.Message, erro
 func (d *BatchEncoder) AppendRowChangedEvent(
 	_ context.Context,
 	_ string,
-	e *commonEvent.RowChangedEvent,
-	callback func(),
+	e *commonEvent.RowEvent,
 ) error {
 	valueBuf := bytes.Buffer{}
 	err := d.codec.EncodeRowChangedEvent(e, &valueBuf)
@@ -69,7 +69,7 @@ func (d *BatchEncoder) AppendRowChangedEvent(
 		Table:    e.TableInfo.GetTableNamePtr(),
 		Type:     model.MessageTypeRow,
 		Protocol: config.ProtocolDebezium,
-		Callback: callback,
+		Callback: e.Callback,
 	}
 	m.IncRowsCount()
 
@@ -80,8 +80,29 @@ func (d *BatchEncoder) AppendRowChangedEvent(
 // EncodeDDLEvent implements the RowEventEncoder interface
 // DDL message unresolved tso
 func (d *BatchEncoder) EncodeDDLEvent(e *commonEvent.DDLEvent) (*ticommon.Message, error) {
-	// Schema Change Events are currently not supported.
-	return nil, nil
+	keyBuf := bytes.Buffer{}
+	valueBuf := bytes.Buffer{}
+	err := d.codec.EncodeDDLEvent(e, &keyBuf, &valueBuf)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	value, err := ticommon.Compress(
+		d.config.ChangefeedID,
+		d.config.LargeMessageHandle.LargeMessageHandleCompression,
+		valueBuf.Bytes(),
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ticommon.Message{
+		Key:      keyBuf.Bytes(),
+		Value:    value,
+		Ts:       e.FinishedTs,
+		Schema:   &e.SchemaName,
+		Table:    &e.TableName,
+		Type:     model.MessageTypeDDL,
+		Protocol: config.ProtocolDebezium,
+	}, nil
 }
 
 // Build implements the RowEventEncoder interface
@@ -97,8 +118,8 @@ func (d *BatchEncoder) Build() []*ticommon.Message {
 
 func (d *BatchEncoder) Clean() {}
 
-// newBatchEncoder creates a new Debezium BatchEncoder.
-func NewBatchEncoder(c *ticommon.Config, clusterID string) encoder.EventEncoder {
+// NewBatchEncoder creates a new Debezium BatchEncoder.
+func NewBatchEncoder(c *newcommon.Config, clusterID string) encoder.EventEncoder {
 	batch := &BatchEncoder{
 		messages: nil,
 		config:   c,
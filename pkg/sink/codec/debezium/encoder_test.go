@@ -0,0 +1,255 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debezium
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeDebeziumMessage(t *testing.T, data []byte) map[string]interface{} {
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &result))
+	return result
+}
+
+func TestEncodeRowChangedEvent(t *testing.T) {
+	ctx := context.Background()
+	cfg := newcommon.NewConfig(config.ProtocolDebezium)
+	e := NewBatchEncoder(cfg, "test-cluster")
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(a int primary key, b varchar(32), c decimal(10, 2))`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1, 'hello', 1.5)`)
+	insertRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+
+	count := 0
+	err := e.AppendRowChangedEvent(ctx, "", &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       417318403368288260,
+		Event:          insertRow,
+		ColumnSelector: common.NewDefaultColumnSelector(),
+		Callback:       func() { count++ },
+	})
+	require.NoError(t, err)
+
+	messages := e.Build()
+	require.Len(t, messages, 1)
+	require.Equal(t, model.MessageTypeRow, messages[0].Type)
+	messages[0].Callback()
+	require.Equal(t, 1, count)
+
+	value := decodeDebeziumMessage(t, messages[0].Value)
+	payload := value["payload"].(map[string]interface{})
+	require.Equal(t, "c", payload["op"])
+	require.Nil(t, payload["before"])
+	require.Equal(t, map[string]interface{}{"a": float64(1), "b": "hello", "c": 1.5}, payload["after"])
+
+	source := payload["source"].(map[string]interface{})
+	require.Equal(t, "test-cluster", source["name"])
+	require.Equal(t, "test", source["db"])
+	require.Equal(t, "t", source["table"])
+	require.Equal(t, float64(417318403368288260), source["commit_ts"])
+
+	schema := value["schema"].(map[string]interface{})
+	require.Equal(t, "test-cluster.test.t.Envelope", schema["name"])
+	fields := schema["fields"].([]interface{})
+	after := fields[1].(map[string]interface{})
+	require.Equal(t, "after", after["field"])
+	require.Len(t, after["fields"], 3)
+
+	// update event carries both the before and after image.
+	dmlEvent = helper.DML2Event("test", "t", `update test.t set b = 'world' where a = 1`)
+	updateRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	updateRow.PreRow = insertRow.Row
+	updateRow.RowType = commonEvent.RowTypeUpdate
+	err = e.AppendRowChangedEvent(ctx, "", &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       417318403368288261,
+		Event:          updateRow,
+		ColumnSelector: common.NewDefaultColumnSelector(),
+	})
+	require.NoError(t, err)
+	messages = e.Build()
+	require.Len(t, messages, 1)
+	payload = decodeDebeziumMessage(t, messages[0].Value)["payload"].(map[string]interface{})
+	require.Equal(t, "u", payload["op"])
+	require.Equal(t, "hello", payload["before"].(map[string]interface{})["b"])
+	require.Equal(t, "world", payload["after"].(map[string]interface{})["b"])
+
+	// delete event only carries the before image.
+	err = e.AppendRowChangedEvent(ctx, "", &commonEvent.RowEvent{
+		TableInfo: tableInfo,
+		CommitTs:  417318403368288262,
+		Event: commonEvent.RowChange{
+			PreRow:  updateRow.Row,
+			RowType: commonEvent.RowTypeDelete,
+		},
+		ColumnSelector: common.NewDefaultColumnSelector(),
+	})
+	require.NoError(t, err)
+	messages = e.Build()
+	require.Len(t, messages, 1)
+	payload = decodeDebeziumMessage(t, messages[0].Value)["payload"].(map[string]interface{})
+	require.Equal(t, "d", payload["op"])
+	require.Nil(t, payload["after"])
+	require.Equal(t, "world", payload["before"].(map[string]interface{})["b"])
+}
+
+func TestEncodeWithoutSchema(t *testing.T) {
+	ctx := context.Background()
+	cfg := newcommon.NewConfig(config.ProtocolDebezium)
+	cfg.DebeziumDisableSchema = true
+	e := NewBatchEncoder(cfg, "test-cluster")
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(a int primary key)`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1)`)
+	insertRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+
+	err := e.AppendRowChangedEvent(ctx, "", &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       1,
+		Event:          insertRow,
+		ColumnSelector: common.NewDefaultColumnSelector(),
+	})
+	require.NoError(t, err)
+	messages := e.Build()
+	require.Len(t, messages, 1)
+	value := decodeDebeziumMessage(t, messages[0].Value)
+	require.NotContains(t, value, "schema")
+	require.Contains(t, value, "payload")
+}
+
+func TestEncodeDDLAndCheckpointEvent(t *testing.T) {
+	cfg := newcommon.NewConfig(config.ProtocolDebezium)
+	e := NewBatchEncoder(cfg, "test-cluster")
+
+	message, err := e.EncodeDDLEvent(&commonEvent.DDLEvent{
+		SchemaName: "test",
+		TableName:  "t",
+		Query:      "create table test.t(a int primary key)",
+		FinishedTs: 417318403368288260,
+	})
+	require.NoError(t, err)
+	require.Equal(t, model.MessageTypeDDL, message.Type)
+	require.Equal(t, uint64(417318403368288260), message.Ts)
+
+	key := decodeDebeziumMessage(t, message.Key)
+	require.Equal(t, map[string]interface{}{"databaseName": "test"}, key["payload"])
+
+	value := decodeDebeziumMessage(t, message.Value)
+	payload := value["payload"].(map[string]interface{})
+	require.Equal(t, "test", payload["databaseName"])
+	require.Equal(t, "create table test.t(a int primary key)", payload["ddl"])
+	require.Equal(t, float64(417318403368288260), payload["source"].(map[string]interface{})["commit_ts"])
+	require.Equal(t, "io.debezium.connector.mysql.SchemaChangeValue",
+		value["schema"].(map[string]interface{})["name"])
+
+	// The schema level DDL doesn't change any table.
+	require.Empty(t, payload["tableChanges"])
+
+	// Debezium does not emit the checkpoint event.
+	message, err = e.EncodeCheckpointEvent(417318403368288260)
+	require.NoError(t, err)
+	require.Nil(t, message)
+}
+
+func TestEncodeDDLEventWithTableChanges(t *testing.T) {
+	cfg := newcommon.NewConfig(config.ProtocolDebezium)
+	e := NewBatchEncoder(cfg, "test-cluster")
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(a int unsigned primary key auto_increment,
+		b varchar(32) not null default 'x' comment 'b column', c decimal(10, 2), d enum('e1', 'e2'))`)
+
+	message, err := e.EncodeDDLEvent(&commonEvent.DDLEvent{
+		Type:       byte(job.Type),
+		SchemaName: job.SchemaName,
+		TableName:  job.TableName,
+		Query:      job.Query,
+		FinishedTs: 417318403368288260,
+		TableInfo:  helper.GetTableInfo(job),
+	})
+	require.NoError(t, err)
+
+	value := decodeDebeziumMessage(t, message.Value)
+	payload := value["payload"].(map[string]interface{})
+	tableChanges := payload["tableChanges"].([]interface{})
+	require.Len(t, tableChanges, 1)
+	change := tableChanges[0].(map[string]interface{})
+	require.Equal(t, "CREATE", change["type"])
+	require.Equal(t, `"test"."t"`, change["id"])
+
+	table := change["table"].(map[string]interface{})
+	require.Equal(t, []interface{}{"a"}, table["primaryKeyColumnNames"])
+	columns := table["columns"].([]interface{})
+	require.Len(t, columns, 4)
+
+	a := columns[0].(map[string]interface{})
+	require.Equal(t, "a", a["name"])
+	require.Equal(t, float64(4), a["jdbcType"])
+	require.Equal(t, "INT UNSIGNED", a["typeName"])
+	require.Equal(t, float64(1), a["position"])
+	require.Equal(t, false, a["optional"])
+	require.Equal(t, true, a["autoIncremented"])
+
+	b := columns[1].(map[string]interface{})
+	require.Equal(t, float64(12), b["jdbcType"])
+	require.Equal(t, float64(32), b["length"])
+	require.Equal(t, "b column", b["comment"])
+	require.Equal(t, true, b["hasDefaultValue"])
+	require.Equal(t, "x", b["defaultValueExpression"])
+
+	c := columns[2].(map[string]interface{})
+	require.Equal(t, float64(3), c["jdbcType"])
+	require.Equal(t, float64(10), c["length"])
+	require.Equal(t, float64(2), c["scale"])
+	require.Equal(t, true, c["optional"])
+
+	d := columns[3].(map[string]interface{})
+	require.Equal(t, []interface{}{"e1", "e2"}, d["enumValues"])
+
+	// The schema describes the source and the items of the table changes.
+	fields := value["schema"].(map[string]interface{})["fields"].([]interface{})
+	source := fields[0].(map[string]interface{})
+	require.Equal(t, "source", source["field"])
+	require.NotEmpty(t, source["fields"])
+	tableChangesSchema := fields[len(fields)-1].(map[string]interface{})
+	require.Equal(t, "tableChanges", tableChangesSchema["field"])
+	items := tableChangesSchema["items"].(map[string]interface{})
+	require.Equal(t, "io.debezium.connector.schema.Change", items["name"])
+	require.Len(t, items["fields"], 3)
+}
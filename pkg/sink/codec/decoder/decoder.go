@@ -14,6 +14,7 @@
 package decoder
 
 import (
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/tiflow/cdc/model"
 )
 
@@ -33,7 +34,7 @@ type RowEventDecoder interface {
 	// NextResolvedEvent returns the next resolved event if exists
 	NextResolvedEvent() (uint64, error)
 	// NextRowChangedEvent returns the next row changed event if exists
-	// NextRowChangedEvent() (*common.RowChangedEvent, error)
+	NextRowChangedEvent() (*commonEvent.RowChangedEvent, error)
 	// NextDDLEvent returns the next DDL event if exists
-	NextDDLEvent() (*model.DDLEvent, error)
+	NextDDLEvent() (*commonEvent.DDLEvent, error)
 }
@@ -44,6 +44,14 @@ type EventEncoder interface {
 	Clean()
 }
 
+// TxnEventEncoder is an abstraction for txn events encoder.
+type TxnEventEncoder interface {
+	// AppendTxnEvent append a txn event into the buffer.
+	AppendTxnEvent(*commonEvent.DMLEvent, func()) error
+	// Build builds the batch messages from AppendTxnEvent and returns the messages.
+	Build() []*ticommon.Message
+}
+
 // IsColumnValueEqual checks whether the preValue and updatedValue are equal.
 func IsColumnValueEqual(preValue, updatedValue interface{}) bool {
 	if preValue == nil || updatedValue == nil {
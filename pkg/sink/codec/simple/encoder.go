@@ -19,23 +19,26 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
 	"github.com/pingcap/ticdc/pkg/sink/codec/encoder"
+	"github.com/pingcap/ticdc/pkg/sink/kafka/claimcheck"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	ticommon "github.com/pingcap/tiflow/pkg/sink/codec/common"
-	"github.com/pingcap/tiflow/pkg/sink/kafka/claimcheck"
 	"go.uber.org/zap"
 )
 
+// Encoder is the encoder for the simple protocol.
 type Encoder struct {
 	messages   []*ticommon.Message
-	config     *ticommon.Config
+	config     *newcommon.Config
 	claimCheck *claimcheck.ClaimCheck
 	marshaller marshaller
 }
 
-func NewEncoder(ctx context.Context, config *ticommon.Config) (encoder.EventEncoder, error) {
+// NewEncoder returns a new simple protocol encoder.
+func NewEncoder(ctx context.Context, config *newcommon.Config) (encoder.EventEncoder, error) {
 	claimCheck, err := claimcheck.New(ctx, config.LargeMessageHandle, config.ChangefeedID)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -53,7 +56,7 @@ func NewEncoder(ctx context.Context, config *ticommon.Config) (encoder.EventEnco
 }
 
 // AppendRowChangedEvent implement the RowEventEncoder interface
-func (e *Encoder) AppendRowChangedEvent(ctx context.Context, _ string, event *commonEvent.RowChangedEvent, callback func()) error {
+func (e *Encoder) AppendRowChangedEvent(ctx context.Context, _ string, event *commonEvent.RowEvent) error {
 	value, err := e.marshaller.MarshalRowChangedEvent(event, false, "")
 	if err != nil {
 		return err
@@ -71,7 +74,7 @@ func (e *Encoder) AppendRowChangedEvent(ctx context.Context, _ string, event *co
 		Table:    event.TableInfo.GetTableNamePtr(),
 		Type:     model.MessageTypeRow,
 		Protocol: config.ProtocolSimple,
-		Callback: callback,
+		Callback: event.Callback,
 	}
 
 	result.IncRowsCount()
@@ -149,27 +152,34 @@ func (e *Encoder) EncodeCheckpointEvent(ts uint64) (*ticommon.Message, error) {
 
 // EncodeDDLEvent implement the DDLEventBatchEncoder interface
 func (e *Encoder) EncodeDDLEvent(event *commonEvent.DDLEvent) (*ticommon.Message, error) {
-	// value, err := e.marshaller.MarshalDDLEvent(event)
-	// if err != nil {
-	// 	return nil, err
-	// }
-
-	// value, err = ticommon.Compress(e.config.ChangefeedID,
-	// 	e.config.LargeMessageHandle.LargeMessageHandleCompression, value)
-	// if err != nil {
-	// 	return nil, err
-	// }
-	// result := ticommon.NewDDLMsg(config.ProtocolSimple, nil, value, event)
-
-	// if result.Length() > e.config.MaxMessageBytes {
-	// 	log.Error("DDL message is too large for simple",
-	// 		zap.Int("maxMessageBytes", e.config.MaxMessageBytes),
-	// 		zap.Int("length", result.Length()),
-	// 		zap.Any("table", event.TableInfo.TableName))
-	// 	return nil, cerror.ErrMessageTooLarge.GenWithStackByArgs()
-	// }
-	// return result, nil
-	return nil, nil
+	value, err := e.marshaller.MarshalDDLEvent(event)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err = ticommon.Compress(e.config.ChangefeedID,
+		e.config.LargeMessageHandle.LargeMessageHandleCompression, value)
+	if err != nil {
+		return nil, err
+	}
+	result := &ticommon.Message{
+		Value:    value,
+		Ts:       event.FinishedTs,
+		Schema:   &event.SchemaName,
+		Table:    &event.TableName,
+		Type:     model.MessageTypeDDL,
+		Protocol: config.ProtocolSimple,
+	}
+
+	if result.Length() > e.config.MaxMessageBytes {
+		log.Error("DDL message is too large for simple",
+			zap.Int("maxMessageBytes", e.config.MaxMessageBytes),
+			zap.Int("length", result.Length()),
+			zap.String("schema", event.SchemaName),
+			zap.String("table", event.TableName))
+		return nil, cerror.ErrMessageTooLarge.GenWithStackByArgs()
+	}
+	return result, nil
 }
 
 // CleanMetrics implement the RowEventEncoderBuilder interface
@@ -0,0 +1,325 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simple
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/model"
+	ticommon "github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/stretchr/testify/require"
+)
+
+var encodingFormats = []newcommon.EncodingFormatType{
+	newcommon.EncodingFormatJSON,
+	newcommon.EncodingFormatAvro,
+}
+
+func newSimpleTestConfig(format newcommon.EncodingFormatType) *newcommon.Config {
+	cfg := newcommon.NewConfig(config.ProtocolSimple).
+		WithChangefeedID(model.DefaultChangeFeedID("simple-test"))
+	cfg.EncodingFormat = format
+	return cfg
+}
+
+func columnValues(columns []*common.Column) map[string]interface{} {
+	result := make(map[string]interface{}, len(columns))
+	for _, col := range columns {
+		result[col.Name] = col.Value
+	}
+	return result
+}
+
+func newBootstrapEvent(tableInfo *common.TableInfo) *commonEvent.DDLEvent {
+	return &commonEvent.DDLEvent{
+		SchemaName:  tableInfo.TableName.Schema,
+		TableName:   tableInfo.TableName.Table,
+		TableInfo:   tableInfo,
+		IsBootstrap: true,
+	}
+}
+
+func decodeDDLEvent(t *testing.T, dec *Decoder, m *ticommon.Message) *commonEvent.DDLEvent {
+	require.NoError(t, dec.AddKeyValue(m.Key, m.Value))
+	tp, hasNext, err := dec.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeDDL, tp)
+	ddl, err := dec.NextDDLEvent()
+	require.NoError(t, err)
+	return ddl
+}
+
+func decodeRowChangedEvent(t *testing.T, dec *Decoder, m *ticommon.Message) *commonEvent.RowChangedEvent {
+	require.NoError(t, dec.AddKeyValue(m.Key, m.Value))
+	tp, hasNext, err := dec.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeRow, tp)
+	row, err := dec.NextRowChangedEvent()
+	require.NoError(t, err)
+	return row
+}
+
+func TestEncodeCheckpointEvent(t *testing.T) {
+	ctx := context.Background()
+	for _, format := range encodingFormats {
+		cfg := newSimpleTestConfig(format)
+		enc, err := NewEncoder(ctx, cfg)
+		require.NoError(t, err)
+		dec, err := NewDecoder(ctx, cfg, nil)
+		require.NoError(t, err)
+
+		checkpoint, err := enc.EncodeCheckpointEvent(417318403368288260)
+		require.NoError(t, err)
+		require.Equal(t, model.MessageTypeResolved, checkpoint.Type)
+
+		require.NoError(t, dec.AddKeyValue(checkpoint.Key, checkpoint.Value))
+		tp, hasNext, err := dec.HasNext()
+		require.NoError(t, err)
+		require.True(t, hasNext)
+		require.Equal(t, model.MessageTypeResolved, tp)
+		ts, err := dec.NextResolvedEvent()
+		require.NoError(t, err)
+		require.Equal(t, uint64(417318403368288260), ts)
+	}
+}
+
+func TestEncodeDDLEvent(t *testing.T) {
+	ctx := context.Background()
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(a int primary key, b varchar(32) default 'x', c int, key idx_c(c))`)
+	tableInfo := helper.GetTableInfo(job)
+	ddlEvent := &commonEvent.DDLEvent{
+		Type:       byte(job.Type),
+		SchemaName: job.SchemaName,
+		TableName:  job.TableName,
+		Query:      job.Query,
+		TableInfo:  tableInfo,
+		FinishedTs: 417318403368288260,
+	}
+
+	for _, format := range encodingFormats {
+		cfg := newSimpleTestConfig(format)
+		enc, err := NewEncoder(ctx, cfg)
+		require.NoError(t, err)
+		dec, err := NewDecoder(ctx, cfg, nil)
+		require.NoError(t, err)
+
+		m, err := enc.EncodeDDLEvent(ddlEvent)
+		require.NoError(t, err)
+		require.Equal(t, model.MessageTypeDDL, m.Type)
+		require.Equal(t, ddlEvent.FinishedTs, m.Ts)
+
+		decoded := decodeDDLEvent(t, dec, m)
+		require.False(t, decoded.IsBootstrap)
+		require.Equal(t, ddlEvent.Query, decoded.Query)
+		require.Equal(t, ddlEvent.FinishedTs, decoded.FinishedTs)
+		require.Equal(t, "test", decoded.SchemaName)
+		require.Equal(t, "t", decoded.TableName)
+		require.Equal(t, tableInfo.ID, decoded.TableInfo.ID)
+		require.Equal(t, tableInfo.GetVersion(), decoded.TableInfo.GetVersion())
+		require.Len(t, decoded.TableInfo.Columns, 3)
+		require.Equal(t, []string{"a"}, decoded.TableInfo.GetPrimaryKeyColumnNames())
+		require.Len(t, decoded.TableInfo.Indices, 2)
+
+		// the table info carried by the DDL event is kept by the decoder.
+		require.NotNil(t, dec.memo.Read("test", "t", tableInfo.GetVersion()))
+	}
+}
+
+func TestEncodeDecodeRowChangedEvent(t *testing.T) {
+	ctx := context.Background()
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(
+		a int primary key, b varchar(32), c bigint unsigned, d double,
+		e enum('x', 'y'), f timestamp, g blob, h bit(8), i decimal(10, 2))`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values
+		(1, 'hello', 18446744073709551615, 1.5, 'y', '2024-01-02 03:04:05', x'0102', b'101', 12.34)`)
+	insertRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	dmlEvent = helper.DML2Event("test", "t", `update test.t set b = 'world', c = 1 where a = 1`)
+	updateRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	updateRow.PreRow = insertRow.Row
+	updateRow.RowType = commonEvent.RowTypeUpdate
+
+	for _, format := range encodingFormats {
+		cfg := newSimpleTestConfig(format)
+		enc, err := NewEncoder(ctx, cfg)
+		require.NoError(t, err)
+		dec, err := NewDecoder(ctx, cfg, nil)
+		require.NoError(t, err)
+
+		count := 0
+		err = enc.AppendRowChangedEvent(ctx, "", &commonEvent.RowEvent{
+			TableInfo:      tableInfo,
+			CommitTs:       417318403368288260,
+			Event:          insertRow,
+			ColumnSelector: common.NewDefaultColumnSelector(),
+			Callback:       func() { count++ },
+		})
+		require.NoError(t, err)
+		messages := enc.Build()
+		require.Len(t, messages, 1)
+		require.Equal(t, 1, messages[0].GetRowsCount())
+		messages[0].Callback()
+		require.Equal(t, 1, count)
+
+		// the table info is not received yet, the event is cached by the decoder.
+		row := decodeRowChangedEvent(t, dec, messages[0])
+		require.Nil(t, row)
+
+		bootstrap, err := enc.EncodeDDLEvent(newBootstrapEvent(tableInfo))
+		require.NoError(t, err)
+		ddl := decodeDDLEvent(t, dec, bootstrap)
+		require.True(t, ddl.IsBootstrap)
+		require.Equal(t, tableInfo.GetVersion(), ddl.TableInfo.GetVersion())
+
+		cached := dec.GetCachedEvents()
+		require.Len(t, cached, 1)
+		row = cached[0]
+		require.True(t, row.IsInsert())
+		require.Equal(t, uint64(417318403368288260), row.CommitTs)
+		require.Equal(t, tableInfo.ID, row.PhysicalTableID)
+		values := columnValues(row.Columns)
+		require.Equal(t, int64(1), values["a"])
+		require.Equal(t, "hello", values["b"])
+		require.Equal(t, uint64(18446744073709551615), values["c"])
+		require.Equal(t, 1.5, values["d"])
+		require.Equal(t, uint64(2), values["e"])
+		require.Equal(t, "2024-01-02 03:04:05", values["f"])
+		require.Equal(t, []byte{0x01, 0x02}, values["g"])
+		require.Equal(t, uint64(5), values["h"])
+		require.Equal(t, "12.34", values["i"])
+		for _, col := range row.Columns {
+			if col.Name == "a" {
+				require.True(t, col.Flag.IsHandleKey())
+			}
+		}
+
+		// the table info is known, the update event is decoded directly.
+		err = enc.AppendRowChangedEvent(ctx, "", &commonEvent.RowEvent{
+			TableInfo:      tableInfo,
+			CommitTs:       417318403368288261,
+			Event:          updateRow,
+			ColumnSelector: common.NewDefaultColumnSelector(),
+		})
+		require.NoError(t, err)
+		messages = enc.Build()
+		require.Len(t, messages, 1)
+		row = decodeRowChangedEvent(t, dec, messages[0])
+		require.True(t, row.IsUpdate())
+		require.Equal(t, "world", columnValues(row.Columns)["b"])
+		require.Equal(t, "hello", columnValues(row.PreColumns)["b"])
+
+		err = enc.AppendRowChangedEvent(ctx, "", &commonEvent.RowEvent{
+			TableInfo: tableInfo,
+			CommitTs:  417318403368288262,
+			Event: commonEvent.RowChange{
+				PreRow:  updateRow.Row,
+				RowType: commonEvent.RowTypeDelete,
+			},
+			ColumnSelector: common.NewDefaultColumnSelector(),
+		})
+		require.NoError(t, err)
+		messages = enc.Build()
+		require.Len(t, messages, 1)
+		row = decodeRowChangedEvent(t, dec, messages[0])
+		require.True(t, row.IsDelete())
+		require.Equal(t, int64(1), columnValues(row.PreColumns)["a"])
+		require.Equal(t, "world", columnValues(row.PreColumns)["b"])
+	}
+}
+
+func TestLargeMessageHandle(t *testing.T) {
+	ctx := context.Background()
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(a int primary key, b text)`)
+	tableInfo := helper.GetTableInfo(job)
+	largeValue := strings.Repeat("a", 8192)
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1, '`+largeValue+`')`)
+	insertRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	rowEvent := &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       417318403368288260,
+		Event:          insertRow,
+		ColumnSelector: common.NewDefaultColumnSelector(),
+	}
+
+	// large message handle is disabled by default.
+	cfg := newSimpleTestConfig(newcommon.EncodingFormatJSON).WithMaxMessageBytes(2048)
+	enc, err := NewEncoder(ctx, cfg)
+	require.NoError(t, err)
+	err = enc.AppendRowChangedEvent(ctx, "", rowEvent)
+	require.Error(t, err)
+
+	// only the handle key columns are encoded.
+	cfg = newSimpleTestConfig(newcommon.EncodingFormatJSON).WithMaxMessageBytes(2048)
+	cfg.LargeMessageHandle = config.NewDefaultLargeMessageHandleConfig()
+	cfg.LargeMessageHandle.LargeMessageHandleOption = config.LargeMessageHandleOptionHandleKeyOnly
+	enc, err = NewEncoder(ctx, cfg)
+	require.NoError(t, err)
+	err = enc.AppendRowChangedEvent(ctx, "", rowEvent)
+	require.NoError(t, err)
+	messages := enc.Build()
+	require.Len(t, messages, 1)
+	var m message
+	require.NoError(t, json.Unmarshal(messages[0].Value, &m))
+	require.True(t, m.HandleKeyOnly)
+	require.Equal(t, map[string]interface{}{"a": "1"}, m.Data)
+
+	// the whole message is written to the external storage, and decoded by the decoder.
+	for _, format := range encodingFormats {
+		cfg = newSimpleTestConfig(format).WithMaxMessageBytes(2048)
+		cfg.LargeMessageHandle = config.NewDefaultLargeMessageHandleConfig()
+		cfg.LargeMessageHandle.LargeMessageHandleOption = config.LargeMessageHandleOptionClaimCheck
+		cfg.LargeMessageHandle.ClaimCheckStorageURI = "file://" + t.TempDir()
+		enc, err = NewEncoder(ctx, cfg)
+		require.NoError(t, err)
+		dec, err := NewDecoder(ctx, cfg, nil)
+		require.NoError(t, err)
+
+		bootstrap, err := enc.EncodeDDLEvent(newBootstrapEvent(tableInfo))
+		require.NoError(t, err)
+		decodeDDLEvent(t, dec, bootstrap)
+
+		err = enc.AppendRowChangedEvent(ctx, "", rowEvent)
+		require.NoError(t, err)
+		messages = enc.Build()
+		require.Len(t, messages, 1)
+		require.LessOrEqual(t, messages[0].Length(), 2048)
+
+		row := decodeRowChangedEvent(t, dec, messages[0])
+		require.Equal(t, largeValue, columnValues(row.Columns)["b"])
+	}
+}
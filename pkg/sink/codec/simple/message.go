@@ -23,12 +23,14 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	commonNew "github.com/pingcap/ticdc/pkg/sink/codec/common"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/types"
 	tiTypes "github.com/pingcap/tidb/pkg/types"
-	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/integrity"
 	ticommon "github.com/pingcap/tiflow/pkg/sink/codec/common"
@@ -146,7 +148,7 @@ func newColumnSchema(col *timodel.ColumnInfo) *columnSchema {
 		tp.Decimal = col.GetDecimal()
 	}
 
-	defaultValue := model.GetColumnDefaultValue(col)
+	defaultValue := common.GetColumnDefaultValue(col)
 	if defaultValue != nil && col.GetType() == mysql.TypeBit {
 		defaultValue = ticommon.MustBinaryLiteralToInt([]byte(defaultValue.(string)))
 	}
@@ -164,7 +166,7 @@ func newTiColumnInfo(
 ) *timodel.ColumnInfo {
 	col := new(timodel.ColumnInfo)
 	col.ID = colID
-	col.Name = timodel.NewCIStr(column.Name)
+	col.Name = pmodel.NewCIStr(column.Name)
 
 	col.FieldType = *types.NewFieldType(types.StrToType(column.DataType.MySQLType))
 	col.SetCharset(column.DataType.Charset)
@@ -258,14 +260,14 @@ func newTiIndexInfo(indexSchema *IndexSchema, columns []*timodel.ColumnInfo, ind
 			}
 		}
 		indexColumns[i] = &timodel.IndexColumn{
-			Name:   timodel.NewCIStr(col),
+			Name:   pmodel.NewCIStr(col),
 			Offset: offset,
 		}
 	}
 
 	return &timodel.IndexInfo{
 		ID:      indexID,
-		Name:    timodel.NewCIStr(indexSchema.Name),
+		Name:    pmodel.NewCIStr(indexSchema.Name),
 		Columns: indexColumns,
 		Unique:  indexSchema.Unique,
 		Primary: indexSchema.Primary,
@@ -282,7 +284,7 @@ type TableSchema struct {
 	Indexes []*IndexSchema  `json:"indexes"`
 }
 
-func newTableSchema(tableInfo *model.TableInfo) *TableSchema {
+func newTableSchema(tableInfo *common.TableInfo) *TableSchema {
 	pkInIndexes := false
 	indexes := make([]*IndexSchema, 0, len(tableInfo.Indices))
 	for _, idx := range tableInfo.Indices {
@@ -308,12 +310,8 @@ func newTableSchema(tableInfo *model.TableInfo) *TableSchema {
 		}
 	}
 
-	sort.SliceStable(tableInfo.Columns, func(i, j int) bool {
-		return tableInfo.Columns[i].ID < tableInfo.Columns[j].ID
-	})
-
 	columns := make([]*columnSchema, 0, len(tableInfo.Columns))
-	for _, col := range tableInfo.Columns {
+	for _, col := range sortColumnsByID(tableInfo.Columns) {
 		colSchema := newColumnSchema(col)
 		columns = append(columns, colSchema)
 	}
@@ -322,26 +320,34 @@ func newTableSchema(tableInfo *model.TableInfo) *TableSchema {
 		Schema:  tableInfo.TableName.Schema,
 		Table:   tableInfo.TableName.Table,
 		TableID: tableInfo.ID,
-		Version: tableInfo.UpdateTS,
+		Version: tableInfo.GetVersion(),
 		Columns: columns,
 		Indexes: indexes,
 	}
 }
 
+// sortColumnsByID returns a copy of the columns sorted by the column ID.
+// The columns of the table info are shared with the row decoder,
+// so the order of the original slice must not be changed.
+func sortColumnsByID(columns []*timodel.ColumnInfo) []*timodel.ColumnInfo {
+	result := make([]*timodel.ColumnInfo, len(columns))
+	copy(result, columns)
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
 // newTableInfo converts from TableSchema to TableInfo.
-func newTableInfo(m *TableSchema) *model.TableInfo {
-	var (
-		database      string
-		schemaVersion uint64
-	)
+func newTableInfo(m *TableSchema) *common.TableInfo {
+	var database string
 
 	tidbTableInfo := &timodel.TableInfo{}
 	if m != nil {
 		database = m.Schema
-		schemaVersion = m.Version
 
 		tidbTableInfo.ID = m.TableID
-		tidbTableInfo.Name = timodel.NewCIStr(m.Table)
+		tidbTableInfo.Name = pmodel.NewCIStr(m.Table)
 		tidbTableInfo.UpdateTS = m.Version
 
 		nextMockID := int64(100)
@@ -358,34 +364,27 @@ func newTableInfo(m *TableSchema) *model.TableInfo {
 			mockIndexID += 1
 		}
 	}
-	return model.WrapTableInfo(100, database, schemaVersion, tidbTableInfo)
+	return common.WrapTableInfo(100, database, tidbTableInfo)
 }
 
 // newDDLEvent converts from message to DDLEvent.
-func newDDLEvent(msg *message) *model.DDLEvent {
-	var (
-		tableInfo    *model.TableInfo
-		preTableInfo *model.TableInfo
-	)
-
-	tableInfo = newTableInfo(msg.TableSchema)
-	if msg.PreTableSchema != nil {
-		preTableInfo = newTableInfo(msg.PreTableSchema)
-	}
-	return &model.DDLEvent{
-		StartTs:      msg.CommitTs,
-		CommitTs:     msg.CommitTs,
-		TableInfo:    tableInfo,
-		PreTableInfo: preTableInfo,
-		Query:        msg.SQL,
+func newDDLEvent(msg *message) *commonEvent.DDLEvent {
+	tableInfo := newTableInfo(msg.TableSchema)
+	return &commonEvent.DDLEvent{
+		SchemaName:  tableInfo.TableName.Schema,
+		TableName:   tableInfo.TableName.Table,
+		Query:       msg.SQL,
+		TableInfo:   tableInfo,
+		FinishedTs:  msg.CommitTs,
+		IsBootstrap: msg.Type == MessageTypeBootstrap,
 	}
 }
 
 // buildRowChangedEvent converts from message to RowChangedEvent.
 func buildRowChangedEvent(
 	msg *message, tableInfo *common.TableInfo, enableRowChecksum bool, db *sql.DB,
-) (*common.RowChangedEvent, error) {
-	result := &common.RowChangedEvent{
+) (*commonEvent.RowChangedEvent, error) {
+	result := &commonEvent.RowChangedEvent{
 		CommitTs:        msg.CommitTs,
 		PhysicalTableID: msg.TableID,
 		TableInfo:       tableInfo,
@@ -453,12 +452,16 @@ func decodeColumns(
 				zap.String("column", info.Name.O))
 			continue
 		}
-		columnID := tableInfo.ForceGetColumnIDByName(info.Name.O)
-		col := decodeColumn(value, columnID, &info.FieldType)
+		col := decodeColumn(value, &info.FieldType)
 		if col == nil {
 			log.Panic("cannot decode column",
 				zap.String("name", info.Name.O), zap.Any("data", value))
 		}
+		col.Name = info.Name.O
+		col.Type = info.GetType()
+		col.Charset = info.GetCharset()
+		col.Collation = info.GetCollate()
+		col.Flag = *tableInfo.ForceGetColumnFlagType(info.ID)
 
 		result = append(result, col)
 	}
@@ -513,7 +516,7 @@ func newResolvedMessage(ts uint64) *message {
 	}
 }
 
-func newBootstrapMessage(tableInfo *model.TableInfo) *message {
+func newBootstrapMessage(tableInfo *common.TableInfo) *message {
 	schema := newTableSchema(tableInfo)
 	msg := &message{
 		Version:     defaultVersion,
@@ -524,35 +527,27 @@ func newBootstrapMessage(tableInfo *model.TableInfo) *message {
 	return msg
 }
 
-func newDDLMessage(ddl *model.DDLEvent) *message {
-	var (
-		schema    *TableSchema
-		preSchema *TableSchema
-	)
+func newDDLMessage(ddl *commonEvent.DDLEvent) *message {
+	var schema *TableSchema
 	// the tableInfo maybe nil if the DDL is `drop database`
 	if ddl.TableInfo != nil && ddl.TableInfo.TableInfo != nil {
 		schema = newTableSchema(ddl.TableInfo)
 	}
-	// `PreTableInfo` may not exist for some DDL, such as `create table`
-	if ddl.PreTableInfo != nil && ddl.PreTableInfo.TableInfo != nil {
-		preSchema = newTableSchema(ddl.PreTableInfo)
-	}
 	msg := &message{
-		Version:        defaultVersion,
-		Type:           getDDLType(ddl.Type),
-		CommitTs:       ddl.CommitTs,
-		BuildTs:        time.Now().UnixMilli(),
-		SQL:            ddl.Query,
-		TableSchema:    schema,
-		PreTableSchema: preSchema,
+		Version:     defaultVersion,
+		Type:        getDDLType(timodel.ActionType(ddl.Type)),
+		CommitTs:    ddl.FinishedTs,
+		BuildTs:     time.Now().UnixMilli(),
+		SQL:         ddl.Query,
+		TableSchema: schema,
 	}
 	return msg
 }
 
 func (a *JSONMarshaller) newDMLMessage(
-	event *common.RowChangedEvent,
+	event *commonEvent.RowEvent,
 	onlyHandleKey bool, claimCheckFileName string,
-) *message {
+) (*message, error) {
 	m := &message{
 		Version:            defaultVersion,
 		Schema:             event.TableInfo.GetSchemaName(),
@@ -560,49 +555,45 @@ func (a *JSONMarshaller) newDMLMessage(
 		TableID:            event.TableInfo.ID,
 		CommitTs:           event.CommitTs,
 		BuildTs:            time.Now().UnixMilli(),
-		SchemaVersion:      event.TableInfo.UpdateTS,
+		SchemaVersion:      event.TableInfo.GetVersion(),
 		HandleKeyOnly:      onlyHandleKey,
 		ClaimCheckLocation: claimCheckFileName,
 	}
+	var err error
 	if event.IsInsert() {
 		m.Type = DMLTypeInsert
-		m.Data = a.formatColumns(event.Columns, event.TableInfo, onlyHandleKey)
+		m.Data, err = a.formatColumns(event.GetRows(), event.TableInfo, onlyHandleKey)
 	} else if event.IsDelete() {
 		m.Type = DMLTypeDelete
-		m.Old = a.formatColumns(event.PreColumns, event.TableInfo, onlyHandleKey)
+		m.Old, err = a.formatColumns(event.GetPreRows(), event.TableInfo, onlyHandleKey)
 	} else if event.IsUpdate() {
 		m.Type = DMLTypeUpdate
-		m.Data = a.formatColumns(event.Columns, event.TableInfo, onlyHandleKey)
-		m.Old = a.formatColumns(event.PreColumns, event.TableInfo, onlyHandleKey)
-	}
-	if a.config.EnableRowChecksum && event.Checksum != nil {
-		m.Checksum = &checksum{
-			Version:   event.Checksum.Version,
-			Corrupted: event.Checksum.Corrupted,
-			Current:   event.Checksum.Current,
-			Previous:  event.Checksum.Previous,
+		m.Data, err = a.formatColumns(event.GetRows(), event.TableInfo, onlyHandleKey)
+		if err == nil {
+			m.Old, err = a.formatColumns(event.GetPreRows(), event.TableInfo, onlyHandleKey)
 		}
 	}
-
-	return m
+	return m, err
 }
 
 func (a *JSONMarshaller) formatColumns(
-	columns []*common.Column, tableInfo *common.TableInfo, onlyHandleKey bool,
-) map[string]interface{} {
-	result := make(map[string]interface{}, len(columns))
-	colInfos := tableInfo.GetColInfosForRowChangedEvent()
-	for i, col := range columns {
-		if col != nil {
-			flag := col.Flag
-			if onlyHandleKey && !flag.IsHandleKey() {
-				continue
-			}
-			value := encodeValue(col.Value, colInfos[i].Ft, a.config.TimeZone.String())
-			result[col.Name] = value
+	row *chunk.Row, tableInfo *common.TableInfo, onlyHandleKey bool,
+) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(tableInfo.Columns))
+	for idx, col := range tableInfo.Columns {
+		if !common.IsColCDCVisible(col) {
+			continue
+		}
+		if onlyHandleKey && !tableInfo.ForceGetColumnFlagType(col.ID).IsHandleKey() {
+			continue
 		}
+		value, err := common.FormatColVal(row, col, idx)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrEncodeFailed, err)
+		}
+		result[col.Name.O] = encodeValue(value, &col.FieldType, a.config.TimeZone.String())
 	}
-	return result
+	return result, nil
 }
 
 func (a *avroMarshaller) encodeValue4Avro(
@@ -725,7 +716,7 @@ func encodeValue(
 	return result
 }
 
-func decodeColumn(value interface{}, id int64, fieldType *types.FieldType) *common.Column {
+func decodeColumn(value interface{}, fieldType *types.FieldType) *common.Column {
 	result := &common.Column{
 		Value: value,
 	}
@@ -791,11 +782,13 @@ func decodeColumn(value interface{}, id int64, fieldType *types.FieldType) *comm
 			value = v
 		}
 	case mysql.TypeEnum:
-		// avro encoding, enum is encoded as `int64`, use it directly.
+		// avro encoding, enum is encoded as `int64`
 		// json encoding, enum is encoded as `string`
 		switch v := value.(type) {
 		case string:
 			value, err = strconv.ParseUint(v, 10, 64)
+		case int64:
+			value = uint64(v)
 		}
 	default:
 	}
@@ -15,18 +15,30 @@ package mysql
 
 import (
 	"context"
+	"crypto/tls"
 	"database/sql"
+	"fmt"
 	"math"
+	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
+	"github.com/gin-gonic/gin/binding"
+	dmysql "github.com/go-sql-driver/mysql"
 	lru "github.com/hashicorp/golang-lru"
+	"github.com/imdario/mergo"
+	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	ticonfig "github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/security"
+	"github.com/pingcap/tiflow/pkg/sink"
 	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
+	"github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/zap"
 )
 
@@ -77,6 +89,25 @@ const (
 	prepStmtCacheSize int = 16 * 1024
 )
 
+type urlConfig struct {
+	WorkerCount                  *int    `form:"worker-count"`
+	MaxTxnRow                    *int    `form:"max-txn-row"`
+	MaxMultiUpdateRowSize        *int    `form:"max-multi-update-row-size"`
+	MaxMultiUpdateRowCount       *int    `form:"max-multi-update-row"`
+	TiDBTxnMode                  *string `form:"tidb-txn-mode"`
+	SSLCa                        *string `form:"ssl-ca"`
+	SSLCert                      *string `form:"ssl-cert"`
+	SSLKey                       *string `form:"ssl-key"`
+	SafeMode                     *bool   `form:"safe-mode"`
+	TimeZone                     *string `form:"time-zone"`
+	WriteTimeout                 *string `form:"write-timeout"`
+	ReadTimeout                  *string `form:"read-timeout"`
+	Timeout                      *string `form:"timeout"`
+	EnableBatchDML               *bool   `form:"batch-dml-enable"`
+	EnableMultiStatement         *bool   `form:"multi-stmt-enable"`
+	EnableCachePreparedStatement *bool   `form:"cache-prep-stmts"`
+}
+
 type MysqlConfig struct {
 	sinkURI                *url.URL
 	WorkerCount            int
@@ -92,6 +123,11 @@ type MysqlConfig struct {
 	TLS                    string
 	ForceReplicate         bool
 
+	// tlsName and tlsConfig are registered to the mysql driver only when
+	// the sink is built, since the registration is global.
+	tlsName   string
+	tlsConfig *tls.Config
+
 	IsTiDB bool // IsTiDB is true if the downstream is TiDB
 	// IsBDRModeSupported is true if the downstream is TiDB and write source is existed.
 	// write source exists when the downstream is TiDB and version is greater than or equal to v6.5.0.
@@ -131,24 +167,345 @@ func NewMysqlConfig() *MysqlConfig {
 	}
 }
 
-func (c *MysqlConfig) Apply(sinkURI *url.URL) error {
+// Apply applies the sink URI parameters and the sink config to the config.
+// The parameters in the sink URI take precedence over the ones in the sink config.
+func (c *MysqlConfig) Apply(
+	changefeedID model.ChangeFeedID,
+	sinkURI *url.URL,
+	config *ticonfig.ChangefeedConfig,
+) (err error) {
 	if sinkURI == nil {
 		log.Error("empty SinkURI")
 		return cerror.ErrMySQLInvalidConfig.GenWithStack("fail to open MySQL sink, empty SinkURI")
 	}
+	scheme := strings.ToLower(sinkURI.Scheme)
+	if !sink.IsMySQLCompatibleScheme(scheme) {
+		return cerror.ErrMySQLInvalidConfig.GenWithStack("can't create MySQL sink with unsupported scheme: %s", scheme)
+	}
 	c.sinkURI = sinkURI
+
+	req := &http.Request{URL: sinkURI}
+	urlParameter := &urlConfig{}
+	if err = binding.Query.Bind(req, urlParameter); err != nil {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+	}
+	var sinkConfig *ticonfig.SinkConfig
+	if config != nil {
+		sinkConfig = config.SinkConfig
+		c.ForceReplicate = config.ForceReplicate
+	}
+	if urlParameter, err = mergeConfig(sinkConfig, urlParameter); err != nil {
+		return err
+	}
+	if err = getWorkerCount(urlParameter, &c.WorkerCount); err != nil {
+		return err
+	}
+	if err = getMaxTxnRow(urlParameter, &c.MaxTxnRow); err != nil {
+		return err
+	}
+	if err = getMaxMultiUpdateRowCount(urlParameter, &c.MaxMultiUpdateRowCount); err != nil {
+		return err
+	}
+	if err = getMaxMultiUpdateRowSize(urlParameter, &c.MaxMultiUpdateRowSize); err != nil {
+		return err
+	}
+	if err = getTiDBTxnMode(urlParameter, &c.tidbTxnMode); err != nil {
+		return err
+	}
+	if err = c.getSSLCA(urlParameter, changefeedID); err != nil {
+		return err
+	}
+	getSafeMode(urlParameter, &c.SafeMode)
+	// The row values are decoded in the timezone of the changefeed,
+	// which falls back to the timezone of the server.
+	changefeedTimezone := ticonfig.GetGlobalServerConfig().TZ
+	if config != nil && config.TimeZone != "" {
+		changefeedTimezone = config.TimeZone
+	}
+	if err = getTimezone(changefeedTimezone, urlParameter, &c.Timezone); err != nil {
+		return err
+	}
+	if err = getDuration(urlParameter.ReadTimeout, "read-timeout", &c.ReadTimeout); err != nil {
+		return err
+	}
+	if err = getDuration(urlParameter.WriteTimeout, "write-timeout", &c.WriteTimeout); err != nil {
+		return err
+	}
+	if err = getDuration(urlParameter.Timeout, "timeout", &c.DialTimeout); err != nil {
+		return err
+	}
+	getBool(urlParameter.EnableBatchDML, &c.BatchDMLEnable)
+	getBool(urlParameter.EnableMultiStatement, &c.MultiStmtEnable)
+	getBool(urlParameter.EnableCachePreparedStatement, &c.CachePrepStmts)
+
+	// The TiDBSourceID should never be 0 here, but it is not marshalled into the
+	// changefeed config sent to the dispatchers, so use the default value.
+	if sinkConfig != nil && sinkConfig.TiDBSourceID != 0 {
+		c.SourceID = sinkConfig.TiDBSourceID
+	}
+	return nil
+}
+
+// mergeConfig merges the sink config and the sink URI parameters,
+// the sink URI parameters have higher priority.
+func mergeConfig(
+	sinkConfig *ticonfig.SinkConfig,
+	urlParameters *urlConfig,
+) (*urlConfig, error) {
+	dest := &urlConfig{}
+	if sinkConfig != nil {
+		dest.SafeMode = sinkConfig.SafeMode
+		if sinkConfig.MySQLConfig != nil {
+			mConfig := sinkConfig.MySQLConfig
+			dest.WorkerCount = mConfig.WorkerCount
+			dest.MaxTxnRow = mConfig.MaxTxnRow
+			dest.MaxMultiUpdateRowCount = mConfig.MaxMultiUpdateRowCount
+			dest.MaxMultiUpdateRowSize = mConfig.MaxMultiUpdateRowSize
+			dest.TiDBTxnMode = mConfig.TiDBTxnMode
+			dest.SSLCa = mConfig.SSLCa
+			dest.SSLCert = mConfig.SSLCert
+			dest.SSLKey = mConfig.SSLKey
+			dest.TimeZone = mConfig.TimeZone
+			dest.WriteTimeout = mConfig.WriteTimeout
+			dest.ReadTimeout = mConfig.ReadTimeout
+			dest.Timeout = mConfig.Timeout
+			dest.EnableBatchDML = mConfig.EnableBatchDML
+			dest.EnableMultiStatement = mConfig.EnableMultiStatement
+			dest.EnableCachePreparedStatement = mConfig.EnableCachePreparedStatement
+		}
+	}
+	// WithoutDereference is required to override the config with a false value in the sink URI.
+	if err := mergo.Merge(dest, urlParameters, mergo.WithOverride, mergo.WithoutDereference); err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+	}
+	return dest, nil
+}
+
+// VerifySinkConfig checks the sink URI parameters and the sink config of a MySQL sink
+// without connecting to the downstream, so the invalid options can be rejected
+// before the changefeed is created.
+func VerifySinkConfig(
+	changefeedID model.ChangeFeedID,
+	sinkURI *url.URL,
+	config *ticonfig.ChangefeedConfig,
+) error {
+	return NewMysqlConfig().Apply(changefeedID, sinkURI, config)
+}
+
+func getWorkerCount(values *urlConfig, workerCount *int) error {
+	if values.WorkerCount == nil {
+		return nil
+	}
+	c := *values.WorkerCount
+	if c <= 0 {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig,
+			fmt.Errorf("invalid worker-count %d, which must be greater than 0", c))
+	}
+	if c > maxWorkerCount {
+		log.Warn("worker-count too large",
+			zap.Int("original", c), zap.Int("override", maxWorkerCount))
+		c = maxWorkerCount
+	}
+	*workerCount = c
 	return nil
 }
 
-func NewMysqlConfigAndDB(ctx context.Context, changefeedID model.ChangeFeedID, sinkURI *url.URL) (*MysqlConfig, *sql.DB, error) {
+func getMaxTxnRow(values *urlConfig, maxTxnRow *int) error {
+	if values.MaxTxnRow == nil {
+		return nil
+	}
+	c := *values.MaxTxnRow
+	if c <= 0 {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig,
+			fmt.Errorf("invalid max-txn-row %d, which must be greater than 0", c))
+	}
+	if c > maxMaxTxnRow {
+		log.Warn("max-txn-row too large",
+			zap.Int("original", c), zap.Int("override", maxMaxTxnRow))
+		c = maxMaxTxnRow
+	}
+	*maxTxnRow = c
+	return nil
+}
+
+func getMaxMultiUpdateRowCount(values *urlConfig, maxMultiUpdateRow *int) error {
+	if values.MaxMultiUpdateRowCount == nil {
+		return nil
+	}
+	c := *values.MaxMultiUpdateRowCount
+	if c <= 0 {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig,
+			fmt.Errorf("invalid max-multi-update-row %d, which must be greater than 0", c))
+	}
+	if c > maxMaxMultiUpdateRowCount {
+		log.Warn("max-multi-update-row too large",
+			zap.Int("original", c), zap.Int("override", maxMaxMultiUpdateRowCount))
+		c = maxMaxMultiUpdateRowCount
+	}
+	*maxMultiUpdateRow = c
+	return nil
+}
+
+func getMaxMultiUpdateRowSize(values *urlConfig, maxMultiUpdateRowSize *int) error {
+	if values.MaxMultiUpdateRowSize == nil {
+		return nil
+	}
+	c := *values.MaxMultiUpdateRowSize
+	if c < 0 {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig,
+			fmt.Errorf("invalid max-multi-update-row-size %d, "+
+				"which must be greater than or equal to 0", c))
+	}
+	if c > maxMaxMultiUpdateRowSize {
+		log.Warn("max-multi-update-row-size too large",
+			zap.Int("original", c), zap.Int("override", maxMaxMultiUpdateRowSize))
+		c = maxMaxMultiUpdateRowSize
+	}
+	*maxMultiUpdateRowSize = c
+	return nil
+}
+
+func getTiDBTxnMode(values *urlConfig, mode *string) error {
+	if values.TiDBTxnMode == nil || len(*values.TiDBTxnMode) == 0 {
+		return nil
+	}
+	s := strings.ToLower(*values.TiDBTxnMode)
+	if s != txnModeOptimistic && s != txnModePessimistic {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig,
+			fmt.Errorf("invalid tidb-txn-mode %s, which must be %s or %s",
+				*values.TiDBTxnMode, txnModeOptimistic, txnModePessimistic))
+	}
+	*mode = s
+	return nil
+}
+
+// getSSLCA builds the tls config from the ssl options, it is not registered
+// to the mysql driver until registerTLSConfig is called.
+func (c *MysqlConfig) getSSLCA(values *urlConfig, changefeedID model.ChangeFeedID) error {
+	if values.SSLCa == nil || len(*values.SSLCa) == 0 {
+		return nil
+	}
+
+	credential := security.Credential{
+		CAPath:   *values.SSLCa,
+		CertPath: util.GetOrZero(values.SSLCert),
+		KeyPath:  util.GetOrZero(values.SSLKey),
+	}
+	tlsCfg, err := credential.ToTLSConfig()
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+	}
+
+	c.tlsName = "cdc_mysql_tls" + changefeedID.Namespace + "_" + changefeedID.ID
+	c.tlsConfig = tlsCfg
+	c.TLS = "?tls=" + c.tlsName
+	return nil
+}
+
+// registerTLSConfig registers the tls config to the mysql driver,
+// so the dsn with the tls name can be used to connect to the downstream.
+func (c *MysqlConfig) registerTLSConfig() error {
+	if c.tlsConfig == nil {
+		return nil
+	}
+	err := dmysql.RegisterTLSConfig(c.tlsName, c.tlsConfig)
+	if err != nil {
+		return cerror.ErrMySQLConnectionError.Wrap(err).GenWithStack("fail to open MySQL connection")
+	}
+	return nil
+}
+
+func getSafeMode(values *urlConfig, safeMode *bool) {
+	if values.SafeMode != nil {
+		*safeMode = *values.SafeMode
+	}
+}
+
+func getTimezone(changefeedTimezoneStr string, values *urlConfig, timezone *string) error {
+	const pleaseSpecifyTimezone = "We recommend that you specify the time-zone explicitly. " +
+		"Please make sure that the timezone of the changefeed, " +
+		"sink-uri and the downstream database are consistent. " +
+		"If the downstream database does not load the timezone information, " +
+		"you can refer to https://dev.mysql.com/doc/refman/8.0/en/mysql-tzinfo-to-sql.html."
+	changefeedTimezone, err := util.GetTimezone(changefeedTimezoneStr)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+	}
+	if values.TimeZone == nil {
+		// If time-zone is not specified, use the timezone of the changefeed.
+		log.Warn("Because time-zone is not specified, "+
+			"the timezone of the changefeed will be used. "+
+			pleaseSpecifyTimezone,
+			zap.String("timezone", changefeedTimezone.String()))
+		*timezone = fmt.Sprintf(`"%s"`, changefeedTimezone.String())
+		return nil
+	}
+
+	s := *values.TimeZone
+	if len(s) == 0 {
+		*timezone = ""
+		log.Warn("Because time-zone is empty, " +
+			"the timezone of the downstream database will be used. " +
+			pleaseSpecifyTimezone)
+		return nil
+	}
+
+	sinkTimezone, err := util.GetTimezone(s)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+	}
+	*timezone = fmt.Sprintf(`"%s"`, sinkTimezone.String())
+	// The row values are decoded in the timezone of the changefeed,
+	// so it must be consistent with the timezone of the sink session.
+	if sinkTimezone.String() != changefeedTimezone.String() {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig, errors.Errorf(
+			"the timezone of the changefeed and the sink are inconsistent. "+
+				"changefeed timezone: %s, sink timezone: %s. "+
+				"Please make sure that the timezone of the changefeed, "+
+				"sink-uri and the downstream database are consistent.",
+			changefeedTimezone.String(), sinkTimezone.String()))
+	}
+	return nil
+}
+
+func getDuration(s *string, name string, target *string) error {
+	if s == nil {
+		return nil
+	}
+	d, err := time.ParseDuration(*s)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+	}
+	if d <= 0 {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig,
+			fmt.Errorf("invalid %s %s, which must be greater than 0", name, *s))
+	}
+	*target = *s
+	return nil
+}
+
+func getBool(value *bool, target *bool) {
+	if value != nil {
+		*target = *value
+	}
+}
+
+func NewMysqlConfigAndDB(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	sinkURI *url.URL,
+	config *ticonfig.ChangefeedConfig,
+) (*MysqlConfig, *sql.DB, error) {
 	log.Info("create db connection", zap.String("sinkURI", sinkURI.String()))
 	// create db connection
 	cfg := NewMysqlConfig()
-	// TODO: apply replica Config
-	err := cfg.Apply(sinkURI)
+	err := cfg.Apply(changefeedID, sinkURI, config)
 	if err != nil {
 		return nil, nil, err
 	}
+	if err = cfg.registerTLSConfig(); err != nil {
+		return nil, nil, err
+	}
 	dsnStr, err := GenerateDSN(cfg)
 	if err != nil {
 		return nil, nil, err
@@ -0,0 +1,329 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newChangefeedConfigForTest() *config.ChangefeedConfig {
+	return &config.ChangefeedConfig{
+		SinkConfig: config.GetDefaultReplicaConfig().Sink,
+	}
+}
+
+func withServerTimezone(t *testing.T, tz string) {
+	old := config.GetGlobalServerConfig()
+	serverConfig := old.Clone()
+	serverConfig.TZ = tz
+	config.StoreGlobalServerConfig(serverConfig)
+	t.Cleanup(func() { config.StoreGlobalServerConfig(old) })
+}
+
+func TestApplySinkURIParamsToConfig(t *testing.T) {
+	withServerTimezone(t, "UTC")
+
+	expected := NewMysqlConfig()
+	expected.WorkerCount = 64
+	expected.MaxTxnRow = 20
+	expected.MaxMultiUpdateRowCount = 80
+	expected.MaxMultiUpdateRowSize = 512
+	expected.SafeMode = true
+	expected.Timezone = `"UTC"`
+	expected.tidbTxnMode = "pessimistic"
+	expected.ReadTimeout = "1m"
+	expected.WriteTimeout = "1m"
+	expected.DialTimeout = "30s"
+	expected.CachePrepStmts = false
+	expected.MultiStmtEnable = false
+	expected.BatchDMLEnable = false
+	uriStr := "mysql://127.0.0.1:3306/?worker-count=64&max-txn-row=20" +
+		"&max-multi-update-row=80&max-multi-update-row-size=512" +
+		"&safe-mode=true&tidb-txn-mode=pessimistic" +
+		"&read-timeout=1m&write-timeout=1m&timeout=30s" +
+		"&test-some-deprecated-config=true" +
+		"&cache-prep-stmts=false&multi-stmt-enable=false&batch-dml-enable=false"
+	uri, err := url.Parse(uriStr)
+	require.NoError(t, err)
+	expected.sinkURI = uri
+
+	cfg := NewMysqlConfig()
+	err = cfg.Apply(model.DefaultChangeFeedID("test"), uri, newChangefeedConfigForTest())
+	require.NoError(t, err)
+	require.Equal(t, expected, cfg)
+}
+
+func TestParseSinkURIOverride(t *testing.T) {
+	withServerTimezone(t, "UTC")
+
+	cases := []struct {
+		uri     string
+		checker func(*MysqlConfig)
+	}{{
+		uri: "mysql://127.0.0.1:3306/?worker-count=2147483648", // int32 max
+		checker: func(c *MysqlConfig) {
+			require.EqualValues(t, maxWorkerCount, c.WorkerCount)
+		},
+	}, {
+		uri: "mysql://127.0.0.1:3306/?max-txn-row=2147483648", // int32 max
+		checker: func(c *MysqlConfig) {
+			require.EqualValues(t, maxMaxTxnRow, c.MaxTxnRow)
+		},
+	}, {
+		uri: "mysql://127.0.0.1:3306/?max-multi-update-row=2147483648", // int32 max
+		checker: func(c *MysqlConfig) {
+			require.EqualValues(t, maxMaxMultiUpdateRowCount, c.MaxMultiUpdateRowCount)
+		},
+	}, {
+		uri: "mysql://127.0.0.1:3306/?max-multi-update-row-size=2147483648", // int32 max
+		checker: func(c *MysqlConfig) {
+			require.EqualValues(t, maxMaxMultiUpdateRowSize, c.MaxMultiUpdateRowSize)
+		},
+	}, {
+		uri: "mysql://127.0.0.1:3306/?tidb-txn-mode=PESSIMISTIC",
+		checker: func(c *MysqlConfig) {
+			require.Equal(t, txnModePessimistic, c.tidbTxnMode)
+		},
+	}}
+	for _, cs := range cases {
+		uri, err := url.Parse(cs.uri)
+		require.NoError(t, err)
+		cfg := NewMysqlConfig()
+		err = cfg.Apply(model.DefaultChangeFeedID("changefeed-01"), uri, newChangefeedConfigForTest())
+		require.NoError(t, err)
+		cs.checker(cfg)
+	}
+}
+
+func TestParseSinkURIBadQueryString(t *testing.T) {
+	withServerTimezone(t, "UTC")
+
+	uris := []string{
+		"",
+		"postgre://127.0.0.1:3306",
+		"mysql://127.0.0.1:3306/?worker-count=not-number",
+		"mysql://127.0.0.1:3306/?worker-count=-1",
+		"mysql://127.0.0.1:3306/?worker-count=0",
+		"mysql://127.0.0.1:3306/?max-txn-row=not-number",
+		"mysql://127.0.0.1:3306/?max-txn-row=-1",
+		"mysql://127.0.0.1:3306/?max-txn-row=0",
+		"mysql://127.0.0.1:3306/?max-multi-update-row=0",
+		"mysql://127.0.0.1:3306/?max-multi-update-row-size=-1",
+		"mysql://127.0.0.1:3306/?tidb-txn-mode=badmode",
+		"mysql://127.0.0.1:3306/?ssl-ca=only-ca-exists",
+		"mysql://127.0.0.1:3306/?safe-mode=not-bool",
+		"mysql://127.0.0.1:3306/?cache-prep-stmts=not-bool",
+		"mysql://127.0.0.1:3306/?time-zone=badtz",
+		"mysql://127.0.0.1:3306/?write-timeout=badduration",
+		"mysql://127.0.0.1:3306/?read-timeout=badduration",
+		"mysql://127.0.0.1:3306/?timeout=badduration",
+		"mysql://127.0.0.1:3306/?timeout=-1s",
+	}
+	for _, uriStr := range uris {
+		var uri *url.URL
+		if uriStr != "" {
+			var err error
+			uri, err = url.Parse(uriStr)
+			require.NoError(t, err)
+		}
+		cfg := NewMysqlConfig()
+		err := cfg.Apply(model.DefaultChangeFeedID("changefeed-01"), uri, newChangefeedConfigForTest())
+		require.Error(t, err, uriStr)
+
+		// the same error is returned by the verification before creating a changefeed.
+		require.Error(t, VerifySinkConfig(model.DefaultChangeFeedID("changefeed-01"), uri, newChangefeedConfigForTest()))
+	}
+}
+
+func TestApplyTimezone(t *testing.T) {
+	localTimezone, err := util.GetTimezone("Local")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name               string
+		noSinkTimezone     bool
+		sinkTimezone       string
+		changefeedTimezone *time.Location
+		expected           string
+		expectedErr        string
+	}{
+		{
+			name:               "no sink timezone",
+			noSinkTimezone:     true,
+			changefeedTimezone: time.UTC,
+			expected:           `"UTC"`,
+		},
+		{
+			name:               "empty sink timezone",
+			sinkTimezone:       "",
+			changefeedTimezone: time.UTC,
+			expected:           "",
+		},
+		{
+			name:               "normal sink timezone",
+			sinkTimezone:       "UTC",
+			changefeedTimezone: time.UTC,
+			expected:           `"UTC"`,
+		},
+		{
+			name:               "local timezone",
+			sinkTimezone:       "Local",
+			changefeedTimezone: localTimezone,
+			expected:           `"` + localTimezone.String() + `"`,
+		},
+		{
+			name:               "sink timezone different from changefeed timezone",
+			sinkTimezone:       "Asia/Shanghai",
+			changefeedTimezone: time.UTC,
+			expectedErr:        "the timezone of the changefeed and the sink are inconsistent",
+		},
+		{
+			name:               "unsupported timezone format",
+			sinkTimezone:       "+08:00",
+			changefeedTimezone: time.UTC,
+			expectedErr:        "unknown time zone +08:00",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			values := &urlConfig{}
+			if !tc.noSinkTimezone {
+				values.TimeZone = &tc.sinkTimezone
+			}
+			var timezone string
+			err := getTimezone(tc.changefeedTimezone.String(), values, &timezone)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, timezone)
+		})
+	}
+}
+
+func TestApplyChangefeedTimezone(t *testing.T) {
+	withServerTimezone(t, "UTC")
+
+	// the sink-uri timezone only needs to be consistent with the changefeed.
+	sinkURI, err := url.Parse("mysql://127.0.0.1:3306/?time-zone=Asia/Shanghai")
+	require.NoError(t, err)
+	changefeedConfig := newChangefeedConfigForTest()
+	changefeedConfig.TimeZone = "Asia/Shanghai"
+	c := NewMysqlConfig()
+	err = c.Apply(model.DefaultChangeFeedID("test"), sinkURI, changefeedConfig)
+	require.NoError(t, err)
+	require.Equal(t, `"Asia/Shanghai"`, c.Timezone)
+
+	// the timezone of the changefeed is used if the sink-uri has no timezone.
+	sinkURI, err = url.Parse("mysql://127.0.0.1:3306/")
+	require.NoError(t, err)
+	c = NewMysqlConfig()
+	err = c.Apply(model.DefaultChangeFeedID("test"), sinkURI, changefeedConfig)
+	require.NoError(t, err)
+	require.Equal(t, `"Asia/Shanghai"`, c.Timezone)
+
+	changefeedConfig.TimeZone = "UTC"
+	sinkURI, err = url.Parse("mysql://127.0.0.1:3306/?time-zone=Asia/Shanghai")
+	require.NoError(t, err)
+	c = NewMysqlConfig()
+	err = c.Apply(model.DefaultChangeFeedID("test"), sinkURI, changefeedConfig)
+	require.ErrorContains(t, err, "the timezone of the changefeed and the sink are inconsistent")
+}
+
+func TestMergeConfig(t *testing.T) {
+	withServerTimezone(t, "Asia/Shanghai")
+
+	sinkURI, err := url.Parse("mysql://127.0.0.1:3306")
+	require.NoError(t, err)
+	changefeedConfig := newChangefeedConfigForTest()
+	changefeedConfig.SinkConfig.SafeMode = util.AddressOf(true)
+	changefeedConfig.SinkConfig.MySQLConfig = &config.MySQLConfig{
+		WorkerCount:                  util.AddressOf(13),
+		MaxTxnRow:                    util.AddressOf(100),
+		MaxMultiUpdateRowSize:        util.AddressOf(102),
+		MaxMultiUpdateRowCount:       util.AddressOf(103),
+		TiDBTxnMode:                  util.AddressOf("pessimistic"),
+		TimeZone:                     util.AddressOf("Asia/Shanghai"),
+		WriteTimeout:                 util.AddressOf("1m1s"),
+		ReadTimeout:                  util.AddressOf("1m2s"),
+		Timeout:                      util.AddressOf("1m3s"),
+		EnableBatchDML:               util.AddressOf(false),
+		EnableMultiStatement:         util.AddressOf(false),
+		EnableCachePreparedStatement: util.AddressOf(false),
+	}
+	c := NewMysqlConfig()
+	err = c.Apply(model.DefaultChangeFeedID("test"), sinkURI, changefeedConfig)
+	require.NoError(t, err)
+	require.Equal(t, 13, c.WorkerCount)
+	require.Equal(t, 100, c.MaxTxnRow)
+	require.Equal(t, 102, c.MaxMultiUpdateRowSize)
+	require.Equal(t, 103, c.MaxMultiUpdateRowCount)
+	require.Equal(t, "pessimistic", c.tidbTxnMode)
+	require.Equal(t, `"Asia/Shanghai"`, c.Timezone)
+	require.Equal(t, "1m1s", c.WriteTimeout)
+	require.Equal(t, "1m2s", c.ReadTimeout)
+	require.Equal(t, "1m3s", c.DialTimeout)
+	require.True(t, c.SafeMode)
+	require.False(t, c.BatchDMLEnable)
+	require.False(t, c.MultiStmtEnable)
+	require.False(t, c.CachePrepStmts)
+
+	// the parameters in the sink URI take precedence over the sink config.
+	sinkURI, err = url.Parse("mysql://127.0.0.1:3306?" +
+		"worker-count=11&" +
+		"max-txn-row=130&" +
+		"max-multi-update-row-size=142&" +
+		"max-multi-update-row=153&" +
+		"tidb-txn-mode=optimistic&" +
+		"safe-mode=false&" +
+		"write-timeout=2m1s&" +
+		"read-timeout=3m2s&" +
+		"timeout=4m3s&" +
+		"batch-dml-enable=true&" +
+		"multi-stmt-enable=true&" +
+		"cache-prep-stmts=true")
+	require.NoError(t, err)
+	c = NewMysqlConfig()
+	err = c.Apply(model.DefaultChangeFeedID("test"), sinkURI, changefeedConfig)
+	require.NoError(t, err)
+	require.Equal(t, 11, c.WorkerCount)
+	require.Equal(t, 130, c.MaxTxnRow)
+	require.Equal(t, 142, c.MaxMultiUpdateRowSize)
+	require.Equal(t, 153, c.MaxMultiUpdateRowCount)
+	require.Equal(t, "optimistic", c.tidbTxnMode)
+	require.Equal(t, `"Asia/Shanghai"`, c.Timezone)
+	require.Equal(t, "2m1s", c.WriteTimeout)
+	require.Equal(t, "3m2s", c.ReadTimeout)
+	require.Equal(t, "4m3s", c.DialTimeout)
+	require.False(t, c.SafeMode)
+	require.True(t, c.BatchDMLEnable)
+	require.True(t, c.MultiStmtEnable)
+	require.True(t, c.CachePrepStmts)
+
+	// invalid options in the sink config are rejected unless they are overridden by the sink URI.
+	changefeedConfig.SinkConfig.MySQLConfig.WorkerCount = util.AddressOf(0)
+	err = c.Apply(model.DefaultChangeFeedID("test"), sinkURI, changefeedConfig)
+	require.NoError(t, err)
+	sinkURI, err = url.Parse("mysql://127.0.0.1:3306")
+	require.NoError(t, err)
+	err = NewMysqlConfig().Apply(model.DefaultChangeFeedID("test"), sinkURI, changefeedConfig)
+	require.Error(t, err)
+}
@@ -781,6 +781,14 @@ func (w *MysqlWriter) prepareDMLs(events []*commonEvent.DMLEvent) *preparedDMLs
 			zap.Uint64("firstRowCommitTs", event.CommitTs),
 			zap.Uint64("firstRowReplicatingTs", event.ReplicatingTs),
 			zap.Bool("safeMode", w.cfg.SafeMode))
+		// Determine whether to use batch dml feature here.
+		if w.cfg.BatchDMLEnable && event.Len() > 1 && hasHandleKey(event.TableInfo) {
+			query, args := w.batchSingleTxnDmls(event, translateToInsert)
+			sqls = append(sqls, query...)
+			values = append(values, args...)
+			continue
+		}
+
 		for {
 			row, ok := event.GetNextRow()
 			if !ok {
@@ -830,6 +838,144 @@ func (w *MysqlWriter) prepareDMLs(events []*commonEvent.DMLEvent) *preparedDMLs
 	}
 }
 
+// batchSingleTxnDmls groups the rows of a single transaction by type, and translates
+// each group into multi-row statements. The statements are ordered as
+// DELETE, UPDATE and INSERT, which is safe because a row key appears at most once
+// in the rows of a transaction.
+func (w *MysqlWriter) batchSingleTxnDmls(
+	event *commonEvent.DMLEvent,
+	translateToInsert bool,
+) (sqls []string, values [][]interface{}) {
+	insertRows, updateRows, deleteRows := w.groupRowsByType(event, translateToInsert)
+	tableInfo := event.TableInfo
+
+	// handle delete
+	for _, rows := range deleteRows {
+		query, args := buildDeleteBatch(tableInfo, rows)
+		if query != "" {
+			sqls = append(sqls, query)
+			values = append(values, args)
+		}
+	}
+
+	// handle update
+	for _, rows := range updateRows {
+		// The behavior of update statement differs between TiDB and MySQL.
+		// So we don't use batch update statement when downstream is MySQL.
+		// Ref:https://docs.pingcap.com/tidb/stable/sql-statement-update#mysql-compatibility
+		//
+		// Also fallback to the single row update if the rows are large,
+		// since the values are repeated in every CASE WHEN clause.
+		avgRowSize := event.GetSize() / int64(event.Len())
+		if w.cfg.IsTiDB && len(rows) > 1 && avgRowSize < int64(w.cfg.MaxMultiUpdateRowSize) {
+			query, args := buildUpdateBatch(tableInfo, rows)
+			if query != "" {
+				sqls = append(sqls, query)
+				values = append(values, args)
+			}
+			continue
+		}
+		for _, row := range rows {
+			query, args := buildUpdate(tableInfo, row)
+			if query != "" {
+				sqls = append(sqls, query)
+				values = append(values, args)
+			}
+		}
+	}
+
+	// handle insert
+	for _, rows := range insertRows {
+		query, args := buildInsertBatch(tableInfo, rows, !translateToInsert)
+		if query != "" {
+			sqls = append(sqls, query)
+			values = append(values, args)
+		}
+	}
+	return
+}
+
+// groupRowsByType splits the rows of the event into insert, update and delete groups.
+// In safe mode, an update row is split into a delete row and an insert row, so it can
+// be replayed idempotently by the DELETE and REPLACE statements.
+// Each group holds at most MaxTxnRow rows (MaxMultiUpdateRowCount for updates), and
+// is also limited by the max_allowed_packet of the downstream.
+func (w *MysqlWriter) groupRowsByType(
+	event *commonEvent.DMLEvent,
+	translateToInsert bool,
+) (insertRows, updateRows, deleteRows [][]commonEvent.RowChange) {
+	maxTxnRow := w.cfg.MaxTxnRow
+	maxUpdateRow := w.cfg.MaxMultiUpdateRowCount
+	// The row size is multiplied by 2 because in extreme circumstances, every
+	// byte in the values can be escaped and adds one byte.
+	rowSize := event.GetSize() / int64(event.Len()) * 2
+	if w.maxAllowedPacket > 0 && rowSize > 0 {
+		maxRowsInPacket := int(w.maxAllowedPacket / rowSize)
+		if maxRowsInPacket < 1 {
+			maxRowsInPacket = 1
+		}
+		maxTxnRow = min(maxTxnRow, maxRowsInPacket)
+		maxUpdateRow = min(maxUpdateRow, maxRowsInPacket)
+	}
+	maxTxnRow = max(maxTxnRow, 1)
+	maxUpdateRow = max(maxUpdateRow, 1)
+
+	preAllocateSize := min(int(event.Len()), maxTxnRow)
+	insertRow := make([]commonEvent.RowChange, 0, preAllocateSize)
+	updateRow := make([]commonEvent.RowChange, 0, min(preAllocateSize, maxUpdateRow))
+	deleteRow := make([]commonEvent.RowChange, 0, preAllocateSize)
+
+	appendInsert := func(row commonEvent.RowChange) {
+		insertRow = append(insertRow, row)
+		if len(insertRow) >= maxTxnRow {
+			insertRows = append(insertRows, insertRow)
+			insertRow = make([]commonEvent.RowChange, 0, preAllocateSize)
+		}
+	}
+	appendDelete := func(row commonEvent.RowChange) {
+		deleteRow = append(deleteRow, row)
+		if len(deleteRow) >= maxTxnRow {
+			deleteRows = append(deleteRows, deleteRow)
+			deleteRow = make([]commonEvent.RowChange, 0, preAllocateSize)
+		}
+	}
+
+	for {
+		row, ok := event.GetNextRow()
+		if !ok {
+			break
+		}
+		switch row.RowType {
+		case commonEvent.RowTypeInsert:
+			appendInsert(row)
+		case commonEvent.RowTypeDelete:
+			appendDelete(row)
+		case commonEvent.RowTypeUpdate:
+			if !translateToInsert {
+				appendDelete(commonEvent.RowChange{PreRow: row.PreRow, RowType: commonEvent.RowTypeDelete})
+				appendInsert(commonEvent.RowChange{Row: row.Row, RowType: commonEvent.RowTypeInsert})
+				continue
+			}
+			updateRow = append(updateRow, row)
+			if len(updateRow) >= maxUpdateRow {
+				updateRows = append(updateRows, updateRow)
+				updateRow = make([]commonEvent.RowChange, 0, min(preAllocateSize, maxUpdateRow))
+			}
+		}
+	}
+
+	if len(insertRow) > 0 {
+		insertRows = append(insertRows, insertRow)
+	}
+	if len(updateRow) > 0 {
+		updateRows = append(updateRows, updateRow)
+	}
+	if len(deleteRow) > 0 {
+		deleteRows = append(deleteRows, deleteRow)
+	}
+	return
+}
+
 func (w *MysqlWriter) execDMLWithMaxRetries(dmls *preparedDMLs) error {
 	if len(dmls.sqls) != len(dmls.values) {
 		log.Error("unexpected number of sqls and values",
@@ -840,7 +986,8 @@ func (w *MysqlWriter) execDMLWithMaxRetries(dmls *preparedDMLs) error {
 
 	// approximateSize is multiplied by 2 because in extreme circustumas, every
 	// byte in dmls can be escaped and adds one byte.
-	fallbackToSeqWay := dmls.approximateSize*2 > w.maxAllowedPacket
+	// The multi statements way is disabled by the multi-stmt-enable option.
+	fallbackToSeqWay := !w.cfg.MultiStmtEnable || dmls.approximateSize*2 > w.maxAllowedPacket
 
 	writeTimeout, _ := time.ParseDuration(w.cfg.WriteTimeout)
 	writeTimeout += networkDriftDuration
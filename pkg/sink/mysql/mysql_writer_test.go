@@ -2,9 +2,13 @@ package mysql
 
 import (
 	"database/sql"
+	"fmt"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	pevent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/stretchr/testify/require"
 )
 
@@ -115,3 +119,181 @@ func newTestMockDB(t *testing.T) (db *sql.DB, mock sqlmock.Sqlmock) {
 // 	err = mock.ExpectationsWereMet()
 // 	require.NoError(t, err)
 // }
+
+// newBatchDMLEventForTest returns a DMLEvent which contains n rows inserted into `test`.`t`,
+// and the row types of the event can be changed by the caller.
+func newBatchDMLEventForTest(t *testing.T, n int) *pevent.DMLEvent {
+	helper := pevent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+
+	dmls := make([]string, 0, n)
+	for i := 1; i <= n; i++ {
+		dmls = append(dmls, fmt.Sprintf("insert into t values (%d, 'name%d');", i, i))
+	}
+	event := helper.DML2Event("test", "t", dmls...)
+	require.NotNil(t, event)
+	return event
+}
+
+func newBatchTestWriter(t *testing.T, db *sql.DB) *MysqlWriter {
+	cfg := NewMysqlConfig()
+	cfg.IsTiDB = true
+	cfg.CachePrepStmts = false
+	cfg.maxAllowedPacket = int64(variable.DefMaxAllowedPacket)
+	return NewMysqlWriter(db, cfg, model.DefaultChangeFeedID("test"))
+}
+
+func TestMysqlWriterFlushBatchInsert(t *testing.T) {
+	db, mock := newTestMockDB(t)
+	defer db.Close()
+	writer := newBatchTestWriter(t, db)
+
+	event := newBatchDMLEventForTest(t, 3)
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?),(?,?),(?,?)").
+		WithArgs(1, "name1", 2, "name2", 3, "name3").
+		WillReturnResult(sqlmock.NewResult(3, 3))
+	mock.ExpectCommit()
+
+	err := writer.Flush([]*pevent.DMLEvent{event}, 0)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// The rows are split into multiple statements by the MaxTxnRow.
+	writer.cfg.MaxTxnRow = 2
+	event = newBatchDMLEventForTest(t, 3)
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?),(?,?);"+
+		"INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, "name1", 2, "name2", 3, "name3").
+		WillReturnResult(sqlmock.NewResult(3, 3))
+	mock.ExpectCommit()
+
+	err = writer.Flush([]*pevent.DMLEvent{event}, 0)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlWriterFlushBatchDeleteAndUpdate(t *testing.T) {
+	db, mock := newTestMockDB(t)
+	defer db.Close()
+	writer := newBatchTestWriter(t, db)
+
+	// delete rows are merged into one statement by the handle key.
+	event := newBatchDMLEventForTest(t, 2)
+	event.RowTypes = []pevent.RowType{pevent.RowTypeDelete, pevent.RowTypeDelete}
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM `test`.`t` WHERE (`id`) IN ((?),(?))").
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(2, 2))
+	mock.ExpectCommit()
+
+	err := writer.Flush([]*pevent.DMLEvent{event}, 0)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// update rows are merged into one CASE WHEN statement.
+	// The rows of an update are stored as the pre row and the row in order.
+	event = newBatchDMLEventForTest(t, 4)
+	event.RowTypes = []pevent.RowType{
+		pevent.RowTypeUpdate, pevent.RowTypeUpdate, pevent.RowTypeUpdate, pevent.RowTypeUpdate,
+	}
+	event.Length = 2
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `test`.`t` SET "+
+		"`id` = CASE WHEN `id` = ? THEN ? WHEN `id` = ? THEN ? END,"+
+		"`name` = CASE WHEN `id` = ? THEN ? WHEN `id` = ? THEN ? END "+
+		"WHERE (`id`) IN ((?),(?))").
+		WithArgs(1, 2, 3, 4, 1, "name2", 3, "name4", 1, 3).
+		WillReturnResult(sqlmock.NewResult(2, 2))
+	mock.ExpectCommit()
+
+	err = writer.Flush([]*pevent.DMLEvent{event}, 0)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// update rows are not merged if the downstream is not TiDB.
+	writer.cfg.IsTiDB = false
+	event.Rewind()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `test`.`t` SET `id` = ?,`name` = ? WHERE `id` = ? LIMIT 1;"+
+		"UPDATE `test`.`t` SET `id` = ?,`name` = ? WHERE `id` = ? LIMIT 1").
+		WithArgs(2, "name2", 1, 4, "name4", 3).
+		WillReturnResult(sqlmock.NewResult(2, 2))
+	mock.ExpectCommit()
+
+	err = writer.Flush([]*pevent.DMLEvent{event}, 0)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlWriterFlushBatchInSafeMode(t *testing.T) {
+	db, mock := newTestMockDB(t)
+	defer db.Close()
+	writer := newBatchTestWriter(t, db)
+	writer.cfg.SafeMode = true
+
+	// In safe mode, the update rows are translated into DELETE and REPLACE statements.
+	event := newBatchDMLEventForTest(t, 5)
+	event.RowTypes = []pevent.RowType{
+		pevent.RowTypeUpdate, pevent.RowTypeUpdate, pevent.RowTypeUpdate, pevent.RowTypeUpdate,
+		pevent.RowTypeInsert,
+	}
+	event.Length = 3
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM `test`.`t` WHERE (`id`) IN ((?),(?));"+
+		"REPLACE INTO `test`.`t` (`id`,`name`) VALUES (?,?),(?,?),(?,?)").
+		WithArgs(1, 3, 2, "name2", 4, "name4", 5, "name5").
+		WillReturnResult(sqlmock.NewResult(3, 3))
+	mock.ExpectCommit()
+
+	err := writer.Flush([]*pevent.DMLEvent{event}, 0)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlWriterFlushBatchWithMaxAllowedPacket(t *testing.T) {
+	db, mock := newTestMockDB(t)
+	defer db.Close()
+	writer := newBatchTestWriter(t, db)
+
+	// Only one row can be held in a statement, and the statements
+	// are executed one by one since the total size exceeds the packet.
+	event := newBatchDMLEventForTest(t, 2)
+	writer.maxAllowedPacket = event.GetSize()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, "name1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(2, "name2").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := writer.Flush([]*pevent.DMLEvent{event}, 0)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlWriterFlushWithoutBatch(t *testing.T) {
+	db, mock := newTestMockDB(t)
+	defer db.Close()
+	writer := newBatchTestWriter(t, db)
+	writer.cfg.BatchDMLEnable = false
+
+	event := newBatchDMLEventForTest(t, 2)
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?);"+
+		"INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, "name1", 2, "name2").
+		WillReturnResult(sqlmock.NewResult(2, 2))
+	mock.ExpectCommit()
+
+	err := writer.Flush([]*pevent.DMLEvent{event}, 0)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
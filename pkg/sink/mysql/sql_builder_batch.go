@@ -0,0 +1,237 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"strings"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/tiflow/pkg/quotes"
+	"go.uber.org/zap"
+)
+
+// buildInsertBatch builds a multi-value INSERT or REPLACE statement as following
+// sql: `INSERT INTO `test`.`t` (`a`,`b`) VALUES (?,?),(?,?)`
+// All rows must belong to the same table.
+func buildInsertBatch(
+	tableInfo *common.TableInfo,
+	rows []commonEvent.RowChange,
+	safeMode bool,
+) (string, []interface{}) {
+	if len(rows) == 0 {
+		return "", nil
+	}
+
+	var args []interface{}
+	columnCount := 0
+	for _, row := range rows {
+		rowArgs, err := getArgs(&row.Row, tableInfo)
+		if err != nil {
+			// FIXME: handle error
+			log.Panic("getArgs failed", zap.Error(err))
+			return "", nil
+		}
+		if args == nil {
+			columnCount = len(rowArgs)
+			args = make([]interface{}, 0, len(rows)*columnCount)
+		}
+		args = append(args, rowArgs...)
+	}
+	if columnCount == 0 {
+		return "", nil
+	}
+
+	var builder strings.Builder
+	if safeMode {
+		builder.WriteString("REPLACE INTO ")
+	} else {
+		builder.WriteString("INSERT INTO ")
+	}
+	builder.WriteString(tableInfo.TableName.QuoteString())
+	builder.WriteString(" (")
+	first := true
+	for _, col := range tableInfo.Columns {
+		if col == nil || tableInfo.ColumnsFlag[col.ID].IsGeneratedColumn() {
+			continue
+		}
+		if !first {
+			builder.WriteString(",")
+		}
+		first = false
+		builder.WriteString(quotes.QuoteName(col.Name.O))
+	}
+	builder.WriteString(") VALUES ")
+	holder := valuesHolder(columnCount)
+	for i := range rows {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString(holder)
+	}
+	return builder.String(), args
+}
+
+// buildDeleteBatch builds a DELETE statement which removes all rows by their handle key as following
+// sql: `DELETE FROM `test`.`t` WHERE (`a`,`b`) IN ((?,?),(?,?))`
+// All rows must belong to the same table, and the table must have a handle key.
+func buildDeleteBatch(tableInfo *common.TableInfo, rows []commonEvent.RowChange) (string, []interface{}) {
+	if len(rows) == 0 {
+		return "", nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString("DELETE FROM ")
+	builder.WriteString(tableInfo.TableName.QuoteString())
+	builder.WriteString(" WHERE ")
+	args := writeWhereIn(&builder, tableInfo, rows)
+	if len(args) == 0 {
+		return "", nil
+	}
+	return builder.String(), args
+}
+
+// buildUpdateBatch builds a multi-row UPDATE statement as following
+// sql: `UPDATE `test`.`t` SET `a` = CASE WHEN `a` = ? THEN ? WHEN `a` = ? THEN ? END,
+// `b` = CASE WHEN `a` = ? THEN ? WHEN `a` = ? THEN ? END WHERE (`a`) IN ((?),(?))`
+// All rows must belong to the same table, and the table must have a handle key.
+func buildUpdateBatch(tableInfo *common.TableInfo, rows []commonEvent.RowChange) (string, []interface{}) {
+	if len(rows) == 0 {
+		return "", nil
+	}
+
+	// Pre-generate the WHEN condition and the values of each row.
+	whenClauses := make([]string, len(rows))
+	whenArgs := make([][]interface{}, len(rows))
+	rowArgs := make([][]interface{}, len(rows))
+	for i, row := range rows {
+		colNames, whereArgs := whereSlice(&row.PreRow, tableInfo)
+		if len(whereArgs) == 0 {
+			return "", nil
+		}
+		var whenBuilder strings.Builder
+		for j, name := range colNames {
+			if j > 0 {
+				whenBuilder.WriteString(" AND ")
+			}
+			whenBuilder.WriteString(quotes.QuoteName(name))
+			whenBuilder.WriteString(" = ?")
+		}
+		whenClauses[i] = whenBuilder.String()
+		whenArgs[i] = whereArgs
+
+		args, err := getArgs(&row.Row, tableInfo)
+		if err != nil {
+			// FIXME: handle error
+			log.Panic("getArgs failed", zap.Error(err))
+			return "", nil
+		}
+		if len(args) == 0 {
+			return "", nil
+		}
+		rowArgs[i] = args
+	}
+
+	var builder strings.Builder
+	builder.WriteString("UPDATE ")
+	builder.WriteString(tableInfo.TableName.QuoteString())
+	builder.WriteString(" SET ")
+
+	args := make([]interface{}, 0, len(rows)*len(rowArgs[0])*(len(whenArgs[0])+1)+len(rows)*len(whenArgs[0]))
+	colIdx := 0
+	for _, col := range tableInfo.Columns {
+		if col == nil || tableInfo.ColumnsFlag[col.ID].IsGeneratedColumn() {
+			continue
+		}
+		if colIdx > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString(quotes.QuoteName(col.Name.O))
+		builder.WriteString(" = CASE")
+		for i := range rows {
+			builder.WriteString(" WHEN ")
+			builder.WriteString(whenClauses[i])
+			builder.WriteString(" THEN ?")
+			args = append(args, whenArgs[i]...)
+			args = append(args, rowArgs[i][colIdx])
+		}
+		builder.WriteString(" END")
+		colIdx++
+	}
+
+	builder.WriteString(" WHERE ")
+	args = append(args, writeWhereIn(&builder, tableInfo, rows)...)
+	return builder.String(), args
+}
+
+// writeWhereIn writes `(`a`,`b`) IN ((?,?),(?,?))` built from the handle key of
+// the pre rows into the builder, and returns the arguments.
+func writeWhereIn(builder *strings.Builder, tableInfo *common.TableInfo, rows []commonEvent.RowChange) []interface{} {
+	var args []interface{}
+	var holder string
+	for i, row := range rows {
+		colNames, whereArgs := whereSlice(&row.PreRow, tableInfo)
+		if len(whereArgs) == 0 {
+			return nil
+		}
+		if i == 0 {
+			builder.WriteString("(")
+			for j, name := range colNames {
+				if j > 0 {
+					builder.WriteString(",")
+				}
+				builder.WriteString(quotes.QuoteName(name))
+			}
+			builder.WriteString(") IN (")
+			holder = valuesHolder(len(colNames))
+			args = make([]interface{}, 0, len(rows)*len(whereArgs))
+		} else {
+			builder.WriteString(",")
+		}
+		builder.WriteString(holder)
+		args = append(args, whereArgs...)
+	}
+	builder.WriteString(")")
+	return args
+}
+
+// valuesHolder returns `(?,?,?)` with n placeholders.
+func valuesHolder(n int) string {
+	var builder strings.Builder
+	builder.Grow(n*2 + 1)
+	builder.WriteString("(")
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString("?")
+	}
+	builder.WriteString(")")
+	return builder.String()
+}
+
+// hasHandleKey returns true if the table has a primary key or a not null unique key,
+// which is required by the batch DELETE and UPDATE statements to locate the rows.
+func hasHandleKey(tableInfo *common.TableInfo) bool {
+	for _, col := range tableInfo.Columns {
+		if col == nil {
+			continue
+		}
+		if tableInfo.ColumnsFlag[col.ID].IsHandleKey() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	pevent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/stretchr/testify/require"
+)
+
+// getBatchRowsForTest returns two inserted rows, and two update rows whose
+// pre rows are the inserted rows.
+func getBatchRowsForTest(t *testing.T, createTableSQL string) (inserts, updates []pevent.RowChange, tableInfo *common.TableInfo) {
+	helper := pevent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(createTableSQL)
+	require.NotNil(t, job)
+
+	event := helper.DML2Event("test", "t",
+		"insert into t values (1, 'a', 10);",
+		"insert into t values (2, 'b', 20);",
+		"insert into t values (3, 'c', 30);",
+		"insert into t values (4, 'd', 40);")
+	require.NotNil(t, event)
+	rows := make([]pevent.RowChange, 0, 4)
+	for {
+		row, ok := event.GetNextRow()
+		if !ok {
+			break
+		}
+		rows = append(rows, row)
+	}
+	require.Len(t, rows, 4)
+
+	// The helper does not support update operation, so build the update rows
+	// by the inserted rows manually.
+	inserts = rows[:2]
+	for i, row := range rows[2:] {
+		row.PreRow = inserts[i].Row
+		row.RowType = pevent.RowTypeUpdate
+		updates = append(updates, row)
+	}
+	return inserts, updates, event.TableInfo
+}
+
+func TestBuildInsertBatch(t *testing.T) {
+	inserts, _, tableInfo := getBatchRowsForTest(t,
+		"create table t (id int primary key, name varchar(32), age int);")
+
+	sql, args := buildInsertBatch(tableInfo, inserts, false)
+	require.Equal(t, "INSERT INTO `test`.`t` (`id`,`name`,`age`) VALUES (?,?,?),(?,?,?)", sql)
+	require.Equal(t, []interface{}{int64(1), "a", int64(10), int64(2), "b", int64(20)}, args)
+
+	sql, args = buildInsertBatch(tableInfo, inserts, true)
+	require.Equal(t, "REPLACE INTO `test`.`t` (`id`,`name`,`age`) VALUES (?,?,?),(?,?,?)", sql)
+	require.Len(t, args, 6)
+
+	sql, args = buildInsertBatch(tableInfo, nil, false)
+	require.Empty(t, sql)
+	require.Nil(t, args)
+}
+
+func TestBuildDeleteBatch(t *testing.T) {
+	// case 1: table has primary key
+	inserts, _, tableInfo := getBatchRowsForTest(t,
+		"create table t (id int primary key, name varchar(32), age int);")
+	deletes := make([]pevent.RowChange, 0, len(inserts))
+	for _, row := range inserts {
+		deletes = append(deletes, pevent.RowChange{PreRow: row.Row, RowType: pevent.RowTypeDelete})
+	}
+
+	sql, args := buildDeleteBatch(tableInfo, deletes)
+	require.Equal(t, "DELETE FROM `test`.`t` WHERE (`id`) IN ((?),(?))", sql)
+	require.Equal(t, []interface{}{int64(1), int64(2)}, args)
+
+	// case 2: table has composite not null uk
+	inserts, _, tableInfo = getBatchRowsForTest(t,
+		"create table t (id int, name varchar(32) not null, age int not null, unique key (age, name));")
+	deletes = deletes[:0]
+	for _, row := range inserts {
+		deletes = append(deletes, pevent.RowChange{PreRow: row.Row, RowType: pevent.RowTypeDelete})
+	}
+
+	sql, args = buildDeleteBatch(tableInfo, deletes)
+	require.Equal(t, "DELETE FROM `test`.`t` WHERE (`name`,`age`) IN ((?,?),(?,?))", sql)
+	require.Equal(t, []interface{}{"a", int64(10), "b", int64(20)}, args)
+}
+
+func TestBuildUpdateBatch(t *testing.T) {
+	_, updates, tableInfo := getBatchRowsForTest(t,
+		"create table t (id int primary key, name varchar(32), age int);")
+
+	sql, args := buildUpdateBatch(tableInfo, updates)
+	require.Equal(t, "UPDATE `test`.`t` SET "+
+		"`id` = CASE WHEN `id` = ? THEN ? WHEN `id` = ? THEN ? END,"+
+		"`name` = CASE WHEN `id` = ? THEN ? WHEN `id` = ? THEN ? END,"+
+		"`age` = CASE WHEN `id` = ? THEN ? WHEN `id` = ? THEN ? END "+
+		"WHERE (`id`) IN ((?),(?))", sql)
+	require.Equal(t, []interface{}{
+		int64(1), int64(3), int64(2), int64(4),
+		int64(1), "c", int64(2), "d",
+		int64(1), int64(30), int64(2), int64(40),
+		int64(1), int64(2),
+	}, args)
+}
+
+func TestHasHandleKey(t *testing.T) {
+	_, _, tableInfo := getBatchRowsForTest(t,
+		"create table t (id int primary key, name varchar(32), age int);")
+	require.True(t, hasHandleKey(tableInfo))
+
+	_, _, tableInfo = getBatchRowsForTest(t,
+		"create table t (id int, name varchar(32), age int, unique key (age));")
+	require.False(t, hasHandleKey(tableInfo))
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsar
+
+import (
+	"github.com/apache/pulsar-client-go/pulsar"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/config"
+	"go.uber.org/zap"
+)
+
+// NewProducer creates a pulsar producer for the given topic.
+func NewProducer(
+	pConfig *config.PulsarConfig,
+	client pulsar.Client,
+	topicName string,
+) (pulsar.Producer, error) {
+	maxReconnectToBroker := uint(config.DefaultMaxReconnectToPulsarBroker)
+	option := pulsar.ProducerOptions{
+		Topic:                topicName,
+		MaxReconnectToBroker: &maxReconnectToBroker,
+	}
+	if pConfig.BatchingMaxMessages != nil {
+		option.BatchingMaxMessages = *pConfig.BatchingMaxMessages
+	}
+	if pConfig.BatchingMaxPublishDelay != nil {
+		option.BatchingMaxPublishDelay = pConfig.BatchingMaxPublishDelay.Duration()
+	}
+	if pConfig.CompressionType != nil {
+		option.CompressionType = pConfig.CompressionType.Value()
+		option.CompressionLevel = pulsar.Default
+	}
+	if pConfig.SendTimeout != nil {
+		option.SendTimeout = pConfig.SendTimeout.Duration()
+	}
+
+	producer, err := client.CreateProducer(option)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("create pulsar producer success", zap.String("topic", topicName))
+	return producer, nil
+}
+
+// NewProducerCache creates a lru cache to hold the producers of different topics,
+// the evicted producer will be closed.
+// One topic only uses one producer, so we can have many topics but use less memory.
+func NewProducerCache(pConfig *config.PulsarConfig) (*lru.Cache, error) {
+	producerCacheSize := config.DefaultPulsarProducerCacheSize
+	if pConfig != nil && pConfig.PulsarProducerCacheSize != nil {
+		producerCacheSize = int(*pConfig.PulsarProducerCacheSize)
+	}
+	return lru.NewWithEvict(producerCacheSize, func(key interface{}, value interface{}) {
+		// this is called when lru removes a producer or evicts it automatically
+		pulsarProducer, ok := value.(pulsar.Producer)
+		if ok && pulsarProducer != nil {
+			pulsarProducer.Close()
+		}
+	})
+}
+
+// GetProducerByTopic returns the producer of the topic in the cache,
+// a new producer will be created and cached if it does not exist.
+func GetProducerByTopic(
+	producers *lru.Cache,
+	pConfig *config.PulsarConfig,
+	client pulsar.Client,
+	topicName string,
+) (pulsar.Producer, error) {
+	if target, ok := producers.Get(topicName); ok {
+		if producer, ok := target.(pulsar.Producer); ok && producer != nil {
+			return producer, nil
+		}
+	}
+	producer, err := NewProducer(pConfig, client, topicName)
+	if err != nil {
+		return nil, err
+	}
+	producers.Add(topicName, producer)
+	return producer, nil
+}
@@ -66,3 +66,7 @@ for pb in $(find pkg/messaging/proto -name '*.proto'); do
 	generate ./pkg/messaging/proto $pb paths="source_relative"
 done
 
+for pb in $(find logservice/logservicepb -name '*.proto'); do
+	# Output generated go files next to protobuf files.
+	generate ./ $pb paths="source_relative"
+done
@@ -116,7 +116,9 @@ func (c *server) initialize(ctx context.Context) error {
 	messageCenter := messaging.NewMessageCenter(ctx, c.info.ID, c.info.Epoch, config.NewDefaultMessageCenterConfig())
 	appcontext.SetService(appcontext.MessageCenter, messageCenter)
 
-	appcontext.SetService(appcontext.EventCollector, eventcollector.New(ctx, 100*1024*1024*1024, c.info.ID)) // 100GB for demo
+	conf := config.GetGlobalServerConfig()
+	eventCollector := eventcollector.New(ctx, 100*1024*1024*1024, c.info.ID, conf.Debug.EventCollector) // 100GB for demo
+	appcontext.SetService(appcontext.EventCollector, eventCollector)
 	appcontext.SetService(appcontext.HeartbeatCollector, dispatchermanager.NewHeartBeatCollector(c.info.ID))
 	c.dispatcherOrchestrator = dispatcherorchestrator.New()
 
@@ -124,10 +126,10 @@ func (c *server) initialize(ctx context.Context) error {
 	nodeManager.RegisterNodeChangeHandler(
 		appcontext.MessageCenter,
 		appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter).OnNodeChanges)
+	nodeManager.RegisterNodeChangeHandler(appcontext.EventCollector, eventCollector.OnNodeChanges)
 
-	conf := config.GetGlobalServerConfig()
 	schemaStore := schemastore.New(ctx, conf.DataDir, c.pdClient, c.RegionCache, c.PDClock, c.KVStorage)
-	eventStore := eventstore.New(ctx, conf.DataDir, c.pdClient, c.RegionCache, c.PDClock, c.KVStorage)
+	eventStore := eventstore.New(ctx, conf.DataDir, c.pdClient, c.RegionCache, c.PDClock, c.KVStorage, conf.Debug.EventStore)
 	eventService := eventservice.New(eventStore, schemaStore)
 	c.subModules = []common.SubModule{
 		nodeManager,
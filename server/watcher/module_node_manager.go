@@ -26,6 +26,7 @@ import (
 	"github.com/pingcap/tiflow/pkg/etcd"
 	"github.com/pingcap/tiflow/pkg/orchestrator"
 	"go.etcd.io/etcd/client/v3/concurrency"
+	"go.uber.org/zap"
 )
 
 const NodeManagerName = "node-manager"
@@ -43,6 +44,13 @@ type NodeManager struct {
 		sync.RWMutex
 		m map[node.ID]NodeChangeHandler
 	}
+
+	// unschedulable holds the nodes that are being drained,
+	// no new maintainers or dispatchers should be scheduled to them
+	unschedulable struct {
+		sync.RWMutex
+		m map[node.ID]struct{}
+	}
 }
 
 func NewNodeManager(
@@ -56,6 +64,10 @@ func NewNodeManager(
 			sync.RWMutex
 			m map[node.ID]NodeChangeHandler
 		}{m: make(map[node.ID]NodeChangeHandler)},
+		unschedulable: struct {
+			sync.RWMutex
+			m map[node.ID]struct{}
+		}{m: make(map[node.ID]struct{})},
 	}
 	m.nodes.Store(&map[node.ID]*node.Info{})
 	return m
@@ -90,6 +102,14 @@ func (c *NodeManager) Tick(
 	}
 	c.nodes.Store(&allNodes)
 	if changed {
+		// the drained nodes are offline, forget them
+		c.unschedulable.Lock()
+		for id := range c.unschedulable.m {
+			if _, ok := allNodes[id]; !ok {
+				delete(c.unschedulable.m, id)
+			}
+		}
+		c.unschedulable.Unlock()
 		log.Info("server change detected")
 		// handle info change event
 		c.nodeChangeHandlers.RLock()
@@ -106,6 +126,90 @@ func (c *NodeManager) GetAliveNodes() map[node.ID]*node.Info {
 	return *c.nodes.Load()
 }
 
+// GetSchedulableNodes get all alive captures that are not unschedulable,
+// new tasks can only be scheduled to these captures
+func (c *NodeManager) GetSchedulableNodes() map[node.ID]*node.Info {
+	aliveNodes := c.GetAliveNodes()
+	c.unschedulable.RLock()
+	defer c.unschedulable.RUnlock()
+	nodes := make(map[node.ID]*node.Info, len(aliveNodes))
+	for id, info := range aliveNodes {
+		if _, ok := c.unschedulable.m[id]; !ok {
+			nodes[id] = info
+		}
+	}
+	return nodes
+}
+
+// SetNodeUnschedulable marks the alive capture as unschedulable, it's used to drain the capture,
+// returns false if the capture is not alive
+func (c *NodeManager) SetNodeUnschedulable(id node.ID) bool {
+	if _, ok := c.GetAliveNodes()[id]; !ok {
+		return false
+	}
+	c.unschedulable.Lock()
+	defer c.unschedulable.Unlock()
+	if _, ok := c.unschedulable.m[id]; !ok {
+		log.Info("mark node unschedulable", zap.Stringer("node", id))
+		c.unschedulable.m[id] = struct{}{}
+	}
+	return true
+}
+
+// SetNodeSchedulable marks the capture as schedulable again, it's used to undrain the capture,
+// returns false if the capture is not unschedulable
+func (c *NodeManager) SetNodeSchedulable(id node.ID) bool {
+	c.unschedulable.Lock()
+	defer c.unschedulable.Unlock()
+	if _, ok := c.unschedulable.m[id]; !ok {
+		return false
+	}
+	log.Info("mark node schedulable", zap.Stringer("node", id))
+	delete(c.unschedulable.m, id)
+	return true
+}
+
+// SetUnschedulableNodes replaces all unschedulable captures with the given ones,
+// it's used to sync the unschedulable captures from the coordinator
+func (c *NodeManager) SetUnschedulableNodes(ids []node.ID) {
+	m := make(map[node.ID]struct{}, len(ids))
+	for _, id := range ids {
+		m[id] = struct{}{}
+	}
+	c.unschedulable.Lock()
+	defer c.unschedulable.Unlock()
+	for id := range c.unschedulable.m {
+		if _, ok := m[id]; !ok {
+			log.Info("mark node schedulable", zap.Stringer("node", id))
+		}
+	}
+	for id := range m {
+		if _, ok := c.unschedulable.m[id]; !ok {
+			log.Info("mark node unschedulable", zap.Stringer("node", id))
+		}
+	}
+	c.unschedulable.m = m
+}
+
+// IsNodeUnschedulable returns true if the capture is being drained
+func (c *NodeManager) IsNodeUnschedulable(id node.ID) bool {
+	c.unschedulable.RLock()
+	defer c.unschedulable.RUnlock()
+	_, ok := c.unschedulable.m[id]
+	return ok
+}
+
+// GetUnschedulableNodes returns all captures that are being drained
+func (c *NodeManager) GetUnschedulableNodes() []node.ID {
+	c.unschedulable.RLock()
+	defer c.unschedulable.RUnlock()
+	nodes := make([]node.ID, 0, len(c.unschedulable.m))
+	for id := range c.unschedulable.m {
+		nodes = append(nodes, id)
+	}
+	return nodes
+}
+
 func (c *NodeManager) Run(ctx context.Context) error {
 	cfg := config.GetGlobalServerConfig()
 	watcher := NewEtcdWatcher(c.etcdClient,